@@ -16,6 +16,19 @@ type BluetoothDeviceParameters struct {
 	// Specifies default device connection timeout
 	// +kubebuilder:default:30s
 	Timeout v1.Duration `json:"timeout,omitempty"`
+
+	// Specifies the maximum amount of silence, the properties are synchronized to limb anyway after it,
+	// even if none of them crossed the deadband.
+	// The default value is "0s", which means there is not any heartbeat.
+	// +optional
+	MaxSilence v1.Duration `json:"maxSilence,omitempty"`
+}
+
+func (in *BluetoothDeviceParameters) GetMaxSilence() time.Duration {
+	if in != nil {
+		return in.MaxSilence.Duration
+	}
+	return 0
 }
 
 func (in *BluetoothDeviceParameters) GetSyncInterval() time.Duration {
@@ -69,6 +82,13 @@ type BluetoothDevicePropertyVisitor struct {
 	// +optional
 	DataWrite map[string][]byte `json:"dataWrite,omitempty"`
 
+	// Specifies the expression to transform the default value, which is referred as "x", into the raw value to write,
+	// e.g. "(x + 40) * 10". The raw value is rounded and written in the byte range of "dataConverter",
+	// from the most significant byte at the "startIndex" to the least significant byte at the "endIndex".
+	// The "dataWrite" is ignored if the expression is specified.
+	// +optional
+	WriteExpression string `json:"writeExpression,omitempty"`
+
 	// Specifies the converter to convert data read from device to a string.
 	// +optional
 	DataConverter BluetoothDataConverter `json:"dataConverter,omitempty"`
@@ -118,6 +138,11 @@ type BluetoothDataConverter struct {
 	// +listType=atomic
 	// +optional
 	OrderOfOperations []BluetoothDeviceArithmeticOperation `json:"orderOfOperations,omitempty"`
+
+	// Specifies the expression to transform the shifted value, which is referred as "x",
+	// e.g. "clamp(x * 0.1 - 40, -40, 125)". The "orderOfOperations" is ignored if the expression is specified.
+	// +optional
+	ReadExpression string `json:"readExpression,omitempty"`
 }
 
 // BluetoothDeviceProperty defines an individual ble device property
@@ -138,8 +163,47 @@ type BluetoothDeviceProperty struct {
 	// Specifies the visitor of property.
 	// +kubebuilder:validation:Required
 	Visitor BluetoothDevicePropertyVisitor `json:"visitor"`
+
+	// Specifies the amount of interval that the property is synchronized to limb,
+	// the default value is the "syncInterval" of parameters.
+	// +optional
+	SyncInterval *v1.Duration `json:"syncInterval,omitempty"`
+
+	// Specifies the deadband of property, the property is synchronized to limb only if its value changed
+	// more than the deadband since the last synchronization, e.g. "0.5" or "5%" of the last synchronized value.
+	// The non-numeric value is synchronized if it changed.
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?%?$`
+	// +optional
+	Deadband string `json:"deadband,omitempty"`
+
+	// Specifies if the property is synchronized to limb only if its value changed since the last synchronization.
+	// The default value is "false".
+	// +optional
+	ReportOnChangeOnly bool `json:"reportOnChangeOnly,omitempty"`
+}
+
+func (in *BluetoothDeviceProperty) GetSyncInterval(params *BluetoothDeviceParameters) time.Duration {
+	if in != nil && in.SyncInterval != nil {
+		if duration := in.SyncInterval.Duration; duration > 0 {
+			return duration
+		}
+	}
+	return params.GetSyncInterval()
 }
 
+// BluetoothDevicePropertyQuality defines the quality of the property value.
+// Good: the value is reliable.
+// Uncertain: the value is available, but it may be inaccurate or stale.
+// Bad: the value is unavailable, e.g. failed to read it from device.
+// +kubebuilder:validation:Enum=Good;Uncertain;Bad
+type BluetoothDevicePropertyQuality string
+
+const (
+	BluetoothDevicePropertyQualityGood      BluetoothDevicePropertyQuality = "Good"
+	BluetoothDevicePropertyQualityUncertain BluetoothDevicePropertyQuality = "Uncertain"
+	BluetoothDevicePropertyQualityBad       BluetoothDevicePropertyQuality = "Bad"
+)
+
 // BluetoothDeviceStatusProperty defines the observed property of BluetoothDevice.
 type BluetoothDeviceStatusProperty struct {
 	// Reports the properties of device.
@@ -154,9 +218,27 @@ type BluetoothDeviceStatusProperty struct {
 	// +optional
 	AccessMode BluetoothDevicePropertyAccessMode `json:"accessMode,omitempty"`
 
+	// Reports the quality of property value,
+	// the value is the last available one if the quality is "Bad".
+	// +optional
+	Quality BluetoothDevicePropertyQuality `json:"quality,omitempty"`
+
+	// Reports the reason if the quality is not "Good".
+	// +optional
+	QualityReason string `json:"qualityReason,omitempty"`
+
+	// Reports the timestamp when the property value was sampled from the source,
+	// it's the received timestamp if the source doesn't provide one.
+	// +optional
+	SourceTimestamp *metav1.Time `json:"sourceTimestamp,omitempty"`
+
 	// Reports the updated timestamp of property.
 	// +optional
 	UpdatedAt *metav1.Time `json:"updatedAt,omitempty"`
+
+	// Reports the count of overruns, which means the scanning of property took longer than its sync interval.
+	// +optional
+	Overruns int32 `json:"overruns,omitempty"`
 }
 
 // BluetoothDeviceSpec defines the desired state of BluetoothDevice
@@ -0,0 +1,151 @@
+package v1alpha1
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BluetoothScannerParameters defines the desired parameters of BluetoothScanner.
+type BluetoothScannerParameters struct {
+	// Specifies the time window to collect the advertisements in each scanning.
+	// The default value is "10s".
+	// +kubebuilder:default="10s"
+	// +optional
+	ScanWindow metav1.Duration `json:"scanWindow,omitempty"`
+
+	// Specifies the interval of scanning, the next scanning starts after this interval since the last one started.
+	// The default value is "1m".
+	// +kubebuilder:default="1m"
+	// +optional
+	ScanInterval metav1.Duration `json:"scanInterval,omitempty"`
+}
+
+func (in *BluetoothScannerParameters) GetScanWindow() time.Duration {
+	if in != nil {
+		if duration := in.ScanWindow.Duration; duration > 0 {
+			return duration
+		}
+	}
+	return 10 * time.Second
+}
+
+func (in *BluetoothScannerParameters) GetScanInterval() time.Duration {
+	if in != nil {
+		if duration := in.ScanInterval.Duration; duration > 0 {
+			return duration
+		}
+	}
+	return time.Minute
+}
+
+// BluetoothScannerFilter defines the filter of the reported peripherals.
+type BluetoothScannerFilter struct {
+	// Specifies the prefix of the peripheral name, it's case-insensitive.
+	// +optional
+	NamePrefix string `json:"namePrefix,omitempty"`
+
+	// Specifies the service UUIDs, the peripheral is reported if it advertises any of them.
+	// +listType=set
+	// +optional
+	ServiceUUIDs []string `json:"serviceUUIDs,omitempty"`
+
+	// Specifies the minimum RSSI in dBm, e.g. "-80".
+	// +optional
+	MinRSSI *int32 `json:"minRSSI,omitempty"`
+}
+
+// BluetoothScannerSpec defines the desired state of BluetoothScanner.
+type BluetoothScannerSpec struct {
+	// Specifies the extension of scanner.
+	// +optional
+	Extension *BluetoothDeviceExtension `json:"extension,omitempty"`
+
+	// Specifies the parameters of scanner.
+	// +optional
+	Parameters *BluetoothScannerParameters `json:"parameters,omitempty"`
+
+	// Specifies the filter of the reported peripherals,
+	// all peripherals seen in the scanning window are reported if it's not specified.
+	// +optional
+	Filter *BluetoothScannerFilter `json:"filter,omitempty"`
+}
+
+// BluetoothScannerStatusPeripheral defines the observed peripheral of BluetoothScanner.
+type BluetoothScannerStatusPeripheral struct {
+	// Reports the address of peripheral, which can be used as the endpoint of BluetoothDevice.
+	// +optional
+	Address string `json:"address,omitempty"`
+
+	// Reports the advertised local name of peripheral.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Reports the latest RSSI of peripheral in dBm.
+	// +optional
+	RSSI int32 `json:"rssi,omitempty"`
+
+	// Reports the advertised service UUIDs of peripheral.
+	// +listType=set
+	// +optional
+	ServiceUUIDs []string `json:"serviceUUIDs,omitempty"`
+
+	// Reports the advertised manufacturer specific data of peripheral in hex.
+	// +optional
+	ManufacturerData string `json:"manufacturerData,omitempty"`
+
+	// Reports if the peripheral is connectable.
+	// +optional
+	Connectable bool `json:"connectable,omitempty"`
+
+	// Reports the timestamp when the latest advertisement of peripheral was received.
+	// +optional
+	LastSeenAt *metav1.Time `json:"lastSeenAt,omitempty"`
+}
+
+// BluetoothScannerStatus defines the observed state of BluetoothScanner.
+type BluetoothScannerStatus struct {
+	// Reports the peripherals seen in the last scanning window, which are ordered by the RSSI descending.
+	// +listType=atomic
+	// +optional
+	Peripherals []BluetoothScannerStatusPeripheral `json:"peripherals,omitempty"`
+
+	// Reports the timestamp when the last scanning finished.
+	// +optional
+	ScannedAt *metav1.Time `json:"scannedAt,omitempty"`
+
+	// Reports the reason if the last scanning failed,
+	// the peripherals are the ones of the last successful scanning.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +k8s:openapi-gen=true
+// +kubebuilder:resource:shortName=blescanner
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="SCANNED",type="date",JSONPath=`.status.scannedAt`
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=`.metadata.creationTimestamp`
+// BluetoothScanner is the schema for the BLE scanner API,
+// which reports the advertisements of the nearby peripherals.
+type BluetoothScanner struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BluetoothScannerSpec   `json:"spec,omitempty"`
+	Status BluetoothScannerStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +k8s:openapi-gen=true
+// BluetoothScannerList contains a list of BluetoothScanner.
+type BluetoothScannerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []BluetoothScanner `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BluetoothScanner{}, &BluetoothScannerList{})
+}
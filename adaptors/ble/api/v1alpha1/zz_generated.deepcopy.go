@@ -21,6 +21,7 @@
 
 import (
 	"github.com/rancher/octopus/pkg/mqtt/api"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -143,6 +144,7 @@ func (in *BluetoothDeviceParameters) DeepCopyInto(out *BluetoothDeviceParameters
 	*out = *in
 	out.SyncInterval = in.SyncInterval
 	out.Timeout = in.Timeout
+	out.MaxSilence = in.MaxSilence
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BluetoothDeviceParameters.
@@ -159,6 +161,11 @@ func (in *BluetoothDeviceParameters) DeepCopy() *BluetoothDeviceParameters {
 func (in *BluetoothDeviceProperty) DeepCopyInto(out *BluetoothDeviceProperty) {
 	*out = *in
 	in.Visitor.DeepCopyInto(&out.Visitor)
+	if in.SyncInterval != nil {
+		in, out := &in.SyncInterval, &out.SyncInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BluetoothDeviceProperty.
@@ -275,6 +282,10 @@ func (in *BluetoothDeviceStatus) DeepCopy() *BluetoothDeviceStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BluetoothDeviceStatusProperty) DeepCopyInto(out *BluetoothDeviceStatusProperty) {
 	*out = *in
+	if in.SourceTimestamp != nil {
+		in, out := &in.SourceTimestamp, &out.SourceTimestamp
+		*out = (*in).DeepCopy()
+	}
 	if in.UpdatedAt != nil {
 		in, out := &in.UpdatedAt, &out.UpdatedAt
 		*out = (*in).DeepCopy()
@@ -290,3 +301,184 @@ func (in *BluetoothDeviceStatusProperty) DeepCopy() *BluetoothDeviceStatusProper
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BluetoothScanner) DeepCopyInto(out *BluetoothScanner) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BluetoothScanner.
+func (in *BluetoothScanner) DeepCopy() *BluetoothScanner {
+	if in == nil {
+		return nil
+	}
+	out := new(BluetoothScanner)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BluetoothScanner) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BluetoothScannerFilter) DeepCopyInto(out *BluetoothScannerFilter) {
+	*out = *in
+	if in.ServiceUUIDs != nil {
+		in, out := &in.ServiceUUIDs, &out.ServiceUUIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MinRSSI != nil {
+		in, out := &in.MinRSSI, &out.MinRSSI
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BluetoothScannerFilter.
+func (in *BluetoothScannerFilter) DeepCopy() *BluetoothScannerFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(BluetoothScannerFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BluetoothScannerList) DeepCopyInto(out *BluetoothScannerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]BluetoothScanner, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BluetoothScannerList.
+func (in *BluetoothScannerList) DeepCopy() *BluetoothScannerList {
+	if in == nil {
+		return nil
+	}
+	out := new(BluetoothScannerList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BluetoothScannerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BluetoothScannerParameters) DeepCopyInto(out *BluetoothScannerParameters) {
+	*out = *in
+	out.ScanWindow = in.ScanWindow
+	out.ScanInterval = in.ScanInterval
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BluetoothScannerParameters.
+func (in *BluetoothScannerParameters) DeepCopy() *BluetoothScannerParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(BluetoothScannerParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BluetoothScannerSpec) DeepCopyInto(out *BluetoothScannerSpec) {
+	*out = *in
+	if in.Extension != nil {
+		in, out := &in.Extension, &out.Extension
+		*out = new(BluetoothDeviceExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = new(BluetoothScannerParameters)
+		**out = **in
+	}
+	if in.Filter != nil {
+		in, out := &in.Filter, &out.Filter
+		*out = new(BluetoothScannerFilter)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BluetoothScannerSpec.
+func (in *BluetoothScannerSpec) DeepCopy() *BluetoothScannerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BluetoothScannerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BluetoothScannerStatus) DeepCopyInto(out *BluetoothScannerStatus) {
+	*out = *in
+	if in.Peripherals != nil {
+		in, out := &in.Peripherals, &out.Peripherals
+		*out = make([]BluetoothScannerStatusPeripheral, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ScannedAt != nil {
+		in, out := &in.ScannedAt, &out.ScannedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BluetoothScannerStatus.
+func (in *BluetoothScannerStatus) DeepCopy() *BluetoothScannerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BluetoothScannerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BluetoothScannerStatusPeripheral) DeepCopyInto(out *BluetoothScannerStatusPeripheral) {
+	*out = *in
+	if in.ServiceUUIDs != nil {
+		in, out := &in.ServiceUUIDs, &out.ServiceUUIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastSeenAt != nil {
+		in, out := &in.LastSeenAt, &out.LastSeenAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BluetoothScannerStatusPeripheral.
+func (in *BluetoothScannerStatusPeripheral) DeepCopy() *BluetoothScannerStatusPeripheral {
+	if in == nil {
+		return nil
+	}
+	out := new(BluetoothScannerStatusPeripheral)
+	in.DeepCopyInto(out)
+	return out
+}
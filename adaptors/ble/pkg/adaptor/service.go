@@ -14,7 +14,7 @@
 
 	"github.com/rancher/octopus/adaptors/ble/api/v1alpha1"
 	"github.com/rancher/octopus/adaptors/ble/pkg/physical"
-	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha1"
+	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha2"
 	"github.com/rancher/octopus/pkg/adaptor/connection"
 	"github.com/rancher/octopus/pkg/adaptor/log"
 	"github.com/rancher/octopus/pkg/mqtt"
@@ -54,6 +54,9 @@ func (s *Service) toJSON(in metav1.Object) []byte {
 }
 
 func (s *Service) Connect(server api.Connection_ConnectServer) error {
+	// the devices sync to limb in their own goroutines
+	server = connection.SerializeSend(server)
+
 	var holder physical.Device
 	defer func() {
 		if holder != nil {
@@ -71,6 +74,12 @@ func (s *Service) Connect(server api.Connection_ConnectServer) error {
 			return nil
 		}
 
+		// executes command in background, the result is sent to limb when it's done
+		if command := req.GetCommand(); command != nil {
+			connection.ExecuteCommandInBackground(server, command, holder)
+			continue
+		}
+
 		// validates model GVK
 		var model = req.GetModel()
 		if model == nil {
@@ -123,7 +132,49 @@ func (s *Service) Connect(server api.Connection_ConnectServer) error {
 
 			// configures device
 			if err := holder.Configure(req.GetReferences(), &device); err != nil {
-				return status.Errorf(codes.InvalidArgument, "failed to connect to BLE device: %v", err)
+				return connection.NewConfigureError("failed to connect to BLE device", err)
+			}
+		case "BluetoothScanner":
+			// gets scanner spec
+			var scanner v1alpha1.BluetoothScanner
+			if err := jsoniter.Unmarshal(req.GetDevice(), &scanner); err != nil {
+				return status.Errorf(codes.InvalidArgument, "failed to unmarshal scanner: %v", err)
+			}
+
+			// creates scanner handler
+			if holder == nil {
+				// gets scanner namespaced name
+				var scannerName = object.GetNamespacedName(&scanner)
+				if scannerName.Namespace == "" || scannerName.Name == "" {
+					return status.Error(codes.InvalidArgument, "failed to recognize the empty scanner as the namespace/name is blank")
+				}
+
+				// gets log
+				var logger = log.WithValues("ble scanner", scannerName)
+
+				var toLimb = func(in *v1alpha1.BluetoothScanner) error {
+					// send scanner by {name, namespace, status} tuple
+					var resp = &v1alpha1.BluetoothScanner{}
+					resp.Namespace = in.Namespace
+					resp.Name = in.Name
+					resp.Status = in.Status
+
+					// convert scanner to json bytes
+					var respBytes = s.toJSON(resp)
+
+					// send scanner to limb
+					if err := server.Send(&api.ConnectResponse{Device: respBytes}); err != nil {
+						return status.Errorf(codes.Unknown, "failed to send scanner to limb, %v", err)
+					}
+					return nil
+				}
+
+				holder = physical.NewScanner(logger, scanner.ObjectMeta, toLimb, s.gattDevice)
+			}
+
+			// configures scanner
+			if err := holder.Configure(req.GetReferences(), &scanner); err != nil {
+				return connection.NewConfigureError("failed to configure BLE scanner", err)
 			}
 		default:
 			return status.Errorf(codes.InvalidArgument, "invalid model kind: %s", modelGVK.Kind)
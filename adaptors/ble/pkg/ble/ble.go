@@ -6,7 +6,7 @@
 
 	"github.com/rancher/octopus/adaptors/ble/pkg/adaptor"
 	"github.com/rancher/octopus/adaptors/ble/pkg/metadata"
-	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha1"
+	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha2"
 	"github.com/rancher/octopus/pkg/adaptor/connection"
 	"github.com/rancher/octopus/pkg/adaptor/log"
 	"github.com/rancher/octopus/pkg/adaptor/registration"
@@ -15,6 +15,8 @@
 
 // +kubebuilder:rbac:groups=devices.edge.cattle.io,resources=bluetoothdevices,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=devices.edge.cattle.io,resources=bluetoothdevices/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=devices.edge.cattle.io,resources=bluetoothscanners,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=devices.edge.cattle.io,resources=bluetoothscanners/status,verbs=get;update;patch
 
 func Run() error {
 	log.Info("Starting")
@@ -133,20 +133,37 @@ func (c *BLEController) readCharacteristic(p gatt.Peripheral, ch *gatt.Character
 	}
 	c.log.Info("ReadCharacteristic value", string(b))
 
-	convertedValue := fmt.Sprintf("%f", ConvertReadData(property.Visitor.DataConverter, b))
+	result, err := ConvertReadData(property.Visitor.DataConverter, b)
+	if err != nil {
+		// feedbacks the failure via the quality, the last available value is kept by the device.
+		c.updateDeviceStatusQuality(property.Name, property.AccessMode, err)
+		return "", err
+	}
+	convertedValue := fmt.Sprintf("%f", result)
 	c.log.Info("Converted read value to", convertedValue)
 	c.updateDeviceStatus(property.Name, convertedValue, property.AccessMode)
 	return convertedValue, nil
 }
 
 func (c *BLEController) writeCharacteristic(p gatt.Peripheral, ch *gatt.Characteristic, property v1alpha1.BluetoothDeviceProperty) error {
-	if len(property.Visitor.DataWrite) == 0 {
-		return fmt.Errorf("invalid length 0 of writeDataTo")
-	}
+	var byteData []byte
+	if property.Visitor.WriteExpression != "" {
+		var data, err = ConvertWriteData(property.Visitor)
+		if err != nil {
+			c.updateDeviceStatusQuality(property.Name, property.AccessMode, err)
+			return err
+		}
+		byteData = data
+	} else {
+		if len(property.Visitor.DataWrite) == 0 {
+			return fmt.Errorf("invalid length 0 of writeDataTo")
+		}
 
-	byteData, hasValue := findDataWriteToDeviceByDefaultValue(property.Visitor)
-	if !hasValue {
-		return fmt.Errorf("invalid length 0 of writeData")
+		var data, hasValue = findDataWriteToDeviceByDefaultValue(property.Visitor)
+		if !hasValue {
+			return fmt.Errorf("invalid length 0 of writeData")
+		}
+		byteData = data
 	}
 
 	err := p.WriteCharacteristic(ch, byteData, true)
@@ -191,11 +208,14 @@ func findDataWriteToDeviceByDefaultValue(visitor v1alpha1.BluetoothDevicePropert
 }
 
 func (c *BLEController) updateDeviceStatus(name, value string, accessMode v1alpha1.BluetoothDevicePropertyAccessMode) {
+	var updatedAt = now()
 	sp := v1alpha1.BluetoothDeviceStatusProperty{
-		Name:       name,
-		Value:      value,
-		AccessMode: accessMode,
-		UpdatedAt:  now(),
+		Name:            name,
+		Value:           value,
+		AccessMode:      accessMode,
+		Quality:         v1alpha1.BluetoothDevicePropertyQualityGood,
+		SourceTimestamp: updatedAt,
+		UpdatedAt:       updatedAt,
 	}
 	found := false
 	for i, property := range c.statusProps {
@@ -210,6 +230,25 @@ func (c *BLEController) updateDeviceStatus(name, value string, accessMode v1alph
 	}
 }
 
+// updateDeviceStatusQuality records the bad quality of property with the reason.
+func (c *BLEController) updateDeviceStatusQuality(name string, accessMode v1alpha1.BluetoothDevicePropertyAccessMode, reason error) {
+	var updatedAt = now()
+	sp := v1alpha1.BluetoothDeviceStatusProperty{
+		Name:          name,
+		AccessMode:    accessMode,
+		Quality:       v1alpha1.BluetoothDevicePropertyQualityBad,
+		QualityReason: reason.Error(),
+		UpdatedAt:     updatedAt,
+	}
+	for i, property := range c.statusProps {
+		if property.Name == sp.Name {
+			c.statusProps[i] = sp
+			return
+		}
+	}
+	c.statusProps = append(c.statusProps, sp)
+}
+
 func now() *metav1.Time {
 	var ret = metav1.Now()
 	return &ret
@@ -1,15 +1,22 @@
 package physical
 
 import (
+	"math"
 	"strconv"
 
+	"github.com/pkg/errors"
+
 	"github.com/rancher/octopus/adaptors/ble/api/v1alpha1"
-	"github.com/sirupsen/logrus"
+	"github.com/rancher/octopus/pkg/util/expression"
 )
 
 // ConvertReadData helps to convert the data read from the device into meaningful data
-func ConvertReadData(dataConverter v1alpha1.BluetoothDataConverter, data []byte) float64 {
-	var intermediateResult uint64
+func ConvertReadData(dataConverter v1alpha1.BluetoothDataConverter, data []byte) (float64, error) {
+	if dataConverter.StartIndex < 0 || dataConverter.EndIndex < 0 ||
+		dataConverter.StartIndex >= len(data) || dataConverter.EndIndex >= len(data) {
+		return 0, errors.Errorf("index range [%d, %d] is out of the %d bytes data", dataConverter.StartIndex, dataConverter.EndIndex, len(data))
+	}
+
 	var initialValue []byte
 	var initialStringValue = ""
 	if dataConverter.StartIndex <= dataConverter.EndIndex {
@@ -26,16 +33,24 @@ func ConvertReadData(dataConverter v1alpha1.BluetoothDataConverter, data []byte)
 	}
 	initialByteValue, _ := strconv.ParseUint(initialStringValue, 16, 16)
 
+	var intermediateResult = initialByteValue
 	if dataConverter.ShiftLeft != 0 {
 		intermediateResult = initialByteValue << dataConverter.ShiftLeft
 	} else if dataConverter.ShiftRight != 0 {
 		intermediateResult = initialByteValue >> dataConverter.ShiftRight
 	}
 	finalResult := float64(intermediateResult)
+	if dataConverter.ReadExpression != "" {
+		var result, err = expression.EvaluateValue(dataConverter.ReadExpression, finalResult)
+		if err != nil {
+			return 0, errors.Wrap(err, "failed to evaluate read expression")
+		}
+		return result, nil
+	}
 	for _, executeOperation := range dataConverter.OrderOfOperations {
 		operationValue, err := strconv.ParseFloat(executeOperation.Value, 64)
 		if err != nil {
-			logrus.Error(err, "failed to parse operation value")
+			return 0, errors.Wrapf(err, "failed to parse %s operation's value", executeOperation.Type)
 		}
 		switch executeOperation.Type {
 		case v1alpha1.BluetoothDeviceArithmeticAdd:
@@ -48,5 +63,52 @@ func ConvertReadData(dataConverter v1alpha1.BluetoothDataConverter, data []byte)
 			finalResult = finalResult / operationValue
 		}
 	}
-	return finalResult
+	return finalResult, nil
+}
+
+// ConvertWriteData helps to convert the default value of visitor into the data written to the device via the write expression,
+// the rounded raw value is placed in the byte range of the data converter, the most significant byte is at the start index.
+func ConvertWriteData(visitor v1alpha1.BluetoothDevicePropertyVisitor) ([]byte, error) {
+	var dataConverter = visitor.DataConverter
+	if dataConverter.StartIndex < 0 || dataConverter.EndIndex < 0 {
+		return nil, errors.Errorf("index range [%d, %d] is invalid", dataConverter.StartIndex, dataConverter.EndIndex)
+	}
+	var step, length = 1, dataConverter.EndIndex - dataConverter.StartIndex + 1
+	if length <= 0 {
+		step, length = -1, dataConverter.StartIndex-dataConverter.EndIndex+1
+	}
+	if length > 8 {
+		return nil, errors.Errorf("index range [%d, %d] is longer than 8 bytes", dataConverter.StartIndex, dataConverter.EndIndex)
+	}
+
+	var value, err = strconv.ParseFloat(visitor.DefaultValue, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to convert the default value to float64 for write expression")
+	}
+	result, err := expression.EvaluateValue(visitor.WriteExpression, value)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to evaluate write expression")
+	}
+	var raw = math.Round(result)
+	var bits = uint(8 * length)
+	if raw >= math.Ldexp(1, int(bits)) || raw < -math.Ldexp(1, int(bits)-1) {
+		return nil, errors.Errorf("raw value %v overflows %d bytes", raw, length)
+	}
+
+	var data = make([]byte, maxInt(dataConverter.StartIndex, dataConverter.EndIndex)+1)
+	var unsigned = uint64(int64(raw))
+	if raw >= 0 {
+		unsigned = uint64(raw)
+	}
+	for i, index := 0, dataConverter.EndIndex; i < length; i, index = i+1, index-step {
+		data[index] = byte(unsigned >> (8 * uint(i)))
+	}
+	return data, nil
+}
+
+func maxInt(x, y int) int {
+	if x > y {
+		return x
+	}
+	return y
 }
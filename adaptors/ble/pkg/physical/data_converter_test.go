@@ -15,6 +15,7 @@ type given struct {
 	}
 	type expect struct {
 		result float64
+		err    bool
 	}
 	var testCases = []struct {
 		given  given
@@ -50,11 +51,152 @@ type expect struct {
 				result: 72,
 			},
 		},
+		{
+			given: given{
+				data: []byte{0x00, 0x01, 0x02, 0x03},
+				converter: v1alpha1.BluetoothDataConverter{
+					StartIndex: 1,
+					EndIndex:   2,
+					ReadExpression: "x / 2 - 1",
+				},
+			},
+			expect: expect{
+				result: 8,
+			},
+		},
+		{
+			given: given{
+				data: []byte{0x00, 0x01, 0x02, 0x03},
+				converter: v1alpha1.BluetoothDataConverter{
+					StartIndex:     1,
+					EndIndex:       2,
+					ReadExpression: "x / y",
+				},
+			},
+			expect: expect{
+				err: true,
+			},
+		},
+		{
+			given: given{
+				data: []byte{0x00, 0x01},
+				converter: v1alpha1.BluetoothDataConverter{
+					StartIndex: 1,
+					EndIndex:   2,
+				},
+			},
+			expect: expect{
+				err: true,
+			},
+		},
+		{
+			given: given{
+				data: []byte{0x00, 0x01},
+				converter: v1alpha1.BluetoothDataConverter{
+					StartIndex: 0,
+					EndIndex:   1,
+					OrderOfOperations: []v1alpha1.BluetoothDeviceArithmeticOperation{
+						{Type: v1alpha1.BluetoothDeviceArithmeticAdd, Value: "one"},
+					},
+				},
+			},
+			expect: expect{
+				err: true,
+			},
+		},
 	}
 	for i, tc := range testCases {
-		var ret = ConvertReadData(tc.given.converter, tc.given.data)
+		var ret, err = ConvertReadData(tc.given.converter, tc.given.data)
+		if (err != nil) != tc.expect.err {
+			t.Errorf("case %v: expected error %v, got %v", i+1, tc.expect.err, err)
+			continue
+		}
 		if !reflect.DeepEqual(ret, tc.expect.result) {
 			t.Errorf("case %v: expected %s, got %s", i+1, spew.Sprintf("%#v", tc.expect), spew.Sprintf("%#v", ret))
 		}
 	}
 }
+
+func TestConvertWriteData(t *testing.T) {
+	type expect struct {
+		result []byte
+		err    bool
+	}
+	var testCases = []struct {
+		given  v1alpha1.BluetoothDevicePropertyVisitor
+		expect expect
+	}{
+		{
+			given: v1alpha1.BluetoothDevicePropertyVisitor{
+				DefaultValue:    "25.3",
+				WriteExpression: "(x + 40) * 10",
+				DataConverter: v1alpha1.BluetoothDataConverter{
+					StartIndex: 1,
+					EndIndex:   2,
+				},
+			},
+			expect: expect{
+				result: []byte{0x00, 0x02, 0x8d},
+			},
+		},
+		{
+			given: v1alpha1.BluetoothDevicePropertyVisitor{
+				DefaultValue:    "653",
+				WriteExpression: "x",
+				DataConverter: v1alpha1.BluetoothDataConverter{
+					StartIndex: 1,
+					EndIndex:   0,
+				},
+			},
+			expect: expect{
+				result: []byte{0x8d, 0x02},
+			},
+		},
+		{
+			given: v1alpha1.BluetoothDevicePropertyVisitor{
+				DefaultValue:    "-1",
+				WriteExpression: "x",
+			},
+			expect: expect{
+				result: []byte{0xff},
+			},
+		},
+		{
+			given: v1alpha1.BluetoothDevicePropertyVisitor{
+				DefaultValue:    "256",
+				WriteExpression: "x",
+			},
+			expect: expect{
+				err: true,
+			},
+		},
+		{
+			given: v1alpha1.BluetoothDevicePropertyVisitor{
+				DefaultValue:    "on",
+				WriteExpression: "x",
+			},
+			expect: expect{
+				err: true,
+			},
+		},
+		{
+			given: v1alpha1.BluetoothDevicePropertyVisitor{
+				DefaultValue:    "1",
+				WriteExpression: "x +",
+			},
+			expect: expect{
+				err: true,
+			},
+		},
+	}
+	for i, tc := range testCases {
+		var ret, err = ConvertWriteData(tc.given)
+		if (err != nil) != tc.expect.err {
+			t.Errorf("case %v: expected error %v, got %v", i+1, tc.expect.err, err)
+			continue
+		}
+		if !reflect.DeepEqual(ret, tc.expect.result) {
+			t.Errorf("case %v: expected %s, got %s", i+1, spew.Sprintf("%#v", tc.expect.result), spew.Sprintf("%#v", ret))
+		}
+	}
+}
@@ -12,13 +12,15 @@
 	"github.com/rancher/octopus/adaptors/ble/pkg/metadata"
 	"github.com/rancher/octopus/pkg/adaptor/socket/handler"
 	"github.com/rancher/octopus/pkg/mqtt"
+	"github.com/rancher/octopus/pkg/util/deadband"
 	"github.com/rancher/octopus/pkg/util/object"
+	"github.com/rancher/octopus/pkg/util/scheduler"
 
 	"github.com/bettercap/gatt"
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/types"
 
-	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha1"
+	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha2"
 
 	"github.com/rancher/octopus/adaptors/ble/api/v1alpha1"
 )
@@ -28,7 +30,7 @@ type Device interface {
 	// Shutdown uses to close the connection between adaptor and real(physical) device.
 	Shutdown()
 	// Configure uses to set up the device.
-	Configure(references api.ReferencesHandler, device *v1alpha1.BluetoothDevice) error
+	Configure(references api.ReferencesHandler, configuration interface{}) error
 }
 
 // NewDevice creates a Device.
@@ -55,16 +57,22 @@ type bleDevice struct {
 	name       types.NamespacedName
 	toLimb     BluetoothDeviceLimSyncer
 	gattDevice gatt.Device
+	reporter   deadband.Reporter
 
 	mqttClient mqtt.Client
 }
 
-func (d *bleDevice) Configure(references api.ReferencesHandler, device *v1alpha1.BluetoothDevice) error {
+func (d *bleDevice) Configure(references api.ReferencesHandler, configuration interface{}) error {
 	defer runtime.HandleCrash(handler.NewPanicsCleanupSocketHandler(metadata.Endpoint))
 
 	d.Lock()
 	defer d.Unlock()
 
+	var device, ok = configuration.(*v1alpha1.BluetoothDevice)
+	if !ok {
+		d.log.Error(errors.New("invalidate configuration type"), "Failed to configure")
+		return nil
+	}
 	var newSpec = device.Spec
 
 	// configures MQTT client if needed
@@ -115,7 +123,8 @@ func (d *bleDevice) refresh(newSpec v1alpha1.BluetoothDeviceSpec) error {
 	var status = d.instance.Status
 	var staleSpec = d.instance.Spec
 	if !reflect.DeepEqual(staleSpec.Protocol, newSpec.Protocol) ||
-		!reflect.DeepEqual(staleSpec.Parameters, newSpec.Parameters) {
+		!reflect.DeepEqual(staleSpec.Parameters, newSpec.Parameters) ||
+		!reflect.DeepEqual(staleSpec.Properties, newSpec.Properties) {
 		d.stopFetch()
 
 		var statusProps, err = d.scanDevice(newSpec)
@@ -126,17 +135,19 @@ func (d *bleDevice) refresh(newSpec v1alpha1.BluetoothDeviceSpec) error {
 	}
 
 	// fetches in backend
-	d.startFetch(newSpec.Parameters.GetSyncInterval())
+	d.startFetch(newSpec)
 
 	// records
 	d.instance.Spec = newSpec
 	d.instance.Status = status
+	d.reporter.MaxSilence = newSpec.Parameters.GetMaxSilence()
 	return d.sync()
 }
 
 // fetch is blocked, it is used to sync the ble device status periodically,
 // it's worth noting that it just reads the properties from bel device.
-func (d *bleDevice) fetch(interval time.Duration, stop <-chan struct{}) {
+// The properties are scanned in their own sync intervals, and the ones due at the same time are scanned together.
+func (d *bleDevice) fetch(spec v1alpha1.BluetoothDeviceSpec, stop <-chan struct{}) {
 	defer runtime.HandleCrash(handler.NewPanicsCleanupSocketHandler(metadata.Endpoint))
 
 	d.log.Info("Fetching")
@@ -144,38 +155,110 @@ func (d *bleDevice) fetch(interval time.Duration, stop <-chan struct{}) {
 		d.log.Info("Finished fetching")
 	}()
 
-	var ticker = time.NewTicker(interval)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-stop:
-			return
-		case <-ticker.C:
-		}
+	var intervals = make([]time.Duration, 0, len(spec.Properties))
+	for i := range spec.Properties {
+		intervals = append(intervals, spec.Properties[i].GetSyncInterval(spec.Parameters))
+	}
+	scheduler.New(intervals, time.Now()).Run(stop, d.fetchProperties, d.recordOverruns)
+}
 
-		d.Lock()
-		func() {
-			defer d.Unlock()
+// fetchProperties scans the given properties and fills them back to status.
+func (d *bleDevice) fetchProperties(indexes []int) {
+	d.Lock()
+	defer d.Unlock()
 
-			var props, err = d.scanDevice(d.instance.Spec)
-			if err != nil {
-				// TODO give a way to feedback this to limb.
-				d.log.Error(err, "failed to scan device")
-			} else {
-				d.instance.Status.Properties = props
+	var spec = d.instance.Spec
+	var props = make([]v1alpha1.BluetoothDeviceProperty, 0, len(indexes))
+	for _, idx := range indexes {
+		if idx < len(spec.Properties) {
+			props = append(props, spec.Properties[idx])
+		}
+	}
+	if len(props) == 0 {
+		return
+	}
+	spec.Properties = props
+
+	var changed = d.reporter.Expired(time.Now())
+	var scannedProps, err = d.scanDevice(spec)
+	if err != nil {
+		d.log.Error(err, "failed to scan device")
+		// the properties keep the last available values, and the failure is fed back via the quality.
+		for _, prop := range props {
+			if statusProp := findStatusProperty(d.instance.Status.Properties, prop.Name); statusProp != nil {
+				statusProp.Quality = v1alpha1.BluetoothDevicePropertyQualityBad
+				statusProp.QualityReason = err.Error()
+				statusProp.UpdatedAt = now()
+			}
+		}
+		changed = true
+	} else {
+		var statusProps = d.instance.Status.Properties
+		for _, scannedProp := range scannedProps {
+			var statusProp = findStatusProperty(statusProps, scannedProp.Name)
+			if !changed {
+				var policy deadband.Policy
+				for _, prop := range props {
+					if prop.Name == scannedProp.Name {
+						policy = deadband.Policy{Deadband: prop.Deadband, ReportOnChangeOnly: prop.ReportOnChangeOnly}
+						break
+					}
+				}
+				changed = (statusProp != nil && statusProp.Quality != scannedProp.Quality) ||
+					d.reporter.Changed(scannedProp.Name, policy, scannedProp.Value)
+			}
+			if statusProp == nil {
+				statusProps = append(statusProps, scannedProp)
+				continue
 			}
-			if err := d.sync(); err != nil {
-				d.log.Error(err, "failed to sync")
+			// keeps the last available value if failed to convert the data.
+			if scannedProp.Quality == v1alpha1.BluetoothDevicePropertyQualityBad {
+				scannedProp.Value = statusProp.Value
+				scannedProp.SourceTimestamp = statusProp.SourceTimestamp
 			}
-		}()
+			scannedProp.Overruns = statusProp.Overruns
+			*statusProp = scannedProp
+		}
+		d.instance.Status.Properties = statusProps
+	}
+	// the synchronization is skipped if none of the properties crossed its deadband.
+	if !changed {
+		d.log.V(4).Info("Skipped syncing, none of the properties changed")
+		return
+	}
+	if err := d.sync(); err != nil {
+		d.log.Error(err, "failed to sync")
+	}
+}
+
+// recordOverruns records the overruns of properties into status.
+func (d *bleDevice) recordOverruns(overruns []scheduler.Overrun) {
+	d.Lock()
+	defer d.Unlock()
+
+	var specProps = d.instance.Spec.Properties
+	for _, overrun := range overruns {
+		if overrun.Index >= len(specProps) {
+			continue
+		}
+		var name = specProps[overrun.Index].Name
+		d.log.Info("Overran fetching device property", "property", name, "interval", overrun.Interval, "elapsed", overrun.Elapsed)
+		if statusProp := findStatusProperty(d.instance.Status.Properties, name); statusProp != nil {
+			statusProp.Overruns++
+		}
+	}
+	if err := d.sync(); err != nil {
+		d.log.Error(err, "failed to sync")
+	}
+}
 
-		select {
-		case <-d.stop:
-			return
-		default:
+func findStatusProperty(statusProps []v1alpha1.BluetoothDeviceStatusProperty, name string) *v1alpha1.BluetoothDeviceStatusProperty {
+	for i := range statusProps {
+		if statusProps[i].Name == name {
+			return &statusProps[i]
 		}
 	}
+	return nil
 }
 
 func (d *bleDevice) scanDevice(spec v1alpha1.BluetoothDeviceSpec) ([]v1alpha1.BluetoothDeviceStatusProperty, error) {
@@ -185,6 +268,9 @@ func (d *bleDevice) scanDevice(spec v1alpha1.BluetoothDeviceSpec) ([]v1alpha1.Bl
 
 	d.log.V(4).Info("Scanning device")
 
+	gattLock.Lock()
+	defer gattLock.Unlock()
+
 	var ctrl = &BLEController{
 		endpoint:   spec.Protocol.Endpoint,
 		properties: spec.Properties,
@@ -218,10 +304,10 @@ func (d *bleDevice) stopFetch() {
 	}
 }
 
-func (d *bleDevice) startFetch(interval time.Duration) {
+func (d *bleDevice) startFetch(spec v1alpha1.BluetoothDeviceSpec) {
 	if d.stop == nil {
 		d.stop = make(chan struct{})
-		go d.fetch(interval, d.stop)
+		go d.fetch(spec, d.stop)
 	}
 }
 
@@ -238,5 +324,11 @@ func (d *bleDevice) sync() error {
 		}
 	}
 	d.log.V(1).Info("Synced")
+
+	var reported = make(map[string]string, len(d.instance.Status.Properties))
+	for _, statusProp := range d.instance.Status.Properties {
+		reported[statusProp.Name] = statusProp.Value
+	}
+	d.reporter.Report(time.Now(), reported)
 	return nil
 }
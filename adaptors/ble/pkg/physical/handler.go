@@ -6,3 +6,6 @@
 
 // BluetoothDeviceLimSyncer is used to sync ble device to limb.
 type BluetoothDeviceLimSyncer func(in *v1alpha1.BluetoothDevice) error
+
+// BluetoothScannerLimbSyncer is used to sync ble scanner to limb.
+type BluetoothScannerLimbSyncer func(in *v1alpha1.BluetoothScanner) error
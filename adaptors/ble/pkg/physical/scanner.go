@@ -0,0 +1,381 @@
+package physical
+
+import (
+	"encoding/hex"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bettercap/gatt"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/runtime"
+
+	"github.com/rancher/octopus/adaptors/ble/api/v1alpha1"
+	"github.com/rancher/octopus/adaptors/ble/pkg/metadata"
+	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha2"
+	"github.com/rancher/octopus/pkg/adaptor/socket/handler"
+	"github.com/rancher/octopus/pkg/mqtt"
+	"github.com/rancher/octopus/pkg/util/object"
+)
+
+// gattLock serializes the usage of gatt device,
+// as the handlers of gatt device are shared by all devices and scanners.
+var gattLock sync.Mutex
+
+// handlePeripheralDiscovered registers the handler of the discovered peripherals to gatt device,
+// it's replaceable in testing as the gatt handlers only work with the built-in gatt devices.
+var handlePeripheralDiscovered = func(gattDevice gatt.Device, f func(gatt.Peripheral, *gatt.Advertisement, int)) {
+	gattDevice.Handle(gatt.PeripheralDiscovered(f))
+}
+
+// NewScanner creates a Device to scan the nearby peripherals.
+func NewScanner(log logr.Logger, meta metav1.ObjectMeta, toLimb BluetoothScannerLimbSyncer, gattDevice gatt.Device) Device {
+	log.Info("Created ")
+	return &bleScanner{
+		log: log,
+		instance: &v1alpha1.BluetoothScanner{
+			ObjectMeta: meta,
+		},
+		toLimb:     toLimb,
+		gattDevice: gattDevice,
+	}
+}
+
+type bleScanner struct {
+	sync.Mutex
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+
+	log        logr.Logger
+	instance   *v1alpha1.BluetoothScanner
+	toLimb     BluetoothScannerLimbSyncer
+	gattDevice gatt.Device
+
+	mqttClient mqtt.Client
+}
+
+func (d *bleScanner) Configure(references api.ReferencesHandler, configuration interface{}) error {
+	defer runtime.HandleCrash(handler.NewPanicsCleanupSocketHandler(metadata.Endpoint))
+
+	d.Lock()
+	defer d.Unlock()
+
+	var scanner, ok = configuration.(*v1alpha1.BluetoothScanner)
+	if !ok {
+		d.log.Error(errors.New("invalidate configuration type"), "Failed to configure")
+		return nil
+	}
+	var newSpec = scanner.Spec
+	if _, err := parseServiceUUIDs(newSpec.Filter); err != nil {
+		return err
+	}
+
+	// configures MQTT client if needed
+	var staleExtension, newExtension v1alpha1.BluetoothDeviceExtension
+	if d.instance.Spec.Extension != nil {
+		staleExtension = *d.instance.Spec.Extension
+	}
+	if newSpec.Extension != nil {
+		newExtension = *newSpec.Extension
+	}
+	if !reflect.DeepEqual(staleExtension.MQTT, newExtension.MQTT) {
+		if d.mqttClient != nil {
+			d.mqttClient.Disconnect()
+			d.mqttClient = nil
+		}
+
+		if newExtension.MQTT != nil {
+			var cli, err = mqtt.NewClient(*newExtension.MQTT, object.GetControlledOwnerObjectReference(scanner), references)
+			if err != nil {
+				return errors.Wrap(err, "failed to create MQTT client")
+			}
+
+			err = cli.Connect()
+			if err != nil {
+				return errors.Wrap(err, "failed to connect MQTT broker")
+			}
+			d.mqttClient = cli
+		}
+	}
+
+	// restarts scanning if the parameters or filter changed
+	var staleSpec = d.instance.Spec
+	if !reflect.DeepEqual(staleSpec.Parameters, newSpec.Parameters) ||
+		!reflect.DeepEqual(staleSpec.Filter, newSpec.Filter) {
+		d.stopScan()
+	}
+	d.startScan(newSpec)
+
+	// records
+	d.instance.Spec = newSpec
+	return d.sync()
+}
+
+func (d *bleScanner) Shutdown() {
+	d.Lock()
+	d.stopScan()
+	if d.mqttClient != nil {
+		d.mqttClient.Disconnect()
+		d.mqttClient = nil
+	}
+	d.Unlock()
+
+	// the scanning is waited outside the lock, as it records the result with the lock.
+	d.wg.Wait()
+	d.log.Info("Shutdown")
+}
+
+// scan is blocked, it is used to scan the nearby peripherals periodically.
+func (d *bleScanner) scan(spec v1alpha1.BluetoothScannerSpec, stop <-chan struct{}) {
+	defer d.wg.Done()
+	defer runtime.HandleCrash(handler.NewPanicsCleanupSocketHandler(metadata.Endpoint))
+
+	d.log.Info("Scanning")
+	defer func() {
+		d.log.Info("Finished scanning")
+	}()
+
+	var ticker = time.NewTicker(spec.Parameters.GetScanInterval())
+	defer ticker.Stop()
+	for {
+		var peripherals, err = d.scanPeripherals(spec, stop)
+		d.recordPeripherals(peripherals, err, stop)
+
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// scanPeripherals collects the advertisements in the scanning window and returns the matched peripherals.
+func (d *bleScanner) scanPeripherals(spec v1alpha1.BluetoothScannerSpec, stop <-chan struct{}) ([]v1alpha1.BluetoothScannerStatusPeripheral, error) {
+	if d.gattDevice == nil {
+		return nil, nil
+	}
+
+	d.log.V(4).Info("Scanning peripherals")
+
+	gattLock.Lock()
+	defer gattLock.Unlock()
+
+	var collector = &advertisementCollector{}
+	var poweredOn = make(chan struct{})
+	var poweredOnOnce sync.Once
+	handlePeripheralDiscovered(d.gattDevice, collector.onPeripheralDiscovered)
+	var err = d.gattDevice.Init(func(gd gatt.Device, s gatt.State) {
+		if s != gatt.StatePoweredOn {
+			gd.StopScanning()
+			return
+		}
+		// the duplicated advertisements are received to refresh the RSSI.
+		gd.Scan([]gatt.UUID{}, true)
+		poweredOnOnce.Do(func() {
+			close(poweredOn)
+		})
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to init gatt device")
+	}
+
+	var window = time.NewTimer(spec.Parameters.GetScanWindow())
+	defer window.Stop()
+	select {
+	case <-stop:
+	case <-window.C:
+	}
+	d.gattDevice.StopScanning()
+	// drops the advertisements received after the scanning window
+	handlePeripheralDiscovered(d.gattDevice, nil)
+
+	select {
+	case <-poweredOn:
+	default:
+		return nil, errors.Errorf("bluetooth adapter is not powered on in %s", spec.Parameters.GetScanWindow())
+	}
+
+	d.log.V(4).Info("Finished scanning peripherals")
+	return collector.list(spec.Filter), nil
+}
+
+// recordPeripherals records the scanned peripherals into status.
+func (d *bleScanner) recordPeripherals(peripherals []v1alpha1.BluetoothScannerStatusPeripheral, err error, stop <-chan struct{}) {
+	d.Lock()
+	defer d.Unlock()
+
+	// drops the result of the stopped scanning
+	select {
+	case <-stop:
+		return
+	default:
+	}
+
+	if err != nil {
+		d.log.Error(err, "Failed to scan peripherals")
+		// the peripherals of the last successful scanning are kept.
+		d.instance.Status.Reason = err.Error()
+	} else {
+		d.instance.Status.Peripherals = peripherals
+		d.instance.Status.Reason = ""
+	}
+	d.instance.Status.ScannedAt = now()
+	if err := d.sync(); err != nil {
+		d.log.Error(err, "failed to sync")
+	}
+}
+
+func (d *bleScanner) stopScan() {
+	if d.stop != nil {
+		close(d.stop)
+		d.stop = nil
+	}
+}
+
+func (d *bleScanner) startScan(spec v1alpha1.BluetoothScannerSpec) {
+	if d.stop == nil {
+		d.stop = make(chan struct{})
+		d.wg.Add(1)
+		go d.scan(spec, d.stop)
+	}
+}
+
+// sync combines all synchronization operations.
+func (d *bleScanner) sync() error {
+	if d.toLimb != nil {
+		if err := d.toLimb(d.instance); err != nil {
+			return err
+		}
+	}
+	if d.mqttClient != nil {
+		if err := d.mqttClient.Publish(mqtt.PublishMessage{Payload: d.instance.Status}); err != nil {
+			return err
+		}
+	}
+	d.log.V(1).Info("Synced")
+	return nil
+}
+
+// advertisementCollector collects the advertisements of peripherals, which are merged by the peripheral address.
+type advertisementCollector struct {
+	sync.Mutex
+
+	peripherals map[string]*v1alpha1.BluetoothScannerStatusPeripheral
+}
+
+func (c *advertisementCollector) onPeripheralDiscovered(p gatt.Peripheral, a *gatt.Advertisement, rssi int) {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.peripherals == nil {
+		c.peripherals = make(map[string]*v1alpha1.BluetoothScannerStatusPeripheral)
+	}
+	var address = strings.ToUpper(p.ID())
+	var peripheral, exist = c.peripherals[address]
+	if !exist {
+		peripheral = &v1alpha1.BluetoothScannerStatusPeripheral{Address: address}
+		c.peripherals[address] = peripheral
+	}
+	peripheral.RSSI = int32(rssi)
+	peripheral.LastSeenAt = now()
+	if a == nil {
+		return
+	}
+
+	// the scan response doesn't carry the fields of advertising packet,
+	// so the fields received before are kept.
+	if a.LocalName != "" {
+		peripheral.Name = a.LocalName
+	}
+	if len(a.ManufacturerData) != 0 {
+		peripheral.ManufacturerData = hex.EncodeToString(a.ManufacturerData)
+	}
+	if a.Connectable {
+		peripheral.Connectable = true
+	}
+	for _, services := range [][]gatt.UUID{a.Services, a.OverflowService} {
+		for _, service := range services {
+			var uuid = service.String()
+			if !containsString(peripheral.ServiceUUIDs, uuid) {
+				peripheral.ServiceUUIDs = append(peripheral.ServiceUUIDs, uuid)
+			}
+		}
+	}
+}
+
+// list returns the peripherals matched the filter, which are ordered by the RSSI descending.
+func (c *advertisementCollector) list(filter *v1alpha1.BluetoothScannerFilter) []v1alpha1.BluetoothScannerStatusPeripheral {
+	c.Lock()
+	defer c.Unlock()
+
+	// the service UUIDs have been validated in configuring
+	var serviceUUIDs, _ = parseServiceUUIDs(filter)
+	var ret = make([]v1alpha1.BluetoothScannerStatusPeripheral, 0, len(c.peripherals))
+	for _, peripheral := range c.peripherals {
+		if !matchPeripheral(filter, serviceUUIDs, peripheral) {
+			continue
+		}
+		ret = append(ret, *peripheral)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].RSSI != ret[j].RSSI {
+			return ret[i].RSSI > ret[j].RSSI
+		}
+		return ret[i].Address < ret[j].Address
+	})
+	return ret
+}
+
+// matchPeripheral returns true if the peripheral matches the filter.
+func matchPeripheral(filter *v1alpha1.BluetoothScannerFilter, serviceUUIDs []gatt.UUID, peripheral *v1alpha1.BluetoothScannerStatusPeripheral) bool {
+	if filter == nil {
+		return true
+	}
+	if filter.NamePrefix != "" &&
+		!strings.HasPrefix(strings.ToUpper(peripheral.Name), strings.ToUpper(filter.NamePrefix)) {
+		return false
+	}
+	if filter.MinRSSI != nil && peripheral.RSSI < *filter.MinRSSI {
+		return false
+	}
+	if len(serviceUUIDs) != 0 {
+		for _, uuid := range peripheral.ServiceUUIDs {
+			var parsed, err = gatt.ParseUUID(uuid)
+			if err == nil && gatt.UUIDContains(serviceUUIDs, parsed) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// parseServiceUUIDs parses the service UUIDs of filter.
+func parseServiceUUIDs(filter *v1alpha1.BluetoothScannerFilter) ([]gatt.UUID, error) {
+	if filter == nil {
+		return nil, nil
+	}
+	var ret = make([]gatt.UUID, 0, len(filter.ServiceUUIDs))
+	for _, uuid := range filter.ServiceUUIDs {
+		var parsed, err = gatt.ParseUUID(uuid)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse service UUID %s", uuid)
+		}
+		ret = append(ret, parsed)
+	}
+	return ret, nil
+}
+
+func containsString(items []string, item string) bool {
+	for _, i := range items {
+		if i == item {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,307 @@
+package physical
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bettercap/gatt"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/rancher/octopus/adaptors/ble/api/v1alpha1"
+	"github.com/rancher/octopus/pkg/util/log/zap"
+)
+
+type fakeAdvertisement struct {
+	address       string
+	advertisement *gatt.Advertisement
+	rssi          int
+}
+
+// fakePeripheral simulates the discovered peripheral, which only knows its address.
+type fakePeripheral struct {
+	gatt.Peripheral
+
+	id string
+}
+
+func (p fakePeripheral) ID() string {
+	return p.id
+}
+
+// fakeGattDevice simulates the gatt device, which emits the given advertisements once starting scanning.
+type fakeGattDevice struct {
+	gatt.Device
+	sync.Mutex
+
+	state          gatt.State
+	advertisements []fakeAdvertisement
+	discovered     func(gatt.Peripheral, *gatt.Advertisement, int)
+	scanning       bool
+}
+
+func (d *fakeGattDevice) Init(stateChanged func(gatt.Device, gatt.State)) error {
+	d.Lock()
+	var state = d.state
+	d.Unlock()
+
+	go stateChanged(d, state)
+	return nil
+}
+
+func (d *fakeGattDevice) Scan(_ []gatt.UUID, _ bool) {
+	d.Lock()
+	d.scanning = true
+	var discovered = d.discovered
+	d.Unlock()
+
+	if discovered == nil {
+		return
+	}
+	for _, adv := range d.advertisements {
+		discovered(fakePeripheral{id: adv.address}, adv.advertisement, adv.rssi)
+	}
+}
+
+func (d *fakeGattDevice) StopScanning() {
+	d.Lock()
+	defer d.Unlock()
+	d.scanning = false
+}
+
+func (d *fakeGattDevice) isScanning() bool {
+	d.Lock()
+	defer d.Unlock()
+	return d.scanning
+}
+
+func (d *fakeGattDevice) handle(f func(gatt.Peripheral, *gatt.Advertisement, int)) {
+	d.Lock()
+	defer d.Unlock()
+	d.discovered = f
+}
+
+// useFakeGattDevice makes the handlers register to fake gatt device,
+// and returns a function to restore.
+func useFakeGattDevice() func() {
+	var previous = handlePeripheralDiscovered
+	handlePeripheralDiscovered = func(gattDevice gatt.Device, f func(gatt.Peripheral, *gatt.Advertisement, int)) {
+		gattDevice.(*fakeGattDevice).handle(f)
+	}
+	return func() {
+		handlePeripheralDiscovered = previous
+	}
+}
+
+func newTestAdvertisements() []fakeAdvertisement {
+	return []fakeAdvertisement{
+		{
+			address: "aa:bb:cc:00:00:01",
+			advertisement: &gatt.Advertisement{
+				Services:         []gatt.UUID{gatt.UUID16(0x180f)},
+				ManufacturerData: []byte{0x4c, 0x00, 0x02, 0x15},
+				Connectable:      true,
+			},
+			rssi: -70,
+		},
+		{
+			// scan response
+			address: "aa:bb:cc:00:00:01",
+			advertisement: &gatt.Advertisement{
+				LocalName: "Thermo-1",
+			},
+			rssi: -60,
+		},
+		{
+			address: "aa:bb:cc:00:00:02",
+			advertisement: &gatt.Advertisement{
+				LocalName: "Band",
+				Services:  []gatt.UUID{gatt.MustParseUUID("0000fee0-0000-1000-8000-00805f9b34fb")},
+			},
+			rssi: -85,
+		},
+		{
+			address: "aa:bb:cc:00:00:03",
+			advertisement: &gatt.Advertisement{
+				LocalName: "thermo-2",
+			},
+			rssi: -60,
+		},
+	}
+}
+
+func TestBLEScanner_ScanPeripherals(t *testing.T) {
+	defer useFakeGattDevice()()
+
+	var minRSSI int32 = -65
+	var testCases = []struct {
+		name     string
+		given    v1alpha1.BluetoothScannerSpec
+		state    gatt.State
+		expected []string
+		err      bool
+	}{
+		{
+			name:     "all",
+			state:    gatt.StatePoweredOn,
+			expected: []string{"AA:BB:CC:00:00:01", "AA:BB:CC:00:00:03", "AA:BB:CC:00:00:02"},
+		},
+		{
+			name:     "name prefix",
+			given:    v1alpha1.BluetoothScannerSpec{Filter: &v1alpha1.BluetoothScannerFilter{NamePrefix: "THERMO"}},
+			state:    gatt.StatePoweredOn,
+			expected: []string{"AA:BB:CC:00:00:01", "AA:BB:CC:00:00:03"},
+		},
+		{
+			name:     "service uuids",
+			given:    v1alpha1.BluetoothScannerSpec{Filter: &v1alpha1.BluetoothScannerFilter{ServiceUUIDs: []string{"0000FEE0-0000-1000-8000-00805F9B34FB", "2A00"}}},
+			state:    gatt.StatePoweredOn,
+			expected: []string{"AA:BB:CC:00:00:02"},
+		},
+		{
+			name:     "min rssi",
+			given:    v1alpha1.BluetoothScannerSpec{Filter: &v1alpha1.BluetoothScannerFilter{MinRSSI: &minRSSI}},
+			state:    gatt.StatePoweredOn,
+			expected: []string{"AA:BB:CC:00:00:01", "AA:BB:CC:00:00:03"},
+		},
+		{
+			name:  "powered off",
+			state: gatt.StatePoweredOff,
+			err:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		var gattDevice = &fakeGattDevice{
+			state:          tc.state,
+			advertisements: newTestAdvertisements(),
+		}
+		var scanner = &bleScanner{
+			log:        zap.WrapAsLogr(zap.NewDevelopmentLogger()),
+			gattDevice: gattDevice,
+		}
+		tc.given.Parameters = &v1alpha1.BluetoothScannerParameters{
+			ScanWindow: metav1.Duration{Duration: 100 * time.Millisecond},
+		}
+
+		var ret, err = scanner.scanPeripherals(tc.given, make(chan struct{}))
+		assert.False(t, gattDevice.isScanning(), "case %s", tc.name)
+		if tc.err {
+			assert.Error(t, err, "case %s", tc.name)
+			continue
+		}
+		if !assert.NoError(t, err, "case %s", tc.name) {
+			continue
+		}
+		var addresses = make([]string, 0, len(ret))
+		for _, p := range ret {
+			addresses = append(addresses, p.Address)
+			assert.NotNil(t, p.LastSeenAt, "case %s", tc.name)
+		}
+		assert.Equal(t, tc.expected, addresses, "case %s", tc.name)
+	}
+}
+
+func TestAdvertisementCollector(t *testing.T) {
+	var collector = &advertisementCollector{}
+	for _, adv := range newTestAdvertisements() {
+		collector.onPeripheralDiscovered(fakePeripheral{id: adv.address}, adv.advertisement, adv.rssi)
+	}
+
+	var ret = collector.list(nil)
+	if !assert.Len(t, ret, 3) {
+		return
+	}
+	// merges the advertising packet and the scan response
+	var merged = ret[0]
+	merged.LastSeenAt = nil
+	assert.Equal(t, v1alpha1.BluetoothScannerStatusPeripheral{
+		Address:          "AA:BB:CC:00:00:01",
+		Name:             "Thermo-1",
+		RSSI:             -60,
+		ServiceUUIDs:     []string{"180f"},
+		ManufacturerData: "4c000215",
+		Connectable:      true,
+	}, merged)
+	assert.Equal(t, []string{"0000fee000001000800000805f9b34fb"}, ret[2].ServiceUUIDs)
+}
+
+func TestBLEScanner(t *testing.T) {
+	defer useFakeGattDevice()()
+
+	var gattDevice = &fakeGattDevice{
+		state:          gatt.StatePoweredOn,
+		advertisements: newTestAdvertisements(),
+	}
+	var mu sync.Mutex
+	var synced []v1alpha1.BluetoothScannerStatus
+	var toLimb = func(in *v1alpha1.BluetoothScanner) error {
+		mu.Lock()
+		defer mu.Unlock()
+		synced = append(synced, *in.Status.DeepCopy())
+		return nil
+	}
+	var lastSynced = func() v1alpha1.BluetoothScannerStatus {
+		mu.Lock()
+		defer mu.Unlock()
+		if len(synced) == 0 {
+			return v1alpha1.BluetoothScannerStatus{}
+		}
+		return synced[len(synced)-1]
+	}
+
+	var scanner = NewScanner(zap.WrapAsLogr(zap.NewDevelopmentLogger()), metav1.ObjectMeta{Namespace: "default", Name: "scanner"}, toLimb, gattDevice)
+	defer scanner.Shutdown()
+
+	// invalid filter
+	var err = scanner.Configure(nil, &v1alpha1.BluetoothScanner{
+		Spec: v1alpha1.BluetoothScannerSpec{
+			Filter: &v1alpha1.BluetoothScannerFilter{ServiceUUIDs: []string{"xyz"}},
+		},
+	})
+	assert.Error(t, err)
+
+	err = scanner.Configure(nil, &v1alpha1.BluetoothScanner{
+		Spec: v1alpha1.BluetoothScannerSpec{
+			Parameters: &v1alpha1.BluetoothScannerParameters{
+				ScanWindow:   metav1.Duration{Duration: 50 * time.Millisecond},
+				ScanInterval: metav1.Duration{Duration: 100 * time.Millisecond},
+			},
+		},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Eventually(t, func() bool {
+		var status = lastSynced()
+		return len(status.Peripherals) == 3 && status.ScannedAt != nil && status.Reason == ""
+	}, 5*time.Second, 50*time.Millisecond)
+
+	// rescans with the new filter
+	err = scanner.Configure(nil, &v1alpha1.BluetoothScanner{
+		Spec: v1alpha1.BluetoothScannerSpec{
+			Parameters: &v1alpha1.BluetoothScannerParameters{
+				ScanWindow:   metav1.Duration{Duration: 50 * time.Millisecond},
+				ScanInterval: metav1.Duration{Duration: 100 * time.Millisecond},
+			},
+			Filter: &v1alpha1.BluetoothScannerFilter{NamePrefix: "band"},
+		},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Eventually(t, func() bool {
+		var status = lastSynced()
+		return len(status.Peripherals) == 1 && status.Peripherals[0].Name == "Band"
+	}, 5*time.Second, 50*time.Millisecond)
+
+	// keeps the last peripherals if the adapter is powered off
+	gattDevice.Lock()
+	gattDevice.state = gatt.StatePoweredOff
+	gattDevice.Unlock()
+	assert.Eventually(t, func() bool {
+		var status = lastSynced()
+		return len(status.Peripherals) == 1 && status.Reason != ""
+	}, 5*time.Second, 50*time.Millisecond)
+}
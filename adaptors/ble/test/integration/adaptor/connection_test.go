@@ -12,8 +12,8 @@
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/rancher/octopus/adaptors/ble/pkg/adaptor"
-	"github.com/rancher/octopus/pkg/adaptor/api/v1alpha1"
-	mock_v1alpha1 "github.com/rancher/octopus/pkg/adaptor/api/v1alpha1/mock"
+	"github.com/rancher/octopus/pkg/adaptor/api/v1alpha2"
+	mock_v1alpha2 "github.com/rancher/octopus/pkg/adaptor/api/v1alpha2/mock"
 )
 
 var _ = Describe("verify Connection", func() {
@@ -40,10 +40,10 @@
 
 	Context("on Connect server", func() {
 
-		var mockServer *mock_v1alpha1.MockConnection_ConnectServer
+		var mockServer *mock_v1alpha2.MockConnection_ConnectServer
 
 		BeforeEach(func() {
-			mockServer = mock_v1alpha1.NewMockConnection_ConnectServer(mockCtrl)
+			mockServer = mock_v1alpha2.NewMockConnection_ConnectServer(mockCtrl)
 		})
 
 		It("should be stopped if closed", func() {
@@ -75,7 +75,7 @@
 
 		It("should process the input device", func() {
 			// failed unmarshal
-			mockServer.EXPECT().Recv().Return(&v1alpha1.ConnectRequest{
+			mockServer.EXPECT().Recv().Return(&v1alpha2.ConnectRequest{
 				Model: &metav1.TypeMeta{
 					APIVersion: "devices.edge.cattle.io/v1alpha1",
 					Kind:       "BluetoothDevice",
@@ -89,7 +89,7 @@
 
 			// failed to connect a device
 			mockServer.EXPECT().Send(gomock.Any()).Return(nil).AnyTimes()
-			mockServer.EXPECT().Recv().Return(&v1alpha1.ConnectRequest{
+			mockServer.EXPECT().Recv().Return(&v1alpha2.ConnectRequest{
 				Model: &metav1.TypeMeta{
 					APIVersion: "devices.edge.cattle.io/v1alpha1",
 					Kind:       "BluetoothDevice",
@@ -1,6 +1,8 @@
 package v1alpha1
 
 import (
+	"time"
+
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -44,6 +46,21 @@ type DummyProtocolDeviceProperty struct {
 	// Specifies the object property if the type is "object".
 	// +optional
 	ObjectProperties map[string]DummyProtocolDeviceObjectOrArrayProperty `json:"objectProperties,omitempty"`
+
+	// Specifies the amount of interval that the property is mocked and synchronized to limb,
+	// only available in the top level properties.
+	// The default value is "2s".
+	// +optional
+	SyncInterval *metav1.Duration `json:"syncInterval,omitempty"`
+}
+
+func (in *DummyProtocolDeviceProperty) GetSyncInterval() time.Duration {
+	if in != nil && in.SyncInterval != nil {
+		if duration := in.SyncInterval.Duration; duration > 0 {
+			return duration
+		}
+	}
+	return 2 * time.Second
 }
 
 // DummyProtocolDeviceProtocol defines the desired protocol of DummyProtocolDevice.
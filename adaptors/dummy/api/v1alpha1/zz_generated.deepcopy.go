@@ -21,6 +21,7 @@
 
 import (
 	"github.com/rancher/octopus/pkg/mqtt/api"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -134,6 +135,11 @@ func (in *DummyProtocolDeviceProperty) DeepCopyInto(out *DummyProtocolDeviceProp
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.SyncInterval != nil {
+		in, out := &in.SyncInterval, &out.SyncInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DummyProtocolDeviceProperty.
@@ -2,6 +2,7 @@
 
 import (
 	"reflect"
+	"sort"
 	"sync"
 	"time"
 
@@ -12,10 +13,11 @@
 
 	"github.com/rancher/octopus/adaptors/dummy/api/v1alpha1"
 	"github.com/rancher/octopus/adaptors/dummy/pkg/metadata"
-	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha1"
+	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha2"
 	"github.com/rancher/octopus/pkg/adaptor/socket/handler"
 	"github.com/rancher/octopus/pkg/mqtt"
 	"github.com/rancher/octopus/pkg/util/object"
+	"github.com/rancher/octopus/pkg/util/scheduler"
 )
 
 func NewProtocolDevice(log logr.Logger, meta metav1.ObjectMeta, toLimb DummyProtocolDeviceLimbSyncer) Device {
@@ -109,7 +111,7 @@ func (d *protocolDevice) refresh(newSpec v1alpha1.DummyProtocolDeviceSpec) error
 	}
 
 	// mocks in backend
-	d.startMock()
+	d.startMock(newSpec)
 
 	// records
 	d.instance.Spec = newSpec
@@ -119,7 +121,8 @@ func (d *protocolDevice) refresh(newSpec v1alpha1.DummyProtocolDeviceSpec) error
 
 // mock is blocked, it is used to simulate real device state changes
 // and synchronize the changed values back to the limb.
-func (d *protocolDevice) mock(stop <-chan struct{}) {
+// The properties are mocked in their own sync intervals, and the ones due at the same time are mocked together.
+func (d *protocolDevice) mock(spec v1alpha1.DummyProtocolDeviceSpec, stop <-chan struct{}) {
 	defer runtime.HandleCrash(handler.NewPanicsCleanupSocketHandler(metadata.Endpoint))
 
 	d.log.Info("Mocking")
@@ -127,32 +130,39 @@ func (d *protocolDevice) mock(stop <-chan struct{}) {
 		d.log.Info("Finished mocking")
 	}()
 
-	var ticker = time.NewTicker(2 * time.Second)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-stop:
-			return
-		case <-ticker.C:
-		}
+	var names = make([]string, 0, len(spec.Properties))
+	for name := range spec.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var intervals = make([]time.Duration, 0, len(names))
+	for _, name := range names {
+		var prop = spec.Properties[name]
+		intervals = append(intervals, prop.GetSyncInterval())
+	}
 
+	var run = func(indexes []int) {
 		d.Lock()
-		func() {
-			defer d.Unlock()
+		defer d.Unlock()
 
-			fillStatusObject(d.instance.Spec.Properties, d.instance.Status.Properties)
-			if err := d.sync(); err != nil {
-				d.log.Error(err, "failed to sync")
+		var props = make(map[string]v1alpha1.DummyProtocolDeviceProperty, len(indexes))
+		for _, idx := range indexes {
+			var name = names[idx]
+			if prop, exist := d.instance.Spec.Properties[name]; exist {
+				props[name] = prop
 			}
-		}()
-
-		select {
-		case <-stop:
-			return
-		default:
+		}
+		fillStatusObject(props, d.instance.Status.Properties)
+		if err := d.sync(); err != nil {
+			d.log.Error(err, "failed to sync")
+		}
+	}
+	var overrun = func(overruns []scheduler.Overrun) {
+		for _, o := range overruns {
+			d.log.Info("Overran mocking device property", "property", names[o.Index], "interval", o.Interval, "elapsed", o.Elapsed)
 		}
 	}
+	scheduler.New(intervals, time.Now()).Run(stop, run, overrun)
 }
 
 func (d *protocolDevice) stopMock() {
@@ -162,10 +172,10 @@ func (d *protocolDevice) stopMock() {
 	}
 }
 
-func (d *protocolDevice) startMock() {
+func (d *protocolDevice) startMock(spec v1alpha1.DummyProtocolDeviceSpec) {
 	if d.stop == nil {
 		d.stop = make(chan struct{})
-		go d.mock(d.stop)
+		go d.mock(spec, d.stop)
 	}
 }
 
@@ -1,6 +1,7 @@
 package physical
 
 import (
+	"encoding/json"
 	"reflect"
 	"sync"
 	"time"
@@ -12,7 +13,8 @@
 
 	"github.com/rancher/octopus/adaptors/dummy/api/v1alpha1"
 	"github.com/rancher/octopus/adaptors/dummy/pkg/metadata"
-	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha1"
+	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha2"
+	"github.com/rancher/octopus/pkg/adaptor/connection"
 	"github.com/rancher/octopus/pkg/adaptor/socket/handler"
 	"github.com/rancher/octopus/pkg/mqtt"
 	"github.com/rancher/octopus/pkg/util/object"
@@ -96,6 +98,35 @@ func (d *specialDevice) Shutdown() {
 	d.log.Info("Shutdown")
 }
 
+// Execute executes the named command on the device,
+// it only supports the `reset` command to reset the rotating speed to the initial value of current gear.
+func (d *specialDevice) Execute(name string, _ []byte) ([]byte, error) {
+	if name != "reset" {
+		return nil, connection.NewUnsupportedCommandError(name)
+	}
+
+	d.Lock()
+	defer d.Unlock()
+
+	if !d.instance.Spec.On {
+		return nil, errors.New("device is off")
+	}
+
+	var status = &d.instance.Status
+	switch status.Gear {
+	case v1alpha1.DummySpecialDeviceGearFast:
+		status.RotatingSpeed = 200
+	case v1alpha1.DummySpecialDeviceGearMiddle:
+		status.RotatingSpeed = 100
+	case v1alpha1.DummySpecialDeviceGearSlow:
+		status.RotatingSpeed = 0
+	}
+	if err := d.sync(); err != nil {
+		return nil, err
+	}
+	return json.Marshal(map[string]interface{}{"rotatingSpeed": status.RotatingSpeed})
+}
+
 // refresh refreshes the status with new spec.
 func (d *specialDevice) refresh(newSpec v1alpha1.DummySpecialDeviceSpec) error {
 	var status = d.instance.Status
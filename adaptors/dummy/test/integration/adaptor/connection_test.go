@@ -11,8 +11,8 @@
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/rancher/octopus/adaptors/dummy/pkg/adaptor"
-	"github.com/rancher/octopus/pkg/adaptor/api/v1alpha1"
-	mock_v1alpha1 "github.com/rancher/octopus/pkg/adaptor/api/v1alpha1/mock"
+	"github.com/rancher/octopus/pkg/adaptor/api/v1alpha2"
+	mock_v1alpha2 "github.com/rancher/octopus/pkg/adaptor/api/v1alpha2/mock"
 )
 
 var _ = Describe("verify Connection", func() {
@@ -34,10 +34,10 @@
 
 	Context("on Connect server", func() {
 
-		var mockServer *mock_v1alpha1.MockConnection_ConnectServer
+		var mockServer *mock_v1alpha2.MockConnection_ConnectServer
 
 		BeforeEach(func() {
-			mockServer = mock_v1alpha1.NewMockConnection_ConnectServer(mockCtrl)
+			mockServer = mock_v1alpha2.NewMockConnection_ConnectServer(mockCtrl)
 		})
 
 		It("should be stopped if closed", func() {
@@ -69,7 +69,7 @@
 
 		It("should process the input model", func() {
 			// failed as model is nil
-			mockServer.EXPECT().Recv().Return(&v1alpha1.ConnectRequest{
+			mockServer.EXPECT().Recv().Return(&v1alpha2.ConnectRequest{
 				Model: nil,
 			}, nil)
 			err = service.Connect(mockServer)
@@ -78,7 +78,7 @@
 			Expect(sts.Message()).To(HavePrefix("invalid empty model"))
 
 			// failed as invalidate group
-			mockServer.EXPECT().Recv().Return(&v1alpha1.ConnectRequest{
+			mockServer.EXPECT().Recv().Return(&v1alpha2.ConnectRequest{
 				Model: &metav1.TypeMeta{
 					APIVersion: "invalidate.group/v1alpha1",
 					Kind:       "DummySpecialDevice",
@@ -90,7 +90,7 @@
 			Expect(sts.Message()).To(Equal("invalid model group: invalidate.group"))
 
 			// failed as invalidate kind
-			mockServer.EXPECT().Recv().Return(&v1alpha1.ConnectRequest{
+			mockServer.EXPECT().Recv().Return(&v1alpha2.ConnectRequest{
 				Model: &metav1.TypeMeta{
 					APIVersion: "devices.edge.cattle.io/v1alpha1",
 					Kind:       "InvalidateSpecialDevice",
@@ -104,7 +104,7 @@
 
 		It("should distinguish the input model", func() {
 			// distinguish the devices.edge.cattle.io/v1alpha1/DummySpecialDevice model
-			mockServer.EXPECT().Recv().Return(&v1alpha1.ConnectRequest{
+			mockServer.EXPECT().Recv().Return(&v1alpha2.ConnectRequest{
 				Model: &metav1.TypeMeta{
 					APIVersion: "devices.edge.cattle.io/v1alpha1",
 					Kind:       "DummySpecialDevice",
@@ -117,7 +117,7 @@
 			Expect(sts.Message()).To(Equal("failed to recognize the empty device as the namespace/name is blank"))
 
 			// distinguish the devices.edge.cattle.io/v1alpha1/DummyProtocolDevice model
-			mockServer.EXPECT().Recv().Return(&v1alpha1.ConnectRequest{
+			mockServer.EXPECT().Recv().Return(&v1alpha2.ConnectRequest{
 				Model: &metav1.TypeMeta{
 					APIVersion: "devices.edge.cattle.io/v1alpha1",
 					Kind:       "DummyProtocolDevice",
@@ -132,7 +132,7 @@
 
 		It("should process the input device", func() {
 			// failed unmarshal
-			mockServer.EXPECT().Recv().Return(&v1alpha1.ConnectRequest{
+			mockServer.EXPECT().Recv().Return(&v1alpha2.ConnectRequest{
 				Model: &metav1.TypeMeta{
 					APIVersion: "devices.edge.cattle.io/v1alpha1",
 					Kind:       "DummySpecialDevice",
@@ -146,7 +146,7 @@
 
 			// correct logic
 			mockServer.EXPECT().Send(gomock.Any()).Return(nil).AnyTimes()
-			mockServer.EXPECT().Recv().Return(&v1alpha1.ConnectRequest{
+			mockServer.EXPECT().Recv().Return(&v1alpha2.ConnectRequest{
 				Model: &metav1.TypeMeta{
 					APIVersion: "devices.edge.cattle.io/v1alpha1",
 					Kind:       "DummySpecialDevice",
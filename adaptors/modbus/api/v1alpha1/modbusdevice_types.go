@@ -19,14 +19,16 @@
 )
 
 // ModbusDevicePropertyType defines the type of the property value.
-// +kubebuilder:validation:Enum=int16;int;int32;int64;uint16;uint;uint32;uint64;float;double;boolean;hexString
+// +kubebuilder:validation:Enum=int8;int16;int;int32;int64;uint8;uint16;uint;uint32;uint64;float;double;boolean;hexString;string;bitfield;bcd
 type ModbusDevicePropertyType string
 
 const (
+	ModbusDevicePropertyTypeInt8      ModbusDevicePropertyType = "int8" // the high or low byte of a 16-bits register
 	ModbusDevicePropertyTypeInt16     ModbusDevicePropertyType = "int16"
 	ModbusDevicePropertyTypeInt       ModbusDevicePropertyType = "int" // as same as int32
 	ModbusDevicePropertyTypeInt32     ModbusDevicePropertyType = "int32"
 	ModbusDevicePropertyTypeInt64     ModbusDevicePropertyType = "int64"
+	ModbusDevicePropertyTypeUint8     ModbusDevicePropertyType = "uint8" // the high or low byte of a 16-bits register
 	ModbusDevicePropertyTypeUint16    ModbusDevicePropertyType = "uint16"
 	ModbusDevicePropertyTypeUint      ModbusDevicePropertyType = "uint" // as same as uint32
 	ModbusDevicePropertyTypeUint32    ModbusDevicePropertyType = "uint32"
@@ -35,8 +37,33 @@
 	ModbusDevicePropertyTypeDouble    ModbusDevicePropertyType = "double"
 	ModbusDevicePropertyTypeHexString ModbusDevicePropertyType = "hexString"
 	ModbusDevicePropertyTypeBoolean   ModbusDevicePropertyType = "boolean"
+	ModbusDevicePropertyTypeString    ModbusDevicePropertyType = "string"   // the ASCII string packed in 16-bits registers
+	ModbusDevicePropertyTypeBitfield  ModbusDevicePropertyType = "bitfield" // the named bits of 16-bits registers, in form of JSON object
+	ModbusDevicePropertyTypeBCD       ModbusDevicePropertyType = "bcd"      // the binary-coded decimal of 16-bits registers
 )
 
+// ModbusDevicePropertyByte defines the byte of a 16-bits register.
+// +kubebuilder:validation:Enum=High;Low
+type ModbusDevicePropertyByte string
+
+const (
+	ModbusDevicePropertyByteHigh ModbusDevicePropertyByte = "High"
+	ModbusDevicePropertyByteLow  ModbusDevicePropertyByte = "Low"
+)
+
+// ModbusDevicePropertyBit defines the name of a bit in the bitfield.
+type ModbusDevicePropertyBit struct {
+	// Specifies the index of bit, 0 is the least significant bit.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=31
+	// +kubebuilder:validation:Required
+	Index uint8 `json:"index"`
+
+	// Specifies the name of bit.
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+}
+
 // ModbusDevicePropertyValueEndianness defines the endianness of the property value.
 // +kubebuilder:validation:Enum=BigEndian;BigEndianSwap;LittleEndian;LittleEndianSwap
 type ModbusDevicePropertyValueEndianness string
@@ -213,6 +240,26 @@ type ModbusDeviceParameters struct {
 	// The default value is "10s".
 	// +kubebuilder:default="10s"
 	Timeout v1.Duration `json:"timeout,omitempty"`
+
+	// Specifies the maximum number of the unused registers between two properties,
+	// the properties of the same register type are read in one request if the gap is not larger than this.
+	// The default value is "0", which means only the adjacent properties are read together.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxReadGap uint16 `json:"maxReadGap,omitempty"`
+
+	// Specifies the maximum quantity of registers in one read request,
+	// the value is limited to 125 for 16-bits registers and 2000 for 1-bit registers.
+	// The default value is the limit of register type, set as "1" to read the properties separately.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxReadQuantity uint16 `json:"maxReadQuantity,omitempty"`
+
+	// Specifies the maximum amount of silence, the properties are synchronized to limb anyway after it,
+	// even if none of them crossed the deadband.
+	// The default value is "0s", which means there is not any heartbeat.
+	// +optional
+	MaxSilence v1.Duration `json:"maxSilence,omitempty"`
 }
 
 func (in *ModbusDeviceParameters) GetSyncInterval() time.Duration {
@@ -224,6 +271,13 @@ func (in *ModbusDeviceParameters) GetSyncInterval() time.Duration {
 	return 15 * time.Second
 }
 
+func (in *ModbusDeviceParameters) GetMaxSilence() time.Duration {
+	if in != nil {
+		return in.MaxSilence.Duration
+	}
+	return 0
+}
+
 func (in *ModbusDeviceParameters) GetTimeout() time.Duration {
 	if in != nil {
 		if duration := in.Timeout.Duration; duration > 0 {
@@ -233,6 +287,28 @@ func (in *ModbusDeviceParameters) GetTimeout() time.Duration {
 	return 10 * time.Second
 }
 
+func (in *ModbusDeviceParameters) GetMaxReadGap() uint16 {
+	if in != nil {
+		return in.MaxReadGap
+	}
+	return 0
+}
+
+// GetMaxReadQuantity returns the maximum quantity of the given register type in one read request.
+func (in *ModbusDeviceParameters) GetMaxReadQuantity(register ModbusDeviceRegisterType) uint16 {
+	var limit uint16 = 125
+	switch register {
+	case ModbusDeviceCoilRegister, ModbusDeviceDiscreteInputRegister:
+		limit = 2000
+	}
+	if in != nil {
+		if quantity := in.MaxReadQuantity; quantity > 0 && quantity < limit {
+			return quantity
+		}
+	}
+	return limit
+}
+
 // ModbusDeviceProtocol defines the desired protocol of ModbusDevice.
 type ModbusDeviceProtocol struct {
 	// Specifies the connection protocol as RTU
@@ -242,9 +318,25 @@ type ModbusDeviceProtocol struct {
 	// Specifies the connection protocol as TCP
 	// +optional
 	TCP *ModbusDeviceProtocolTCP `json:"tcp,omitempty"`
+
+	// Specifies the connection protocol as RTU over TCP,
+	// which is usually used by the serial-to-Ethernet gateways.
+	// +optional
+	RTUOverTCP *ModbusDeviceProtocolTCP `json:"rtuOverTCP,omitempty"`
+
+	// Specifies the connection protocol as RTU over UDP,
+	// which is usually used by the serial-to-Ethernet gateways.
+	// +optional
+	RTUOverUDP *ModbusDeviceProtocolTCP `json:"rtuOverUDP,omitempty"`
+
+	// Specifies the connection protocol as ASCII,
+	// which is used by the legacy serial devices.
+	// +optional
+	ASCII *ModbusDeviceProtocolRTU `json:"ascii,omitempty"`
 }
 
-// ModbusDeviceProtocolTCP defines the TCP protocol of ModbusDevice.
+// ModbusDeviceProtocolTCP defines the TCP protocol of ModbusDevice,
+// it's also used by the RTU over TCP/UDP protocol.
 type ModbusDeviceProtocolTCP struct {
 	// Specifies the IP address of device,
 	// which is in form of "ip:port".
@@ -259,7 +351,8 @@ type ModbusDeviceProtocolTCP struct {
 	WorkerID int `json:"workerID"`
 }
 
-// ModbusDeviceProtocolRTU defines the RTU protocol of ModbusDevice.
+// ModbusDeviceProtocolRTU defines the RTU protocol of ModbusDevice,
+// it's also used by the ASCII protocol.
 type ModbusDeviceProtocolRTU struct {
 	// Specifies the serial port of device,
 	// which is in form of "/dev/ttyS0".
@@ -341,14 +434,40 @@ type ModbusDevicePropertyVisitor struct {
 	// +kubebuilder:default=1
 	Quantity uint16 `json:"quantity,omitempty"`
 
-	// Specifies the endianness of value.
+	// Specifies the endianness of value,
+	// the string type is read from the high byte of register if it's BigEndian or BigEndianSwap,
+	// otherwise, it's read from the low byte of register.
 	// +kubebuilder:default="BigEndian"
 	Endianness ModbusDevicePropertyValueEndianness `json:"endianness,omitempty"`
 
+	// Specifies the byte of register for the int8/uint8 type,
+	// the other byte of register is kept in writing.
+	// The default value is "Low".
+	// +optional
+	Byte ModbusDevicePropertyByte `json:"byte,omitempty"`
+
+	// Specifies the named bits of register for the bitfield type,
+	// only the given bits are changed in writing, the unnamed and omitted bits of register are kept.
+	// +listType=map
+	// +listMapKey=index
+	// +optional
+	Bits []ModbusDevicePropertyBit `json:"bits,omitempty"`
+
 	// Specifies the operations in order if needed.
 	// +listType=atomic
 	// +optional
 	OrderOfOperations []ModbusDeviceArithmeticOperation `json:"orderOfOperations,omitempty"`
+
+	// Specifies the expression to calculate the operated value from the raw value read from register,
+	// the raw value is referred as "x", e.g. "clamp(x * 0.1 - 40, -40, 125)".
+	// The orderOfOperations is ignored if the expression is specified.
+	// +optional
+	ReadExpression string `json:"readExpression,omitempty"`
+
+	// Specifies the expression to calculate the raw value written to register from the value of property,
+	// the value of property is referred as "x", e.g. "(x + 40) * 10".
+	// +optional
+	WriteExpression string `json:"writeExpression,omitempty"`
 }
 
 // ModbusDeviceProperty defines the desired property of ModbusDevice.
@@ -377,6 +496,32 @@ type ModbusDeviceProperty struct {
 	// Specifies the value of property, only available in the writable property.
 	// +optional
 	Value string `json:"value,omitempty"`
+
+	// Specifies the amount of interval that the property is synchronized to limb,
+	// the default value is the "syncInterval" of parameters.
+	// +optional
+	SyncInterval *v1.Duration `json:"syncInterval,omitempty"`
+
+	// Specifies the deadband of property, the property is synchronized to limb only if its value changed
+	// more than the deadband since the last synchronization, e.g. "0.5" or "5%" of the last synchronized value.
+	// The non-numeric value is synchronized if it changed.
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?%?$`
+	// +optional
+	Deadband string `json:"deadband,omitempty"`
+
+	// Specifies if the property is synchronized to limb only if its value changed since the last synchronization.
+	// The default value is "false".
+	// +optional
+	ReportOnChangeOnly bool `json:"reportOnChangeOnly,omitempty"`
+}
+
+func (in *ModbusDeviceProperty) GetSyncInterval(params *ModbusDeviceParameters) time.Duration {
+	if in != nil && in.SyncInterval != nil {
+		if duration := in.SyncInterval.Duration; duration > 0 {
+			return duration
+		}
+	}
+	return params.GetSyncInterval()
 }
 
 // ModbusDeviceSpec defines the desired state of ModbusDevice.
@@ -407,6 +552,19 @@ type ModbusDeviceStatus struct {
 	Properties []ModbusDeviceStatusProperty `json:"properties,omitempty"`
 }
 
+// ModbusDevicePropertyQuality defines the quality of the property value.
+// Good: the value is reliable.
+// Uncertain: the value is available, but it may be inaccurate or stale.
+// Bad: the value is unavailable, e.g. failed to read it from device.
+// +kubebuilder:validation:Enum=Good;Uncertain;Bad
+type ModbusDevicePropertyQuality string
+
+const (
+	ModbusDevicePropertyQualityGood      ModbusDevicePropertyQuality = "Good"
+	ModbusDevicePropertyQualityUncertain ModbusDevicePropertyQuality = "Uncertain"
+	ModbusDevicePropertyQualityBad       ModbusDevicePropertyQuality = "Bad"
+)
+
 // ModbusDeviceStatusProperty defines the observed property of ModbusDevice.
 type ModbusDeviceStatusProperty struct {
 	// Reports the name of property.
@@ -425,9 +583,27 @@ type ModbusDeviceStatusProperty struct {
 	// +optional
 	OperatedValue string `json:"operatedValue,omitempty"`
 
+	// Reports the quality of property value,
+	// the value is the last available one if the quality is "Bad".
+	// +optional
+	Quality ModbusDevicePropertyQuality `json:"quality,omitempty"`
+
+	// Reports the reason if the quality is not "Good".
+	// +optional
+	QualityReason string `json:"qualityReason,omitempty"`
+
+	// Reports the timestamp when the property value was sampled from the source,
+	// it's the received timestamp if the source doesn't provide one.
+	// +optional
+	SourceTimestamp *metav1.Time `json:"sourceTimestamp,omitempty"`
+
 	// Reports the updated timestamp of property.
 	// +optional
 	UpdatedAt *metav1.Time `json:"updatedAt,omitempty"`
+
+	// Reports the count of overruns, which means the reading of property took longer than its sync interval.
+	// +optional
+	Overruns int32 `json:"overruns,omitempty"`
 }
 
 // +kubebuilder:object:root=true
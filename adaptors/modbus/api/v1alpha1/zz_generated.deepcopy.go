@@ -21,6 +21,7 @@
 
 import (
 	"github.com/rancher/octopus/pkg/mqtt/api"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -123,6 +124,7 @@ func (in *ModbusDeviceParameters) DeepCopyInto(out *ModbusDeviceParameters) {
 	*out = *in
 	out.SyncInterval = in.SyncInterval
 	out.Timeout = in.Timeout
+	out.MaxSilence = in.MaxSilence
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModbusDeviceParameters.
@@ -139,6 +141,11 @@ func (in *ModbusDeviceParameters) DeepCopy() *ModbusDeviceParameters {
 func (in *ModbusDeviceProperty) DeepCopyInto(out *ModbusDeviceProperty) {
 	*out = *in
 	in.Visitor.DeepCopyInto(&out.Visitor)
+	if in.SyncInterval != nil {
+		in, out := &in.SyncInterval, &out.SyncInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModbusDeviceProperty.
@@ -151,9 +158,29 @@ func (in *ModbusDeviceProperty) DeepCopy() *ModbusDeviceProperty {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModbusDevicePropertyBit) DeepCopyInto(out *ModbusDevicePropertyBit) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModbusDevicePropertyBit.
+func (in *ModbusDevicePropertyBit) DeepCopy() *ModbusDevicePropertyBit {
+	if in == nil {
+		return nil
+	}
+	out := new(ModbusDevicePropertyBit)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ModbusDevicePropertyVisitor) DeepCopyInto(out *ModbusDevicePropertyVisitor) {
 	*out = *in
+	if in.Bits != nil {
+		in, out := &in.Bits, &out.Bits
+		*out = make([]ModbusDevicePropertyBit, len(*in))
+		copy(*out, *in)
+	}
 	if in.OrderOfOperations != nil {
 		in, out := &in.OrderOfOperations, &out.OrderOfOperations
 		*out = make([]ModbusDeviceArithmeticOperation, len(*in))
@@ -184,6 +211,21 @@ func (in *ModbusDeviceProtocol) DeepCopyInto(out *ModbusDeviceProtocol) {
 		*out = new(ModbusDeviceProtocolTCP)
 		**out = **in
 	}
+	if in.RTUOverTCP != nil {
+		in, out := &in.RTUOverTCP, &out.RTUOverTCP
+		*out = new(ModbusDeviceProtocolTCP)
+		**out = **in
+	}
+	if in.RTUOverUDP != nil {
+		in, out := &in.RTUOverUDP, &out.RTUOverUDP
+		*out = new(ModbusDeviceProtocolTCP)
+		**out = **in
+	}
+	if in.ASCII != nil {
+		in, out := &in.ASCII, &out.ASCII
+		*out = new(ModbusDeviceProtocolRTU)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModbusDeviceProtocol.
@@ -284,6 +326,10 @@ func (in *ModbusDeviceStatus) DeepCopy() *ModbusDeviceStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ModbusDeviceStatusProperty) DeepCopyInto(out *ModbusDeviceStatusProperty) {
 	*out = *in
+	if in.SourceTimestamp != nil {
+		in, out := &in.SourceTimestamp, &out.SourceTimestamp
+		*out = (*in).DeepCopy()
+	}
 	if in.UpdatedAt != nil {
 		in, out := &in.UpdatedAt, &out.UpdatedAt
 		*out = (*in).DeepCopy()
@@ -1,6 +1,9 @@
 package adaptor
 
 import (
+	"reflect"
+	"sync"
+
 	jsoniter "github.com/json-iterator/go"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -11,7 +14,7 @@
 
 	"github.com/rancher/octopus/adaptors/modbus/api/v1alpha1"
 	"github.com/rancher/octopus/adaptors/modbus/pkg/physical"
-	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha1"
+	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha2"
 	"github.com/rancher/octopus/pkg/adaptor/connection"
 	"github.com/rancher/octopus/pkg/adaptor/log"
 	"github.com/rancher/octopus/pkg/mqtt"
@@ -43,7 +46,15 @@ func (s *Service) toJSON(in metav1.Object) []byte {
 }
 
 func (s *Service) Connect(server api.Connection_ConnectServer) error {
+	// the devices sync to limb in their own goroutines
+	server = connection.SerializeSend(server)
+
 	var holder physical.Device
+	// records the properties synced to limb, guarded by the lock as it is reset on receiving the device.
+	var (
+		syncedLock sync.Mutex
+		synced     map[string]v1alpha1.ModbusDeviceStatusProperty
+	)
 	defer func() {
 		if holder != nil {
 			holder.Shutdown()
@@ -60,6 +71,12 @@ func (s *Service) Connect(server api.Connection_ConnectServer) error {
 			return nil
 		}
 
+		// executes command in background, the result is sent to limb when it's done
+		if command := req.GetCommand(); command != nil {
+			connection.ExecuteCommandInBackground(server, command, holder)
+			continue
+		}
+
 		// validates model GVK
 		var model = req.GetModel()
 		if model == nil {
@@ -92,6 +109,24 @@ func (s *Service) Connect(server api.Connection_ConnectServer) error {
 
 				// creates handler for syncing to limb
 				var toLimb = func(in *v1alpha1.ModbusDevice) error {
+					syncedLock.Lock()
+					defer syncedLock.Unlock()
+
+					// only the changed properties are sent if the device has been synced.
+					// the device is synced without any changes if it has been silent for too long,
+					// so sends the whole device as the heartbeat.
+					if changed, ok := getChangedProperties(synced, in.Status.Properties); ok && len(changed) != 0 {
+						var resp, err = connection.NewPropertiesPatchResponse(changed)
+						if err != nil {
+							return status.Errorf(codes.Internal, "failed to create properties patch, %v", err)
+						}
+						if err := server.Send(resp); err != nil {
+							return status.Errorf(codes.Unknown, "failed to send properties patch to limb, %v", err)
+						}
+						synced = indexProperties(in.Status.Properties)
+						return nil
+					}
+
 					// send device by {name, namespace, status} tuple
 					var resp = &v1alpha1.ModbusDevice{}
 					resp.Namespace = in.Namespace
@@ -105,17 +140,65 @@ func (s *Service) Connect(server api.Connection_ConnectServer) error {
 					if err := server.Send(&api.ConnectResponse{Device: respBytes}); err != nil {
 						return status.Errorf(codes.Unknown, "failed to send device to limb, %v", err)
 					}
+					synced = indexProperties(in.Status.Properties)
 					return nil
 				}
 
 				holder = physical.NewDevice(logger, device.ObjectMeta, toLimb)
 			}
 
+			// the limb sends the device again when it could have dropped the synced data,
+			// e.g. the device is recreated, so syncs the whole device next time.
+			syncedLock.Lock()
+			synced = nil
+			syncedLock.Unlock()
+
 			if err := holder.Configure(req.GetReferencesHandler(), &device); err != nil {
-				return status.Errorf(codes.InvalidArgument, "failed to connect to device endpoint: %v", err)
+				return connection.NewConfigureError("failed to connect to device endpoint", err)
 			}
 		default:
 			return status.Errorf(codes.InvalidArgument, "invalid model kind: %s", modelGVK.Kind)
 		}
 	}
 }
+
+// getChangedProperties returns the properties which are different from the synced properties,
+// it returns false if the device hasn't been synced or any synced property is removed.
+func getChangedProperties(synced map[string]v1alpha1.ModbusDeviceStatusProperty, properties []v1alpha1.ModbusDeviceStatusProperty) ([]v1alpha1.ModbusDeviceStatusProperty, bool) {
+	if synced == nil || len(synced) > len(properties) {
+		return nil, false
+	}
+
+	var changed []v1alpha1.ModbusDeviceStatusProperty
+	var matched int
+	for _, prop := range properties {
+		var syncedProp, exist = synced[prop.Name]
+		if exist {
+			matched++
+			if isSameProperty(syncedProp, prop) {
+				continue
+			}
+		}
+		changed = append(changed, prop)
+	}
+	if matched != len(synced) {
+		return nil, false
+	}
+	return changed, true
+}
+
+// isSameProperty returns true if the properties are the same except the timestamps,
+// which are refreshed on every reading.
+func isSameProperty(x, y v1alpha1.ModbusDeviceStatusProperty) bool {
+	x.SourceTimestamp, x.UpdatedAt = nil, nil
+	y.SourceTimestamp, y.UpdatedAt = nil, nil
+	return reflect.DeepEqual(x, y)
+}
+
+func indexProperties(properties []v1alpha1.ModbusDeviceStatusProperty) map[string]v1alpha1.ModbusDeviceStatusProperty {
+	var ret = make(map[string]v1alpha1.ModbusDeviceStatusProperty, len(properties))
+	for _, prop := range properties {
+		ret[prop.Name] = prop
+	}
+	return ret
+}
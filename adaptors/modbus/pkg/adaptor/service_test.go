@@ -0,0 +1,289 @@
+package adaptor
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/atomic"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/rancher/octopus/adaptors/modbus/api/v1alpha1"
+	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha2"
+)
+
+// fakeModbusSlave simulates the Modbus TCP slave,
+// it responds the holding registers as 0x0001 or the slave device failure exception if failing.
+type fakeModbusSlave struct {
+	lis     net.Listener
+	failing atomic.Bool
+}
+
+func (s *fakeModbusSlave) serve() {
+	for {
+		var conn, err = s.lis.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *fakeModbusSlave) handle(conn net.Conn) {
+	defer conn.Close()
+
+	for {
+		// MBAP header: transaction id(2), protocol id(2), length(2), unit id(1)
+		var header = make([]byte, 7)
+		if _, err := io.ReadFull(conn, header); err != nil {
+			return
+		}
+		var pdu = make([]byte, binary.BigEndian.Uint16(header[4:6])-1)
+		if _, err := io.ReadFull(conn, pdu); err != nil {
+			return
+		}
+
+		var respPDU []byte
+		if s.failing.Load() {
+			respPDU = []byte{pdu[0] | 0x80, 0x04}
+		} else {
+			var quantity = binary.BigEndian.Uint16(pdu[3:5])
+			respPDU = []byte{pdu[0], byte(quantity * 2)}
+			for i := uint16(0); i < quantity; i++ {
+				respPDU = append(respPDU, 0x00, 0x01)
+			}
+		}
+		var resp = append([]byte{}, header[:4]...)
+		resp = append(resp, 0, 0, header[6])
+		binary.BigEndian.PutUint16(resp[4:6], uint16(len(respPDU)+1))
+		if _, err := conn.Write(append(resp, respPDU...)); err != nil {
+			return
+		}
+	}
+}
+
+// fakeConnectServer receives the requests from channel and records the responses.
+type fakeConnectServer struct {
+	api.Connection_ConnectServer
+
+	requests chan *api.ConnectRequest
+
+	mu        sync.Mutex
+	responses []*api.ConnectResponse
+}
+
+func (s *fakeConnectServer) Recv() (*api.ConnectRequest, error) {
+	var req, ok = <-s.requests
+	if !ok {
+		return nil, io.EOF
+	}
+	return req, nil
+}
+
+func (s *fakeConnectServer) Send(resp *api.ConnectResponse) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.responses = append(s.responses, resp)
+	return nil
+}
+
+func (s *fakeConnectServer) getResponses() []*api.ConnectResponse {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*api.ConnectResponse{}, s.responses...)
+}
+
+// newTestDevice returns a device to read the holding register 0 of the given endpoint.
+func newTestDevice(endpoint string) v1alpha1.ModbusDevice {
+	return v1alpha1.ModbusDevice{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "devices.edge.cattle.io/v1alpha1",
+			Kind:       "ModbusDevice",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Spec: v1alpha1.ModbusDeviceSpec{
+			Protocol: v1alpha1.ModbusDeviceProtocol{
+				TCP: &v1alpha1.ModbusDeviceProtocolTCP{
+					Endpoint: endpoint,
+					WorkerID: 1,
+				},
+			},
+			Parameters: &v1alpha1.ModbusDeviceParameters{
+				SyncInterval: metav1.Duration{Duration: 50 * time.Millisecond},
+				Timeout:      metav1.Duration{Duration: time.Second},
+			},
+			Properties: []v1alpha1.ModbusDeviceProperty{
+				{
+					Name: "temperature",
+					Type: v1alpha1.ModbusDevicePropertyTypeInt16,
+					Visitor: v1alpha1.ModbusDevicePropertyVisitor{
+						Register: v1alpha1.ModbusDeviceHoldingRegister,
+						Offset:   0,
+						Quantity: 1,
+					},
+					ReadOnly: true,
+				},
+			},
+		},
+	}
+}
+
+// connect connects the given device to a new service,
+// and returns the server to observe the responses and a function to disconnect.
+func connect(t *testing.T, device v1alpha1.ModbusDevice) (*fakeConnectServer, func()) {
+	var deviceBytes, err = json.Marshal(device)
+	if err != nil {
+		t.Fatalf("failed to marshal device: %v", err)
+	}
+
+	var server = &fakeConnectServer{requests: make(chan *api.ConnectRequest, 1)}
+	var done = make(chan error)
+	go func() {
+		done <- NewService().Connect(server)
+	}()
+	server.requests <- &api.ConnectRequest{
+		Model:  &device.TypeMeta,
+		Device: deviceBytes,
+	}
+	return server, func() {
+		close(server.requests)
+		assert.NoError(t, <-done)
+	}
+}
+
+func TestService_Connect(t *testing.T) {
+	var lis, err = net.Listen("tcp", "127.0.0.1:0")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer lis.Close()
+	var slave = &fakeModbusSlave{lis: lis}
+	go slave.serve()
+
+	var server, stop = connect(t, newTestDevice(lis.Addr().String()))
+	defer stop()
+
+	// syncs the whole device at first
+	assert.Eventually(t, func() bool {
+		var resps = server.getResponses()
+		return len(resps) > 0 && resps[0].GetDevice() != nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// syncs the quality change of property if failed to read
+	slave.failing.Store(true)
+	var patched []v1alpha1.ModbusDeviceStatusProperty
+	assert.Eventually(t, func() bool {
+		for _, resp := range server.getResponses() {
+			var patch = resp.GetDevicePatch()
+			if patch == nil || patch.GetType() != api.PatchType_PropertiesPatch {
+				continue
+			}
+			patched = nil
+			if err := json.Unmarshal(patch.GetData(), &patched); err != nil {
+				continue
+			}
+			if len(patched) == 1 && patched[0].Quality == v1alpha1.ModbusDevicePropertyQualityBad {
+				return true
+			}
+		}
+		return false
+	}, 5*time.Second, 10*time.Millisecond)
+	if assert.Len(t, patched, 1) {
+		assert.Equal(t, "temperature", patched[0].Name)
+		assert.Equal(t, "1", patched[0].Value, "keeps the last available value")
+		assert.NotEmpty(t, patched[0].QualityReason)
+	}
+}
+
+func TestService_Connect_Heartbeat(t *testing.T) {
+	var lis, err = net.Listen("tcp", "127.0.0.1:0")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer lis.Close()
+	var slave = &fakeModbusSlave{lis: lis}
+	go slave.serve()
+
+	var device = newTestDevice(lis.Addr().String())
+	device.Spec.Parameters.MaxSilence = metav1.Duration{Duration: 200 * time.Millisecond}
+	device.Spec.Properties[0].ReportOnChangeOnly = true
+	var server, stop = connect(t, device)
+	defer stop()
+
+	// syncs the whole device again although the value doesn't change
+	assert.Eventually(t, func() bool {
+		var devices int
+		for _, resp := range server.getResponses() {
+			if resp.GetDevice() != nil {
+				devices++
+			}
+		}
+		return devices >= 2
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestService_Connect_Resync(t *testing.T) {
+	var lis, err = net.Listen("tcp", "127.0.0.1:0")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer lis.Close()
+	var slave = &fakeModbusSlave{lis: lis}
+	go slave.serve()
+
+	var device = newTestDevice(lis.Addr().String())
+	var server, stop = connect(t, device)
+	defer stop()
+
+	// syncs the whole device at first, which could be dropped by limb
+	assert.Eventually(t, func() bool {
+		var resps = server.getResponses()
+		return len(resps) > 0 && resps[0].GetDevice() != nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// the limb sends the device again, with a new property
+	var synced = len(server.getResponses())
+	device.Spec.Properties = append(device.Spec.Properties, v1alpha1.ModbusDeviceProperty{
+		Name: "humidity",
+		Type: v1alpha1.ModbusDevicePropertyTypeInt16,
+		Visitor: v1alpha1.ModbusDevicePropertyVisitor{
+			Register: v1alpha1.ModbusDeviceHoldingRegister,
+			Offset:   1,
+			Quantity: 1,
+		},
+		ReadOnly: true,
+	})
+	deviceBytes, err := json.Marshal(device)
+	if !assert.NoError(t, err) {
+		return
+	}
+	server.requests <- &api.ConnectRequest{
+		Model:  &device.TypeMeta,
+		Device: deviceBytes,
+	}
+
+	// syncs the whole device rather than the changed properties
+	var resync *api.ConnectResponse
+	assert.Eventually(t, func() bool {
+		var resps = server.getResponses()
+		if len(resps) <= synced {
+			return false
+		}
+		resync = resps[synced]
+		return true
+	}, 5*time.Second, 10*time.Millisecond)
+	if assert.NotNil(t, resync) && assert.NotNil(t, resync.GetDevice(), "syncs the whole device") {
+		var actual v1alpha1.ModbusDevice
+		if assert.NoError(t, json.Unmarshal(resync.GetDevice(), &actual)) {
+			assert.Len(t, actual.Status.Properties, 2)
+		}
+	}
+}
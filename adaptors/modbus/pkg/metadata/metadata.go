@@ -2,6 +2,6 @@
 
 const (
 	Name     = "adaptors.edge.cattle.io/modbus"
-	Version  = "v1alpha1"
+	Version  = "v1alpha2"
 	Endpoint = "modbus.sock"
 )
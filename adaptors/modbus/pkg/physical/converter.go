@@ -1,13 +1,56 @@
 package physical
 
 import (
+	"math"
 	"strconv"
 
 	"github.com/pkg/errors"
 
 	"github.com/rancher/octopus/adaptors/modbus/api/v1alpha1"
+	"github.com/rancher/octopus/pkg/util/expression"
 )
 
+// operate helps to calculate the raw value with the read expression or the operations of visitor,
+// the operations are ignored if the read expression is specified.
+func operate(raw float64, visitor v1alpha1.ModbusDevicePropertyVisitor) (string, error) {
+	if visitor.ReadExpression == "" {
+		return doArithmeticOperations(raw, visitor.OrderOfOperations)
+	}
+
+	var result, err = expression.EvaluateValue(visitor.ReadExpression, raw)
+	if err != nil {
+		return "", err
+	}
+	return strconv.FormatFloat(result, byte('f'), 6, 64), nil
+}
+
+// transformWriteValue transforms the value of property into the raw value with the write expression of visitor,
+// the result is rounded if the property is an integer type.
+func transformWriteValue(prop *v1alpha1.ModbusDeviceProperty) (string, error) {
+	var visitor = prop.Visitor
+
+	var value, err = strconv.ParseFloat(prop.Value, 64)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to convert the %s's value to float64 for write expression", visitor.Register)
+	}
+	result, err := expression.EvaluateValue(visitor.WriteExpression, value)
+	if err != nil {
+		return "", err
+	}
+
+	switch prop.Type {
+	case v1alpha1.ModbusDevicePropertyTypeFloat, v1alpha1.ModbusDevicePropertyTypeDouble:
+		return strconv.FormatFloat(result, byte('g'), -1, 64), nil
+	case v1alpha1.ModbusDevicePropertyTypeInt8, v1alpha1.ModbusDevicePropertyTypeInt16,
+		v1alpha1.ModbusDevicePropertyTypeInt, v1alpha1.ModbusDevicePropertyTypeInt32, v1alpha1.ModbusDevicePropertyTypeInt64,
+		v1alpha1.ModbusDevicePropertyTypeUint8, v1alpha1.ModbusDevicePropertyTypeUint16,
+		v1alpha1.ModbusDevicePropertyTypeUint, v1alpha1.ModbusDevicePropertyTypeUint32, v1alpha1.ModbusDevicePropertyTypeUint64,
+		v1alpha1.ModbusDevicePropertyTypeBCD:
+		return strconv.FormatInt(int64(math.Round(result)), 10), nil
+	}
+	return "", errors.Errorf("write expression cannot be used on %s type", prop.Type)
+}
+
 // doArithmeticOperations helps to calculate the raw value with operations,
 // and returns the calculated raw result in 6 digit precision.
 func doArithmeticOperations(raw float64, operations []v1alpha1.ModbusDeviceArithmeticOperation) (string, error) {
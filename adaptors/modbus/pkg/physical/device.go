@@ -7,16 +7,19 @@
 	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/goburrow/modbus"
 	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/runtime"
 
 	"github.com/rancher/octopus/adaptors/modbus/api/v1alpha1"
 	"github.com/rancher/octopus/adaptors/modbus/pkg/metadata"
-	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha1"
+	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha2"
 	"github.com/rancher/octopus/pkg/adaptor/socket/handler"
 	"github.com/rancher/octopus/pkg/mqtt"
+	"github.com/rancher/octopus/pkg/util/deadband"
 	"github.com/rancher/octopus/pkg/util/object"
+	"github.com/rancher/octopus/pkg/util/scheduler"
 )
 
 // Device is an interface for device operations set.
@@ -51,6 +54,7 @@ type modbusDevice struct {
 	toLimb        ModbusDeviceLimbSyncer
 	stop          chan struct{}
 	modbusHandler ModbusClientHandler
+	reporter      deadband.Reporter
 
 	mqttClient mqtt.Client
 }
@@ -142,7 +146,6 @@ func (d *modbusDevice) refresh(newSpec v1alpha1.ModbusDeviceSpec) error {
 
 		// configures properties
 		var specProps = newSpec.Properties
-		var statusProps = make([]v1alpha1.ModbusDeviceStatusProperty, 0, len(specProps))
 		for _, prop := range specProps {
 			if !prop.ReadOnly {
 				if err := d.writeProperty(&prop); err != nil {
@@ -150,34 +153,46 @@ func (d *modbusDevice) refresh(newSpec v1alpha1.ModbusDeviceSpec) error {
 				}
 				d.log.V(4).Info("Write property", "property", prop.Name, "type", prop.Type)
 			}
-			var value, operatedValue, err = d.readProperty(&prop)
-			if err != nil {
-				return errors.Wrapf(err, "failed to read property %s", prop.Name)
+		}
+		var readings = d.readProperties(specProps, newSpec.Parameters)
+		var statusProps = make([]v1alpha1.ModbusDeviceStatusProperty, 0, len(specProps))
+		for i, prop := range specProps {
+			var reading = readings[i]
+			if reading.err != nil {
+				return errors.Wrapf(reading.err, "failed to read property %s", prop.Name)
 			}
 			d.log.V(4).Info("Read property", "property", prop.Name, "type", prop.Type)
 			statusProps = append(statusProps, v1alpha1.ModbusDeviceStatusProperty{
-				Name:          prop.Name,
-				Value:         value,
-				OperatedValue: operatedValue,
-				Type:          prop.Type,
-				UpdatedAt:     now(),
+				Name:            prop.Name,
+				Value:           reading.value,
+				OperatedValue:   reading.operatedValue,
+				Type:            prop.Type,
+				Quality:         v1alpha1.ModbusDevicePropertyQualityGood,
+				SourceTimestamp: now(),
+				UpdatedAt:       now(),
 			})
 		}
 		status = v1alpha1.ModbusDeviceStatus{Properties: statusProps}
 	}
 
 	// fetches in backend
-	d.startFetch(newSpec.Parameters.GetSyncInterval())
+	if !reflect.DeepEqual(staleSpec.Parameters, newSpec.Parameters) {
+		// the fetching is restarted to apply the changed sync interval.
+		d.stopFetch()
+	}
+	d.startFetch(newSpec)
 
 	// records
 	d.instance.Spec = newSpec
 	d.instance.Status = status
+	d.reporter.MaxSilence = newSpec.Parameters.GetMaxSilence()
 	return d.sync()
 }
 
 // fetch is blocked, it is used to sync the modbus device status periodically,
 // it's worth noting that it just reads the properties from modbus device.
-func (d *modbusDevice) fetch(interval time.Duration, stop <-chan struct{}) {
+// The properties are read in their own sync intervals, and the ones due at the same time are read together.
+func (d *modbusDevice) fetch(spec v1alpha1.ModbusDeviceSpec, stop <-chan struct{}) {
 	defer runtime.HandleCrash(handler.NewPanicsCleanupSocketHandler(metadata.Endpoint))
 
 	d.log.Info("Fetching")
@@ -185,50 +200,84 @@ func (d *modbusDevice) fetch(interval time.Duration, stop <-chan struct{}) {
 		d.log.Info("Finished fetching")
 	}()
 
-	var ticker = time.NewTicker(interval)
-	defer ticker.Stop()
+	var intervals = make([]time.Duration, 0, len(spec.Properties))
+	for i := range spec.Properties {
+		intervals = append(intervals, spec.Properties[i].GetSyncInterval(spec.Parameters))
+	}
+	scheduler.New(intervals, time.Now()).Run(stop, d.fetchProperties, d.recordOverruns)
+}
+
+// fetchProperties reads the given properties and fills them back to status.
+func (d *modbusDevice) fetchProperties(indexes []int) {
+	d.Lock()
+	defer d.Unlock()
 
-	for {
-		select {
-		case <-stop:
-			return
-		case <-ticker.C:
+	var specProps = d.instance.Spec.Properties
+	var statusProps = d.instance.Status.Properties
+	var props = make([]v1alpha1.ModbusDeviceProperty, 0, len(indexes))
+	for _, idx := range indexes {
+		if idx >= len(specProps) || idx >= len(statusProps) {
+			continue
 		}
+		props = append(props, specProps[idx])
+	}
+	if len(props) == 0 {
+		return
+	}
 
-		d.Lock()
-		func() {
-			defer d.Unlock()
-
-			// read according to the properties defined by the spec,
-			// and finally fill it back to status.
-			var specProps = d.instance.Spec.Properties
-			var statusProps = make([]v1alpha1.ModbusDeviceStatusProperty, 0, len(specProps))
-			for _, prop := range specProps {
-				var value, operatedValue, err = d.readProperty(&prop)
-				if err != nil {
-					// TODO give a way to feedback this to limb.
-					d.log.Error(err, "Error fetching device property", "property", prop.Name)
-				}
-				d.log.V(4).Info("Read property", "property", prop.Name, "type", prop.Type)
-				statusProps = append(statusProps, v1alpha1.ModbusDeviceStatusProperty{
-					Name:          prop.Name,
-					Value:         value,
-					OperatedValue: operatedValue,
-					Type:          prop.Type,
-					UpdatedAt:     now(),
-				})
-			}
-			d.instance.Status.Properties = statusProps
-			if err := d.sync(); err != nil {
-				d.log.Error(err, "failed to sync")
-			}
-		}()
+	var readings = d.readProperties(props, d.instance.Spec.Parameters)
+	var changed = d.reporter.Expired(time.Now())
+	for i, prop := range props {
+		var reading = readings[i]
+		var statusProp = &statusProps[indexes[i]]
+		var staleQuality = statusProp.Quality
+		statusProp.Name = prop.Name
+		statusProp.Type = prop.Type
+		statusProp.UpdatedAt = now()
+		if reading.err != nil {
+			// the property keeps the last available value, and the failure is fed back via the quality.
+			d.log.Error(reading.err, "Error fetching device property", "property", prop.Name)
+			statusProp.Quality = v1alpha1.ModbusDevicePropertyQualityBad
+			statusProp.QualityReason = reading.err.Error()
+		} else {
+			d.log.V(4).Info("Read property", "property", prop.Name, "type", prop.Type)
+			statusProp.Value = reading.value
+			statusProp.OperatedValue = reading.operatedValue
+			statusProp.Quality = v1alpha1.ModbusDevicePropertyQualityGood
+			statusProp.QualityReason = ""
+			statusProp.SourceTimestamp = statusProp.UpdatedAt
+		}
+		if !changed {
+			var policy = deadband.Policy{Deadband: prop.Deadband, ReportOnChangeOnly: prop.ReportOnChangeOnly}
+			changed = staleQuality != statusProp.Quality || d.reporter.Changed(prop.Name, policy, getReportedValue(statusProp))
+		}
+	}
+	// the synchronization is skipped if none of the properties crossed its deadband.
+	if !changed {
+		d.log.V(4).Info("Skipped syncing, none of the properties changed")
+		return
+	}
+	if err := d.sync(); err != nil {
+		d.log.Error(err, "failed to sync")
+	}
+}
+
+// recordOverruns records the overruns of properties into status.
+func (d *modbusDevice) recordOverruns(overruns []scheduler.Overrun) {
+	d.Lock()
+	defer d.Unlock()
 
-		select {
-		case <-d.stop:
-			return
-		default:
+	var statusProps = d.instance.Status.Properties
+	for _, overrun := range overruns {
+		if overrun.Index >= len(statusProps) {
+			continue
 		}
+		var statusProp = &statusProps[overrun.Index]
+		statusProp.Overruns++
+		d.log.Info("Overran fetching device property", "property", statusProp.Name, "interval", overrun.Interval, "elapsed", overrun.Elapsed)
+	}
+	if err := d.sync(); err != nil {
+		d.log.Error(err, "failed to sync")
 	}
 }
 
@@ -240,29 +289,80 @@ func (d *modbusDevice) writeProperty(prop *v1alpha1.ModbusDeviceProperty) error
 	case v1alpha1.ModbusDeviceCoilRegister:
 		return write1BitRegister(prop, client.WriteMultipleCoils)
 	case v1alpha1.ModbusDeviceHoldingRegister:
-		return write16BitsRegister(prop, client.WriteMultipleRegisters)
+		return write16BitsRegister(prop, client.ReadHoldingRegisters, client.WriteMultipleRegisters)
 	default:
 		return errors.Errorf("invalid writable register %s", prop.Visitor.Register)
 	}
 }
 
-// readProperty reads data of a property from its corresponding register.
-// boolean/hex type is not supported to operate.
-func (d *modbusDevice) readProperty(prop *v1alpha1.ModbusDeviceProperty) (value string, operatedValue string, err error) {
+// propertyReading is the result of reading a property.
+type propertyReading struct {
+	value         string
+	operatedValue string
+	err           error
+}
+
+// readProperties reads data of the properties in the planned block reads,
+// the returning readings are in the same order as the given properties.
+func (d *modbusDevice) readProperties(props []v1alpha1.ModbusDeviceProperty, params *v1alpha1.ModbusDeviceParameters) []propertyReading {
 	var client = d.modbusHandler.Connect()
 
-	switch prop.Visitor.Register {
+	var ret = make([]propertyReading, len(props))
+	for _, block := range planReads(props, params) {
+		var data, err = readBlockData(client, block)
+		if err != nil {
+			for _, idx := range block.properties {
+				ret[idx].err = err
+			}
+			continue
+		}
+		d.log.V(4).Info("Read block", "register", block.register, "offset", block.offset, "quantity", block.quantity)
+
+		for _, idx := range block.properties {
+			var prop = &props[idx]
+			var propData, err = sliceBlock(block, data, prop.Visitor)
+			if err != nil {
+				ret[idx].err = err
+				continue
+			}
+
+			// the decoding of register is reused by returning the sliced data.
+			var read = func(address, quantity uint16) ([]byte, error) {
+				return propData, nil
+			}
+			var reading = &ret[idx]
+			switch block.register {
+			case v1alpha1.ModbusDeviceCoilRegister, v1alpha1.ModbusDeviceDiscreteInputRegister:
+				reading.value, reading.operatedValue, reading.err = read1BitRegister(prop, read)
+			default:
+				reading.value, reading.operatedValue, reading.err = read16BitsRegister(prop, read)
+			}
+		}
+	}
+	return ret
+}
+
+// readBlockData reads data of a block from its corresponding register.
+func readBlockData(client modbus.Client, block readBlock) ([]byte, error) {
+	var read registerReadFunc
+	switch block.register {
 	case v1alpha1.ModbusDeviceCoilRegister:
-		return read1BitRegister(prop, client.ReadCoils)
+		read = client.ReadCoils
 	case v1alpha1.ModbusDeviceDiscreteInputRegister:
-		return read1BitRegister(prop, client.ReadDiscreteInputs)
+		read = client.ReadDiscreteInputs
 	case v1alpha1.ModbusDeviceHoldingRegister:
-		return read16BitsRegister(prop, client.ReadHoldingRegisters)
+		read = client.ReadHoldingRegisters
 	case v1alpha1.ModbusDeviceInputRegister:
-		return read16BitsRegister(prop, client.ReadInputRegisters)
+		read = client.ReadInputRegisters
 	default:
-		return "", "", errors.Errorf("invalid readable register %s", prop.Visitor.Register)
+		return nil, errors.Errorf("invalid readable register %s", block.register)
+	}
+
+	var data, err = read(block.offset, block.quantity)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %d quantities from %s %d", block.quantity, block.register, block.offset)
 	}
+	return data, nil
 }
 
 func (d *modbusDevice) stopFetch() {
@@ -272,10 +372,10 @@ func (d *modbusDevice) stopFetch() {
 	}
 }
 
-func (d *modbusDevice) startFetch(fetchInterval time.Duration) {
+func (d *modbusDevice) startFetch(spec v1alpha1.ModbusDeviceSpec) {
 	if d.stop == nil {
 		d.stop = make(chan struct{})
-		go d.fetch(fetchInterval, d.stop)
+		go d.fetch(spec, d.stop)
 	}
 }
 
@@ -292,9 +392,24 @@ func (d *modbusDevice) sync() error {
 		}
 	}
 	d.log.V(1).Info("Synced")
+
+	var reported = make(map[string]string, len(d.instance.Status.Properties))
+	for i := range d.instance.Status.Properties {
+		var statusProp = &d.instance.Status.Properties[i]
+		reported[statusProp.Name] = getReportedValue(statusProp)
+	}
+	d.reporter.Report(time.Now(), reported)
 	return nil
 }
 
+// getReportedValue returns the operated value of property if any, otherwise returns the raw value.
+func getReportedValue(statusProp *v1alpha1.ModbusDeviceStatusProperty) string {
+	if statusProp.OperatedValue != "" {
+		return statusProp.OperatedValue
+	}
+	return statusProp.Value
+}
+
 func now() *metav1.Time {
 	var ret = metav1.Now()
 	return &ret
@@ -1,9 +1,11 @@
 package physical
 
 import (
+	"fmt"
 	"io"
 	"log"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/goburrow/modbus"
@@ -25,54 +27,173 @@ type ModbusClientHandler interface {
 }
 
 type modbusClientHandler struct {
-	handler modbus.ClientHandler
-	closer  io.Closer
+	packager    modbus.Packager
+	transporter *workerTransporter
+	closeOnce   sync.Once
 }
 
 func (h *modbusClientHandler) Connect() modbus.Client {
-	return modbus.NewClient(h.handler)
+	return modbus.NewClient2(h.packager, h.transporter)
 }
 
+// Close releases the shared transport,
+// the transport is closed until all devices on the same endpoint are closed.
 func (h *modbusClientHandler) Close() error {
-	return h.closer.Close()
+	var err error
+	h.closeOnce.Do(func() {
+		err = defaultTransportPool.release(h.transporter.transport)
+	})
+	return err
 }
 
-// NewModbusClientHandler creates a ModbusClientHandler.
+// framing is the framing of the transport,
+// the devices on the same endpoint must use the same framing.
+type framing string
+
+const (
+	framingTCP   framing = "tcp"
+	framingRTU   framing = "rtu"
+	framingASCII framing = "ascii"
+)
+
+// serialSettings is the settings of the serial transport,
+// the devices on the same bus must use the same settings.
+type serialSettings struct {
+	framing  framing
+	baudRate int
+	dataBits int
+	parity   string
+	stopBits int
+	timeout  time.Duration
+}
+
+// NewModbusClientHandler creates a ModbusClientHandler,
+// the devices on the same endpoint share one transport.
 func NewModbusClientHandler(protocol v1alpha1.ModbusDeviceProtocol, timeout time.Duration) (ModbusClientHandler, error) {
 	var logger *log.Logger
 	if logflag.GetLogVerbosity() > 4 {
 		logger = log.New(os.Stdout, "modbus.client", log.LstdFlags)
 	}
 
-	if protocol.TCP != nil {
+	switch {
+	case protocol.TCP != nil:
 		var tcpConfig = protocol.TCP
-		var tcpClientHandler = modbus.NewTCPClientHandler(tcpConfig.Endpoint)
-		tcpClientHandler.Timeout = timeout
-		tcpClientHandler.SlaveId = byte(tcpConfig.WorkerID)
-		tcpClientHandler.Logger = logger
-
-		if err := tcpClientHandler.Connect(); err != nil {
-			return nil, errors.Wrap(err, "failed to connect via TCP")
-		}
-		return &modbusClientHandler{handler: tcpClientHandler, closer: tcpClientHandler}, nil
-	}
 
-	if protocol.RTU != nil {
+		// the handler is only used as the packager of the worker,
+		// the transport is shared by the devices on the same endpoint.
+		var tcpPackager = modbus.NewTCPClientHandler(tcpConfig.Endpoint)
+		tcpPackager.SlaveId = byte(tcpConfig.WorkerID)
+
+		return newModbusClientHandler(tcpPackager, tcpConfig.WorkerID, transportOpener{
+			key:      newNetTransportKey("tcp", tcpConfig.Endpoint, timeout),
+			settings: framingTCP,
+			open: func() (modbus.Transporter, error) {
+				var tcpClientHandler = modbus.NewTCPClientHandler(tcpConfig.Endpoint)
+				tcpClientHandler.Timeout = timeout
+				tcpClientHandler.Logger = logger
+				if err := tcpClientHandler.Connect(); err != nil {
+					return nil, errors.Wrap(err, "failed to connect via TCP")
+				}
+				return tcpClientHandler, nil
+			},
+		})
+	case protocol.RTUOverTCP != nil:
+		return newRTUOverNetClientHandler("tcp", protocol.RTUOverTCP, timeout, logger)
+	case protocol.RTUOverUDP != nil:
+		return newRTUOverNetClientHandler("udp", protocol.RTUOverUDP, timeout, logger)
+	case protocol.RTU != nil:
 		var rtuConfig = protocol.RTU
-		rtuClientHandler := modbus.NewRTUClientHandler(rtuConfig.Endpoint)
-		rtuClientHandler.BaudRate = rtuConfig.BaudRate
-		rtuClientHandler.DataBits = rtuConfig.DataBits
-		rtuClientHandler.Parity = rtuConfig.Parity
-		rtuClientHandler.StopBits = rtuConfig.StopBits
-		rtuClientHandler.SlaveId = byte(rtuConfig.WorkerID)
-		rtuClientHandler.Timeout = timeout
-		rtuClientHandler.Logger = logger
-
-		if err := rtuClientHandler.Connect(); err != nil {
-			return nil, errors.Wrap(err, "failed to connect via RTU")
-		}
-		return &modbusClientHandler{handler: rtuClientHandler, closer: rtuClientHandler}, nil
+
+		var rtuPackager = modbus.NewRTUClientHandler(rtuConfig.Endpoint)
+		rtuPackager.SlaveId = byte(rtuConfig.WorkerID)
+
+		return newModbusClientHandler(rtuPackager, rtuConfig.WorkerID, transportOpener{
+			key:      "serial://" + rtuConfig.Endpoint,
+			settings: newSerialSettings(framingRTU, rtuConfig, timeout),
+			open: func() (modbus.Transporter, error) {
+				var rtuClientHandler = modbus.NewRTUClientHandler(rtuConfig.Endpoint)
+				rtuClientHandler.BaudRate = rtuConfig.BaudRate
+				rtuClientHandler.DataBits = rtuConfig.DataBits
+				rtuClientHandler.Parity = rtuConfig.Parity
+				rtuClientHandler.StopBits = rtuConfig.StopBits
+				rtuClientHandler.Timeout = timeout
+				rtuClientHandler.Logger = logger
+				if err := rtuClientHandler.Connect(); err != nil {
+					return nil, errors.Wrap(err, "failed to connect via RTU")
+				}
+				return rtuClientHandler, nil
+			},
+		})
+	case protocol.ASCII != nil:
+		var asciiConfig = protocol.ASCII
+
+		var asciiPackager = modbus.NewASCIIClientHandler(asciiConfig.Endpoint)
+		asciiPackager.SlaveId = byte(asciiConfig.WorkerID)
+
+		return newModbusClientHandler(asciiPackager, asciiConfig.WorkerID, transportOpener{
+			key:      "serial://" + asciiConfig.Endpoint,
+			settings: newSerialSettings(framingASCII, asciiConfig, timeout),
+			open: func() (modbus.Transporter, error) {
+				var asciiClientHandler = modbus.NewASCIIClientHandler(asciiConfig.Endpoint)
+				asciiClientHandler.BaudRate = asciiConfig.BaudRate
+				asciiClientHandler.DataBits = asciiConfig.DataBits
+				asciiClientHandler.Parity = asciiConfig.Parity
+				asciiClientHandler.StopBits = asciiConfig.StopBits
+				asciiClientHandler.Timeout = timeout
+				asciiClientHandler.Logger = logger
+				if err := asciiClientHandler.Connect(); err != nil {
+					return nil, errors.Wrap(err, "failed to connect via ASCII")
+				}
+				return asciiClientHandler, nil
+			},
+		})
 	}
 
 	return nil, errors.New("failed to create Modbus handler with empty protocol")
 }
+
+// newRTUOverNetClientHandler creates a ModbusClientHandler which sends the RTU frames over TCP or UDP.
+func newRTUOverNetClientHandler(network string, config *v1alpha1.ModbusDeviceProtocolTCP, timeout time.Duration, logger *log.Logger) (ModbusClientHandler, error) {
+	var rtuPackager = modbus.NewRTUClientHandler(config.Endpoint)
+	rtuPackager.SlaveId = byte(config.WorkerID)
+
+	return newModbusClientHandler(rtuPackager, config.WorkerID, transportOpener{
+		key:      newNetTransportKey(network, config.Endpoint, timeout),
+		settings: framingRTU,
+		open: func() (modbus.Transporter, error) {
+			var transporter = newRTUNetTransporter(network, config.Endpoint, timeout, logger)
+			if err := transporter.Connect(); err != nil {
+				return nil, errors.Wrapf(err, "failed to connect via RTU over %s", network)
+			}
+			return transporter, nil
+		},
+	})
+}
+
+func newModbusClientHandler(packager modbus.Packager, workerID int, opener transportOpener) (ModbusClientHandler, error) {
+	var transport, err = defaultTransportPool.acquire(opener)
+	if err != nil {
+		return nil, err
+	}
+	return &modbusClientHandler{
+		packager:    packager,
+		transporter: &workerTransporter{worker: byte(workerID), transport: transport},
+	}, nil
+}
+
+func newSerialSettings(framing framing, config *v1alpha1.ModbusDeviceProtocolRTU, timeout time.Duration) serialSettings {
+	return serialSettings{
+		framing:  framing,
+		baudRate: config.BaudRate,
+		dataBits: config.DataBits,
+		parity:   config.Parity,
+		stopBits: config.StopBits,
+		timeout:  timeout,
+	}
+}
+
+// newNetTransportKey returns the key of the network transport,
+// the devices on the same endpoint with different timeouts open their own connections.
+func newNetTransportKey(network, endpoint string, timeout time.Duration) string {
+	return fmt.Sprintf("%s://%s?timeout=%s", network, endpoint, timeout)
+}
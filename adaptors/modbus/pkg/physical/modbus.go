@@ -2,9 +2,11 @@
 
 import (
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"math"
 	"strconv"
+	"strings"
 
 	"github.com/pkg/errors"
 
@@ -79,14 +81,40 @@ func write1BitRegister(prop *v1alpha1.ModbusDeviceProperty, write registerWriteF
 	return nil
 }
 
-// write16BitsRegister writes the given property's value to 16-bits register.
-func write16BitsRegister(prop *v1alpha1.ModbusDeviceProperty, write registerWriteFunc) error {
+// write16BitsRegister writes the given property's value to 16-bits register,
+// the register is read before writing the int8/uint8 or bitfield value to keep the sibling byte or the other bits.
+func write16BitsRegister(prop *v1alpha1.ModbusDeviceProperty, read registerReadFunc, write registerWriteFunc) error {
 	if prop.Value == "" {
 		return nil
 	}
 
 	var visitor = prop.Visitor
 
+	// transforms the value into raw value if needed
+	if visitor.WriteExpression != "" {
+		var value, err = transformWriteValue(prop)
+		if err != nil {
+			return err
+		}
+		var transformed = *prop
+		transformed.Value = value
+		prop = &transformed
+	}
+
+	// string, bitfield and bcd type can be set on single or multiple quantities.
+	switch prop.Type {
+	case v1alpha1.ModbusDevicePropertyTypeString, v1alpha1.ModbusDevicePropertyTypeBitfield, v1alpha1.ModbusDevicePropertyTypeBCD:
+		var data, err = encodeExtended16Bits(prop, read)
+		if err != nil {
+			return err
+		}
+		_, err = write(visitor.Offset, visitor.Quantity, data)
+		if err != nil {
+			return errors.Wrapf(err, "failed to write %s to 16-bits quantities %s", prop.Value, visitor.Register)
+		}
+		return nil
+	}
+
 	if visitor.Quantity == 1 {
 		// parse value
 		var data []byte
@@ -118,6 +146,30 @@ func write16BitsRegister(prop *v1alpha1.ModbusDeviceProperty, write registerWrit
 
 			data = make([]byte, 2)
 			visitor.Endianness.PutUint16(data, uint16(val))
+		case v1alpha1.ModbusDevicePropertyTypeInt8:
+			var val, err = strconv.ParseInt(prop.Value, 10, 8)
+			if err != nil {
+				return errors.Wrapf(err, "failed to convert the single 16-bits quantity %s's value to int8", visitor.Register)
+			}
+			origin, err := readSingle16Bits(visitor, read)
+			if err != nil {
+				return err
+			}
+
+			data = make([]byte, 2)
+			visitor.Endianness.PutUint16(data, putByte(visitor.Byte, uint8(val), origin))
+		case v1alpha1.ModbusDevicePropertyTypeUint8:
+			var val, err = strconv.ParseUint(prop.Value, 10, 8)
+			if err != nil {
+				return errors.Wrapf(err, "failed to convert the single 16-bits quantity %s's value to uint8", visitor.Register)
+			}
+			origin, err := readSingle16Bits(visitor, read)
+			if err != nil {
+				return err
+			}
+
+			data = make([]byte, 2)
+			visitor.Endianness.PutUint16(data, putByte(visitor.Byte, uint8(val), origin))
 		default:
 			return errors.Errorf("single 16-bits quantity %s cannot set as %s type", visitor.Register, prop.Type)
 		}
@@ -246,6 +298,19 @@ func read1BitRegister(prop *v1alpha1.ModbusDeviceProperty, read registerReadFunc
 func read16BitsRegister(prop *v1alpha1.ModbusDeviceProperty, read registerReadFunc) (value string, operatedValue string, berr error) {
 	var visitor = prop.Visitor
 
+	// string, bitfield and bcd type can be set on single or multiple quantities.
+	switch prop.Type {
+	case v1alpha1.ModbusDevicePropertyTypeString, v1alpha1.ModbusDevicePropertyTypeBitfield, v1alpha1.ModbusDevicePropertyTypeBCD:
+		var val, err = read(visitor.Offset, visitor.Quantity)
+		if err != nil {
+			return "", "", errors.Wrapf(err, "failed to read from 16-bits quantities %s", visitor.Register)
+		}
+		if len(val) != int(visitor.Quantity)*2 {
+			return "", "", errors.Errorf("failed to read from 16-bits quantities %s, response bytes isn't in valid size", visitor.Register)
+		}
+		return decodeExtended16Bits(prop, val)
+	}
+
 	if visitor.Quantity == 1 {
 		// validate first
 		switch prop.Type {
@@ -253,6 +318,8 @@ func read16BitsRegister(prop *v1alpha1.ModbusDeviceProperty, read registerReadFu
 			// pass
 		case v1alpha1.ModbusDevicePropertyTypeInt16, v1alpha1.ModbusDevicePropertyTypeUint16:
 			// pass
+		case v1alpha1.ModbusDevicePropertyTypeInt8, v1alpha1.ModbusDevicePropertyTypeUint8:
+			// pass
 		default:
 			return "", "", errors.Errorf("single 16-bits quantity %s cannot set as %s type", visitor.Register, prop.Type)
 		}
@@ -275,18 +342,30 @@ func read16BitsRegister(prop *v1alpha1.ModbusDeviceProperty, read registerReadFu
 		case v1alpha1.ModbusDevicePropertyTypeHexString:
 			data = hex.EncodeToString(val)
 		case v1alpha1.ModbusDevicePropertyTypeInt16:
-			var valBits = visitor.Endianness.Uint16(val)
-			var valFloat64 = math.Float64frombits(uint64(valBits))
-			data = strconv.FormatInt(int64(valBits), 10)
-			operatedData, err = doArithmeticOperations(valFloat64, visitor.OrderOfOperations)
+			var valInt = int16(visitor.Endianness.Uint16(val))
+			data = strconv.FormatInt(int64(valInt), 10)
+			operatedData, err = operate(float64(valInt), visitor)
 			if err != nil {
 				return "", "", errors.Wrap(err, "failed to execute arithmetic operations")
 			}
 		case v1alpha1.ModbusDevicePropertyTypeUint16:
-			var valBits = visitor.Endianness.Uint16(val)
-			var valFloat64 = math.Float64frombits(uint64(valBits))
-			data = strconv.FormatUint(uint64(valBits), 10)
-			operatedData, err = doArithmeticOperations(valFloat64, visitor.OrderOfOperations)
+			var valUint = visitor.Endianness.Uint16(val)
+			data = strconv.FormatUint(uint64(valUint), 10)
+			operatedData, err = operate(float64(valUint), visitor)
+			if err != nil {
+				return "", "", errors.Wrap(err, "failed to execute arithmetic operations")
+			}
+		case v1alpha1.ModbusDevicePropertyTypeInt8:
+			var valByte = int8(getByte(visitor.Byte, visitor.Endianness.Uint16(val)))
+			data = strconv.FormatInt(int64(valByte), 10)
+			operatedData, err = operate(float64(valByte), visitor)
+			if err != nil {
+				return "", "", errors.Wrap(err, "failed to execute arithmetic operations")
+			}
+		case v1alpha1.ModbusDevicePropertyTypeUint8:
+			var valByte = getByte(visitor.Byte, visitor.Endianness.Uint16(val))
+			data = strconv.FormatUint(uint64(valByte), 10)
+			operatedData, err = operate(float64(valByte), visitor)
 			if err != nil {
 				return "", "", errors.Wrap(err, "failed to execute arithmetic operations")
 			}
@@ -322,34 +401,30 @@ func read16BitsRegister(prop *v1alpha1.ModbusDeviceProperty, read registerReadFu
 	)
 	switch prop.Type {
 	case v1alpha1.ModbusDevicePropertyTypeInt, v1alpha1.ModbusDevicePropertyTypeInt32:
-		var valBits = visitor.Endianness.Uint32(val)
-		var valFloat32 = math.Float32frombits(valBits)
-		data = strconv.FormatInt(int64(valBits), 10)
-		operatedData, err = doArithmeticOperations(float64(valFloat32), visitor.OrderOfOperations)
+		var valInt = int32(visitor.Endianness.Uint32(val))
+		data = strconv.FormatInt(int64(valInt), 10)
+		operatedData, err = operate(float64(valInt), visitor)
 		if err != nil {
 			return "", "", errors.Wrap(err, "failed to execute arithmetic operations")
 		}
 	case v1alpha1.ModbusDevicePropertyTypeUint, v1alpha1.ModbusDevicePropertyTypeUint32:
-		var valBits = visitor.Endianness.Uint32(val)
-		var valFloat32 = math.Float32frombits(valBits)
-		data = strconv.FormatUint(uint64(valBits), 10)
-		operatedData, err = doArithmeticOperations(float64(valFloat32), visitor.OrderOfOperations)
+		var valUint = visitor.Endianness.Uint32(val)
+		data = strconv.FormatUint(uint64(valUint), 10)
+		operatedData, err = operate(float64(valUint), visitor)
 		if err != nil {
 			return "", "", errors.Wrap(err, "failed to execute arithmetic operations")
 		}
 	case v1alpha1.ModbusDevicePropertyTypeInt64:
-		var valBits = visitor.Endianness.Uint64(val)
-		var valFloat64 = math.Float64frombits(valBits)
-		data = strconv.FormatInt(int64(valBits), 10)
-		operatedData, err = doArithmeticOperations(valFloat64, visitor.OrderOfOperations)
+		var valInt = int64(visitor.Endianness.Uint64(val))
+		data = strconv.FormatInt(valInt, 10)
+		operatedData, err = operate(float64(valInt), visitor)
 		if err != nil {
 			return "", "", errors.Wrap(err, "failed to execute arithmetic operations")
 		}
 	case v1alpha1.ModbusDevicePropertyTypeUint64:
-		var valBits = visitor.Endianness.Uint64(val)
-		var valFloat64 = math.Float64frombits(valBits)
-		data = strconv.FormatUint(valBits, 10)
-		operatedData, err = doArithmeticOperations(valFloat64, visitor.OrderOfOperations)
+		var valUint = visitor.Endianness.Uint64(val)
+		data = strconv.FormatUint(valUint, 10)
+		operatedData, err = operate(float64(valUint), visitor)
 		if err != nil {
 			return "", "", errors.Wrap(err, "failed to execute arithmetic operations")
 		}
@@ -357,7 +432,7 @@ func read16BitsRegister(prop *v1alpha1.ModbusDeviceProperty, read registerReadFu
 		var valBits = visitor.Endianness.Uint32(val)
 		var valFloat32 = math.Float32frombits(valBits)
 		data = fmt.Sprint(valFloat32)
-		operatedData, err = doArithmeticOperations(float64(valFloat32), visitor.OrderOfOperations)
+		operatedData, err = operate(float64(valFloat32), visitor)
 		if err != nil {
 			return "", "", errors.Wrap(err, "failed to execute arithmetic operations")
 		}
@@ -365,10 +440,223 @@ func read16BitsRegister(prop *v1alpha1.ModbusDeviceProperty, read registerReadFu
 		var valBits = visitor.Endianness.Uint64(val)
 		var valFloat64 = math.Float64frombits(valBits)
 		data = fmt.Sprint(valFloat64)
-		operatedData, err = doArithmeticOperations(valFloat64, visitor.OrderOfOperations)
+		operatedData, err = operate(valFloat64, visitor)
 		if err != nil {
 			return "", "", errors.Wrap(err, "failed to execute arithmetic operations")
 		}
 	}
 	return data, operatedData, nil
 }
+
+// getByte returns the high or low byte of the given 16-bits value.
+func getByte(b v1alpha1.ModbusDevicePropertyByte, v uint16) uint8 {
+	if b == v1alpha1.ModbusDevicePropertyByteHigh {
+		return uint8(v >> 8)
+	}
+	return uint8(v)
+}
+
+// putByte puts the given byte into the high or low byte of the origin 16-bits value,
+// the other byte of the origin value is kept.
+func putByte(b v1alpha1.ModbusDevicePropertyByte, v uint8, origin uint16) uint16 {
+	if b == v1alpha1.ModbusDevicePropertyByteHigh {
+		return origin&0x00FF | uint16(v)<<8
+	}
+	return origin&0xFF00 | uint16(v)
+}
+
+// readSingle16Bits reads the current value of the single 16-bits quantity.
+func readSingle16Bits(visitor v1alpha1.ModbusDevicePropertyVisitor, read registerReadFunc) (uint16, error) {
+	var val, err = read(visitor.Offset, 1)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to read from single 16-bits quantity %s", visitor.Register)
+	}
+	if len(val) != 2 {
+		return 0, errors.Errorf("failed to read from single 16-bits quantity %s, response bytes isn't in valid size", visitor.Register)
+	}
+	return visitor.Endianness.Uint16(val), nil
+}
+
+// decodeExtended16Bits decodes the string, bitfield or bcd value from the data of 16-bits registers.
+func decodeExtended16Bits(prop *v1alpha1.ModbusDeviceProperty, val []byte) (value string, operatedValue string, berr error) {
+	var visitor = prop.Visitor
+
+	switch prop.Type {
+	case v1alpha1.ModbusDevicePropertyTypeString:
+		var data = swapStringBytes(visitor.Endianness, val)
+		return strings.TrimRight(string(data), "\x00"), "", nil
+	case v1alpha1.ModbusDevicePropertyTypeBitfield:
+		if len(visitor.Bits) == 0 {
+			return "", "", errors.Errorf("bitfield type requires named bits")
+		}
+		var raw, err = getBitfieldRaw(visitor, val)
+		if err != nil {
+			return "", "", err
+		}
+		var bits = make(map[string]bool, len(visitor.Bits))
+		for _, bit := range visitor.Bits {
+			bits[bit.Name] = raw&(1<<uint(bit.Index)) != 0
+		}
+		var data, _ = json.Marshal(bits)
+		return string(data), "", nil
+	case v1alpha1.ModbusDevicePropertyTypeBCD:
+		var raw, err = getBCDRaw(visitor, val)
+		if err != nil {
+			return "", "", err
+		}
+		var digits = int(visitor.Quantity) * 4
+		var result uint64
+		for i := digits - 1; i >= 0; i-- {
+			var digit = (raw >> uint(4*i)) & 0xF
+			if digit > 9 {
+				return "", "", errors.Errorf("failed to decode the bcd value %x of 16-bits quantities %s", raw, visitor.Register)
+			}
+			result = result*10 + digit
+		}
+		var operatedData string
+		operatedData, err = operate(float64(result), visitor)
+		if err != nil {
+			return "", "", errors.Wrap(err, "failed to execute arithmetic operations")
+		}
+		return strconv.FormatUint(result, 10), operatedData, nil
+	}
+	return "", "", errors.Errorf("16-bits quantities %s cannot set as %s type", visitor.Register, prop.Type)
+}
+
+// encodeExtended16Bits encodes the string, bitfield or bcd value to the data of 16-bits registers,
+// the bitfield value only changes the given bits of the current registers.
+func encodeExtended16Bits(prop *v1alpha1.ModbusDeviceProperty, read registerReadFunc) ([]byte, error) {
+	var visitor = prop.Visitor
+	var data = make([]byte, int(visitor.Quantity)*2)
+
+	switch prop.Type {
+	case v1alpha1.ModbusDevicePropertyTypeString:
+		if len(prop.Value) > len(data) {
+			return nil, errors.Errorf("the length of 16-bits quantities %s's string value is longer than %d", visitor.Register, len(data))
+		}
+		copy(data, prop.Value)
+		return swapStringBytes(visitor.Endianness, data), nil
+	case v1alpha1.ModbusDevicePropertyTypeBitfield:
+		var bits map[string]bool
+		if err := json.Unmarshal([]byte(prop.Value), &bits); err != nil {
+			return nil, errors.Wrapf(err, "failed to convert the 16-bits quantities %s's value to bitfield", visitor.Register)
+		}
+		var indexes = make(map[string]uint8, len(visitor.Bits))
+		for _, bit := range visitor.Bits {
+			indexes[bit.Name] = bit.Index
+		}
+		for name := range bits {
+			if _, exist := indexes[name]; !exist {
+				return nil, errors.Errorf("bit %s isn't named in the 16-bits quantities %s", name, visitor.Register)
+			}
+		}
+		var origin, err = read(visitor.Offset, visitor.Quantity)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read from 16-bits quantities %s", visitor.Register)
+		}
+		if len(origin) != len(data) {
+			return nil, errors.Errorf("failed to read from 16-bits quantities %s, response bytes isn't in valid size", visitor.Register)
+		}
+		raw, err := getBitfieldRaw(visitor, origin)
+		if err != nil {
+			return nil, err
+		}
+		for name, set := range bits {
+			var mask = uint64(1) << uint(indexes[name])
+			if set {
+				raw |= mask
+			} else {
+				raw &^= mask
+			}
+		}
+		if err := putBitfieldRaw(visitor, data, raw); err != nil {
+			return nil, err
+		}
+		return data, nil
+	case v1alpha1.ModbusDevicePropertyTypeBCD:
+		var val, err = strconv.ParseUint(prop.Value, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to convert the 16-bits quantities %s's value to bcd", visitor.Register)
+		}
+		var digits = int(visitor.Quantity) * 4
+		var raw uint64
+		for i := 0; i < digits; i++ {
+			raw |= (val % 10) << uint(4*i)
+			val /= 10
+		}
+		if val != 0 {
+			return nil, errors.Errorf("the 16-bits quantities %s's bcd value is longer than %d digits", visitor.Register, digits)
+		}
+		if err := putBCDRaw(visitor, data, raw); err != nil {
+			return nil, err
+		}
+		return data, nil
+	}
+	return nil, errors.Errorf("16-bits quantities %s cannot set as %s type", visitor.Register, prop.Type)
+}
+
+// swapStringBytes returns the bytes of string in order,
+// the bytes of each register are swapped if the endianness is LittleEndian or LittleEndianSwap.
+func swapStringBytes(endianness v1alpha1.ModbusDevicePropertyValueEndianness, val []byte) []byte {
+	var ret = make([]byte, len(val))
+	copy(ret, val)
+	switch endianness {
+	case v1alpha1.ModbusDevicePropertyValueEndiannessLittleEndian, v1alpha1.ModbusDevicePropertyValueEndiannessLittleEndianSwap:
+		for i := 0; i+1 < len(ret); i += 2 {
+			ret[i], ret[i+1] = ret[i+1], ret[i]
+		}
+	}
+	return ret
+}
+
+func getBitfieldRaw(visitor v1alpha1.ModbusDevicePropertyVisitor, val []byte) (uint64, error) {
+	switch visitor.Quantity {
+	case 1:
+		return uint64(visitor.Endianness.Uint16(val)), nil
+	case 2:
+		return uint64(visitor.Endianness.Uint32(val)), nil
+	}
+	return 0, errors.Errorf("bitfield type can only set on 1 or 2 quantities of %s", visitor.Register)
+}
+
+func putBitfieldRaw(visitor v1alpha1.ModbusDevicePropertyVisitor, data []byte, raw uint64) error {
+	switch visitor.Quantity {
+	case 1:
+		if raw > math.MaxUint16 {
+			return errors.Errorf("bit index is out of the single 16-bits quantity %s", visitor.Register)
+		}
+		visitor.Endianness.PutUint16(data, uint16(raw))
+		return nil
+	case 2:
+		visitor.Endianness.PutUint32(data, uint32(raw))
+		return nil
+	}
+	return errors.Errorf("bitfield type can only set on 1 or 2 quantities of %s", visitor.Register)
+}
+
+func getBCDRaw(visitor v1alpha1.ModbusDevicePropertyVisitor, val []byte) (uint64, error) {
+	switch visitor.Quantity {
+	case 1:
+		return uint64(visitor.Endianness.Uint16(val)), nil
+	case 2:
+		return uint64(visitor.Endianness.Uint32(val)), nil
+	case 4:
+		return visitor.Endianness.Uint64(val), nil
+	}
+	return 0, errors.Errorf("bcd type can only set on 1, 2 or 4 quantities of %s", visitor.Register)
+}
+
+func putBCDRaw(visitor v1alpha1.ModbusDevicePropertyVisitor, data []byte, raw uint64) error {
+	switch visitor.Quantity {
+	case 1:
+		visitor.Endianness.PutUint16(data, uint16(raw))
+		return nil
+	case 2:
+		visitor.Endianness.PutUint32(data, uint32(raw))
+		return nil
+	case 4:
+		visitor.Endianness.PutUint64(data, raw)
+		return nil
+	}
+	return errors.Errorf("bcd type can only set on 1, 2 or 4 quantities of %s", visitor.Register)
+}
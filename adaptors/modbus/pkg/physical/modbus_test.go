@@ -0,0 +1,408 @@
+package physical
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/rancher/octopus/adaptors/modbus/api/v1alpha1"
+)
+
+// fakeRegisters simulates the 16-bits registers.
+type fakeRegisters map[uint16][]byte
+
+func (r fakeRegisters) write(address, quantity uint16, value []byte) ([]byte, error) {
+	for i := uint16(0); i < quantity; i++ {
+		r[address+i] = value[i*2 : i*2+2]
+	}
+	return nil, nil
+}
+
+func (r fakeRegisters) read(address, quantity uint16) ([]byte, error) {
+	var ret = make([]byte, 0, quantity*2)
+	for i := uint16(0); i < quantity; i++ {
+		var val, exist = r[address+i]
+		if !exist {
+			val = []byte{0, 0}
+		}
+		ret = append(ret, val...)
+	}
+	return ret, nil
+}
+
+func TestRoundTrip16BitsRegister(t *testing.T) {
+	var bits = []v1alpha1.ModbusDevicePropertyBit{
+		{Index: 0, Name: "running"},
+		{Index: 3, Name: "alarm"},
+		{Index: 15, Name: "fault"},
+	}
+
+	var testCases = []struct {
+		name          string
+		typ           v1alpha1.ModbusDevicePropertyType
+		visitor       v1alpha1.ModbusDevicePropertyVisitor
+		value         string
+		expected      string
+		expectedBytes []byte
+		expectedErr   bool
+	}{
+		{
+			name:          "big endian string",
+			typ:           v1alpha1.ModbusDevicePropertyTypeString,
+			visitor:       v1alpha1.ModbusDevicePropertyVisitor{Quantity: 3, Endianness: v1alpha1.ModbusDevicePropertyValueEndiannessBigEndian},
+			value:         "ABCDE",
+			expected:      "ABCDE",
+			expectedBytes: []byte{'A', 'B', 'C', 'D', 'E', 0},
+		},
+		{
+			name:          "little endian string",
+			typ:           v1alpha1.ModbusDevicePropertyTypeString,
+			visitor:       v1alpha1.ModbusDevicePropertyVisitor{Quantity: 2, Endianness: v1alpha1.ModbusDevicePropertyValueEndiannessLittleEndian},
+			value:         "ABCD",
+			expected:      "ABCD",
+			expectedBytes: []byte{'B', 'A', 'D', 'C'},
+		},
+		{
+			name:        "too long string",
+			typ:         v1alpha1.ModbusDevicePropertyTypeString,
+			visitor:     v1alpha1.ModbusDevicePropertyVisitor{Quantity: 1},
+			value:       "ABC",
+			expectedErr: true,
+		},
+		{
+			name:          "bitfield",
+			typ:           v1alpha1.ModbusDevicePropertyTypeBitfield,
+			visitor:       v1alpha1.ModbusDevicePropertyVisitor{Quantity: 1, Bits: bits},
+			value:         `{"running":true,"alarm":true}`,
+			expected:      `{"alarm":true,"fault":false,"running":true}`,
+			expectedBytes: []byte{0x00, 0x09},
+		},
+		{
+			name:        "bitfield with unnamed bit",
+			typ:         v1alpha1.ModbusDevicePropertyTypeBitfield,
+			visitor:     v1alpha1.ModbusDevicePropertyVisitor{Quantity: 1, Bits: bits},
+			value:       `{"unknown":true}`,
+			expectedErr: true,
+		},
+		{
+			name:          "4 digits bcd",
+			typ:           v1alpha1.ModbusDevicePropertyTypeBCD,
+			visitor:       v1alpha1.ModbusDevicePropertyVisitor{Quantity: 1},
+			value:         "1234",
+			expected:      "1234",
+			expectedBytes: []byte{0x12, 0x34},
+		},
+		{
+			name:          "8 digits bcd",
+			typ:           v1alpha1.ModbusDevicePropertyTypeBCD,
+			visitor:       v1alpha1.ModbusDevicePropertyVisitor{Quantity: 2},
+			value:         "90817",
+			expected:      "90817",
+			expectedBytes: []byte{0x00, 0x09, 0x08, 0x17},
+		},
+		{
+			name:        "overflowed bcd",
+			typ:         v1alpha1.ModbusDevicePropertyTypeBCD,
+			visitor:     v1alpha1.ModbusDevicePropertyVisitor{Quantity: 1},
+			value:       "12345",
+			expectedErr: true,
+		},
+		{
+			name:          "low byte int8",
+			typ:           v1alpha1.ModbusDevicePropertyTypeInt8,
+			visitor:       v1alpha1.ModbusDevicePropertyVisitor{Quantity: 1},
+			value:         "-2",
+			expected:      "-2",
+			expectedBytes: []byte{0x00, 0xFE},
+		},
+		{
+			name:          "high byte uint8",
+			typ:           v1alpha1.ModbusDevicePropertyTypeUint8,
+			visitor:       v1alpha1.ModbusDevicePropertyVisitor{Quantity: 1, Byte: v1alpha1.ModbusDevicePropertyByteHigh},
+			value:         "200",
+			expected:      "200",
+			expectedBytes: []byte{0xC8, 0x00},
+		},
+		{
+			name:        "overflowed uint8",
+			typ:         v1alpha1.ModbusDevicePropertyTypeUint8,
+			visitor:     v1alpha1.ModbusDevicePropertyVisitor{Quantity: 1},
+			value:       "256",
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		var registers = fakeRegisters{}
+		var prop = &v1alpha1.ModbusDeviceProperty{
+			Name:    tc.name,
+			Type:    tc.typ,
+			Visitor: tc.visitor,
+			Value:   tc.value,
+		}
+		prop.Visitor.Register = v1alpha1.ModbusDeviceHoldingRegister
+
+		var err = write16BitsRegister(prop, registers.read, registers.write)
+		if tc.expectedErr {
+			assert.Error(t, err, "case %q", tc.name)
+			continue
+		}
+		if !assert.NoError(t, err, "case %q", tc.name) {
+			continue
+		}
+		var written, _ = registers.read(0, tc.visitor.Quantity)
+		assert.Equal(t, tc.expectedBytes, written, "case %q", tc.name)
+
+		value, _, err := read16BitsRegister(prop, registers.read)
+		assert.NoError(t, err, "case %q", tc.name)
+		assert.Equal(t, tc.expected, value, "case %q", tc.name)
+	}
+}
+
+func TestWrite16BitsRegisterKeepsUntouched(t *testing.T) {
+	var bits = []v1alpha1.ModbusDevicePropertyBit{
+		{Index: 0, Name: "running"},
+		{Index: 3, Name: "alarm"},
+		{Index: 15, Name: "fault"},
+	}
+
+	var testCases = []struct {
+		name          string
+		typ           v1alpha1.ModbusDevicePropertyType
+		visitor       v1alpha1.ModbusDevicePropertyVisitor
+		origin        []byte
+		value         string
+		expectedBytes []byte
+	}{
+		{
+			name:          "low byte keeps the high byte",
+			typ:           v1alpha1.ModbusDevicePropertyTypeInt8,
+			visitor:       v1alpha1.ModbusDevicePropertyVisitor{Quantity: 1},
+			origin:        []byte{0xAB, 0xCD},
+			value:         "-2",
+			expectedBytes: []byte{0xAB, 0xFE},
+		},
+		{
+			name:          "high byte keeps the low byte",
+			typ:           v1alpha1.ModbusDevicePropertyTypeUint8,
+			visitor:       v1alpha1.ModbusDevicePropertyVisitor{Quantity: 1, Byte: v1alpha1.ModbusDevicePropertyByteHigh},
+			origin:        []byte{0xAB, 0xCD},
+			value:         "200",
+			expectedBytes: []byte{0xC8, 0xCD},
+		},
+		{
+			name:          "little endian low byte keeps the high byte",
+			typ:           v1alpha1.ModbusDevicePropertyTypeUint8,
+			visitor:       v1alpha1.ModbusDevicePropertyVisitor{Quantity: 1, Endianness: v1alpha1.ModbusDevicePropertyValueEndiannessLittleEndian},
+			origin:        []byte{0xCD, 0xAB},
+			value:         "1",
+			expectedBytes: []byte{0x01, 0xAB},
+		},
+		{
+			name:          "bitfield keeps the unnamed and omitted bits",
+			typ:           v1alpha1.ModbusDevicePropertyTypeBitfield,
+			visitor:       v1alpha1.ModbusDevicePropertyVisitor{Quantity: 1, Bits: bits},
+			origin:        []byte{0x80, 0x16},
+			value:         `{"running":true,"alarm":false}`,
+			expectedBytes: []byte{0x80, 0x17},
+		},
+		{
+			name:          "bitfield clears the given bits",
+			typ:           v1alpha1.ModbusDevicePropertyTypeBitfield,
+			visitor:       v1alpha1.ModbusDevicePropertyVisitor{Quantity: 1, Bits: bits},
+			origin:        []byte{0xFF, 0xFF},
+			value:         `{"fault":false,"alarm":false}`,
+			expectedBytes: []byte{0x7F, 0xF7},
+		},
+		{
+			name:          "bitfield on 2 quantities",
+			typ:           v1alpha1.ModbusDevicePropertyTypeBitfield,
+			visitor:       v1alpha1.ModbusDevicePropertyVisitor{Quantity: 2, Bits: bits},
+			origin:        []byte{0x12, 0x34, 0x00, 0x00},
+			value:         `{"running":true}`,
+			expectedBytes: []byte{0x12, 0x34, 0x00, 0x01},
+		},
+	}
+
+	for _, tc := range testCases {
+		var registers = fakeRegisters{}
+		_, _ = registers.write(0, tc.visitor.Quantity, tc.origin)
+		var prop = &v1alpha1.ModbusDeviceProperty{
+			Name:    tc.name,
+			Type:    tc.typ,
+			Visitor: tc.visitor,
+			Value:   tc.value,
+		}
+		prop.Visitor.Register = v1alpha1.ModbusDeviceHoldingRegister
+
+		var err = write16BitsRegister(prop, registers.read, registers.write)
+		if !assert.NoError(t, err, "case %q", tc.name) {
+			continue
+		}
+		var written, _ = registers.read(0, tc.visitor.Quantity)
+		assert.Equal(t, tc.expectedBytes, written, "case %q", tc.name)
+	}
+}
+
+func TestRead16BitsRegisterWithInvalidBCD(t *testing.T) {
+	var registers = fakeRegisters{0: []byte{0x12, 0x3A}}
+	var prop = &v1alpha1.ModbusDeviceProperty{
+		Type: v1alpha1.ModbusDevicePropertyTypeBCD,
+		Visitor: v1alpha1.ModbusDevicePropertyVisitor{
+			Register: v1alpha1.ModbusDeviceHoldingRegister,
+			Quantity: 1,
+		},
+	}
+
+	var _, _, err = read16BitsRegister(prop, registers.read)
+	assert.Error(t, err)
+}
+
+func TestExpression16BitsRegister(t *testing.T) {
+	var registers = fakeRegisters{}
+	var prop = &v1alpha1.ModbusDeviceProperty{
+		Type: v1alpha1.ModbusDevicePropertyTypeFloat,
+		Visitor: v1alpha1.ModbusDevicePropertyVisitor{
+			Register:        v1alpha1.ModbusDeviceHoldingRegister,
+			Quantity:        2,
+			ReadExpression:  "x * 0.1 - 40",
+			WriteExpression: "(x + 40) * 10",
+		},
+		Value: "-15.5",
+	}
+
+	var err = write16BitsRegister(prop, registers.read, registers.write)
+	assert.NoError(t, err)
+	var written, _ = registers.read(0, 2)
+	assert.Equal(t, []byte{0x43, 0x75, 0x00, 0x00}, written)
+
+	value, operatedValue, err := read16BitsRegister(prop, registers.read)
+	assert.NoError(t, err)
+	assert.Equal(t, "245", value)
+	assert.Equal(t, "-15.500000", operatedValue)
+
+	// negative raw value
+	_, _ = registers.write(0, 2, []byte{0xC2, 0xC8, 0x00, 0x00})
+	value, operatedValue, err = read16BitsRegister(prop, registers.read)
+	assert.NoError(t, err)
+	assert.Equal(t, "-100", value)
+	assert.Equal(t, "-50.000000", operatedValue)
+}
+
+func TestRead16BitsRegisterDecodesIntegers(t *testing.T) {
+	var operations = []v1alpha1.ModbusDeviceArithmeticOperation{
+		{Type: v1alpha1.ModbusDeviceArithmeticAdd, Value: "1"},
+	}
+
+	var testCases = []struct {
+		name                  string
+		typ                   v1alpha1.ModbusDevicePropertyType
+		given                 []byte
+		expectedValue         string
+		expectedOperatedValue string
+		// the operated value of the previous decoding, which reinterpreted the integer bits as float
+		legacyOperatedValue string
+	}{
+		{
+			name:                  "int16",
+			typ:                   v1alpha1.ModbusDevicePropertyTypeInt16,
+			given:                 []byte{0x00, 0x64},
+			expectedValue:         "100",
+			expectedOperatedValue: "101.000000",
+			legacyOperatedValue:   "1.000000",
+		},
+		{
+			name:                  "negative int16",
+			typ:                   v1alpha1.ModbusDevicePropertyTypeInt16,
+			given:                 []byte{0xFF, 0x9C},
+			expectedValue:         "-100",
+			expectedOperatedValue: "-99.000000",
+			legacyOperatedValue:   "1.000000",
+		},
+		{
+			name:                  "uint16",
+			typ:                   v1alpha1.ModbusDevicePropertyTypeUint16,
+			given:                 []byte{0xFF, 0x9C},
+			expectedValue:         "65436",
+			expectedOperatedValue: "65437.000000",
+			legacyOperatedValue:   "1.000000",
+		},
+		{
+			name:                  "int32",
+			typ:                   v1alpha1.ModbusDevicePropertyTypeInt32,
+			given:                 []byte{0x00, 0x00, 0x00, 0x64},
+			expectedValue:         "100",
+			expectedOperatedValue: "101.000000",
+			legacyOperatedValue:   "1.000000",
+		},
+		{
+			name:                  "negative int32",
+			typ:                   v1alpha1.ModbusDevicePropertyTypeInt32,
+			given:                 []byte{0xFF, 0xFF, 0xFF, 0x9C},
+			expectedValue:         "-100",
+			expectedOperatedValue: "-99.000000",
+			legacyOperatedValue:   "NaN",
+		},
+		{
+			name:                  "uint32",
+			typ:                   v1alpha1.ModbusDevicePropertyTypeUint32,
+			given:                 []byte{0x00, 0x00, 0x00, 0x64},
+			expectedValue:         "100",
+			expectedOperatedValue: "101.000000",
+			legacyOperatedValue:   "1.000000",
+		},
+		{
+			name:                  "int64",
+			typ:                   v1alpha1.ModbusDevicePropertyTypeInt64,
+			given:                 []byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x9C},
+			expectedValue:         "-100",
+			expectedOperatedValue: "-99.000000",
+			legacyOperatedValue:   "NaN",
+		},
+		{
+			name:                  "uint64",
+			typ:                   v1alpha1.ModbusDevicePropertyTypeUint64,
+			given:                 []byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x64},
+			expectedValue:         "100",
+			expectedOperatedValue: "101.000000",
+			legacyOperatedValue:   "1.000000",
+		},
+	}
+
+	for _, tc := range testCases {
+		var quantity = uint16(len(tc.given) / 2)
+		var registers = fakeRegisters{}
+		_, _ = registers.write(0, quantity, tc.given)
+		var prop = &v1alpha1.ModbusDeviceProperty{
+			Name: tc.name,
+			Type: tc.typ,
+			Visitor: v1alpha1.ModbusDevicePropertyVisitor{
+				Register:          v1alpha1.ModbusDeviceHoldingRegister,
+				Quantity:          quantity,
+				OrderOfOperations: operations,
+			},
+		}
+
+		var value, operatedValue, err = read16BitsRegister(prop, registers.read)
+		if !assert.NoError(t, err, "case %q", tc.name) {
+			continue
+		}
+		assert.Equal(t, tc.expectedValue, value, "case %q", tc.name)
+		assert.Equal(t, tc.expectedOperatedValue, operatedValue, "case %q", tc.name)
+
+		// pins the output of the previous decoding
+		var legacyRaw float64
+		switch quantity {
+		case 1:
+			legacyRaw = math.Float64frombits(uint64(prop.Visitor.Endianness.Uint16(tc.given)))
+		case 2:
+			legacyRaw = float64(math.Float32frombits(prop.Visitor.Endianness.Uint32(tc.given)))
+		case 4:
+			legacyRaw = math.Float64frombits(prop.Visitor.Endianness.Uint64(tc.given))
+		}
+		legacyOperatedValue, err := doArithmeticOperations(legacyRaw, operations)
+		assert.NoError(t, err, "case %q", tc.name)
+		assert.Equal(t, tc.legacyOperatedValue, legacyOperatedValue, "case %q", tc.name)
+	}
+}
@@ -0,0 +1,104 @@
+package physical
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/rancher/octopus/adaptors/modbus/api/v1alpha1"
+)
+
+// readBlock is a read request of the contiguous registers,
+// which covers the registers of one or more properties.
+type readBlock struct {
+	register v1alpha1.ModbusDeviceRegisterType
+	offset   uint16
+	quantity uint16
+	// properties are the indexes of the covered properties.
+	properties []int
+}
+
+func (b *readBlock) end() int {
+	return int(b.offset) + int(b.quantity)
+}
+
+// planReads groups the properties by register type and adjacent offsets into the block reads,
+// a property is merged into the previous block if the gap between them is not larger than the max gap
+// and the merged quantity is not larger than the max quantity of register type.
+func planReads(props []v1alpha1.ModbusDeviceProperty, params *v1alpha1.ModbusDeviceParameters) []readBlock {
+	var indexes = make([]int, len(props))
+	for i := range props {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		var pi, pj = props[indexes[i]].Visitor, props[indexes[j]].Visitor
+		if pi.Register != pj.Register {
+			return pi.Register < pj.Register
+		}
+		return pi.Offset < pj.Offset
+	})
+
+	var maxGap = int(params.GetMaxReadGap())
+	var blocks []readBlock
+	for _, idx := range indexes {
+		var visitor = props[idx].Visitor
+		var quantity = getQuantity(visitor)
+
+		if len(blocks) != 0 {
+			var last = &blocks[len(blocks)-1]
+			var end = int(visitor.Offset) + int(quantity)
+			if end < last.end() {
+				end = last.end()
+			}
+			if last.register == visitor.Register &&
+				int(visitor.Offset) <= last.end()+maxGap &&
+				end-int(last.offset) <= int(params.GetMaxReadQuantity(visitor.Register)) {
+				last.quantity = uint16(end - int(last.offset))
+				last.properties = append(last.properties, idx)
+				continue
+			}
+		}
+		blocks = append(blocks, readBlock{
+			register:   visitor.Register,
+			offset:     visitor.Offset,
+			quantity:   quantity,
+			properties: []int{idx},
+		})
+	}
+	return blocks
+}
+
+// sliceBlock returns the data of the given visitor from the data of block.
+func sliceBlock(block readBlock, data []byte, visitor v1alpha1.ModbusDevicePropertyVisitor) ([]byte, error) {
+	var start = int(visitor.Offset - block.offset)
+	var quantity = int(getQuantity(visitor))
+
+	switch block.register {
+	case v1alpha1.ModbusDeviceCoilRegister, v1alpha1.ModbusDeviceDiscreteInputRegister:
+		// the 1-bit registers are packed into bytes from the low bit,
+		// so we need to shift the bits to make the first register as the low bit of first byte.
+		if (start+quantity+7)/8 > len(data) {
+			return nil, errors.Errorf("failed to slice %d 1-bit quantities from %d of block, response bytes isn't in valid size", quantity, start)
+		}
+		var ret = make([]byte, (quantity+7)/8)
+		for i := 0; i < quantity; i++ {
+			var bit = start + i
+			if data[bit/8]&(1<<uint(bit%8)) != 0 {
+				ret[i/8] |= 1 << uint(i%8)
+			}
+		}
+		return ret, nil
+	default:
+		if (start+quantity)*2 > len(data) {
+			return nil, errors.Errorf("failed to slice %d 16-bits quantities from %d of block, response bytes isn't in valid size", quantity, start)
+		}
+		return data[start*2 : (start+quantity)*2], nil
+	}
+}
+
+func getQuantity(visitor v1alpha1.ModbusDevicePropertyVisitor) uint16 {
+	if visitor.Quantity == 0 {
+		return 1
+	}
+	return visitor.Quantity
+}
@@ -0,0 +1,123 @@
+package physical
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/rancher/octopus/adaptors/modbus/api/v1alpha1"
+)
+
+func newProperty(name string, register v1alpha1.ModbusDeviceRegisterType, offset, quantity uint16) v1alpha1.ModbusDeviceProperty {
+	return v1alpha1.ModbusDeviceProperty{
+		Name: name,
+		Visitor: v1alpha1.ModbusDevicePropertyVisitor{
+			Register: register,
+			Offset:   offset,
+			Quantity: quantity,
+		},
+	}
+}
+
+func TestPlanReads(t *testing.T) {
+	var props = []v1alpha1.ModbusDeviceProperty{
+		newProperty("h0", v1alpha1.ModbusDeviceHoldingRegister, 0, 2),
+		newProperty("c1", v1alpha1.ModbusDeviceCoilRegister, 1, 1),
+		newProperty("h2", v1alpha1.ModbusDeviceHoldingRegister, 2, 1),
+		newProperty("h5", v1alpha1.ModbusDeviceHoldingRegister, 5, 1),
+		newProperty("c0", v1alpha1.ModbusDeviceCoilRegister, 0, 1),
+		newProperty("i0", v1alpha1.ModbusDeviceInputRegister, 0, 1),
+	}
+
+	var testCases = []struct {
+		name     string
+		params   *v1alpha1.ModbusDeviceParameters
+		expected []readBlock
+	}{
+		{
+			name:   "adjacent only",
+			params: nil,
+			expected: []readBlock{
+				{register: v1alpha1.ModbusDeviceCoilRegister, offset: 0, quantity: 2, properties: []int{4, 1}},
+				{register: v1alpha1.ModbusDeviceHoldingRegister, offset: 0, quantity: 3, properties: []int{0, 2}},
+				{register: v1alpha1.ModbusDeviceHoldingRegister, offset: 5, quantity: 1, properties: []int{3}},
+				{register: v1alpha1.ModbusDeviceInputRegister, offset: 0, quantity: 1, properties: []int{5}},
+			},
+		},
+		{
+			name:   "with gap",
+			params: &v1alpha1.ModbusDeviceParameters{MaxReadGap: 2},
+			expected: []readBlock{
+				{register: v1alpha1.ModbusDeviceCoilRegister, offset: 0, quantity: 2, properties: []int{4, 1}},
+				{register: v1alpha1.ModbusDeviceHoldingRegister, offset: 0, quantity: 6, properties: []int{0, 2, 3}},
+				{register: v1alpha1.ModbusDeviceInputRegister, offset: 0, quantity: 1, properties: []int{5}},
+			},
+		},
+		{
+			name:   "limited quantity",
+			params: &v1alpha1.ModbusDeviceParameters{MaxReadGap: 2, MaxReadQuantity: 3},
+			expected: []readBlock{
+				{register: v1alpha1.ModbusDeviceCoilRegister, offset: 0, quantity: 2, properties: []int{4, 1}},
+				{register: v1alpha1.ModbusDeviceHoldingRegister, offset: 0, quantity: 3, properties: []int{0, 2}},
+				{register: v1alpha1.ModbusDeviceHoldingRegister, offset: 5, quantity: 1, properties: []int{3}},
+				{register: v1alpha1.ModbusDeviceInputRegister, offset: 0, quantity: 1, properties: []int{5}},
+			},
+		},
+		{
+			name:   "separately",
+			params: &v1alpha1.ModbusDeviceParameters{MaxReadQuantity: 1},
+			expected: []readBlock{
+				{register: v1alpha1.ModbusDeviceCoilRegister, offset: 0, quantity: 1, properties: []int{4}},
+				{register: v1alpha1.ModbusDeviceCoilRegister, offset: 1, quantity: 1, properties: []int{1}},
+				{register: v1alpha1.ModbusDeviceHoldingRegister, offset: 0, quantity: 2, properties: []int{0}},
+				{register: v1alpha1.ModbusDeviceHoldingRegister, offset: 2, quantity: 1, properties: []int{2}},
+				{register: v1alpha1.ModbusDeviceHoldingRegister, offset: 5, quantity: 1, properties: []int{3}},
+				{register: v1alpha1.ModbusDeviceInputRegister, offset: 0, quantity: 1, properties: []int{5}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		var ret = planReads(props, tc.params)
+		assert.Equal(t, tc.expected, ret, "case %q", tc.name)
+	}
+}
+
+func TestSliceBlock(t *testing.T) {
+	// 16-bits registers
+	var holdingBlock = readBlock{register: v1alpha1.ModbusDeviceHoldingRegister, offset: 10, quantity: 4}
+	var holdingData = []byte{0x00, 0x01, 0x00, 0x02, 0x00, 0x03, 0x00, 0x04}
+
+	var prop = newProperty("h12", v1alpha1.ModbusDeviceHoldingRegister, 12, 1)
+	prop.Type = v1alpha1.ModbusDevicePropertyTypeUint16
+	var data, err = sliceBlock(holdingBlock, holdingData, prop.Visitor)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x00, 0x03}, data)
+	value, _, err := read16BitsRegister(&prop, func(address, quantity uint16) ([]byte, error) { return data, nil })
+	assert.NoError(t, err)
+	assert.Equal(t, "3", value)
+
+	_, err = sliceBlock(holdingBlock, holdingData[:4], prop.Visitor)
+	assert.Error(t, err)
+
+	// 1-bit registers: 0b1011_0100, 0b0000_0001
+	var coilBlock = readBlock{register: v1alpha1.ModbusDeviceCoilRegister, offset: 0, quantity: 9}
+	var coilData = []byte{0xB4, 0x01}
+
+	var testCases = []struct {
+		offset   uint16
+		quantity uint16
+		expected []byte
+	}{
+		{offset: 0, quantity: 1, expected: []byte{0x00}},
+		{offset: 2, quantity: 1, expected: []byte{0x01}},
+		{offset: 2, quantity: 4, expected: []byte{0x0D}},
+		{offset: 4, quantity: 5, expected: []byte{0x1B}},
+	}
+	for i, tc := range testCases {
+		var visitor = v1alpha1.ModbusDevicePropertyVisitor{Register: v1alpha1.ModbusDeviceCoilRegister, Offset: tc.offset, Quantity: tc.quantity}
+		var ret, err = sliceBlock(coilBlock, coilData, visitor)
+		assert.NoError(t, err, "case %v", i+1)
+		assert.Equal(t, tc.expected, ret, "case %v", i+1)
+	}
+}
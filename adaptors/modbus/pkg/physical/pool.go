@@ -0,0 +1,164 @@
+package physical
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/goburrow/modbus"
+	"github.com/pkg/errors"
+)
+
+// transportPool keeps one transport per key in process,
+// the devices on the same endpoint(e.g. the slaves on the same RS-485 bus or behind the same TCP gateway) share the transport,
+// the key of network endpoint includes the timeout, so the devices with different timeouts open their own connections.
+type transportPool struct {
+	sync.Mutex
+	transports map[string]*sharedTransport
+}
+
+var defaultTransportPool = &transportPool{
+	transports: make(map[string]*sharedTransport),
+}
+
+// transportOpener opens the transport of endpoint, the settings are used to verify that the devices share the same transport settings.
+type transportOpener struct {
+	key      string
+	settings interface{}
+	open     func() (modbus.Transporter, error)
+}
+
+// acquire returns the shared transport of the given endpoint and increases the reference count,
+// the transport is opened if it's the first reference.
+func (p *transportPool) acquire(opener transportOpener) (*sharedTransport, error) {
+	p.Lock()
+	defer p.Unlock()
+
+	if t, exist := p.transports[opener.key]; exist {
+		if !reflect.DeepEqual(t.settings, opener.settings) {
+			return nil, errors.Errorf("endpoint %s has been opened with different settings", opener.key)
+		}
+		t.references++
+		return t, nil
+	}
+
+	var transporter, err = opener.open()
+	if err != nil {
+		return nil, err
+	}
+	var t = &sharedTransport{
+		key:         opener.key,
+		settings:    opener.settings,
+		transporter: transporter,
+		scheduler:   newFairScheduler(),
+		references:  1,
+	}
+	p.transports[opener.key] = t
+	return t, nil
+}
+
+// release decreases the reference count of the given transport,
+// the transport is closed if there is not any references.
+func (p *transportPool) release(t *sharedTransport) error {
+	p.Lock()
+	defer p.Unlock()
+
+	t.references--
+	if t.references > 0 {
+		return nil
+	}
+	delete(p.transports, t.key)
+	if closer, ok := t.transporter.(interface{ Close() error }); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// references returns the reference count of the given endpoint.
+func (p *transportPool) references(key string) int {
+	p.Lock()
+	defer p.Unlock()
+
+	if t, exist := p.transports[key]; exist {
+		return t.references
+	}
+	return 0
+}
+
+// sharedTransport is a reference counted transport,
+// the requests from different workers are serialized by a fair scheduler.
+type sharedTransport struct {
+	key         string
+	settings    interface{}
+	transporter modbus.Transporter
+	scheduler   *fairScheduler
+	references  int
+}
+
+// workerTransporter is the modbus.Transporter of a worker on the shared transport.
+type workerTransporter struct {
+	worker    byte
+	transport *sharedTransport
+}
+
+func (t *workerTransporter) Send(aduRequest []byte) ([]byte, error) {
+	t.transport.scheduler.acquire(t.worker)
+	defer t.transport.scheduler.release()
+
+	return t.transport.transporter.Send(aduRequest)
+}
+
+// fairScheduler serializes the requests on a transport,
+// the waiting workers take turns in round-robin, so a busy worker cannot starve the others.
+type fairScheduler struct {
+	sync.Mutex
+	busy    bool
+	waiters map[byte][]chan struct{}
+	// order is the round-robin queue of the workers which have waiters.
+	order []byte
+}
+
+func newFairScheduler() *fairScheduler {
+	return &fairScheduler{
+		waiters: make(map[byte][]chan struct{}),
+	}
+}
+
+// acquire blocks until the given worker takes the transport.
+func (s *fairScheduler) acquire(worker byte) {
+	s.Lock()
+	if !s.busy {
+		s.busy = true
+		s.Unlock()
+		return
+	}
+	var ch = make(chan struct{})
+	if len(s.waiters[worker]) == 0 {
+		s.order = append(s.order, worker)
+	}
+	s.waiters[worker] = append(s.waiters[worker], ch)
+	s.Unlock()
+
+	<-ch
+}
+
+// release hands the transport over to the first waiter of the next worker.
+func (s *fairScheduler) release() {
+	s.Lock()
+	defer s.Unlock()
+
+	if len(s.order) == 0 {
+		s.busy = false
+		return
+	}
+	var worker = s.order[0]
+	s.order = s.order[1:]
+	var waiters = s.waiters[worker]
+	var ch = waiters[0]
+	if len(waiters) > 1 {
+		s.waiters[worker] = waiters[1:]
+		s.order = append(s.order, worker)
+	} else {
+		delete(s.waiters, worker)
+	}
+	close(ch)
+}
@@ -0,0 +1,132 @@
+package physical
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/goburrow/modbus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/rancher/octopus/adaptors/modbus/api/v1alpha1"
+)
+
+type fakeTransporter struct {
+	closed int
+}
+
+func (t *fakeTransporter) Send(aduRequest []byte) ([]byte, error) {
+	return aduRequest, nil
+}
+
+func (t *fakeTransporter) Close() error {
+	t.closed++
+	return nil
+}
+
+func TestTransportPool(t *testing.T) {
+	var pool = &transportPool{transports: make(map[string]*sharedTransport)}
+	var fake = &fakeTransporter{}
+	var opened int
+	var opener = transportOpener{
+		key:      "rtu:///dev/ttyUSB0",
+		settings: 9600,
+		open: func() (modbus.Transporter, error) {
+			opened++
+			return fake, nil
+		},
+	}
+
+	var t1, err = pool.acquire(opener)
+	assert.NoError(t, err)
+	t2, err := pool.acquire(opener)
+	assert.NoError(t, err)
+	assert.True(t, t1 == t2, "the transport should be shared")
+	assert.Equal(t, 1, opened)
+	assert.Equal(t, 2, pool.references(opener.key))
+
+	// different settings on the same endpoint
+	_, err = pool.acquire(transportOpener{key: opener.key, settings: 19200, open: opener.open})
+	assert.Error(t, err)
+	assert.Equal(t, 2, pool.references(opener.key))
+
+	assert.NoError(t, pool.release(t1))
+	assert.Equal(t, 0, fake.closed, "the transport should not be closed as it's still referenced")
+	assert.NoError(t, pool.release(t2))
+	assert.Equal(t, 1, fake.closed)
+	assert.Equal(t, 0, pool.references(opener.key))
+}
+
+func TestFairScheduler(t *testing.T) {
+	var s = newFairScheduler()
+	s.acquire(0)
+
+	// worker 1 queues 3 requests before worker 2 queues 1 request
+	var (
+		mu     sync.Mutex
+		served []byte
+		wg     sync.WaitGroup
+	)
+	var enqueue = func(worker byte) {
+		s.Lock()
+		var expected = len(s.waiters[worker]) + 1
+		s.Unlock()
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.acquire(worker)
+			mu.Lock()
+			served = append(served, worker)
+			mu.Unlock()
+			s.release()
+		}()
+		// waits for the request to be queued
+		for {
+			s.Lock()
+			var queued = len(s.waiters[worker])
+			s.Unlock()
+			if queued == expected {
+				break
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+	enqueue(1)
+	enqueue(1)
+	enqueue(1)
+	enqueue(2)
+
+	s.release()
+	wg.Wait()
+	assert.Equal(t, []byte{1, 2, 1, 1}, served)
+	assert.False(t, s.busy)
+}
+
+func TestTransportPool_Timeout(t *testing.T) {
+	var pool = &transportPool{transports: make(map[string]*sharedTransport)}
+	var opened int
+	var open = func() (modbus.Transporter, error) {
+		opened++
+		return &fakeTransporter{}, nil
+	}
+
+	// the network endpoint opens a connection per timeout
+	var t1, err = pool.acquire(transportOpener{key: newNetTransportKey("tcp", "127.0.0.1:502", time.Second), settings: framingTCP, open: open})
+	assert.NoError(t, err)
+	t2, err := pool.acquire(transportOpener{key: newNetTransportKey("tcp", "127.0.0.1:502", 2*time.Second), settings: framingTCP, open: open})
+	assert.NoError(t, err)
+	assert.True(t, t1 != t2, "the transport should not be shared with different timeouts")
+	t3, err := pool.acquire(transportOpener{key: newNetTransportKey("tcp", "127.0.0.1:502", time.Second), settings: framingTCP, open: open})
+	assert.NoError(t, err)
+	assert.True(t, t1 == t3, "the transport should be shared with the same timeout")
+	assert.Equal(t, 2, opened)
+
+	// the serial bus cannot be opened with different timeouts
+	var config = &v1alpha1.ModbusDeviceProtocolRTU{Endpoint: "/dev/ttyUSB0", BaudRate: 9600, DataBits: 8, Parity: "E", StopBits: 1}
+	_, err = pool.acquire(transportOpener{key: "serial:///dev/ttyUSB0", settings: newSerialSettings(framingRTU, config, time.Second), open: open})
+	assert.NoError(t, err)
+	_, err = pool.acquire(transportOpener{key: "serial:///dev/ttyUSB0", settings: newSerialSettings(framingRTU, config, 2*time.Second), open: open})
+	assert.Error(t, err)
+	assert.Equal(t, 1, pool.references("serial:///dev/ttyUSB0"))
+}
@@ -0,0 +1,164 @@
+package physical
+
+import (
+	"io"
+	"log"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	rtuMinSize = 5
+	rtuMaxSize = 256
+)
+
+// rtuNetTransporter implements the modbus.Transporter interface,
+// which sends the RTU frames over TCP or UDP.
+type rtuNetTransporter struct {
+	mu sync.Mutex
+
+	network string
+	address string
+	timeout time.Duration
+	logger  *log.Logger
+
+	conn net.Conn
+}
+
+func newRTUNetTransporter(network, address string, timeout time.Duration, logger *log.Logger) *rtuNetTransporter {
+	return &rtuNetTransporter{
+		network: network,
+		address: address,
+		timeout: timeout,
+		logger:  logger,
+	}
+}
+
+func (t *rtuNetTransporter) Connect() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.connect()
+}
+
+func (t *rtuNetTransporter) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.close()
+}
+
+func (t *rtuNetTransporter) Send(aduRequest []byte) ([]byte, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if err := t.connect(); err != nil {
+		return nil, err
+	}
+	if err := t.conn.SetDeadline(time.Now().Add(t.timeout)); err != nil {
+		return nil, err
+	}
+
+	t.logf("modbus: sending % x", aduRequest)
+	if _, err := t.conn.Write(aduRequest); err != nil {
+		_ = t.close()
+		return nil, err
+	}
+
+	var aduResponse, err = t.receive()
+	if err != nil {
+		// the rest of a broken frame may be received by the next request,
+		// so we close the connection and reconnect at the next sending.
+		_ = t.close()
+		return nil, err
+	}
+	t.logf("modbus: received % x", aduResponse)
+	return aduResponse, nil
+}
+
+// receive receives a RTU frame, the datagram is a complete frame in UDP,
+// otherwise the length of frame is calculated by the header of frame.
+func (t *rtuNetTransporter) receive() ([]byte, error) {
+	var data [rtuMaxSize]byte
+
+	if t.network == "udp" {
+		var n, err = t.conn.Read(data[:])
+		if err != nil {
+			return nil, err
+		}
+		return data[:n], nil
+	}
+
+	if _, err := io.ReadFull(t.conn, data[:rtuMinSize]); err != nil {
+		return nil, err
+	}
+	var length, err = getRTUFrameLength(data[:rtuMinSize])
+	if err != nil {
+		return nil, err
+	}
+	if length > rtuMinSize {
+		if _, err := io.ReadFull(t.conn, data[rtuMinSize:length]); err != nil {
+			return nil, err
+		}
+	}
+	return data[:length], nil
+}
+
+func (t *rtuNetTransporter) connect() error {
+	if t.conn != nil {
+		return nil
+	}
+	var dialer = net.Dialer{Timeout: t.timeout}
+	var conn, err = dialer.Dial(t.network, t.address)
+	if err != nil {
+		return err
+	}
+	t.conn = conn
+	return nil
+}
+
+func (t *rtuNetTransporter) close() error {
+	if t.conn == nil {
+		return nil
+	}
+	var err = t.conn.Close()
+	t.conn = nil
+	return err
+}
+
+func (t *rtuNetTransporter) logf(format string, v ...interface{}) {
+	if t.logger != nil {
+		t.logger.Printf(format, v...)
+	}
+}
+
+// getRTUFrameLength returns the length of RTU response frame by the header.
+func getRTUFrameLength(header []byte) (int, error) {
+	var function = header[1]
+	// exception response: address(1) + function(1) + exception code(1) + CRC(2)
+	if function&0x80 != 0 {
+		return rtuMinSize, nil
+	}
+
+	var length int
+	switch function {
+	case 0x01, 0x02, 0x03, 0x04, 0x17:
+		// read response: address(1) + function(1) + byte count(1) + data(n) + CRC(2)
+		length = 3 + int(header[2]) + 2
+	case 0x05, 0x06, 0x0F, 0x10:
+		// write response: address(1) + function(1) + address(2) + value/quantity(2) + CRC(2)
+		length = 8
+	case 0x16:
+		// mask write response: address(1) + function(1) + address(2) + and mask(2) + or mask(2) + CRC(2)
+		length = 10
+	default:
+		return 0, errors.Errorf("unsupported function code %#x", function)
+	}
+	if length > rtuMaxSize {
+		return 0, errors.Errorf("invalid length %d of response frame", length)
+	}
+	return length, nil
+}
@@ -0,0 +1,87 @@
+package physical
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/goburrow/modbus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRTUFrameLength(t *testing.T) {
+	var testCases = []struct {
+		given       []byte
+		expected    int
+		expectedErr bool
+	}{
+		{given: []byte{0x01, 0x83, 0x02, 0x00, 0x00}, expected: 5},
+		{given: []byte{0x01, 0x03, 0x04, 0x00, 0x00}, expected: 9},
+		{given: []byte{0x01, 0x01, 0x01, 0x00, 0x00}, expected: 6},
+		{given: []byte{0x01, 0x10, 0x00, 0x00, 0x00}, expected: 8},
+		{given: []byte{0x01, 0x16, 0x00, 0x00, 0x00}, expected: 10},
+		{given: []byte{0x01, 0x2B, 0x00, 0x00, 0x00}, expectedErr: true},
+	}
+
+	for i, tc := range testCases {
+		var ret, err = getRTUFrameLength(tc.given)
+		if tc.expectedErr {
+			assert.Error(t, err, "case %v", i+1)
+			continue
+		}
+		assert.NoError(t, err, "case %v", i+1)
+		assert.Equal(t, tc.expected, ret, "case %v", i+1)
+	}
+}
+
+func TestRTUNetTransporter(t *testing.T) {
+	var lis, err = net.Listen("tcp", "127.0.0.1:0")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer lis.Close()
+
+	// responds the holding registers [0x0001, 0x0002] in two segments
+	go func() {
+		var conn, err = lis.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var req = make([]byte, 8)
+		if _, err := conn.Read(req); err != nil {
+			return
+		}
+		var resp = withCRC([]byte{req[0], 0x03, 0x04, 0x00, 0x01, 0x00, 0x02})
+		_, _ = conn.Write(resp[:3])
+		time.Sleep(10 * time.Millisecond)
+		_, _ = conn.Write(resp[3:])
+	}()
+
+	var transporter = newRTUNetTransporter("tcp", lis.Addr().String(), time.Second, nil)
+	defer transporter.Close()
+	var packager = modbus.NewRTUClientHandler("")
+	packager.SlaveId = 1
+
+	var client = modbus.NewClient2(packager, transporter)
+	data, err := client.ReadHoldingRegisters(0, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x00, 0x01, 0x00, 0x02}, data)
+}
+
+// withCRC appends the CRC-16/MODBUS of the given frame.
+func withCRC(frame []byte) []byte {
+	var crc uint16 = 0xFFFF
+	for _, b := range frame {
+		crc ^= uint16(b)
+		for i := 0; i < 8; i++ {
+			if crc&1 != 0 {
+				crc = (crc >> 1) ^ 0xA001
+			} else {
+				crc >>= 1
+			}
+		}
+	}
+	return append(frame, byte(crc), byte(crc>>8))
+}
@@ -131,10 +131,37 @@ type MQTTDeviceProperty struct {
 	ReadOnly *bool `json:"readOnly,omitempty"`
 }
 
+// MQTTDevicePropertyQuality defines the quality of the property value.
+// Good: the value is reliable.
+// Uncertain: the value is available, but it may be inaccurate or stale.
+// Bad: the value is unavailable, e.g. failed to read it from device.
+// +kubebuilder:validation:Enum=Good;Uncertain;Bad
+type MQTTDevicePropertyQuality string
+
+const (
+	MQTTDevicePropertyQualityGood      MQTTDevicePropertyQuality = "Good"
+	MQTTDevicePropertyQualityUncertain MQTTDevicePropertyQuality = "Uncertain"
+	MQTTDevicePropertyQualityBad       MQTTDevicePropertyQuality = "Bad"
+)
+
 // MQTTDeviceStatusProperty defines the observed property of MQTTDevice.
 type MQTTDeviceStatusProperty struct {
 	MQTTDeviceProperty `json:",inline"`
 
+	// Reports the quality of property value,
+	// the value is the last available one if the quality is "Bad".
+	// +optional
+	Quality MQTTDevicePropertyQuality `json:"quality,omitempty"`
+
+	// Reports the reason if the quality is not "Good".
+	// +optional
+	QualityReason string `json:"qualityReason,omitempty"`
+
+	// Reports the timestamp when the property value was sampled from the source,
+	// it's the received timestamp if the source doesn't provide one.
+	// +optional
+	SourceTimestamp *metav1.Time `json:"sourceTimestamp,omitempty"`
+
 	// Reports the updated timestamp of property.
 	// +optional
 	UpdatedAt *metav1.Time `json:"updateAt,omitempty"`
@@ -221,6 +221,10 @@ func (in *MQTTDeviceStatus) DeepCopy() *MQTTDeviceStatus {
 func (in *MQTTDeviceStatusProperty) DeepCopyInto(out *MQTTDeviceStatusProperty) {
 	*out = *in
 	in.MQTTDeviceProperty.DeepCopyInto(&out.MQTTDeviceProperty)
+	if in.SourceTimestamp != nil {
+		in, out := &in.SourceTimestamp, &out.SourceTimestamp
+		*out = (*in).DeepCopy()
+	}
 	if in.UpdatedAt != nil {
 		in, out := &in.UpdatedAt, &out.UpdatedAt
 		*out = (*in).DeepCopy()
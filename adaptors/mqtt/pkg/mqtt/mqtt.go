@@ -6,7 +6,7 @@
 
 	"github.com/rancher/octopus/adaptors/mqtt/pkg/adaptor"
 	"github.com/rancher/octopus/adaptors/mqtt/pkg/metadata"
-	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha1"
+	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha2"
 	"github.com/rancher/octopus/pkg/adaptor/connection"
 	"github.com/rancher/octopus/pkg/adaptor/log"
 	"github.com/rancher/octopus/pkg/adaptor/registration"
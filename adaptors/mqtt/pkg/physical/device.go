@@ -1,6 +1,7 @@
 package physical
 
 import (
+	"fmt"
 	"io"
 	"reflect"
 	"sync"
@@ -15,7 +16,7 @@
 
 	"github.com/rancher/octopus/adaptors/mqtt/api/v1alpha1"
 	"github.com/rancher/octopus/adaptors/mqtt/pkg/metadata"
-	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha1"
+	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha2"
 	"github.com/rancher/octopus/pkg/adaptor/socket/handler"
 	"github.com/rancher/octopus/pkg/mqtt"
 	"github.com/rancher/octopus/pkg/util/object"
@@ -151,6 +152,9 @@ func (d *mqttDevice) refresh(newSpec v1alpha1.MQTTDeviceSpec) error {
 			newStatusProp.Value = nil
 		} else {
 			newStatusProp.Value = staleStatusProp.Value
+			newStatusProp.Quality = staleStatusProp.Quality
+			newStatusProp.QualityReason = staleStatusProp.QualityReason
+			newStatusProp.SourceTimestamp = staleStatusProp.SourceTimestamp
 			newStatusProp.UpdatedAt = staleStatusProp.UpdatedAt
 		}
 		newStatusProps = append(newStatusProps, newStatusProp)
@@ -200,15 +204,26 @@ func (d *mqttDevice) refreshAsAttributedMessage(staleSpecPropsIndex map[string]v
 
 			var payload = msg.Payload
 			for idx, prop := range d.instance.Status.Properties {
+				prop.UpdatedAt = now()
+				var path = getPath(prop.Name, prop.Path)
+				var result = gjson.GetBytes(payload, path)
+				if !result.Exists() {
+					// the property keeps the last available value, and the absence is fed back via the quality.
+					prop.Quality = v1alpha1.MQTTDevicePropertyQualityBad
+					prop.QualityReason = fmt.Sprintf("path %s is not found in payload", path)
+					d.instance.Status.Properties[idx] = prop
+					continue
+				}
 				var propValue = &v1alpha1.MQTTDevicePropertyValue{}
-				var result = gjson.GetBytes(payload, getPath(prop.Name, prop.Path))
 				if result.Index > 0 {
 					propValue.Raw = payload[result.Index : result.Index+len(result.Raw)]
 				} else {
 					propValue.Raw = []byte(result.Raw)
 				}
 				prop.Value = propValue
-				prop.UpdatedAt = now()
+				prop.Quality = v1alpha1.MQTTDevicePropertyQualityGood
+				prop.QualityReason = ""
+				prop.SourceTimestamp = prop.UpdatedAt
 				d.instance.Status.Properties[idx] = prop
 			}
 			d.log.V(4).Info("Received payload", "type", "AttributedMessage")
@@ -287,7 +302,9 @@ func (d *mqttDevice) refreshAsAttributedTopic(staleSpecPropsIndex map[string]v1a
 
 			var prop = &d.instance.Status.Properties[msg.Index]
 			prop.Value = &v1alpha1.MQTTDevicePropertyValue{Raw: msg.Payload}
+			prop.Quality = v1alpha1.MQTTDevicePropertyQualityGood
 			prop.UpdatedAt = now()
+			prop.SourceTimestamp = prop.UpdatedAt
 			d.log.V(4).Info("Received payload", "type", "AttributedTopic", "property", prop.Name)
 			// TODO should we debounce here?
 			if err := d.sync(); err != nil {
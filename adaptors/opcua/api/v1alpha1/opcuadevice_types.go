@@ -20,6 +20,17 @@ type OPCUADeviceParameters struct {
 	// The default value is "10s".
 	// +kubebuilder:default="10s"
 	Timeout v1.Duration `json:"timeout,omitempty"`
+
+	// Specifies the initial backoff to reconnect the lost OPC-UA session,
+	// the backoff is doubled after each failed reconnection.
+	// The default value is "1s".
+	// +kubebuilder:default="1s"
+	ReconnectInitialBackoff v1.Duration `json:"reconnectInitialBackoff,omitempty"`
+
+	// Specifies the maximum backoff to reconnect the lost OPC-UA session.
+	// The default value is "1m".
+	// +kubebuilder:default="1m"
+	ReconnectMaxBackoff v1.Duration `json:"reconnectMaxBackoff,omitempty"`
 }
 
 func (in *OPCUADeviceParameters) GetSyncInterval() time.Duration {
@@ -40,14 +51,42 @@ func (in *OPCUADeviceParameters) GetTimeout() time.Duration {
 	return 10 * time.Second
 }
 
+func (in *OPCUADeviceParameters) GetReconnectInitialBackoff() time.Duration {
+	if in != nil {
+		if duration := in.ReconnectInitialBackoff.Duration; duration > 0 {
+			return duration
+		}
+	}
+	return time.Second
+}
+
+func (in *OPCUADeviceParameters) GetReconnectMaxBackoff() time.Duration {
+	if in != nil {
+		if duration := in.ReconnectMaxBackoff.Duration; duration > 0 {
+			return duration
+		}
+	}
+	return time.Minute
+}
+
 // OPCUADeviceProtocolSecurityPolicy defines the policy of OPCUADeviceProtocol security.
-// +kubebuilder:validation:Enum=None;Basic128Rsa15;Basic256;Basic256Sha256;Aes128Sha256RsaOaep;Aes256Sha256RsaPss
+// +kubebuilder:validation:Enum=Auto;None;Basic128Rsa15;Basic256;Basic256Sha256;Aes128Sha256RsaOaep;Aes256Sha256RsaPss
 type OPCUADeviceProtocolSecurityPolicy string
 
+const (
+	// Selects the most secure policy which the client supports from the endpoints of server.
+	OPCUADeviceProtocolSecurityPolicyAuto OPCUADeviceProtocolSecurityPolicy = "Auto"
+)
+
 // OPCUADeviceProtocolSecurityMode defines the model of OPCUADeviceProtocol security.
-// +kubebuilder:validation:Enum=None;Sign;SignAndEncrypt
+// +kubebuilder:validation:Enum=Auto;None;Sign;SignAndEncrypt
 type OPCUADeviceProtocolSecurityMode string
 
+const (
+	// Selects the most secure mode which the client supports from the endpoints of server.
+	OPCUADeviceProtocolSecurityModeAuto OPCUADeviceProtocolSecurityMode = "Auto"
+)
+
 // OPCUADeviceProtocolBasicAuth defines the basic authentication information.
 type OPCUADeviceProtocolBasicAuth struct {
 	// Specifies the username for accessing OPC-UA server.
@@ -88,6 +127,32 @@ type OPCUADeviceProtocolTLS struct {
 	// refer to the value as the client key file PEM content.
 	// +optional
 	KeyFilePEMRef *edgev1alpha1.DeviceLinkReferenceRelationship `json:"keyFilePEMRef,omitempty"`
+
+	// Specifies to generate a self-signed client certificate if the certificate and key are not specified,
+	// the generated certificate is persisted on the node and reused until expired.
+	// +optional
+	GenerateClientCert bool `json:"generateClientCert,omitempty"`
+
+	// Specifies the PEM format content of the CA certificates,
+	// which is used to verify the certificate of OPC-UA server.
+	// +optional
+	CAFilePEM string `json:"caFilePEM,omitempty"`
+
+	// Specifies the relationship of DeviceLink's references to
+	// refer to the value as the CA file PEM content.
+	// +optional
+	CAFilePEMRef *edgev1alpha1.DeviceLinkReferenceRelationship `json:"caFilePEMRef,omitempty"`
+
+	// Specifies the SHA-1 thumbprint of the certificate of OPC-UA server in hex, e.g. "9A:3C:...",
+	// which is used to pin the server certificate.
+	// +kubebuilder:validation:Pattern="^([0-9a-fA-F]{2}:?){19}[0-9a-fA-F]{2}$"
+	// +optional
+	ServerCertThumbprint string `json:"serverCertThumbprint,omitempty"`
+
+	// Specifies the relationship of DeviceLink's references to
+	// refer to the value as the server certificate thumbprint.
+	// +optional
+	ServerCertThumbprintRef *edgev1alpha1.DeviceLinkReferenceRelationship `json:"serverCertThumbprintRef,omitempty"`
 }
 
 // OPCUADeviceProtocol defines the desired protocol of OPCUADevice.
@@ -98,12 +163,14 @@ type OPCUADeviceProtocol struct {
 	// +kubebuilder:validation:Required
 	Endpoint string `json:"endpoint"`
 
-	// Specifies the security policy for accessing OPC-UA server.
+	// Specifies the security policy for accessing OPC-UA server,
+	// "Auto" selects the most secure policy which the client supports from the endpoints of server.
 	// The default value is "None".
 	// +kubebuilder:default="None"
 	SecurityPolicy OPCUADeviceProtocolSecurityPolicy `json:"securityPolicy,omitempty"`
 
-	// Specifies the security mode for accessing OPC-UA server.
+	// Specifies the security mode for accessing OPC-UA server,
+	// "Auto" selects the most secure mode which the client supports from the endpoints of server.
 	// The default value is "None".
 	// +kubebuilder:default="None"
 	SecurityMode OPCUADeviceProtocolSecurityMode `json:"securityMode,omitempty"`
@@ -131,6 +198,10 @@ type OPCUADeviceProperty struct {
 	// +kubebuilder:validation:Required
 	Type OPCUADevicePropertyType `json:"type"`
 
+	// Specifies the type of array element, only available if the type is "array".
+	// +optional
+	ElementType OPCUADevicePropertyType `json:"elementType,omitempty"`
+
 	// Specifies the visitor of property.
 	// +kubebuilder:validation:Required
 	Visitor OPCUADevicePropertyVisitor `json:"visitor"`
@@ -143,10 +214,25 @@ type OPCUADeviceProperty struct {
 	// Specifies the value of property, only available in the writable property.
 	// +optional
 	Value string `json:"value,omitempty"`
+
+	// Specifies the publishing interval of the subscription which monitors the property,
+	// the properties in the same interval are grouped into one subscription,
+	// the default value is the "syncInterval" of parameters.
+	// +optional
+	SyncInterval *v1.Duration `json:"syncInterval,omitempty"`
+}
+
+func (in *OPCUADeviceProperty) GetSyncInterval(params *OPCUADeviceParameters) time.Duration {
+	if in != nil && in.SyncInterval != nil {
+		if duration := in.SyncInterval.Duration; duration > 0 {
+			return duration
+		}
+	}
+	return params.GetSyncInterval()
 }
 
 // OPCUADevicePropertyType defines the type of property.
-// +kubebuilder:validation:Enum=float;double;int64;int32;int16;uint64;uint32;uint16;string;boolean;byteString;datetime
+// +kubebuilder:validation:Enum=float;double;int64;int32;int16;uint64;uint32;uint16;string;boolean;byteString;datetime;localizedText;guid;nodeId;extensionObject;array
 type OPCUADevicePropertyType string
 
 const (
@@ -162,6 +248,16 @@ type OPCUADeviceProperty struct {
 	OPCUADevicePropertyTypeByteString OPCUADevicePropertyType = "byteString"
 	OPCUADevicePropertyTypeBoolean    OPCUADevicePropertyType = "boolean"
 	OPCUADevicePropertyTypeDatetime   OPCUADevicePropertyType = "datetime"
+	// The text of localized text, the locale is not reported.
+	OPCUADevicePropertyTypeLocalizedText OPCUADevicePropertyType = "localizedText"
+	// The GUID string, e.g. "72962B91-FA75-4AE6-8D28-B404DC7DAF63".
+	OPCUADevicePropertyTypeGUID OPCUADevicePropertyType = "guid"
+	// The node ID string, e.g. "ns=1;s=Boiler".
+	OPCUADevicePropertyTypeNodeID OPCUADevicePropertyType = "nodeId"
+	// The JSON object of structured value, which is decoded via the data type dictionary of OPC-UA server.
+	OPCUADevicePropertyTypeExtensionObject OPCUADevicePropertyType = "extensionObject"
+	// The JSON array of elements, the type of element is specified by "elementType".
+	OPCUADevicePropertyTypeArray OPCUADevicePropertyType = "array"
 )
 
 // OPCUADevicePropertyVisitor defines the visitor of property.
@@ -173,8 +269,76 @@ type OPCUADevicePropertyVisitor struct {
 	// Specifies the name of OPC-UA node.
 	// +optional
 	BrowseName string `json:"browseName,omitempty"`
+
+	// Specifies the expression to transform the numeric value read from the OPC-UA node,
+	// the read value is referred as "x", e.g. "x * 0.1 - 40".
+	// +optional
+	ReadExpression string `json:"readExpression,omitempty"`
+
+	// Specifies the expression to transform the numeric value of property before writing to the OPC-UA node,
+	// the value of property is referred as "x", e.g. "(x + 40) * 10".
+	// +optional
+	WriteExpression string `json:"writeExpression,omitempty"`
+
+	// Specifies the interval that the OPC-UA server samples the node.
+	// The default value is "0s", which means the fastest practical rate of server.
+	// +optional
+	SamplingInterval *v1.Duration `json:"samplingInterval,omitempty"`
+
+	// Specifies the size of queue that the OPC-UA server keeps the sampled values between publishing.
+	// The default value is "10".
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	QueueSize *uint32 `json:"queueSize,omitempty"`
+
+	// Specifies if the oldest sampled value is discarded when the queue is full,
+	// otherwise, the newest one is discarded.
+	// The default value is "true".
+	// +optional
+	DiscardOldest *bool `json:"discardOldest,omitempty"`
+
+	// Specifies the data change deadband of node, the OPC-UA server only notifies the value
+	// changed more than the deadband since the last notification,
+	// e.g. "0.5" or "5%" of the engineering unit range of node.
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?%?$`
+	// +optional
+	Deadband string `json:"deadband,omitempty"`
+}
+
+func (in *OPCUADevicePropertyVisitor) GetSamplingInterval() time.Duration {
+	if in != nil && in.SamplingInterval != nil && in.SamplingInterval.Duration > 0 {
+		return in.SamplingInterval.Duration
+	}
+	return 0
+}
+
+func (in *OPCUADevicePropertyVisitor) GetQueueSize() uint32 {
+	if in != nil && in.QueueSize != nil && *in.QueueSize > 0 {
+		return *in.QueueSize
+	}
+	return 10
 }
 
+func (in *OPCUADevicePropertyVisitor) GetDiscardOldest() bool {
+	if in != nil && in.DiscardOldest != nil {
+		return *in.DiscardOldest
+	}
+	return true
+}
+
+// OPCUADevicePropertyQuality defines the quality of the property value.
+// Good: the value is reliable.
+// Uncertain: the value is available, but it may be inaccurate or stale.
+// Bad: the value is unavailable, e.g. failed to read it from device.
+// +kubebuilder:validation:Enum=Good;Uncertain;Bad
+type OPCUADevicePropertyQuality string
+
+const (
+	OPCUADevicePropertyQualityGood      OPCUADevicePropertyQuality = "Good"
+	OPCUADevicePropertyQualityUncertain OPCUADevicePropertyQuality = "Uncertain"
+	OPCUADevicePropertyQualityBad       OPCUADevicePropertyQuality = "Bad"
+)
+
 // OPCUADeviceStatusProperty defines the observed property of OPCUADevice.
 type OPCUADeviceStatusProperty struct {
 	// Reports the name of property.
@@ -185,15 +349,358 @@ type OPCUADeviceStatusProperty struct {
 	// +optional
 	Type OPCUADevicePropertyType `json:"type,omitempty"`
 
+	// Reports the type of array element if the type is "array".
+	// +optional
+	ElementType OPCUADevicePropertyType `json:"elementType,omitempty"`
+
 	// Reports the value of property.
 	// +optional
 	Value string `json:"value,omitempty"`
 
+	// Reports the quality of property value,
+	// the value is the last available one if the quality is "Bad".
+	// +optional
+	Quality OPCUADevicePropertyQuality `json:"quality,omitempty"`
+
+	// Reports the reason if the quality is not "Good".
+	// +optional
+	QualityReason string `json:"qualityReason,omitempty"`
+
+	// Reports the timestamp when the property value was sampled from the source,
+	// it's the received timestamp if the source doesn't provide one.
+	// +optional
+	SourceTimestamp *metav1.Time `json:"sourceTimestamp,omitempty"`
+
 	// Reports the updated timestamp of property.
 	// +optional
 	UpdatedAt *metav1.Time `json:"updatedAt,omitempty"`
 }
 
+// OPCUADeviceNodeClass defines the class of OPC-UA node.
+// +kubebuilder:validation:Enum=Object;Variable;Method
+type OPCUADeviceNodeClass string
+
+const (
+	OPCUADeviceNodeClassObject   OPCUADeviceNodeClass = "Object"
+	OPCUADeviceNodeClassVariable OPCUADeviceNodeClass = "Variable"
+	OPCUADeviceNodeClassMethod   OPCUADeviceNodeClass = "Method"
+)
+
+// OPCUADeviceDiscovery defines the discovery of OPCUADevice,
+// which browses the address space of OPC-UA server from the root node.
+type OPCUADeviceDiscovery struct {
+	// Specifies the id of root node to browse from, e.g. "ns=1;s=Device".
+	// The default value is "i=85", which is the "Objects" folder.
+	// +optional
+	RootNodeID string `json:"rootNodeID,omitempty"`
+
+	// Specifies the maximum depth to browse, the children of root node are in depth 1.
+	// The default value is "3".
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=16
+	// +optional
+	MaxDepth int32 `json:"maxDepth,omitempty"`
+
+	// Specifies the maximum number of the discovered nodes to report.
+	// The default value is "1000".
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=10000
+	// +optional
+	MaxNodes int32 `json:"maxNodes,omitempty"`
+
+	// Specifies the regular expression to filter the discovered nodes by browse name,
+	// it doesn't stop browsing the children of the unmatched nodes.
+	// +optional
+	BrowseNamePattern string `json:"browseNamePattern,omitempty"`
+
+	// Specifies the classes of the discovered nodes to report.
+	// The default value is ["Variable"].
+	// +listType=set
+	// +optional
+	NodeClasses []OPCUADeviceNodeClass `json:"nodeClasses,omitempty"`
+}
+
+func (in *OPCUADeviceDiscovery) GetRootNodeID() string {
+	if in != nil && in.RootNodeID != "" {
+		return in.RootNodeID
+	}
+	return "i=85"
+}
+
+func (in *OPCUADeviceDiscovery) GetMaxDepth() int {
+	if in != nil && in.MaxDepth > 0 {
+		return int(in.MaxDepth)
+	}
+	return 3
+}
+
+func (in *OPCUADeviceDiscovery) GetMaxNodes() int {
+	if in != nil && in.MaxNodes > 0 {
+		return int(in.MaxNodes)
+	}
+	return 1000
+}
+
+func (in *OPCUADeviceDiscovery) GetNodeClasses() []OPCUADeviceNodeClass {
+	if in != nil && len(in.NodeClasses) != 0 {
+		return in.NodeClasses
+	}
+	return []OPCUADeviceNodeClass{OPCUADeviceNodeClassVariable}
+}
+
+// OPCUADeviceDiscoveredNode defines the discovered node of OPCUADevice.
+type OPCUADeviceDiscoveredNode struct {
+	// Reports the id of node, which can be used as the "nodeID" of property visitor.
+	// +optional
+	NodeID string `json:"nodeID,omitempty"`
+
+	// Reports the browse name of node.
+	// +optional
+	BrowseName string `json:"browseName,omitempty"`
+
+	// Reports the class of node.
+	// +optional
+	NodeClass OPCUADeviceNodeClass `json:"nodeClass,omitempty"`
+
+	// Reports the id of data type if the node is a variable.
+	// +optional
+	DataType string `json:"dataType,omitempty"`
+
+	// Reports the corresponding property type of the data type if any.
+	// +optional
+	Type OPCUADevicePropertyType `json:"type,omitempty"`
+
+	// Reports the access level of node if the node is a variable,
+	// e.g. ["CurrentRead", "CurrentWrite"].
+	// +listType=atomic
+	// +optional
+	AccessLevel []string `json:"accessLevel,omitempty"`
+}
+
+// OPCUADeviceArgument defines the argument of OPC-UA method.
+type OPCUADeviceArgument struct {
+	// Specifies the type of argument.
+	// +kubebuilder:validation:Required
+	Type OPCUADevicePropertyType `json:"type"`
+
+	// Specifies the value of argument.
+	// +optional
+	Value string `json:"value,omitempty"`
+}
+
+// OPCUADeviceMethod defines the desired method of OPCUADevice,
+// the method is only called by the DeviceCommand named after it,
+// whose optional "inputArguments" arguments override the declared input arguments.
+type OPCUADeviceMethod struct {
+	// Specifies the name of method.
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Specifies the description of method.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Specifies the id of OPC-UA object node which owns the method, e.g. "ns=1;s=Device".
+	// +kubebuilder:validation:Required
+	ObjectNodeID string `json:"objectNodeID"`
+
+	// Specifies the id of OPC-UA method node, e.g. "ns=1;s=Device.Reset".
+	// +kubebuilder:validation:Required
+	MethodNodeID string `json:"methodNodeID"`
+
+	// Specifies the default input arguments of method in order.
+	// +listType=atomic
+	// +optional
+	InputArguments []OPCUADeviceArgument `json:"inputArguments,omitempty"`
+}
+
+// OPCUADeviceEventSelectClause defines the field to select from the OPC-UA event.
+type OPCUADeviceEventSelectClause struct {
+	// Specifies the name of field to report.
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Specifies the browse path of field relative to the event type,
+	// the browse names are separated by "/" and can be qualified by namespace index,
+	// e.g. "Message", "1:Sensor/1:Value".
+	// +kubebuilder:validation:Required
+	BrowsePath string `json:"browsePath"`
+
+	// Specifies the id of event type which defines the field.
+	// The default value is "i=2041", which is the "BaseEventType".
+	// +optional
+	TypeDefinitionID string `json:"typeDefinitionID,omitempty"`
+}
+
+// OPCUADeviceEventFilterOperator defines the operator of OPC-UA event where clause.
+// +kubebuilder:validation:Enum=Equals;GreaterThan;GreaterThanOrEqual;LessThan;LessThanOrEqual;Like;OfType
+type OPCUADeviceEventFilterOperator string
+
+const (
+	OPCUADeviceEventFilterOperatorEquals             OPCUADeviceEventFilterOperator = "Equals"
+	OPCUADeviceEventFilterOperatorGreaterThan        OPCUADeviceEventFilterOperator = "GreaterThan"
+	OPCUADeviceEventFilterOperatorGreaterThanOrEqual OPCUADeviceEventFilterOperator = "GreaterThanOrEqual"
+	OPCUADeviceEventFilterOperatorLessThan           OPCUADeviceEventFilterOperator = "LessThan"
+	OPCUADeviceEventFilterOperatorLessThanOrEqual    OPCUADeviceEventFilterOperator = "LessThanOrEqual"
+	OPCUADeviceEventFilterOperatorLike               OPCUADeviceEventFilterOperator = "Like"
+	OPCUADeviceEventFilterOperatorOfType             OPCUADeviceEventFilterOperator = "OfType"
+)
+
+// OPCUADeviceEventWhereClause defines the condition to filter the OPC-UA event.
+type OPCUADeviceEventWhereClause struct {
+	// Specifies the browse path of field to compare, it is ignored by the "OfType" operator,
+	// the format is the same as the browse path of select clause.
+	// +optional
+	BrowsePath string `json:"browsePath,omitempty"`
+
+	// Specifies the id of event type which defines the field.
+	// The default value is "i=2041", which is the "BaseEventType".
+	// +optional
+	TypeDefinitionID string `json:"typeDefinitionID,omitempty"`
+
+	// Specifies the operator of condition.
+	// +kubebuilder:validation:Required
+	Operator OPCUADeviceEventFilterOperator `json:"operator"`
+
+	// Specifies the type of value, it is ignored by the "OfType" operator.
+	// The default value is "string".
+	// +optional
+	Type OPCUADevicePropertyType `json:"type,omitempty"`
+
+	// Specifies the value to compare with,
+	// it is the id of event type if the operator is "OfType", e.g. "i=2041".
+	// +kubebuilder:validation:Required
+	Value string `json:"value"`
+}
+
+// OPCUADeviceEvent defines the desired event of OPCUADevice.
+type OPCUADeviceEvent struct {
+	// Specifies the name of event.
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Specifies the description of event.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Specifies the id of OPC-UA node which notifies the event.
+	// The default value is "i=2253", which is the "Server" object.
+	// +optional
+	NodeID string `json:"nodeID,omitempty"`
+
+	// Specifies the fields to select from the event.
+	// +kubebuilder:validation:MinItems=1
+	// +listType=map
+	// +listMapKey=name
+	SelectClauses []OPCUADeviceEventSelectClause `json:"selectClauses"`
+
+	// Specifies the conditions to filter the event, all conditions must be satisfied.
+	// +listType=atomic
+	// +optional
+	WhereClause []OPCUADeviceEventWhereClause `json:"whereClause,omitempty"`
+}
+
+func (in *OPCUADeviceEvent) GetNodeID() string {
+	if in != nil && in.NodeID != "" {
+		return in.NodeID
+	}
+	return "i=2253"
+}
+
+// OPCUADeviceStatusMethod defines the observed method of OPCUADevice.
+type OPCUADeviceStatusMethod struct {
+	// Reports the name of method.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Reports the output arguments of the last call.
+	// +listType=atomic
+	// +optional
+	OutputArguments []OPCUADeviceArgument `json:"outputArguments,omitempty"`
+
+	// Reports the error of the last call if any.
+	// +optional
+	Error string `json:"error,omitempty"`
+
+	// Reports the timestamp of the last call.
+	// +optional
+	CalledAt *metav1.Time `json:"calledAt,omitempty"`
+}
+
+// OPCUADeviceStatusEvent defines the observed event of OPCUADevice.
+type OPCUADeviceStatusEvent struct {
+	// Reports the name of event.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Reports the selected fields of the last received event.
+	// +optional
+	Fields map[string]string `json:"fields,omitempty"`
+
+	// Reports the timestamp of the last received event.
+	// +optional
+	ReceivedAt *metav1.Time `json:"receivedAt,omitempty"`
+}
+
+// OPCUADeviceSessionState defines the state of OPC-UA session.
+// +kubebuilder:validation:Enum=Connected;Reconnecting
+type OPCUADeviceSessionState string
+
+const (
+	// The session is connected, and the subscriptions are publishing.
+	OPCUADeviceSessionStateConnected OPCUADeviceSessionState = "Connected"
+	// The session is lost, and the adaptor is reconnecting with backoff.
+	OPCUADeviceSessionStateReconnecting OPCUADeviceSessionState = "Reconnecting"
+)
+
+// OPCUADeviceSessionRecovery defines how the subscriptions are recovered after reconnecting.
+// +kubebuilder:validation:Enum=Reactivated;Transferred;Recreated
+type OPCUADeviceSessionRecovery string
+
+const (
+	// The previous session is activated on the new secure channel, the subscriptions are kept.
+	OPCUADeviceSessionRecoveryReactivated OPCUADeviceSessionRecovery = "Reactivated"
+	// The subscriptions are transferred from the previous session to a new session.
+	OPCUADeviceSessionRecoveryTransferred OPCUADeviceSessionRecovery = "Transferred"
+	// The subscriptions are lost, they are re-created in a new session.
+	OPCUADeviceSessionRecoveryRecreated OPCUADeviceSessionRecovery = "Recreated"
+)
+
+// OPCUADeviceStatusSession defines the observed session of OPCUADevice.
+type OPCUADeviceStatusSession struct {
+	// Reports the state of session.
+	// +optional
+	State OPCUADeviceSessionState `json:"state,omitempty"`
+
+	// Reports the reason of losing session or the error of the last reconnection.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Reports the attempts of reconnection since the session was lost.
+	// +optional
+	ReconnectAttempts int32 `json:"reconnectAttempts,omitempty"`
+
+	// Reports the timestamp of the next reconnection.
+	// +optional
+	NextReconnectAt *metav1.Time `json:"nextReconnectAt,omitempty"`
+
+	// Reports how the subscriptions were recovered in the last reconnection.
+	// +optional
+	Recovery OPCUADeviceSessionRecovery `json:"recovery,omitempty"`
+
+	// Reports the amount of missed notification messages which were republished in the last reconnection.
+	// +optional
+	RepublishedMessages int32 `json:"republishedMessages,omitempty"`
+
+	// Reports the timestamp of the last lost.
+	// +optional
+	LostAt *metav1.Time `json:"lostAt,omitempty"`
+
+	// Reports the timestamp of the last connection.
+	// +optional
+	ConnectedAt *metav1.Time `json:"connectedAt,omitempty"`
+}
+
 // OPCUADeviceSpec defines the desired state of OPCUADevice.
 type OPCUADeviceSpec struct {
 	// Specifies the extension of device.
@@ -213,13 +720,45 @@ type OPCUADeviceSpec struct {
 	// +listMapKey=name
 	// +optional
 	Properties []OPCUADeviceProperty `json:"properties,omitempty"`
+
+	// Specifies the discovery of device, the discovered nodes are reported in status.
+	// +optional
+	Discovery *OPCUADeviceDiscovery `json:"discovery,omitempty"`
+
+	// Specifies the methods of device.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Methods []OPCUADeviceMethod `json:"methods,omitempty"`
+
+	// Specifies the events of device.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Events []OPCUADeviceEvent `json:"events,omitempty"`
 }
 
 // OPCUADeviceStatus defines the observed state of OPCUADevice.
 type OPCUADeviceStatus struct {
+	// Reports the session of device.
+	// +optional
+	Session *OPCUADeviceStatusSession `json:"session,omitempty"`
+
 	// Reports the properties of device.
 	// +optional
 	Properties []OPCUADeviceStatusProperty `json:"properties,omitempty"`
+
+	// Reports the discovered nodes of device.
+	// +optional
+	DiscoveredNodes []OPCUADeviceDiscoveredNode `json:"discoveredNodes,omitempty"`
+
+	// Reports the methods of device.
+	// +optional
+	Methods []OPCUADeviceStatusMethod `json:"methods,omitempty"`
+
+	// Reports the events of device.
+	// +optional
+	Events []OPCUADeviceStatusEvent `json:"events,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -22,6 +22,7 @@
 import (
 	apiv1alpha1 "github.com/rancher/octopus/api/v1alpha1"
 	"github.com/rancher/octopus/pkg/mqtt/api"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -52,6 +53,116 @@ func (in *OPCUADevice) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OPCUADeviceArgument) DeepCopyInto(out *OPCUADeviceArgument) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OPCUADeviceArgument.
+func (in *OPCUADeviceArgument) DeepCopy() *OPCUADeviceArgument {
+	if in == nil {
+		return nil
+	}
+	out := new(OPCUADeviceArgument)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OPCUADeviceDiscoveredNode) DeepCopyInto(out *OPCUADeviceDiscoveredNode) {
+	*out = *in
+	if in.AccessLevel != nil {
+		in, out := &in.AccessLevel, &out.AccessLevel
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OPCUADeviceDiscoveredNode.
+func (in *OPCUADeviceDiscoveredNode) DeepCopy() *OPCUADeviceDiscoveredNode {
+	if in == nil {
+		return nil
+	}
+	out := new(OPCUADeviceDiscoveredNode)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OPCUADeviceDiscovery) DeepCopyInto(out *OPCUADeviceDiscovery) {
+	*out = *in
+	if in.NodeClasses != nil {
+		in, out := &in.NodeClasses, &out.NodeClasses
+		*out = make([]OPCUADeviceNodeClass, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OPCUADeviceDiscovery.
+func (in *OPCUADeviceDiscovery) DeepCopy() *OPCUADeviceDiscovery {
+	if in == nil {
+		return nil
+	}
+	out := new(OPCUADeviceDiscovery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OPCUADeviceEvent) DeepCopyInto(out *OPCUADeviceEvent) {
+	*out = *in
+	if in.SelectClauses != nil {
+		in, out := &in.SelectClauses, &out.SelectClauses
+		*out = make([]OPCUADeviceEventSelectClause, len(*in))
+		copy(*out, *in)
+	}
+	if in.WhereClause != nil {
+		in, out := &in.WhereClause, &out.WhereClause
+		*out = make([]OPCUADeviceEventWhereClause, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OPCUADeviceEvent.
+func (in *OPCUADeviceEvent) DeepCopy() *OPCUADeviceEvent {
+	if in == nil {
+		return nil
+	}
+	out := new(OPCUADeviceEvent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OPCUADeviceEventSelectClause) DeepCopyInto(out *OPCUADeviceEventSelectClause) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OPCUADeviceEventSelectClause.
+func (in *OPCUADeviceEventSelectClause) DeepCopy() *OPCUADeviceEventSelectClause {
+	if in == nil {
+		return nil
+	}
+	out := new(OPCUADeviceEventSelectClause)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OPCUADeviceEventWhereClause) DeepCopyInto(out *OPCUADeviceEventWhereClause) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OPCUADeviceEventWhereClause.
+func (in *OPCUADeviceEventWhereClause) DeepCopy() *OPCUADeviceEventWhereClause {
+	if in == nil {
+		return nil
+	}
+	out := new(OPCUADeviceEventWhereClause)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OPCUADeviceExtension) DeepCopyInto(out *OPCUADeviceExtension) {
 	*out = *in
@@ -104,11 +215,33 @@ func (in *OPCUADeviceList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OPCUADeviceMethod) DeepCopyInto(out *OPCUADeviceMethod) {
+	*out = *in
+	if in.InputArguments != nil {
+		in, out := &in.InputArguments, &out.InputArguments
+		*out = make([]OPCUADeviceArgument, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OPCUADeviceMethod.
+func (in *OPCUADeviceMethod) DeepCopy() *OPCUADeviceMethod {
+	if in == nil {
+		return nil
+	}
+	out := new(OPCUADeviceMethod)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OPCUADeviceParameters) DeepCopyInto(out *OPCUADeviceParameters) {
 	*out = *in
 	out.SyncInterval = in.SyncInterval
 	out.Timeout = in.Timeout
+	out.ReconnectInitialBackoff = in.ReconnectInitialBackoff
+	out.ReconnectMaxBackoff = in.ReconnectMaxBackoff
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OPCUADeviceParameters.
@@ -124,7 +257,12 @@ func (in *OPCUADeviceParameters) DeepCopy() *OPCUADeviceParameters {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OPCUADeviceProperty) DeepCopyInto(out *OPCUADeviceProperty) {
 	*out = *in
-	out.Visitor = in.Visitor
+	in.Visitor.DeepCopyInto(&out.Visitor)
+	if in.SyncInterval != nil {
+		in, out := &in.SyncInterval, &out.SyncInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OPCUADeviceProperty.
@@ -140,6 +278,21 @@ func (in *OPCUADeviceProperty) DeepCopy() *OPCUADeviceProperty {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OPCUADevicePropertyVisitor) DeepCopyInto(out *OPCUADevicePropertyVisitor) {
 	*out = *in
+	if in.SamplingInterval != nil {
+		in, out := &in.SamplingInterval, &out.SamplingInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.QueueSize != nil {
+		in, out := &in.QueueSize, &out.QueueSize
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.DiscardOldest != nil {
+		in, out := &in.DiscardOldest, &out.DiscardOldest
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OPCUADevicePropertyVisitor.
@@ -215,6 +368,16 @@ func (in *OPCUADeviceProtocolTLS) DeepCopyInto(out *OPCUADeviceProtocolTLS) {
 		*out = new(apiv1alpha1.DeviceLinkReferenceRelationship)
 		**out = **in
 	}
+	if in.CAFilePEMRef != nil {
+		in, out := &in.CAFilePEMRef, &out.CAFilePEMRef
+		*out = new(apiv1alpha1.DeviceLinkReferenceRelationship)
+		**out = **in
+	}
+	if in.ServerCertThumbprintRef != nil {
+		in, out := &in.ServerCertThumbprintRef, &out.ServerCertThumbprintRef
+		*out = new(apiv1alpha1.DeviceLinkReferenceRelationship)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OPCUADeviceProtocolTLS.
@@ -244,7 +407,28 @@ func (in *OPCUADeviceSpec) DeepCopyInto(out *OPCUADeviceSpec) {
 	if in.Properties != nil {
 		in, out := &in.Properties, &out.Properties
 		*out = make([]OPCUADeviceProperty, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Discovery != nil {
+		in, out := &in.Discovery, &out.Discovery
+		*out = new(OPCUADeviceDiscovery)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Methods != nil {
+		in, out := &in.Methods, &out.Methods
+		*out = make([]OPCUADeviceMethod, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]OPCUADeviceEvent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -261,6 +445,11 @@ func (in *OPCUADeviceSpec) DeepCopy() *OPCUADeviceSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OPCUADeviceStatus) DeepCopyInto(out *OPCUADeviceStatus) {
 	*out = *in
+	if in.Session != nil {
+		in, out := &in.Session, &out.Session
+		*out = new(OPCUADeviceStatusSession)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Properties != nil {
 		in, out := &in.Properties, &out.Properties
 		*out = make([]OPCUADeviceStatusProperty, len(*in))
@@ -268,6 +457,27 @@ func (in *OPCUADeviceStatus) DeepCopyInto(out *OPCUADeviceStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DiscoveredNodes != nil {
+		in, out := &in.DiscoveredNodes, &out.DiscoveredNodes
+		*out = make([]OPCUADeviceDiscoveredNode, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Methods != nil {
+		in, out := &in.Methods, &out.Methods
+		*out = make([]OPCUADeviceStatusMethod, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]OPCUADeviceStatusEvent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OPCUADeviceStatus.
@@ -280,9 +490,63 @@ func (in *OPCUADeviceStatus) DeepCopy() *OPCUADeviceStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OPCUADeviceStatusEvent) DeepCopyInto(out *OPCUADeviceStatusEvent) {
+	*out = *in
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ReceivedAt != nil {
+		in, out := &in.ReceivedAt, &out.ReceivedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OPCUADeviceStatusEvent.
+func (in *OPCUADeviceStatusEvent) DeepCopy() *OPCUADeviceStatusEvent {
+	if in == nil {
+		return nil
+	}
+	out := new(OPCUADeviceStatusEvent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OPCUADeviceStatusMethod) DeepCopyInto(out *OPCUADeviceStatusMethod) {
+	*out = *in
+	if in.OutputArguments != nil {
+		in, out := &in.OutputArguments, &out.OutputArguments
+		*out = make([]OPCUADeviceArgument, len(*in))
+		copy(*out, *in)
+	}
+	if in.CalledAt != nil {
+		in, out := &in.CalledAt, &out.CalledAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OPCUADeviceStatusMethod.
+func (in *OPCUADeviceStatusMethod) DeepCopy() *OPCUADeviceStatusMethod {
+	if in == nil {
+		return nil
+	}
+	out := new(OPCUADeviceStatusMethod)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OPCUADeviceStatusProperty) DeepCopyInto(out *OPCUADeviceStatusProperty) {
 	*out = *in
+	if in.SourceTimestamp != nil {
+		in, out := &in.SourceTimestamp, &out.SourceTimestamp
+		*out = (*in).DeepCopy()
+	}
 	if in.UpdatedAt != nil {
 		in, out := &in.UpdatedAt, &out.UpdatedAt
 		*out = (*in).DeepCopy()
@@ -298,3 +562,30 @@ func (in *OPCUADeviceStatusProperty) DeepCopy() *OPCUADeviceStatusProperty {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OPCUADeviceStatusSession) DeepCopyInto(out *OPCUADeviceStatusSession) {
+	*out = *in
+	if in.NextReconnectAt != nil {
+		in, out := &in.NextReconnectAt, &out.NextReconnectAt
+		*out = (*in).DeepCopy()
+	}
+	if in.LostAt != nil {
+		in, out := &in.LostAt, &out.LostAt
+		*out = (*in).DeepCopy()
+	}
+	if in.ConnectedAt != nil {
+		in, out := &in.ConnectedAt, &out.ConnectedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OPCUADeviceStatusSession.
+func (in *OPCUADeviceStatusSession) DeepCopy() *OPCUADeviceStatusSession {
+	if in == nil {
+		return nil
+	}
+	out := new(OPCUADeviceStatusSession)
+	in.DeepCopyInto(out)
+	return out
+}
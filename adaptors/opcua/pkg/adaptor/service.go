@@ -11,7 +11,7 @@
 
 	"github.com/rancher/octopus/adaptors/opcua/api/v1alpha1"
 	"github.com/rancher/octopus/adaptors/opcua/pkg/physical"
-	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha1"
+	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha2"
 	"github.com/rancher/octopus/pkg/adaptor/connection"
 	"github.com/rancher/octopus/pkg/adaptor/log"
 	"github.com/rancher/octopus/pkg/mqtt"
@@ -43,6 +43,9 @@ func (s *Service) toJSON(in metav1.Object) []byte {
 }
 
 func (s *Service) Connect(server api.Connection_ConnectServer) error {
+	// the devices sync to limb in their own goroutines
+	server = connection.SerializeSend(server)
+
 	var holder physical.Device
 	defer func() {
 		if holder != nil {
@@ -60,6 +63,12 @@ func (s *Service) Connect(server api.Connection_ConnectServer) error {
 			return nil
 		}
 
+		// executes command in background, the result is sent to limb when it's done
+		if command := req.GetCommand(); command != nil {
+			connection.ExecuteCommandInBackground(server, command, holder)
+			continue
+		}
+
 		// validates model GVK
 		var model = req.GetModel()
 		if model == nil {
@@ -113,7 +122,7 @@ func (s *Service) Connect(server api.Connection_ConnectServer) error {
 
 			// configures device
 			if err := holder.Configure(req.GetReferencesHandler(), &device); err != nil {
-				return status.Errorf(codes.InvalidArgument, "failed to configure the device: %v", err)
+				return connection.NewConfigureError("failed to configure the device", err)
 			}
 		default:
 			return status.Errorf(codes.InvalidArgument, "invalid model kind: %s", modelGVK.Kind)
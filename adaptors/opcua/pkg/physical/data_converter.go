@@ -1,13 +1,17 @@
 package physical
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
+	"reflect"
 	"strconv"
 	"time"
 
 	"github.com/gopcua/opcua/ua"
 	"github.com/rancher/octopus/adaptors/opcua/api/v1alpha1"
+	"github.com/rancher/octopus/pkg/util/expression"
 )
 
 var typeMap = map[ua.TypeID]v1alpha1.OPCUADevicePropertyType{
@@ -23,9 +27,59 @@
 	ua.TypeIDString:     v1alpha1.OPCUADevicePropertyTypeString,
 	ua.TypeIDByteString: v1alpha1.OPCUADevicePropertyTypeByteString,
 	ua.TypeIDDateTime:   v1alpha1.OPCUADevicePropertyTypeDatetime,
+
+	ua.TypeIDLocalizedText:   v1alpha1.OPCUADevicePropertyTypeLocalizedText,
+	ua.TypeIDGUID:            v1alpha1.OPCUADevicePropertyTypeGUID,
+	ua.TypeIDNodeID:          v1alpha1.OPCUADevicePropertyTypeNodeID,
+	ua.TypeIDExtensionObject: v1alpha1.OPCUADevicePropertyTypeExtensionObject,
+}
+
+// arrayElementTypeMap maps the element type of array property to the Go type of variant value.
+var arrayElementTypeMap = map[v1alpha1.OPCUADevicePropertyType]reflect.Type{
+	v1alpha1.OPCUADevicePropertyTypeInt16:           reflect.TypeOf(int16(0)),
+	v1alpha1.OPCUADevicePropertyTypeInt32:           reflect.TypeOf(int32(0)),
+	v1alpha1.OPCUADevicePropertyTypeInt64:           reflect.TypeOf(int64(0)),
+	v1alpha1.OPCUADevicePropertyTypeUInt16:          reflect.TypeOf(uint16(0)),
+	v1alpha1.OPCUADevicePropertyTypeUInt32:          reflect.TypeOf(uint32(0)),
+	v1alpha1.OPCUADevicePropertyTypeUInt64:          reflect.TypeOf(uint64(0)),
+	v1alpha1.OPCUADevicePropertyTypeFloat:           reflect.TypeOf(float32(0)),
+	v1alpha1.OPCUADevicePropertyTypeDouble:          reflect.TypeOf(float64(0)),
+	v1alpha1.OPCUADevicePropertyTypeString:          reflect.TypeOf(""),
+	v1alpha1.OPCUADevicePropertyTypeByteString:      reflect.TypeOf([]byte{}),
+	v1alpha1.OPCUADevicePropertyTypeBoolean:         reflect.TypeOf(false),
+	v1alpha1.OPCUADevicePropertyTypeDatetime:        reflect.TypeOf(time.Time{}),
+	v1alpha1.OPCUADevicePropertyTypeLocalizedText:   reflect.TypeOf(new(ua.LocalizedText)),
+	v1alpha1.OPCUADevicePropertyTypeGUID:            reflect.TypeOf(new(ua.GUID)),
+	v1alpha1.OPCUADevicePropertyTypeNodeID:          reflect.TypeOf(new(ua.NodeID)),
+	v1alpha1.OPCUADevicePropertyTypeExtensionObject: reflect.TypeOf(new(ua.ExtensionObject)),
+}
+
+// getPropertyType returns the property type and the array element type of the given variant.
+func getPropertyType(input *ua.Variant) (v1alpha1.OPCUADevicePropertyType, v1alpha1.OPCUADevicePropertyType) {
+	if input.Has(ua.VariantArrayValues) {
+		return v1alpha1.OPCUADevicePropertyTypeArray, typeMap[input.Type()]
+	}
+	return typeMap[input.Type()], ""
 }
 
+// VariantToString returns the string of variant,
+// the array and the extension object are returned as JSON, and it returns a blank string if failed to decode.
 func VariantToString(dataType ua.TypeID, input *ua.Variant) string {
+	var ret, _ = variantToString(dataType, input)
+	return ret
+}
+
+func variantToString(dataType ua.TypeID, input *ua.Variant) (string, error) {
+	if input.Has(ua.VariantArrayValues) {
+		return arrayToString(dataType, input)
+	}
+	if dataType == ua.TypeIDExtensionObject {
+		return extensionObjectToString(input.ExtensionObject())
+	}
+	return scalarToString(dataType, input), nil
+}
+
+func scalarToString(dataType ua.TypeID, input *ua.Variant) string {
 	switch dataType {
 	case ua.TypeIDBoolean:
 		return strconv.FormatBool(input.Bool())
@@ -39,11 +93,102 @@ func VariantToString(dataType ua.TypeID, input *ua.Variant) string {
 		return strconv.FormatUint(input.Uint(), 10)
 	case ua.TypeIDByteString:
 		return string(input.ByteString())
+	case ua.TypeIDLocalizedText:
+		if text := input.LocalizedText(); text != nil {
+			return text.Text
+		}
+		return ""
+	case ua.TypeIDQualifiedName:
+		if name := input.QualifiedName(); name != nil {
+			return name.Name
+		}
+		return ""
+	case ua.TypeIDNodeID:
+		if id := input.NodeID(); id != nil {
+			return id.String()
+		}
+		return ""
+	case ua.TypeIDGUID:
+		if guid := input.GUID(); guid != nil {
+			return guid.String()
+		}
+		return ""
 	default:
 		return fmt.Sprintf("%v", input.Value())
 	}
 }
 
+// arrayToString returns the JSON array of the array variant,
+// the numeric, boolean, structured and nested array elements are not quoted.
+func arrayToString(dataType ua.TypeID, input *ua.Variant) (string, error) {
+	var values = reflect.ValueOf(input.Value())
+	if values.Kind() != reflect.Slice {
+		return "", fmt.Errorf("%T is not an array", input.Value())
+	}
+
+	var elements = make([]json.RawMessage, 0, values.Len())
+	for i := 0; i < values.Len(); i++ {
+		var element, err = ua.NewVariant(values.Index(i).Interface())
+		if err != nil {
+			return "", err
+		}
+		ret, err := variantToString(dataType, element)
+		if err != nil {
+			return "", err
+		}
+
+		var raw = []byte(ret)
+		switch {
+		case element.Has(ua.VariantArrayValues), dataType == ua.TypeIDExtensionObject:
+		case dataType == ua.TypeIDBoolean, dataType == ua.TypeIDFloat, dataType == ua.TypeIDDouble,
+			dataType == ua.TypeIDInt16, dataType == ua.TypeIDInt32, dataType == ua.TypeIDInt64,
+			dataType == ua.TypeIDUint16, dataType == ua.TypeIDUint32, dataType == ua.TypeIDUint64:
+		default:
+			raw = nil
+		}
+		if raw == nil || !json.Valid(raw) {
+			raw, _ = json.Marshal(ret)
+		}
+		elements = append(elements, raw)
+	}
+
+	var ret, err = json.Marshal(elements)
+	if err != nil {
+		return "", err
+	}
+	return string(ret), nil
+}
+
+// extensionObjectToString returns the JSON object of the extension object,
+// the unknown structure is decoded via the registered structure definition.
+func extensionObjectToString(input *ua.ExtensionObject) (string, error) {
+	if input == nil || input.Value == nil {
+		return "", nil
+	}
+
+	var value = input.Value
+	if body, ok := input.Value.(*structureBody); ok {
+		var def *structureDefinition
+		if input.TypeID != nil {
+			def = getStructure(input.TypeID.NodeID)
+		}
+		if def == nil {
+			return "", fmt.Errorf("unknown structure %v", input.TypeID)
+		}
+		var obj, err = def.decode(body.raw)
+		if err != nil {
+			return "", err
+		}
+		value = obj
+	}
+
+	var ret, err = json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(ret), nil
+}
+
 func StringToVariant(dataType v1alpha1.OPCUADevicePropertyType, input string) (*ua.Variant, error) {
 	var result interface{}
 	var err error
@@ -107,8 +252,141 @@ func StringToVariant(dataType v1alpha1.OPCUADevicePropertyType, input string) (*
 		}
 	case v1alpha1.OPCUADevicePropertyTypeByteString:
 		result = []byte(input)
+	case v1alpha1.OPCUADevicePropertyTypeLocalizedText:
+		var text = &ua.LocalizedText{Text: input}
+		text.UpdateMask()
+		result = text
+	case v1alpha1.OPCUADevicePropertyTypeGUID:
+		var guid = ua.NewGUID(input)
+		if guid == nil {
+			return nil, fmt.Errorf("invalid GUID %s", input)
+		}
+		result = guid
+	case v1alpha1.OPCUADevicePropertyTypeNodeID:
+		result, err = ua.ParseNodeID(input)
+		if err != nil {
+			return nil, err
+		}
 	default:
 		return nil, errors.New("invalid data type")
 	}
 	return ua.NewVariant(result)
 }
+
+// stringToArrayVariant converts the JSON array to the array variant of the given element type,
+// the structure is only required if the element type is "extensionObject".
+func stringToArrayVariant(elementType v1alpha1.OPCUADevicePropertyType, input string, encodingID *ua.NodeID, structure *structureDefinition) (*ua.Variant, error) {
+	var typ, ok = arrayElementTypeMap[elementType]
+	if !ok {
+		return nil, fmt.Errorf("invalid element type %q", elementType)
+	}
+	var elements []json.RawMessage
+	if err := json.Unmarshal([]byte(input), &elements); err != nil {
+		return nil, fmt.Errorf("invalid JSON array: %v", err)
+	}
+
+	var values = reflect.MakeSlice(reflect.SliceOf(typ), 0, len(elements))
+	for idx, element := range elements {
+		var variant *ua.Variant
+		var err error
+		if elementType == v1alpha1.OPCUADevicePropertyTypeExtensionObject {
+			variant, err = stringToStructureVariant(encodingID, structure, string(element))
+		} else {
+			// both quoted and unquoted elements are accepted.
+			var str string
+			if json.Unmarshal(element, &str) != nil {
+				str = string(element)
+			}
+			variant, err = StringToVariant(elementType, str)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid element %d: %v", idx, err)
+		}
+		values = reflect.Append(values, reflect.ValueOf(variant.Value()))
+	}
+	return ua.NewVariant(values.Interface())
+}
+
+// stringToStructureVariant converts the JSON object to the extension object variant of the given structure.
+func stringToStructureVariant(encodingID *ua.NodeID, structure *structureDefinition, input string) (*ua.Variant, error) {
+	if encodingID == nil || structure == nil {
+		return nil, errors.New("unknown structure")
+	}
+	var raw, err = structure.encode(input)
+	if err != nil {
+		return nil, err
+	}
+	return ua.NewVariant(&ua.ExtensionObject{
+		EncodingMask: ua.ExtensionObjectBinary,
+		TypeID:       ua.NewExpandedNodeID(false, false, encodingID, "", 0),
+		Value:        &structureBody{raw: raw},
+	})
+}
+
+// OperateReadValue transforms the variant read from the OPC-UA node with the given expression,
+// it returns the string of variant if the expression is blank.
+func OperateReadValue(dataType ua.TypeID, input *ua.Variant, expr string) (string, error) {
+	if expr == "" {
+		return variantToString(dataType, input)
+	}
+	if input.Has(ua.VariantArrayValues) {
+		return "", errors.New("expression is not supported for array type")
+	}
+
+	var x float64
+	switch dataType {
+	case ua.TypeIDFloat, ua.TypeIDDouble:
+		x = input.Float()
+	case ua.TypeIDInt16, ua.TypeIDInt32, ua.TypeIDInt64:
+		x = float64(input.Int())
+	case ua.TypeIDUint16, ua.TypeIDUint32, ua.TypeIDUint64:
+		x = float64(input.Uint())
+	default:
+		return "", fmt.Errorf("expression is not supported for %s type", typeMap[dataType])
+	}
+	var ret, err = expression.EvaluateValue(expr, x)
+	if err != nil {
+		return "", err
+	}
+	return strconv.FormatFloat(ret, 'g', -1, 64), nil
+}
+
+// OperateWriteValue transforms the value of property with the given expression before writing to the OPC-UA node,
+// it returns the value directly if the expression is blank.
+func OperateWriteValue(dataType v1alpha1.OPCUADevicePropertyType, input string, expr string) (string, error) {
+	if expr == "" {
+		return input, nil
+	}
+
+	var x, err = strconv.ParseFloat(input, 64)
+	if err != nil {
+		return "", err
+	}
+	ret, err := expression.EvaluateValue(expr, x)
+	if err != nil {
+		return "", err
+	}
+	switch dataType {
+	case v1alpha1.OPCUADevicePropertyTypeFloat:
+		return strconv.FormatFloat(ret, 'g', -1, 32), nil
+	case v1alpha1.OPCUADevicePropertyTypeDouble:
+		return strconv.FormatFloat(ret, 'g', -1, 64), nil
+	case v1alpha1.OPCUADevicePropertyTypeInt16, v1alpha1.OPCUADevicePropertyTypeInt32, v1alpha1.OPCUADevicePropertyTypeInt64,
+		v1alpha1.OPCUADevicePropertyTypeUInt16, v1alpha1.OPCUADevicePropertyTypeUInt32, v1alpha1.OPCUADevicePropertyTypeUInt64:
+		// the result is rounded to the nearest integer, the range is checked when converting to variant.
+		return strconv.FormatFloat(math.Round(ret), 'f', 0, 64), nil
+	}
+	return "", fmt.Errorf("expression is not supported for %s type", dataType)
+}
+
+// getQuality returns the quality and the reason of the given status code,
+// the quality is decided by the severity bits of status code.
+func getQuality(code ua.StatusCode) (v1alpha1.OPCUADevicePropertyQuality, string) {
+	switch {
+	case code&ua.StatusBad != 0:
+		return v1alpha1.OPCUADevicePropertyQualityBad, code.Error()
+	case code&ua.StatusUncertain != 0:
+		return v1alpha1.OPCUADevicePropertyQualityUncertain, code.Error()
+	}
+	return v1alpha1.OPCUADevicePropertyQualityGood, ""
+}
@@ -0,0 +1,169 @@
+package physical
+
+import (
+	"testing"
+
+	"github.com/gopcua/opcua/ua"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/rancher/octopus/adaptors/opcua/api/v1alpha1"
+)
+
+func TestOperateReadValue(t *testing.T) {
+	var testCases = []struct {
+		name        string
+		input       interface{}
+		expr        string
+		expected    string
+		expectedErr bool
+	}{
+		{name: "without expression", input: int16(650), expected: "650"},
+		{name: "int16", input: int16(650), expr: "x * 0.1 - 40", expected: "25"},
+		{name: "uint32", input: uint32(4095), expr: "lookup(x, 0, 0, 4095, 100)", expected: "100"},
+		{name: "double", input: float64(1.5), expr: "x * 2", expected: "3"},
+		{name: "string", input: "1", expr: "x * 2", expectedErr: true},
+	}
+
+	for _, tc := range testCases {
+		var variant = ua.MustVariant(tc.input)
+		var ret, err = OperateReadValue(variant.Type(), variant, tc.expr)
+		if tc.expectedErr {
+			assert.Error(t, err, "case %q", tc.name)
+			continue
+		}
+		assert.NoError(t, err, "case %q", tc.name)
+		assert.Equal(t, tc.expected, ret, "case %q", tc.name)
+	}
+}
+
+func TestOperateWriteValue(t *testing.T) {
+	var testCases = []struct {
+		name        string
+		dataType    v1alpha1.OPCUADevicePropertyType
+		input       string
+		expr        string
+		expected    string
+		expectedErr bool
+	}{
+		{name: "without expression", dataType: v1alpha1.OPCUADevicePropertyTypeString, input: "abc", expected: "abc"},
+		{name: "int16", dataType: v1alpha1.OPCUADevicePropertyTypeInt16, input: "-15.55", expr: "(x + 40) * 10", expected: "245"},
+		{name: "double", dataType: v1alpha1.OPCUADevicePropertyTypeDouble, input: "1.5", expr: "x / 2", expected: "0.75"},
+		{name: "boolean", dataType: v1alpha1.OPCUADevicePropertyTypeBoolean, input: "1", expr: "x", expectedErr: true},
+		{name: "not a number", dataType: v1alpha1.OPCUADevicePropertyTypeInt16, input: "abc", expr: "x", expectedErr: true},
+	}
+
+	for _, tc := range testCases {
+		var ret, err = OperateWriteValue(tc.dataType, tc.input, tc.expr)
+		if tc.expectedErr {
+			assert.Error(t, err, "case %q", tc.name)
+			continue
+		}
+		assert.NoError(t, err, "case %q", tc.name)
+		assert.Equal(t, tc.expected, ret, "case %q", tc.name)
+	}
+}
+
+func TestGetQuality(t *testing.T) {
+	var testCases = []struct {
+		code     ua.StatusCode
+		expected v1alpha1.OPCUADevicePropertyQuality
+	}{
+		{code: ua.StatusOK, expected: v1alpha1.OPCUADevicePropertyQualityGood},
+		{code: ua.StatusUncertainLastUsableValue, expected: v1alpha1.OPCUADevicePropertyQualityUncertain},
+		{code: ua.StatusBadNodeIDUnknown, expected: v1alpha1.OPCUADevicePropertyQualityBad},
+	}
+
+	for _, tc := range testCases {
+		var quality, reason = getQuality(tc.code)
+		assert.Equal(t, tc.expected, quality, "case %v", tc.code)
+		if tc.expected == v1alpha1.OPCUADevicePropertyQualityGood {
+			assert.Empty(t, reason)
+		} else {
+			assert.NotEmpty(t, reason)
+		}
+	}
+}
+
+func TestVariantToString(t *testing.T) {
+	var text = &ua.LocalizedText{Text: "boiler"}
+	text.UpdateMask()
+
+	var testCases = []struct {
+		name     string
+		input    interface{}
+		expected string
+	}{
+		{name: "localizedText", input: text, expected: "boiler"},
+		{name: "guid", input: ua.NewGUID("72962B91-FA75-4AE6-8D28-B404DC7DAF63"), expected: "72962B91-FA75-4AE6-8D28-B404DC7DAF63"},
+		{name: "nodeId", input: ua.NewStringNodeID(1, "Boiler"), expected: "ns=1;s=Boiler"},
+		{name: "double array", input: []float64{1.5, 2.5}, expected: "[1.5,2.5]"},
+		{name: "empty array", input: []int32{}, expected: "[]"},
+		{name: "string array", input: []string{"a", "b"}, expected: `["a","b"]`},
+		{name: "localizedText array", input: []*ua.LocalizedText{text}, expected: `["boiler"]`},
+		{name: "two dimensions array", input: [][]int16{{1, 2}, {3, 4}}, expected: "[[1,2],[3,4]]"},
+	}
+
+	for _, tc := range testCases {
+		var variant = ua.MustVariant(tc.input)
+		var ret = VariantToString(variant.Type(), variant)
+		assert.Equal(t, tc.expected, ret, "case %q", tc.name)
+	}
+}
+
+func TestStringToVariant(t *testing.T) {
+	var testCases = []struct {
+		name        string
+		dataType    v1alpha1.OPCUADevicePropertyType
+		input       string
+		expected    string
+		expectedErr bool
+	}{
+		{name: "localizedText", dataType: v1alpha1.OPCUADevicePropertyTypeLocalizedText, input: "boiler", expected: "boiler"},
+		{name: "guid", dataType: v1alpha1.OPCUADevicePropertyTypeGUID, input: "72962b91-fa75-4ae6-8d28-b404dc7daf63", expected: "72962B91-FA75-4AE6-8D28-B404DC7DAF63"},
+		{name: "invalid guid", dataType: v1alpha1.OPCUADevicePropertyTypeGUID, input: "boiler", expectedErr: true},
+		{name: "nodeId", dataType: v1alpha1.OPCUADevicePropertyTypeNodeID, input: "ns=1;i=1005", expected: "ns=1;i=1005"},
+		{name: "invalid nodeId", dataType: v1alpha1.OPCUADevicePropertyTypeNodeID, input: "ns=x;i=1", expectedErr: true},
+		{name: "array", dataType: v1alpha1.OPCUADevicePropertyTypeArray, input: "[1]", expectedErr: true},
+	}
+
+	for _, tc := range testCases {
+		var ret, err = StringToVariant(tc.dataType, tc.input)
+		if tc.expectedErr {
+			assert.Error(t, err, "case %q", tc.name)
+			continue
+		}
+		assert.NoError(t, err, "case %q", tc.name)
+		assert.Equal(t, tc.expected, VariantToString(ret.Type(), ret), "case %q", tc.name)
+	}
+}
+
+func TestStringToArrayVariant(t *testing.T) {
+	var testCases = []struct {
+		name        string
+		elementType v1alpha1.OPCUADevicePropertyType
+		input       string
+		expected    interface{}
+		expectedErr bool
+	}{
+		{name: "float", elementType: v1alpha1.OPCUADevicePropertyTypeFloat, input: "[1.5, 2.5]", expected: []float32{1.5, 2.5}},
+		{name: "quoted uint16", elementType: v1alpha1.OPCUADevicePropertyTypeUInt16, input: `["1", 2]`, expected: []uint16{1, 2}},
+		{name: "string", elementType: v1alpha1.OPCUADevicePropertyTypeString, input: `["a", "b"]`, expected: []string{"a", "b"}},
+		{name: "empty", elementType: v1alpha1.OPCUADevicePropertyTypeBoolean, input: "[]", expected: []bool{}},
+		{name: "nodeId", elementType: v1alpha1.OPCUADevicePropertyTypeNodeID, input: `["ns=1;s=Boiler"]`, expected: []*ua.NodeID{ua.NewStringNodeID(1, "Boiler")}},
+		{name: "out of range", elementType: v1alpha1.OPCUADevicePropertyTypeInt16, input: "[65536]", expectedErr: true},
+		{name: "not an array", elementType: v1alpha1.OPCUADevicePropertyTypeInt16, input: "1", expectedErr: true},
+		{name: "invalid element type", elementType: v1alpha1.OPCUADevicePropertyTypeArray, input: "[]", expectedErr: true},
+		{name: "unknown structure", elementType: v1alpha1.OPCUADevicePropertyTypeExtensionObject, input: "[{}]", expectedErr: true},
+	}
+
+	for _, tc := range testCases {
+		var ret, err = stringToArrayVariant(tc.elementType, tc.input, nil, nil)
+		if tc.expectedErr {
+			assert.Error(t, err, "case %q", tc.name)
+			continue
+		}
+		assert.NoError(t, err, "case %q", tc.name)
+		assert.True(t, ret.Has(ua.VariantArrayValues), "case %q", tc.name)
+		assert.Equal(t, tc.expected, ret.Value(), "case %q", tc.name)
+	}
+}
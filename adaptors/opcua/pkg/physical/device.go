@@ -2,6 +2,7 @@
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"reflect"
@@ -17,7 +18,8 @@
 
 	"github.com/rancher/octopus/adaptors/opcua/api/v1alpha1"
 	"github.com/rancher/octopus/adaptors/opcua/pkg/metadata"
-	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha1"
+	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha2"
+	"github.com/rancher/octopus/pkg/adaptor/connection"
 	"github.com/rancher/octopus/pkg/adaptor/socket/handler"
 	"github.com/rancher/octopus/pkg/mqtt"
 	"github.com/rancher/octopus/pkg/util/critical"
@@ -47,11 +49,17 @@ func NewDevice(log logr.Logger, meta metav1.ObjectMeta, toLimb OPCUADeviceLimbSy
 type opcuaDevice struct {
 	sync.Mutex
 
-	log         logr.Logger
-	instance    *v1alpha1.OPCUADevice
-	toLimb      OPCUADeviceLimbSyncer
-	stop        chan struct{}
-	opcuaClient *opcua.Client
+	log      logr.Logger
+	instance *v1alpha1.OPCUADevice
+	toLimb   OPCUADeviceLimbSyncer
+	session  *session
+
+	// subscriptions are indexed by the publishing interval.
+	subscriptions map[time.Duration]*propertySubscription
+	handle        uint32
+
+	discoveryStop chan struct{}
+	eventStop     chan struct{}
 
 	mqttClient mqtt.Client
 }
@@ -95,20 +103,21 @@ func (d *opcuaDevice) Configure(references api.ReferencesHandler, device *v1alph
 
 	// configures OPC-UA client
 	if !reflect.DeepEqual(staleSpec.Protocol, newSpec.Protocol) || !reflect.DeepEqual(staleSpec.Parameters, newSpec.Parameters) {
-		if d.opcuaClient != nil {
-			if err := d.opcuaClient.Close(); err != nil {
-				if err != io.EOF {
-					d.log.Error(err, "Error closing OPC-UA connection")
-				}
-			}
-			d.opcuaClient = nil
+		d.stopSubscribe()
+		d.stopDiscovery()
+		d.stopEvents()
+		if d.session != nil {
+			d.session.Close()
+			d.session = nil
 		}
 
-		var client, err = NewOPCUAClient(newSpec.Protocol, newSpec.Parameters.GetTimeout(), references)
+		var sess, err = newSession(d.log, newSpec.Protocol, newSpec.Parameters, references, d.receiveSession)
 		if err != nil {
 			return errors.Wrap(err, "failed to create OPC-UA client")
 		}
-		d.opcuaClient = client
+		d.session = sess
+		var status = sess.Status()
+		d.instance.Status.Session = &status
 	}
 
 	return d.refresh(newSpec)
@@ -119,13 +128,11 @@ func (d *opcuaDevice) Shutdown() {
 	defer d.Unlock()
 
 	d.stopSubscribe()
-	if d.opcuaClient != nil {
-		if err := d.opcuaClient.Close(); err != nil {
-			if err != io.EOF {
-				d.log.Error(err, "Error closing OPC-UA connection")
-			}
-		}
-		d.opcuaClient = nil
+	d.stopEvents()
+	d.stopDiscovery()
+	if d.session != nil {
+		d.session.Close()
+		d.session = nil
 	}
 	if d.mqttClient != nil {
 		d.mqttClient.Disconnect()
@@ -139,18 +146,29 @@ func (d *opcuaDevice) refresh(newSpec v1alpha1.OPCUADeviceSpec) error {
 	var status = d.instance.Status
 	var staleSpec = d.instance.Spec
 	if !reflect.DeepEqual(staleSpec.Properties, newSpec.Properties) {
-		d.stopSubscribe()
+		var staleProps = make(map[string]v1alpha1.OPCUADeviceProperty, len(staleSpec.Properties))
+		for _, prop := range staleSpec.Properties {
+			staleProps[prop.Name] = prop
+		}
 
 		// configures properties
 		var specProps = newSpec.Properties
 		var statusProps = make([]v1alpha1.OPCUADeviceStatusProperty, 0, len(specProps))
 		for _, prop := range specProps {
-			if !prop.ReadOnly {
-				if err := d.writeProperty(prop.Type, prop.Visitor, prop.Value); err != nil {
+			var staleProp, existed = staleProps[prop.Name]
+			if !prop.ReadOnly && (!existed || !reflect.DeepEqual(staleProp, prop)) {
+				if err := d.writeProperty(prop); err != nil {
 					return errors.Wrapf(err, "failed to write property %s", prop.Name)
 				}
 				d.log.V(4).Info("Write property", "property", prop.Name, "type", prop.Type)
 			}
+			// the observed status is kept if the property still refers to the same node.
+			if existed && staleProp.Type == prop.Type && staleProp.Visitor.NodeID == prop.Visitor.NodeID {
+				if statusProp := findStatusProperty(status.Properties, prop.Name); statusProp != nil {
+					statusProps = append(statusProps, *statusProp)
+					continue
+				}
+			}
 			// TODO need to read property at first?
 			statusProps = append(statusProps, v1alpha1.OPCUADeviceStatusProperty{
 				Name:      prop.Name,
@@ -158,14 +176,42 @@ func (d *opcuaDevice) refresh(newSpec v1alpha1.OPCUADeviceSpec) error {
 				UpdatedAt: now(),
 			})
 		}
-		status = v1alpha1.OPCUADeviceStatus{Properties: statusProps}
+		status.Properties = statusProps
 	}
 
 	// subscribed in backend
-	if err := d.startSubscribe(newSpec.Parameters.GetSyncInterval(), newSpec.Properties); err != nil {
+	if err := d.syncSubscriptions(newSpec.Parameters, newSpec.Properties); err != nil {
 		return errors.Wrap(err, "failed to subscribing")
 	}
 
+	// drops the status of the removed methods, the methods are only called by commands
+	if !reflect.DeepEqual(staleSpec.Methods, newSpec.Methods) {
+		status.Methods = filterStatusMethods(newSpec.Methods, status.Methods)
+	}
+
+	// subscribes events in backend
+	if !reflect.DeepEqual(staleSpec.Events, newSpec.Events) {
+		d.stopEvents()
+
+		var statusEvents = make([]v1alpha1.OPCUADeviceStatusEvent, 0, len(newSpec.Events))
+		for _, event := range newSpec.Events {
+			statusEvents = append(statusEvents, v1alpha1.OPCUADeviceStatusEvent{
+				Name: event.Name,
+			})
+		}
+		status.Events = statusEvents
+	}
+	if err := d.startEvents(newSpec.Parameters.GetSyncInterval(), newSpec.Events); err != nil {
+		return errors.Wrap(err, "failed to subscribing events")
+	}
+
+	// discovers in backend
+	if !reflect.DeepEqual(staleSpec.Discovery, newSpec.Discovery) {
+		d.stopDiscovery()
+		status.DiscoveredNodes = nil
+	}
+	d.startDiscovery(newSpec.Discovery)
+
 	// records
 	d.instance.Spec = newSpec
 	d.instance.Status = status
@@ -173,21 +219,47 @@ func (d *opcuaDevice) refresh(newSpec v1alpha1.OPCUADeviceSpec) error {
 }
 
 // writeProperty writes data of a property to the corresponding OPC-UA node.
-func (d *opcuaDevice) writeProperty(dataType v1alpha1.OPCUADevicePropertyType, visitor v1alpha1.OPCUADevicePropertyVisitor, value string) error {
+func (d *opcuaDevice) writeProperty(prop v1alpha1.OPCUADeviceProperty) error {
+	var dataType, visitor, value = prop.Type, prop.Visitor, prop.Value
 	// NB(thxCode) don't write the property if the value is blank.
 	if value == "" {
 		return nil
 	}
 
-	var data, err = StringToVariant(dataType, value)
-	if err != nil {
-		return errors.Wrapf(err, "failed to convert %s string to %s variant", value, dataType)
-	}
-
 	id, err := ua.ParseNodeID(visitor.NodeID)
 	if err != nil {
 		return errors.Wrapf(err, "failed to parse node ID %s", visitor.NodeID)
 	}
+
+	var data *ua.Variant
+	switch dataType {
+	case v1alpha1.OPCUADevicePropertyTypeArray, v1alpha1.OPCUADevicePropertyTypeExtensionObject:
+		var encodingID *ua.NodeID
+		var structure *structureDefinition
+		if isStructureProperty(prop) {
+			encodingID, structure, err = resolveStructure(clientBrowser{client: d.session.Client()}, id)
+			if err != nil {
+				return errors.Wrapf(err, "failed to resolve the structure of node %s", visitor.NodeID)
+			}
+		}
+		if dataType == v1alpha1.OPCUADevicePropertyTypeArray {
+			data, err = stringToArrayVariant(prop.ElementType, value, encodingID, structure)
+		} else {
+			data, err = stringToStructureVariant(encodingID, structure, value)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to convert %s string to %s variant", value, dataType)
+		}
+	default:
+		var operated, err = OperateWriteValue(dataType, value, visitor.WriteExpression)
+		if err != nil {
+			return errors.Wrapf(err, "failed to operate %s with write expression", value)
+		}
+		data, err = StringToVariant(dataType, operated)
+		if err != nil {
+			return errors.Wrapf(err, "failed to convert %s string to %s variant", value, dataType)
+		}
+	}
 	var req = &ua.WriteRequest{
 		NodesToWrite: []*ua.WriteValue{
 			{
@@ -200,19 +272,63 @@ func (d *opcuaDevice) writeProperty(dataType v1alpha1.OPCUADevicePropertyType, v
 			},
 		},
 	}
-	_, err = d.opcuaClient.Write(req)
+	_, err = d.session.Client().Write(req)
 	if err != nil {
 		return errors.Wrapf(err, "failed to write")
 	}
 	return nil
 }
 
+// Execute executes the named command on the device,
+// the command calls the method with the same name, so that the method is called once per request.
+func (d *opcuaDevice) Execute(name string, arguments []byte) ([]byte, error) {
+	d.Lock()
+	defer d.Unlock()
+
+	if d.instance == nil {
+		return nil, errors.New("device is not configured")
+	}
+	var method = findMethod(d.instance.Spec.Methods, name)
+	if method == nil {
+		return nil, connection.NewUnsupportedCommandError(name)
+	}
+	var req, err = newCallMethodRequest(*method, arguments)
+	if err != nil {
+		return nil, connection.NewInvalidArgumentsError("invalid arguments of method %s: %v", name, err)
+	}
+	if d.session == nil {
+		return nil, errors.New("device is not connected")
+	}
+
+	// feedbacks the result of calling via the status as well
+	var statusMethod = v1alpha1.OPCUADeviceStatusMethod{
+		Name:     name,
+		CalledAt: now(),
+	}
+	var outputs, callErr = callMethod(d.session.Client(), req)
+	if callErr != nil {
+		d.log.Error(callErr, "Unable to call method", "method", name)
+		statusMethod.Error = callErr.Error()
+	} else {
+		d.log.V(4).Info("Called method", "method", name)
+		statusMethod.OutputArguments = outputs
+	}
+	d.instance.Status.Methods = setStatusMethod(d.instance.Status.Methods, statusMethod)
+	if err := d.sync(); err != nil {
+		d.log.Error(err, "failed to sync")
+	}
+	if callErr != nil {
+		return nil, callErr
+	}
+	return json.Marshal(methodOutput{OutputArguments: outputs})
+}
+
 // subscribe is blocked, it is used to watch the notification from OPC-UA server
 // and update the opcua device status.
-func (d *opcuaDevice) subscribe(ctx context.Context, notifyCh chan *opcua.PublishNotificationData) {
+func (d *opcuaDevice) subscribe(ctx context.Context, group *propertySubscription, notifyCh chan *opcua.PublishNotificationData) {
 	defer runtime.HandleCrash(handler.NewPanicsCleanupSocketHandler(metadata.Endpoint))
 
-	d.log.Info("Subscribing")
+	d.log.Info("Subscribing", "interval", group.interval)
 	defer func() {
 		d.log.Info("Finished subscription")
 	}()
@@ -223,8 +339,25 @@ func (d *opcuaDevice) subscribe(ctx context.Context, notifyCh chan *opcua.Publis
 			return
 		case res := <-notifyCh:
 			if res.Error != nil {
-				// TODO give a way to feedback this to limb.
 				d.log.Error(res.Error, "Received error from subscription")
+				d.Lock()
+				func() {
+					defer d.Unlock()
+
+					// the properties keep the last available values, and the error is fed back via the quality.
+					for _, item := range group.items {
+						var prop = findStatusProperty(d.instance.Status.Properties, item.property.Name)
+						if prop == nil {
+							continue
+						}
+						prop.Quality = v1alpha1.OPCUADevicePropertyQualityBad
+						prop.QualityReason = res.Error.Error()
+						prop.UpdatedAt = now()
+					}
+					if err := d.sync(); err != nil {
+						d.log.Error(err, "failed to sync")
+					}
+				}()
 				continue
 			}
 
@@ -234,25 +367,50 @@ func (d *opcuaDevice) subscribe(ctx context.Context, notifyCh chan *opcua.Publis
 				func() {
 					defer d.Unlock()
 
-					var statusProps = d.instance.Status.Properties
 					for _, item := range v.MonitoredItems {
-						var idx = int(item.ClientHandle)
-						if idx >= len(statusProps) {
+						var monitored, ok = group.items[item.ClientHandle]
+						if !ok {
+							continue
+						}
+						var prop = findStatusProperty(d.instance.Status.Properties, monitored.property.Name)
+						if prop == nil {
+							continue
+						}
+						prop.UpdatedAt = now()
+						if item.Value == nil || item.Value.Value == nil {
+							prop.Quality = v1alpha1.OPCUADevicePropertyQualityBad
+							prop.QualityReason = "no value"
+							if item.Value != nil {
+								prop.QualityReason = item.Value.Status.Error()
+							}
+							continue
+						}
+
+						var quality, reason = getQuality(item.Value.Status)
+						if quality == v1alpha1.OPCUADevicePropertyQualityBad {
+							prop.Quality, prop.QualityReason = quality, reason
 							continue
 						}
-						var prop = statusProps[idx]
 						var variant = item.Value.Value
-						var value = VariantToString(variant.Type(), variant)
-						var propType = typeMap[variant.Type()]
-						d.log.V(4).Info("Received property", "property", item.ClientHandle, "type", propType)
-						statusProps[idx] = v1alpha1.OPCUADeviceStatusProperty{
-							Name:      prop.Name,
-							Value:     value,
-							Type:      propType,
-							UpdatedAt: now(),
+						var value, err = OperateReadValue(variant.Type(), variant, monitored.property.Visitor.ReadExpression)
+						if err != nil {
+							d.log.Error(err, "Unable to operate the read value", "property", prop.Name)
+							prop.Quality = v1alpha1.OPCUADevicePropertyQualityBad
+							prop.QualityReason = err.Error()
+							continue
+						}
+						var propType, elementType = getPropertyType(variant)
+						d.log.V(4).Info("Received property", "property", prop.Name, "type", propType)
+						prop.Value = value
+						prop.Type = propType
+						prop.ElementType = elementType
+						prop.Quality, prop.QualityReason = quality, reason
+						prop.SourceTimestamp = prop.UpdatedAt
+						if !item.Value.SourceTimestamp.IsZero() {
+							var sourceTimestamp = metav1.NewTime(item.Value.SourceTimestamp)
+							prop.SourceTimestamp = &sourceTimestamp
 						}
 					}
-					d.instance.Status.Properties = statusProps
 					if err := d.sync(); err != nil {
 						d.log.Error(err, "failed to sync")
 					}
@@ -264,62 +422,311 @@ func() {
 	}
 }
 
+// receiveEvents is blocked, it is used to watch the event notification from OPC-UA server
+// and update the opcua device status.
+func (d *opcuaDevice) receiveEvents(ctx context.Context, notifyCh chan *opcua.PublishNotificationData) {
+	defer runtime.HandleCrash(handler.NewPanicsCleanupSocketHandler(metadata.Endpoint))
+
+	d.log.Info("Receiving events")
+	defer func() {
+		d.log.Info("Finished receiving events")
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case res := <-notifyCh:
+			if res.Error != nil {
+				d.log.Error(res.Error, "Received error from event subscription")
+				continue
+			}
+
+			switch v := res.Value.(type) {
+			case *ua.EventNotificationList:
+				d.Lock()
+				func() {
+					defer d.Unlock()
+
+					var specEvents = d.instance.Spec.Events
+					var statusEvents = d.instance.Status.Events
+					for _, item := range v.Events {
+						var idx = int(item.ClientHandle)
+						if idx >= len(statusEvents) || idx >= len(specEvents) {
+							continue
+						}
+						var event = &statusEvents[idx]
+						event.Fields = getEventFields(specEvents[idx], item.EventFields)
+						event.ReceivedAt = now()
+						d.log.V(4).Info("Received event", "event", event.Name)
+
+						// every event is synced, otherwise the previous one is overwritten.
+						if err := d.sync(); err != nil {
+							d.log.Error(err, "failed to sync")
+						}
+					}
+				}()
+			default:
+				d.log.V(4).Info(fmt.Sprintf("Received unknown event %+v", res.Value))
+			}
+		}
+	}
+}
+
+// discover is used to browse the address space of OPC-UA server in backend,
+// and report the discovered nodes in status.
+func (d *opcuaDevice) discover(client *opcua.Client, discovery *v1alpha1.OPCUADeviceDiscovery, stop <-chan struct{}) {
+	defer runtime.HandleCrash(handler.NewPanicsCleanupSocketHandler(metadata.Endpoint))
+
+	d.log.Info("Discovering")
+	defer func() {
+		d.log.Info("Finished discovery")
+	}()
+
+	var nodes, err = discoverNodes(clientBrowser{client: client}, discovery, stop)
+
+	d.Lock()
+	defer d.Unlock()
+
+	select {
+	case <-stop:
+		return
+	default:
+	}
+	if err != nil {
+		d.log.Error(err, "Unable to discover nodes")
+		return
+	}
+	d.log.V(4).Info("Discovered nodes", "count", len(nodes))
+	d.instance.Status.DiscoveredNodes = nodes
+	if err := d.sync(); err != nil {
+		d.log.Error(err, "failed to sync")
+	}
+}
+
+func (d *opcuaDevice) stopDiscovery() {
+	if d.discoveryStop != nil {
+		close(d.discoveryStop)
+		d.discoveryStop = nil
+	}
+}
+
+func (d *opcuaDevice) startDiscovery(discovery *v1alpha1.OPCUADeviceDiscovery) {
+	if discovery == nil || d.session == nil {
+		return
+	}
+	if d.discoveryStop == nil {
+		d.discoveryStop = make(chan struct{})
+		go d.discover(d.session.Client(), discovery.DeepCopy(), d.discoveryStop)
+	}
+}
+
 func (d *opcuaDevice) stopSubscribe() {
-	if d.stop != nil {
-		close(d.stop)
-		d.stop = nil
+	for interval, group := range d.subscriptions {
+		close(group.stop)
+		delete(d.subscriptions, interval)
 	}
 }
 
-func (d *opcuaDevice) startSubscribe(subscribeInterval time.Duration, properties []v1alpha1.OPCUADeviceProperty) error {
-	if d.stop == nil {
-		d.stop = make(chan struct{})
+// syncSubscriptions groups the properties into the subscriptions by the publishing interval,
+// and only re-creates the monitored items of the changed properties.
+func (d *opcuaDevice) syncSubscriptions(params *v1alpha1.OPCUADeviceParameters, properties []v1alpha1.OPCUADeviceProperty) error {
+	if d.subscriptions == nil {
+		d.subscriptions = make(map[time.Duration]*propertySubscription)
+	}
 
-		// creates subscription
-		var notifyCh = make(chan *opcua.PublishNotificationData)
-		var sub, err = d.opcuaClient.Subscribe(&opcua.SubscriptionParameters{Interval: subscribeInterval}, notifyCh)
-		if err != nil {
-			return errors.Wrap(err, "failed to create subscription")
+	var desiredProps = make(map[string]v1alpha1.OPCUADeviceProperty, len(properties))
+	var desiredIntervals = make(map[time.Duration]struct{})
+	for _, prop := range properties {
+		desiredProps[prop.Name] = prop
+		desiredIntervals[prop.GetSyncInterval(params)] = struct{}{}
+	}
+
+	// removes the monitored items of the deleted or changed properties
+	for interval, group := range d.subscriptions {
+		var handles []uint32
+		for handle, item := range group.items {
+			var prop, exist = desiredProps[item.property.Name]
+			if exist && prop.GetSyncInterval(params) == interval && !isMonitoringChanged(item.property.Visitor, prop.Visitor) {
+				// the property is refreshed to pick up the changed expressions.
+				item.property = prop
+				group.items[handle] = item
+				continue
+			}
+			handles = append(handles, handle)
+			delete(group.items, handle)
 		}
-		d.log.Info("Created subscription", "id", sub.SubscriptionID)
 
-		// creates monitoring request for all properties
-		for idx, prop := range properties {
-			var id, err = ua.ParseNodeID(prop.Visitor.NodeID)
+		if _, desired := desiredIntervals[interval]; !desired {
+			close(group.stop)
+			delete(d.subscriptions, interval)
+			d.log.Info("Deleted subscription", "id", group.sub.ID(), "interval", interval)
+			continue
+		}
+		if len(handles) != 0 {
+			var res, err = group.sub.Unmonitor(handles...)
 			if err != nil {
-				return errors.Wrapf(err, "failed to parse node ID %s", prop.Visitor.NodeID)
+				return errors.Wrapf(err, "error unmonitoring properties in %s subscription", interval)
+			}
+			for _, code := range res.Results {
+				if code != ua.StatusOK {
+					d.log.Error(code, "Unable to unmonitor property", "interval", interval)
+				}
 			}
+		}
+	}
 
-			var handle = uint32(idx)
-			var miCreateRequest = opcua.NewMonitoredItemCreateRequestWithDefaults(id, ua.AttributeIDValue, handle)
-			res, err := sub.Monitor(ua.TimestampsToReturnBoth, miCreateRequest)
+	// monitors the created or changed properties
+	for _, prop := range properties {
+		var interval = prop.GetSyncInterval(params)
+		var group = d.subscriptions[interval]
+		if group != nil && group.has(prop.Name) {
+			continue
+		}
+		if group == nil {
+			var err error
+			group, err = d.createSubscription(interval)
 			if err != nil {
-				return errors.Wrapf(err, "error monitoring property %s", prop.Name)
+				return err
 			}
-			if res.Results[0].StatusCode != ua.StatusOK {
-				return errors.Errorf("failed to monitor property %s", prop.Name)
-			}
-			d.log.V(4).Info("Monitored property", "property", prop.Name)
+			d.subscriptions[interval] = group
 		}
 
-		// subscribes
-		var ctx = critical.Context(d.stop, func() {
-			var err = sub.Cancel()
+		// the structure is registered before monitoring,
+		// otherwise the gopcua library fails to decode the notification of the unknown extension object.
+		if isStructureProperty(prop) {
+			var nodeID, err = ua.ParseNodeID(prop.Visitor.NodeID)
 			if err != nil {
-				if err != io.EOF {
-					d.log.Error(err, "Failed to cancel subscription")
-				}
+				return errors.Wrapf(err, "failed to parse node ID %s", prop.Visitor.NodeID)
 			}
-		})
-		go sub.Run(ctx)
-		d.log.Info("Running subscription", "id", sub.SubscriptionID)
+			if _, _, err = resolveStructure(clientBrowser{client: d.session.Client()}, nodeID); err != nil {
+				return errors.Wrapf(err, "failed to resolve the structure of property %s", prop.Name)
+			}
+		}
 
-		go d.subscribe(ctx, notifyCh)
+		d.handle++
+		var handle = d.handle
+		var req, err = newPropertyMonitoredItemCreateRequest(prop, handle)
+		if err != nil {
+			return errors.Wrapf(err, "invalid property %s", prop.Name)
+		}
+		res, err := group.sub.Monitor(ua.TimestampsToReturnBoth, req)
+		if err != nil {
+			return errors.Wrapf(err, "error monitoring property %s", prop.Name)
+		}
+		if res.Results[0].StatusCode != ua.StatusOK {
+			return errors.Wrapf(res.Results[0].StatusCode, "failed to monitor property %s", prop.Name)
+		}
+		group.items[handle] = monitoredItem{
+			property: prop,
+		}
+		d.log.V(4).Info("Monitored property", "property", prop.Name, "interval", interval)
 	}
 
 	return nil
 }
 
+// createSubscription creates and runs a subscription in the given publishing interval.
+func (d *opcuaDevice) createSubscription(interval time.Duration) (*propertySubscription, error) {
+	var notifyCh = make(chan *opcua.PublishNotificationData)
+	var sub, err = d.session.Subscribe(interval, notifyCh)
+	if err != nil {
+		return nil, err
+	}
+	d.log.Info("Created subscription", "id", sub.ID(), "interval", interval)
+
+	var group = &propertySubscription{
+		interval: interval,
+		sub:      sub,
+		stop:     make(chan struct{}),
+		items:    make(map[uint32]monitoredItem),
+	}
+	var ctx = critical.Context(group.stop, func() {
+		var err = sub.Cancel()
+		if err != nil {
+			if err != io.EOF {
+				d.log.Error(err, "Failed to cancel subscription")
+			}
+		}
+	})
+	go d.subscribe(ctx, group, notifyCh)
+	return group, nil
+}
+
+func (d *opcuaDevice) stopEvents() {
+	if d.eventStop != nil {
+		close(d.eventStop)
+		d.eventStop = nil
+	}
+}
+
+func (d *opcuaDevice) startEvents(subscribeInterval time.Duration, events []v1alpha1.OPCUADeviceEvent) error {
+	if len(events) == 0 || d.eventStop != nil {
+		return nil
+	}
+
+	// creates subscription
+	var notifyCh = make(chan *opcua.PublishNotificationData)
+	var sub, err = d.session.Subscribe(subscribeInterval, notifyCh)
+	if err != nil {
+		return errors.Wrap(err, "failed to create event subscription")
+	}
+	d.log.Info("Created event subscription", "id", sub.ID())
+
+	// creates monitoring request for all events
+	var cancel = func() {
+		var err = sub.Cancel()
+		if err != nil {
+			if err != io.EOF {
+				d.log.Error(err, "Failed to cancel event subscription")
+			}
+		}
+	}
+	for idx, event := range events {
+		var miCreateRequest, err = newEventMonitoredItemCreateRequest(event, uint32(idx))
+		if err != nil {
+			cancel()
+			return errors.Wrapf(err, "invalid event %s", event.Name)
+		}
+		res, err := sub.Monitor(ua.TimestampsToReturnBoth, miCreateRequest)
+		if err != nil {
+			cancel()
+			return errors.Wrapf(err, "error monitoring event %s", event.Name)
+		}
+		if res.Results[0].StatusCode != ua.StatusOK {
+			cancel()
+			return errors.Wrapf(res.Results[0].StatusCode, "failed to monitor event %s", event.Name)
+		}
+		d.log.V(4).Info("Monitored event", "event", event.Name)
+	}
+
+	// subscribes
+	d.eventStop = make(chan struct{})
+	var ctx = critical.Context(d.eventStop, cancel)
+	go d.receiveEvents(ctx, notifyCh)
+	return nil
+}
+
+// receiveSession feedbacks the progress of reconnecting session via the status.
+func (d *opcuaDevice) receiveSession(s *session, status v1alpha1.OPCUADeviceStatusSession) {
+	d.Lock()
+	defer d.Unlock()
+
+	// the status of a replaced session is ignored.
+	if d.session != s {
+		return
+	}
+	d.instance.Status.Session = &status
+	// the discovery interrupted by the loss of session is restarted once the session is connected again.
+	if status.State == v1alpha1.OPCUADeviceSessionStateConnected && d.instance.Status.DiscoveredNodes == nil {
+		d.stopDiscovery()
+		d.startDiscovery(d.instance.Spec.Discovery)
+	}
+	if err := d.sync(); err != nil {
+		d.log.Error(err, "failed to sync")
+	}
+}
+
 // sync combines all synchronization operations.
 func (d *opcuaDevice) sync() error {
 	if d.toLimb != nil {
@@ -340,3 +747,13 @@ func now() *metav1.Time {
 	var ret = metav1.Now()
 	return &ret
 }
+
+// findStatusProperty returns the status property with the given name.
+func findStatusProperty(props []v1alpha1.OPCUADeviceStatusProperty, name string) *v1alpha1.OPCUADeviceStatusProperty {
+	for idx := range props {
+		if props[idx].Name == name {
+			return &props[idx]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,400 @@
+// +build !race
+
+// the secure channel of gopcua v0.1.11 reads the channel ID without lock
+// when dispatching the concurrent responses of subscription, which fails the race detector.
+
+package physical
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gopcua/opcua/ua"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/rancher/octopus/adaptors/opcua/api/v1alpha1"
+	"github.com/rancher/octopus/pkg/adaptor/connection"
+	"github.com/rancher/octopus/pkg/util/log/zap"
+)
+
+func TestOPCUADevice_MethodsAndEvents(t *testing.T) {
+	var server = newTestServer(t)
+	defer server.Close()
+	server.AddMethod("ns=1;s=Boiler.SetPoint", func(inputs []*ua.Variant) ([]*ua.Variant, ua.StatusCode) {
+		if len(inputs) != 1 {
+			return nil, ua.StatusBadArgumentsMissing
+		}
+		return []*ua.Variant{ua.MustVariant(inputs[0].Float())}, ua.StatusOK
+	})
+
+	var mu sync.Mutex
+	var status v1alpha1.OPCUADeviceStatus
+	var getStatus = func() v1alpha1.OPCUADeviceStatus {
+		mu.Lock()
+		defer mu.Unlock()
+		return *status.DeepCopy()
+	}
+	var device = NewDevice(zap.WrapAsLogr(zap.NewDevelopmentLogger()), metav1.ObjectMeta{Name: "boiler"}, func(in *v1alpha1.OPCUADevice) error {
+		mu.Lock()
+		defer mu.Unlock()
+		status = *in.Status.DeepCopy()
+		return nil
+	})
+	defer device.Shutdown()
+
+	var spec = v1alpha1.OPCUADeviceSpec{
+		Parameters: &v1alpha1.OPCUADeviceParameters{
+			SyncInterval: metav1.Duration{Duration: 100 * time.Millisecond},
+			Timeout:      metav1.Duration{Duration: 5 * time.Second},
+		},
+		Protocol: v1alpha1.OPCUADeviceProtocol{
+			Endpoint:       server.endpoint,
+			SecurityPolicy: "None",
+			SecurityMode:   "None",
+		},
+		Methods: []v1alpha1.OPCUADeviceMethod{
+			{
+				Name:         "setPoint",
+				ObjectNodeID: "ns=1;s=Boiler",
+				MethodNodeID: "ns=1;s=Boiler.SetPoint",
+				InputArguments: []v1alpha1.OPCUADeviceArgument{
+					{Type: v1alpha1.OPCUADevicePropertyTypeDouble, Value: "65.5"},
+				},
+			},
+			{
+				Name:         "unknown",
+				ObjectNodeID: "ns=1;s=Boiler",
+				MethodNodeID: "ns=1;s=Boiler.Unknown",
+			},
+		},
+		Events: []v1alpha1.OPCUADeviceEvent{
+			{
+				Name:   "alarm",
+				NodeID: "ns=1;s=Boiler",
+				SelectClauses: []v1alpha1.OPCUADeviceEventSelectClause{
+					{Name: "message", BrowsePath: "Message"},
+					{Name: "severity", BrowsePath: "Severity"},
+				},
+				WhereClause: []v1alpha1.OPCUADeviceEventWhereClause{
+					{BrowsePath: "Severity", Operator: v1alpha1.OPCUADeviceEventFilterOperatorGreaterThanOrEqual, Type: v1alpha1.OPCUADevicePropertyTypeUInt16, Value: "500"},
+				},
+			},
+		},
+	}
+	var err = device.Configure(nil, &v1alpha1.OPCUADevice{Spec: *spec.DeepCopy()})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// doesn't call methods without commands
+	assert.Empty(t, getStatus().Methods)
+	assert.Len(t, server.Calls(), 0)
+
+	// calls method by command
+	var executor, ok = device.(connection.CommandExecutor)
+	if !assert.True(t, ok) {
+		return
+	}
+	output, err := executor.Execute("setPoint", nil)
+	if assert.NoError(t, err) {
+		assert.JSONEq(t, `{"outputArguments":[{"type":"double","value":"65.5"}]}`, string(output))
+	}
+	var statusMethods = getStatus().Methods
+	if assert.Len(t, statusMethods, 1) {
+		assert.Equal(t, "setPoint", statusMethods[0].Name)
+		assert.Empty(t, statusMethods[0].Error)
+		assert.Equal(t, []v1alpha1.OPCUADeviceArgument{{Type: v1alpha1.OPCUADevicePropertyTypeDouble, Value: "65.5"}}, statusMethods[0].OutputArguments)
+		assert.NotNil(t, statusMethods[0].CalledAt)
+	}
+	assert.Len(t, server.Calls(), 1)
+
+	// overrides the input arguments by command
+	output, err = executor.Execute("setPoint", []byte(`{"inputArguments":[{"type":"double","value":"70"}]}`))
+	if assert.NoError(t, err) {
+		assert.JSONEq(t, `{"outputArguments":[{"type":"double","value":"70"}]}`, string(output))
+	}
+	assert.Equal(t, "70", getStatus().Methods[0].OutputArguments[0].Value)
+	assert.Len(t, server.Calls(), 2)
+
+	// feedbacks the calling error
+	_, err = executor.Execute("unknown", nil)
+	assert.Error(t, err)
+	statusMethods = getStatus().Methods
+	if assert.Len(t, statusMethods, 2) {
+		assert.Equal(t, "unknown", statusMethods[1].Name)
+		assert.NotEmpty(t, statusMethods[1].Error)
+	}
+	assert.Len(t, server.Calls(), 3)
+
+	// rejects the undeclared method
+	_, err = executor.Execute("reset", nil)
+	assert.Error(t, err)
+	assert.Len(t, server.Calls(), 3)
+
+	// doesn't call the changed methods again, but drops the status of removed methods
+	spec.Methods[0].InputArguments[0].Value = "80"
+	spec.Methods = spec.Methods[:1]
+	err = device.Configure(nil, &v1alpha1.OPCUADevice{Spec: *spec.DeepCopy()})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Len(t, server.Calls(), 3)
+	statusMethods = getStatus().Methods
+	if assert.Len(t, statusMethods, 1) {
+		assert.Equal(t, "70", statusMethods[0].OutputArguments[0].Value)
+	}
+
+	// receives events
+	var newMessage = func(text string) *ua.Variant {
+		var message = &ua.LocalizedText{Text: text}
+		message.UpdateMask()
+		return ua.MustVariant(message)
+	}
+	server.FireEvent("ns=1;s=Boiler", "i=2041", map[string]*ua.Variant{
+		"Message":  newMessage("warm"),
+		"Severity": ua.MustVariant(uint16(100)),
+	})
+	server.FireEvent("ns=1;s=Boiler", "i=2041", map[string]*ua.Variant{
+		"Message":  newMessage("overheat"),
+		"Severity": ua.MustVariant(uint16(800)),
+	})
+	assert.Eventually(t, func() bool {
+		var statusEvents = getStatus().Events
+		return len(statusEvents) == 1 && statusEvents[0].ReceivedAt != nil
+	}, 5*time.Second, 50*time.Millisecond)
+	var statusEvents = getStatus().Events
+	if assert.Len(t, statusEvents, 1) {
+		assert.Equal(t, "alarm", statusEvents[0].Name)
+		assert.Equal(t, map[string]string{"message": "overheat", "severity": "800"}, statusEvents[0].Fields)
+	}
+
+	// resubscribes the changed events
+	spec.Events[0].WhereClause = nil
+	err = device.Configure(nil, &v1alpha1.OPCUADevice{Spec: *spec.DeepCopy()})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Nil(t, getStatus().Events[0].ReceivedAt)
+	// the stale event subscription is cancelled in backend.
+	assert.Eventually(t, func() bool {
+		var eventItems int
+		for _, item := range server.Items() {
+			if item.attributeID == ua.AttributeIDEventNotifier {
+				if item.filter.WhereClause.Elements != nil {
+					return false
+				}
+				eventItems++
+			}
+		}
+		return eventItems == 1
+	}, 5*time.Second, 50*time.Millisecond)
+}
+
+func TestOPCUADevice_Properties(t *testing.T) {
+	var server = newTestServer(t)
+	defer server.Close()
+
+	var mu sync.Mutex
+	var status v1alpha1.OPCUADeviceStatus
+	var getStatusProperty = func(name string) v1alpha1.OPCUADeviceStatusProperty {
+		mu.Lock()
+		defer mu.Unlock()
+		if prop := findStatusProperty(status.Properties, name); prop != nil {
+			return *prop.DeepCopy()
+		}
+		return v1alpha1.OPCUADeviceStatusProperty{}
+	}
+	var device = NewDevice(zap.WrapAsLogr(zap.NewDevelopmentLogger()), metav1.ObjectMeta{Name: "boiler"}, func(in *v1alpha1.OPCUADevice) error {
+		mu.Lock()
+		defer mu.Unlock()
+		status = *in.Status.DeepCopy()
+		return nil
+	})
+	defer device.Shutdown()
+
+	var getValueItems = func() map[string]testMonitoredItem {
+		var ret = map[string]testMonitoredItem{}
+		for _, item := range server.Items() {
+			if item.attributeID == ua.AttributeIDValue {
+				ret[item.nodeID] = item
+			}
+		}
+		return ret
+	}
+
+	var spec = v1alpha1.OPCUADeviceSpec{
+		Parameters: &v1alpha1.OPCUADeviceParameters{
+			SyncInterval: metav1.Duration{Duration: 100 * time.Millisecond},
+			Timeout:      metav1.Duration{Duration: 5 * time.Second},
+		},
+		Protocol: v1alpha1.OPCUADeviceProtocol{
+			Endpoint:       server.endpoint,
+			SecurityPolicy: "None",
+			SecurityMode:   "None",
+		},
+		Properties: []v1alpha1.OPCUADeviceProperty{
+			{
+				Name:     "temperature",
+				Type:     v1alpha1.OPCUADevicePropertyTypeDouble,
+				ReadOnly: true,
+				Visitor: v1alpha1.OPCUADevicePropertyVisitor{
+					NodeID: "ns=1;s=Temperature",
+				},
+			},
+			{
+				Name:         "humidity",
+				Type:         v1alpha1.OPCUADevicePropertyTypeDouble,
+				ReadOnly:     true,
+				SyncInterval: &metav1.Duration{Duration: 200 * time.Millisecond},
+				Visitor: v1alpha1.OPCUADevicePropertyVisitor{
+					NodeID:   "ns=1;s=Humidity",
+					Deadband: "1",
+				},
+			},
+		},
+	}
+	var err = device.Configure(nil, &v1alpha1.OPCUADevice{Spec: *spec.DeepCopy()})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// groups the properties by sync interval
+	var items = getValueItems()
+	if !assert.Len(t, items, 2) {
+		return
+	}
+	var temperatureItem, humidityItem = items["ns=1;s=Temperature"], items["ns=1;s=Humidity"]
+	assert.NotEqual(t, temperatureItem.subscriptionID, humidityItem.subscriptionID)
+	assert.Nil(t, temperatureItem.parameters.Filter.Value)
+	if assert.NotNil(t, humidityItem.parameters.Filter) {
+		assert.Equal(t, &ua.DataChangeFilter{
+			Trigger:       ua.DataChangeTriggerStatusValue,
+			DeadbandType:  uint32(ua.DeadbandTypeAbsolute),
+			DeadbandValue: 1,
+		}, humidityItem.parameters.Filter.Value)
+	}
+
+	// receives values
+	server.ChangeValue("ns=1;s=Temperature", ua.MustVariant(float64(25.5)))
+	server.ChangeValue("ns=1;s=Humidity", ua.MustVariant(float64(60)))
+	assert.Eventually(t, func() bool {
+		return getStatusProperty("temperature").Value == "25.5" && getStatusProperty("humidity").Value == "60"
+	}, 5*time.Second, 50*time.Millisecond)
+
+	// keeps the monitored items if only the expressions changed
+	spec.Properties[0].Visitor.ReadExpression = "x * 2"
+	err = device.Configure(nil, &v1alpha1.OPCUADevice{Spec: *spec.DeepCopy()})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "25.5", getStatusProperty("temperature").Value)
+	items = getValueItems()
+	assert.Equal(t, temperatureItem.id, items["ns=1;s=Temperature"].id)
+	assert.Equal(t, humidityItem.id, items["ns=1;s=Humidity"].id)
+	server.ChangeValue("ns=1;s=Temperature", ua.MustVariant(float64(10)))
+	assert.Eventually(t, func() bool {
+		return getStatusProperty("temperature").Value == "20"
+	}, 5*time.Second, 50*time.Millisecond)
+
+	// only re-creates the changed monitored items
+	spec.Properties[1].Visitor.Deadband = "5%"
+	err = device.Configure(nil, &v1alpha1.OPCUADevice{Spec: *spec.DeepCopy()})
+	if !assert.NoError(t, err) {
+		return
+	}
+	items = getValueItems()
+	if assert.Len(t, items, 2) {
+		assert.Equal(t, temperatureItem.id, items["ns=1;s=Temperature"].id)
+		assert.NotEqual(t, humidityItem.id, items["ns=1;s=Humidity"].id)
+		assert.Equal(t, humidityItem.subscriptionID, items["ns=1;s=Humidity"].subscriptionID)
+	}
+
+	// deletes the subscription without monitored items
+	spec.Properties = spec.Properties[:1]
+	err = device.Configure(nil, &v1alpha1.OPCUADevice{Spec: *spec.DeepCopy()})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Eventually(t, func() bool {
+		var items = getValueItems()
+		return len(items) == 1 && items["ns=1;s=Temperature"].id == temperatureItem.id
+	}, 5*time.Second, 50*time.Millisecond)
+	assert.Equal(t, v1alpha1.OPCUADeviceStatusProperty{}, getStatusProperty("humidity"))
+}
+
+func TestOPCUADevice_Reconnect(t *testing.T) {
+	var server = newTestServer(t)
+	defer server.Close()
+
+	var mu sync.Mutex
+	var status v1alpha1.OPCUADeviceStatus
+	var qualities []v1alpha1.OPCUADevicePropertyQuality
+	var getStatus = func() v1alpha1.OPCUADeviceStatus {
+		mu.Lock()
+		defer mu.Unlock()
+		return *status.DeepCopy()
+	}
+	var device = NewDevice(zap.WrapAsLogr(zap.NewDevelopmentLogger()), metav1.ObjectMeta{Name: "boiler"}, func(in *v1alpha1.OPCUADevice) error {
+		mu.Lock()
+		defer mu.Unlock()
+		status = *in.Status.DeepCopy()
+		if prop := findStatusProperty(status.Properties, "temperature"); prop != nil {
+			qualities = append(qualities, prop.Quality)
+		}
+		return nil
+	})
+	defer device.Shutdown()
+
+	var spec = v1alpha1.OPCUADeviceSpec{
+		Parameters: &v1alpha1.OPCUADeviceParameters{
+			SyncInterval:            metav1.Duration{Duration: 100 * time.Millisecond},
+			Timeout:                 metav1.Duration{Duration: 2 * time.Second},
+			ReconnectInitialBackoff: metav1.Duration{Duration: 100 * time.Millisecond},
+		},
+		Protocol: v1alpha1.OPCUADeviceProtocol{
+			Endpoint:       server.endpoint,
+			SecurityPolicy: "None",
+			SecurityMode:   "None",
+		},
+		Properties: []v1alpha1.OPCUADeviceProperty{
+			{
+				Name:     "temperature",
+				Type:     v1alpha1.OPCUADevicePropertyTypeDouble,
+				ReadOnly: true,
+				Visitor: v1alpha1.OPCUADevicePropertyVisitor{
+					NodeID: "ns=1;s=Temperature",
+				},
+			},
+		},
+	}
+	var err = device.Configure(nil, &v1alpha1.OPCUADevice{Spec: *spec.DeepCopy()})
+	if !assert.NoError(t, err) {
+		return
+	}
+	if session := getStatus().Session; assert.NotNil(t, session) {
+		assert.Equal(t, v1alpha1.OPCUADeviceSessionStateConnected, session.State)
+		assert.NotNil(t, session.ConnectedAt)
+	}
+
+	// reports the recovery after restarting server
+	server.Restart()
+	assert.Eventually(t, func() bool {
+		var session = getStatus().Session
+		return session != nil && session.State == v1alpha1.OPCUADeviceSessionStateConnected && session.LostAt != nil
+	}, 5*time.Second, 50*time.Millisecond)
+	var session = getStatus().Session
+	assert.Equal(t, v1alpha1.OPCUADeviceSessionRecoveryRecreated, session.Recovery)
+	assert.Equal(t, int32(1), session.ReconnectAttempts)
+	mu.Lock()
+	assert.Contains(t, qualities, v1alpha1.OPCUADevicePropertyQualityBad)
+	mu.Unlock()
+
+	// receives the value from the re-created subscription
+	server.ChangeValue("ns=1;s=Temperature", ua.MustVariant(float64(21.5)))
+	assert.Eventually(t, func() bool {
+		var prop = findStatusProperty(getStatus().Properties, "temperature")
+		return prop != nil && prop.Value == "21.5" && prop.Quality == v1alpha1.OPCUADevicePropertyQualityGood
+	}, 5*time.Second, 50*time.Millisecond)
+}
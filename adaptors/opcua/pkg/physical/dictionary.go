@@ -0,0 +1,584 @@
+package physical
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"encoding/xml"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gopcua/opcua/id"
+	"github.com/gopcua/opcua/ua"
+	"github.com/pkg/errors"
+
+	"github.com/rancher/octopus/adaptors/opcua/api/v1alpha1"
+)
+
+// maxStructureDepth is the maximum depth of the nested structures,
+// which prevents the self-referencing structure from exhausting the stack.
+const maxStructureDepth = 32
+
+// structureBrowser browses the data type nodes of OPC-UA server.
+type structureBrowser interface {
+	// References returns the references of the given node in the given type and direction.
+	References(nodeID *ua.NodeID, referenceType uint32, direction ua.BrowseDirection) ([]*ua.ReferenceDescription, error)
+	// Read returns the given attribute values of the given nodes in order.
+	Read(nodeIDs []*ua.NodeID, attributeID ua.AttributeID) ([]*ua.DataValue, error)
+}
+
+// isStructureProperty returns true if the value of property is the extension object or the array of extension object.
+func isStructureProperty(prop v1alpha1.OPCUADeviceProperty) bool {
+	switch prop.Type {
+	case v1alpha1.OPCUADevicePropertyTypeExtensionObject:
+		return true
+	case v1alpha1.OPCUADevicePropertyTypeArray:
+		return prop.ElementType == v1alpha1.OPCUADevicePropertyTypeExtensionObject
+	}
+	return false
+}
+
+// structureBody keeps the binary body of the extension object which is unknown to the gopcua library,
+// the body is decoded to JSON via the structure definition.
+type structureBody struct {
+	raw []byte
+}
+
+func (b *structureBody) Decode(data []byte) (int, error) {
+	b.raw = append([]byte(nil), data...)
+	return len(data), nil
+}
+
+func (b *structureBody) Encode() ([]byte, error) {
+	return b.raw, nil
+}
+
+// structures holds the structure definitions indexed by the ID of binary encoding node.
+var structures = struct {
+	sync.RWMutex
+	definitions map[string]*structureDefinition
+}{
+	definitions: make(map[string]*structureDefinition),
+}
+
+// registerStructure registers the structure definition of the given binary encoding,
+// so that the gopcua library can decode the extension object of the encoding.
+func registerStructure(encodingID *ua.NodeID, def *structureDefinition) {
+	structures.Lock()
+	defer structures.Unlock()
+
+	var key = encodingID.String()
+	if _, exist := structures.definitions[key]; !exist {
+		// the gopcua library panics if the same extension object is registered twice.
+		ua.RegisterExtensionObject(encodingID, new(structureBody))
+	}
+	structures.definitions[key] = def
+}
+
+// getStructure returns the structure definition of the given binary encoding.
+func getStructure(encodingID *ua.NodeID) *structureDefinition {
+	structures.RLock()
+	defer structures.RUnlock()
+	return structures.definitions[encodingID.String()]
+}
+
+// resolveStructure resolves the structure definition of the given node's data type via the data type dictionary,
+// the data type dictionary is found by following the "Default Binary" encoding and its description of data type.
+func resolveStructure(browser structureBrowser, nodeID *ua.NodeID) (*ua.NodeID, *structureDefinition, error) {
+	// finds the data type of node
+	var values, err = browser.Read([]*ua.NodeID{nodeID}, ua.AttributeIDDataType)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to read the data type of node %s", nodeID)
+	}
+	var dataTypeID *ua.NodeID
+	if values[0].Value != nil {
+		dataTypeID = values[0].Value.NodeID()
+	}
+	if dataTypeID == nil {
+		return nil, nil, errors.Errorf("failed to read the data type of node %s: %v", nodeID, values[0].Status)
+	}
+
+	// finds the binary encoding of data type
+	var encodingID *ua.NodeID
+	refs, err := browser.References(dataTypeID, id.HasEncoding, ua.BrowseDirectionForward)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to browse the encodings of data type %s", dataTypeID)
+	}
+	for _, ref := range refs {
+		if ref.BrowseName != nil && ref.BrowseName.Name == "Default Binary" && ref.NodeID != nil {
+			encodingID = ref.NodeID.NodeID
+			break
+		}
+	}
+	if encodingID == nil {
+		return nil, nil, errors.Errorf("data type %s doesn't have binary encoding", dataTypeID)
+	}
+	if encodingID.Namespace() == 0 {
+		return nil, nil, errors.Errorf("data type %s is not a custom structure", dataTypeID)
+	}
+	if def := getStructure(encodingID); def != nil {
+		return encodingID, def, nil
+	}
+
+	// finds the description of binary encoding
+	refs, err = browser.References(encodingID, id.HasDescription, ua.BrowseDirectionForward)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to browse the description of encoding %s", encodingID)
+	}
+	if len(refs) == 0 || refs[0].NodeID == nil {
+		return nil, nil, errors.Errorf("encoding %s doesn't have description", encodingID)
+	}
+	var descriptionID = refs[0].NodeID.NodeID
+	values, err = browser.Read([]*ua.NodeID{descriptionID}, ua.AttributeIDValue)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to read the description %s", descriptionID)
+	}
+	if values[0].Value == nil {
+		return nil, nil, errors.Errorf("failed to read the description %s: %v", descriptionID, values[0].Status)
+	}
+	var name = VariantToString(values[0].Value.Type(), values[0].Value)
+
+	// finds the data type dictionary which contains the description
+	refs, err = browser.References(descriptionID, id.HasComponent, ua.BrowseDirectionInverse)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to browse the dictionary of description %s", descriptionID)
+	}
+	if len(refs) == 0 || refs[0].NodeID == nil {
+		return nil, nil, errors.Errorf("description %s doesn't belong to any dictionary", descriptionID)
+	}
+	var dictionaryID = refs[0].NodeID.NodeID
+	values, err = browser.Read([]*ua.NodeID{dictionaryID}, ua.AttributeIDValue)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to read the dictionary %s", dictionaryID)
+	}
+	if values[0].Value == nil {
+		return nil, nil, errors.Errorf("failed to read the dictionary %s: %v", dictionaryID, values[0].Status)
+	}
+	dictionary, err := parseTypeDictionary(values[0].Value.ByteString())
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to parse the dictionary %s", dictionaryID)
+	}
+
+	var def = dictionary.structure(name)
+	if def == nil {
+		return nil, nil, errors.Errorf("dictionary %s doesn't define structure %s", dictionaryID, name)
+	}
+	registerStructure(encodingID, def)
+	return encodingID, def, nil
+}
+
+// typeDictionary is the OPC binary schema of data types.
+//
+// Specification: Part 3, 5.8.2
+type typeDictionary struct {
+	StructuredTypes []*structureDefinition `xml:"StructuredType"`
+	EnumeratedTypes []struct {
+		Name string `xml:"Name,attr"`
+	} `xml:"EnumeratedType"`
+}
+
+// structure returns the structure definition of the given name.
+func (d *typeDictionary) structure(name string) *structureDefinition {
+	for _, def := range d.StructuredTypes {
+		if def.Name == name {
+			return def
+		}
+	}
+	return nil
+}
+
+// isEnumeration returns true if the given name is an enumeration.
+func (d *typeDictionary) isEnumeration(name string) bool {
+	for _, enum := range d.EnumeratedTypes {
+		if enum.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// structureDefinition is the structured type of data type dictionary.
+type structureDefinition struct {
+	Name   string           `xml:"Name,attr"`
+	Fields []structureField `xml:"Field"`
+
+	dictionary *typeDictionary
+}
+
+// structureField is the field of structured type,
+// the field is an array if the length field is specified.
+type structureField struct {
+	Name        string `xml:"Name,attr"`
+	TypeName    string `xml:"TypeName,attr"`
+	LengthField string `xml:"LengthField,attr"`
+}
+
+func parseTypeDictionary(data []byte) (*typeDictionary, error) {
+	var dictionary typeDictionary
+	if err := xml.Unmarshal(data, &dictionary); err != nil {
+		return nil, err
+	}
+	for _, def := range dictionary.StructuredTypes {
+		def.dictionary = &dictionary
+	}
+	return &dictionary, nil
+}
+
+// decode decodes the binary body of extension object to the JSON object.
+func (s *structureDefinition) decode(data []byte) (map[string]interface{}, error) {
+	var buf = ua.NewBuffer(data)
+	return s.read(buf, 0)
+}
+
+// encode encodes the JSON object to the binary body of extension object.
+func (s *structureDefinition) encode(input string) ([]byte, error) {
+	var decoder = json.NewDecoder(strings.NewReader(input))
+	decoder.UseNumber()
+	var obj map[string]interface{}
+	if err := decoder.Decode(&obj); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal %s structure", s.Name)
+	}
+
+	var buf = ua.NewBuffer(nil)
+	if err := s.write(buf, obj, 0); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// lengthFields returns the length fields which are indexed by the name of their array fields.
+func (s *structureDefinition) lengthFields() map[string]string {
+	var ret = make(map[string]string)
+	for _, field := range s.Fields {
+		if field.LengthField != "" {
+			ret[field.LengthField] = field.Name
+		}
+	}
+	return ret
+}
+
+func (s *structureDefinition) read(buf *ua.Buffer, depth int) (map[string]interface{}, error) {
+	if depth > maxStructureDepth {
+		return nil, errors.Errorf("%s structure is nested deeper than %d", s.Name, maxStructureDepth)
+	}
+
+	var lengthFields = s.lengthFields()
+	var lengths = make(map[string]int32)
+	var ret = make(map[string]interface{}, len(s.Fields))
+	for _, field := range s.Fields {
+		// the length field is implied by the length of array.
+		if _, isLength := lengthFields[field.Name]; isLength {
+			lengths[field.Name] = buf.ReadInt32()
+			if err := buf.Error(); err != nil {
+				return nil, errors.Wrapf(err, "failed to read field %s of %s structure", field.Name, s.Name)
+			}
+			continue
+		}
+
+		if field.LengthField == "" {
+			var value, err = s.readField(buf, field.TypeName, depth)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to read field %s of %s structure", field.Name, s.Name)
+			}
+			ret[field.Name] = value
+			continue
+		}
+
+		var length = lengths[field.LengthField]
+		if length < 0 {
+			ret[field.Name] = nil
+			continue
+		}
+		// every element takes one byte at least, so the length cannot exceed the remaining bytes.
+		if int(length) > buf.Len() {
+			return nil, errors.Errorf("length %d of field %s of %s structure exceeds the remaining %d bytes", length, field.Name, s.Name, buf.Len())
+		}
+		var values = make([]interface{}, 0, length)
+		for i := int32(0); i < length; i++ {
+			var value, err = s.readField(buf, field.TypeName, depth)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to read field %s[%d] of %s structure", field.Name, i, s.Name)
+			}
+			values = append(values, value)
+		}
+		ret[field.Name] = values
+	}
+	return ret, buf.Error()
+}
+
+func (s *structureDefinition) readField(buf *ua.Buffer, typeName string, depth int) (interface{}, error) {
+	var prefix, name = splitTypeName(typeName)
+	switch prefix {
+	case "opc", "ua":
+		var value interface{}
+		switch name {
+		case "Boolean":
+			value = buf.ReadBool()
+		case "SByte":
+			value = buf.ReadInt8()
+		case "Byte":
+			value = buf.ReadByte()
+		case "Int16":
+			value = buf.ReadInt16()
+		case "UInt16":
+			value = buf.ReadUint16()
+		case "Int32":
+			value = buf.ReadInt32()
+		case "UInt32", "StatusCode":
+			value = buf.ReadUint32()
+		case "Int64":
+			value = buf.ReadInt64()
+		case "UInt64":
+			value = buf.ReadUint64()
+		case "Float":
+			value = buf.ReadFloat32()
+		case "Double":
+			value = buf.ReadFloat64()
+		case "String", "CharArray":
+			value = buf.ReadString()
+		case "DateTime":
+			value = buf.ReadTime().UTC().Format(time.RFC3339Nano)
+		case "ByteString":
+			value = buf.ReadBytes()
+		case "Guid":
+			var guid = new(ua.GUID)
+			if buf.ReadStruct(guid); buf.Error() == nil {
+				value = guid.String()
+			}
+		case "LocalizedText":
+			var text = new(ua.LocalizedText)
+			buf.ReadStruct(text)
+			value = text.Text
+		case "QualifiedName":
+			var qualifiedName = new(ua.QualifiedName)
+			buf.ReadStruct(qualifiedName)
+			value = qualifiedName.Name
+		case "NodeId":
+			var nodeID = new(ua.NodeID)
+			if buf.ReadStruct(nodeID); buf.Error() == nil {
+				value = nodeID.String()
+			}
+		default:
+			return nil, errors.Errorf("unsupported type %s", typeName)
+		}
+		return value, buf.Error()
+	}
+
+	if s.dictionary != nil {
+		if s.dictionary.isEnumeration(name) {
+			return buf.ReadInt32(), buf.Error()
+		}
+		if def := s.dictionary.structure(name); def != nil {
+			return def.read(buf, depth+1)
+		}
+	}
+	return nil, errors.Errorf("unsupported type %s", typeName)
+}
+
+func (s *structureDefinition) write(buf *ua.Buffer, obj map[string]interface{}, depth int) error {
+	if depth > maxStructureDepth {
+		return errors.Errorf("%s structure is nested deeper than %d", s.Name, maxStructureDepth)
+	}
+
+	var lengthFields = s.lengthFields()
+	for _, field := range s.Fields {
+		if arrayField, isLength := lengthFields[field.Name]; isLength {
+			switch values := obj[arrayField].(type) {
+			case nil:
+				buf.WriteInt32(-1)
+			case []interface{}:
+				buf.WriteInt32(int32(len(values)))
+			default:
+				return errors.Errorf("field %s of %s structure is not an array", arrayField, s.Name)
+			}
+			continue
+		}
+
+		if field.LengthField == "" {
+			if err := s.writeField(buf, field.TypeName, obj[field.Name], depth); err != nil {
+				return errors.Wrapf(err, "failed to write field %s of %s structure", field.Name, s.Name)
+			}
+			continue
+		}
+
+		var values, _ = obj[field.Name].([]interface{})
+		for i, value := range values {
+			if err := s.writeField(buf, field.TypeName, value, depth); err != nil {
+				return errors.Wrapf(err, "failed to write field %s[%d] of %s structure", field.Name, i, s.Name)
+			}
+		}
+	}
+	return buf.Error()
+}
+
+func (s *structureDefinition) writeField(buf *ua.Buffer, typeName string, value interface{}, depth int) error {
+	var prefix, name = splitTypeName(typeName)
+	if prefix != "opc" && prefix != "ua" {
+		if s.dictionary != nil {
+			if s.dictionary.isEnumeration(name) {
+				var input, err = fieldToString(value)
+				if err != nil {
+					return err
+				}
+				v, err := parseInt(input, 32)
+				if err != nil {
+					return err
+				}
+				buf.WriteInt32(int32(v))
+				return nil
+			}
+			if def := s.dictionary.structure(name); def != nil {
+				if value == nil {
+					return def.write(buf, map[string]interface{}{}, depth+1)
+				}
+				var obj, ok = value.(map[string]interface{})
+				if !ok {
+					return errors.Errorf("%v is not a %s object", value, name)
+				}
+				return def.write(buf, obj, depth+1)
+			}
+		}
+		return errors.Errorf("unsupported type %s", typeName)
+	}
+
+	var input, err = fieldToString(value)
+	if err != nil {
+		return err
+	}
+	switch name {
+	case "Boolean":
+		var v bool
+		if input != "" {
+			v, err = strconv.ParseBool(input)
+		}
+		buf.WriteBool(v)
+	case "SByte":
+		var v int64
+		v, err = parseInt(input, 8)
+		buf.WriteInt8(int8(v))
+	case "Byte":
+		var v uint64
+		v, err = parseUint(input, 8)
+		buf.WriteByte(byte(v))
+	case "Int16":
+		var v int64
+		v, err = parseInt(input, 16)
+		buf.WriteInt16(int16(v))
+	case "UInt16":
+		var v uint64
+		v, err = parseUint(input, 16)
+		buf.WriteUint16(uint16(v))
+	case "Int32":
+		var v int64
+		v, err = parseInt(input, 32)
+		buf.WriteInt32(int32(v))
+	case "UInt32", "StatusCode":
+		var v uint64
+		v, err = parseUint(input, 32)
+		buf.WriteUint32(uint32(v))
+	case "Int64":
+		var v int64
+		v, err = parseInt(input, 64)
+		buf.WriteInt64(v)
+	case "UInt64":
+		var v uint64
+		v, err = parseUint(input, 64)
+		buf.WriteUint64(v)
+	case "Float":
+		var v float64
+		v, err = parseFloat(input, 32)
+		buf.WriteFloat32(float32(v))
+	case "Double":
+		var v float64
+		v, err = parseFloat(input, 64)
+		buf.WriteFloat64(v)
+	case "String", "CharArray":
+		buf.WriteString(input)
+	case "DateTime":
+		var v time.Time
+		if input != "" {
+			v, err = time.Parse(time.RFC3339Nano, input)
+		}
+		buf.WriteTime(v)
+	case "ByteString":
+		var v []byte
+		v, err = base64.StdEncoding.DecodeString(input)
+		buf.WriteByteString(v)
+	case "Guid":
+		var v = &ua.GUID{Data4: make([]byte, 8)}
+		if input != "" {
+			if v = ua.NewGUID(input); v == nil {
+				return errors.Errorf("invalid GUID %s", input)
+			}
+		}
+		buf.WriteStruct(v)
+	case "LocalizedText":
+		var v = &ua.LocalizedText{Text: input}
+		v.UpdateMask()
+		buf.WriteStruct(v)
+	case "QualifiedName":
+		buf.WriteStruct(&ua.QualifiedName{Name: input})
+	case "NodeId":
+		var v = ua.NewTwoByteNodeID(0)
+		if input != "" {
+			if v, err = ua.ParseNodeID(input); err != nil {
+				return err
+			}
+		}
+		buf.WriteStruct(v)
+	default:
+		return errors.Errorf("unsupported type %s", typeName)
+	}
+	if err != nil {
+		return err
+	}
+	return buf.Error()
+}
+
+// splitTypeName splits the qualified type name into the namespace prefix and the name,
+// e.g. "opc:Double" is split into "opc" and "Double".
+func splitTypeName(typeName string) (string, string) {
+	var idx = strings.Index(typeName, ":")
+	if idx < 0 {
+		return "", typeName
+	}
+	return typeName[:idx], typeName[idx+1:]
+}
+
+// fieldToString returns the string of the scalar value unmarshalled from JSON,
+// the nil value is returned as a blank string.
+func fieldToString(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	}
+	return "", errors.Errorf("%v is not a scalar value", value)
+}
+
+func parseInt(input string, bitSize int) (int64, error) {
+	if input == "" {
+		return 0, nil
+	}
+	return strconv.ParseInt(input, 10, bitSize)
+}
+
+func parseUint(input string, bitSize int) (uint64, error) {
+	if input == "" {
+		return 0, nil
+	}
+	return strconv.ParseUint(input, 10, bitSize)
+}
+
+func parseFloat(input string, bitSize int) (float64, error) {
+	if input == "" {
+		return 0, nil
+	}
+	return strconv.ParseFloat(input, bitSize)
+}
@@ -0,0 +1,296 @@
+package physical
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gopcua/opcua/id"
+	"github.com/gopcua/opcua/ua"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/rancher/octopus/adaptors/opcua/api/v1alpha1"
+)
+
+const testTypeDictionary = `<opc:TypeDictionary xmlns:opc="http://opcfoundation.org/BinarySchema/" xmlns:ua="http://opcfoundation.org/UA/" xmlns:tns="urn:octopus:test" TargetNamespace="urn:octopus:test">
+  <opc:Import Namespace="http://opcfoundation.org/UA/"/>
+  <opc:EnumeratedType Name="Mode" LengthInBits="32">
+    <opc:EnumeratedValue Name="Auto" Value="0"/>
+    <opc:EnumeratedValue Name="Manual" Value="1"/>
+  </opc:EnumeratedType>
+  <opc:StructuredType Name="Point" BaseType="ua:ExtensionObject">
+    <opc:Field Name="X" TypeName="opc:Double"/>
+    <opc:Field Name="Y" TypeName="opc:Double"/>
+  </opc:StructuredType>
+  <opc:StructuredType Name="Batch" BaseType="ua:ExtensionObject">
+    <opc:Field Name="ID" TypeName="opc:Guid"/>
+    <opc:Field Name="Name" TypeName="opc:String"/>
+    <opc:Field Name="Label" TypeName="ua:LocalizedText"/>
+    <opc:Field Name="Source" TypeName="ua:NodeId"/>
+    <opc:Field Name="Mode" TypeName="tns:Mode"/>
+    <opc:Field Name="Done" TypeName="opc:Boolean"/>
+    <opc:Field Name="NoOfPoints" TypeName="opc:Int32"/>
+    <opc:Field Name="Points" TypeName="tns:Point" LengthField="NoOfPoints"/>
+  </opc:StructuredType>
+</opc:TypeDictionary>`
+
+// fakeStructureBrowser simulates the data type nodes of OPC-UA server,
+// the references are indexed by "<node>/<reference type>/<direction>".
+type fakeStructureBrowser struct {
+	dataTypes  map[string]string
+	references map[string]*ua.ReferenceDescription
+	values     map[string]*ua.Variant
+}
+
+func (b fakeStructureBrowser) References(nodeID *ua.NodeID, referenceType uint32, direction ua.BrowseDirection) ([]*ua.ReferenceDescription, error) {
+	if ref, exist := b.references[fmt.Sprintf("%s/%d/%d", nodeID, referenceType, direction)]; exist {
+		return []*ua.ReferenceDescription{ref}, nil
+	}
+	return nil, nil
+}
+
+func (b fakeStructureBrowser) Read(nodeIDs []*ua.NodeID, attributeID ua.AttributeID) ([]*ua.DataValue, error) {
+	var ret = make([]*ua.DataValue, 0, len(nodeIDs))
+	for _, nodeID := range nodeIDs {
+		var value *ua.Variant
+		switch attributeID {
+		case ua.AttributeIDDataType:
+			if dataType, exist := b.dataTypes[nodeID.String()]; exist {
+				value = ua.MustVariant(mustParseNodeID(dataType))
+			}
+		case ua.AttributeIDValue:
+			value = b.values[nodeID.String()]
+		}
+		if value == nil {
+			ret = append(ret, &ua.DataValue{Status: ua.StatusBadAttributeIDInvalid})
+			continue
+		}
+		ret = append(ret, &ua.DataValue{Value: value})
+	}
+	return ret, nil
+}
+
+func mustParseNodeID(s string) *ua.NodeID {
+	var nodeID, err = ua.ParseNodeID(s)
+	if err != nil {
+		panic(err)
+	}
+	return nodeID
+}
+
+func newTestStructureBrowser() fakeStructureBrowser {
+	var newReference = func(nodeID string, browseName string) *ua.ReferenceDescription {
+		return &ua.ReferenceDescription{
+			NodeID:     ua.NewExpandedNodeID(false, false, mustParseNodeID(nodeID), "", 0),
+			BrowseName: &ua.QualifiedName{Name: browseName},
+		}
+	}
+	return fakeStructureBrowser{
+		dataTypes: map[string]string{
+			"ns=2;s=Batch":   "ns=2;i=3002",
+			"ns=2;s=Level":   "i=11",
+			"ns=2;s=Unknown": "ns=2;i=3009",
+		},
+		references: map[string]*ua.ReferenceDescription{
+			fmt.Sprintf("ns=2;i=3002/%d/%d", id.HasEncoding, ua.BrowseDirectionForward):    newReference("ns=2;i=5002", "Default Binary"),
+			fmt.Sprintf("ns=2;i=5002/%d/%d", id.HasDescription, ua.BrowseDirectionForward): newReference("ns=2;i=6002", "Batch"),
+			fmt.Sprintf("ns=2;i=6002/%d/%d", id.HasComponent, ua.BrowseDirectionInverse):   newReference("ns=2;i=6001", "TypeDictionary"),
+			fmt.Sprintf("i=11/%d/%d", id.HasEncoding, ua.BrowseDirectionForward):           newReference("i=13", "Default Binary"),
+			fmt.Sprintf("ns=2;i=3009/%d/%d", id.HasEncoding, ua.BrowseDirectionForward):    newReference("ns=2;i=5009", "Default Binary"),
+			fmt.Sprintf("ns=2;i=5009/%d/%d", id.HasDescription, ua.BrowseDirectionForward): newReference("ns=2;i=6009", "Unknown"),
+			fmt.Sprintf("ns=2;i=6009/%d/%d", id.HasComponent, ua.BrowseDirectionInverse):   newReference("ns=2;i=6001", "TypeDictionary"),
+		},
+		values: map[string]*ua.Variant{
+			"ns=2;i=6001": ua.MustVariant([]byte(testTypeDictionary)),
+			"ns=2;i=6002": ua.MustVariant("Batch"),
+			"ns=2;i=6009": ua.MustVariant("Unknown"),
+		},
+	}
+}
+
+func TestResolveStructure(t *testing.T) {
+	type expected struct {
+		encodingID string
+		name       string
+		err        bool
+	}
+
+	var testCases = []struct {
+		given    string
+		expected expected
+	}{
+		{
+			given: "ns=2;s=Batch",
+			expected: expected{
+				encodingID: "ns=2;i=5002",
+				name:       "Batch",
+			},
+		},
+		{
+			// cached
+			given: "ns=2;s=Batch",
+			expected: expected{
+				encodingID: "ns=2;i=5002",
+				name:       "Batch",
+			},
+		},
+		{
+			// built-in data type
+			given: "ns=2;s=Level",
+			expected: expected{
+				err: true,
+			},
+		},
+		{
+			// undefined in dictionary
+			given: "ns=2;s=Unknown",
+			expected: expected{
+				err: true,
+			},
+		},
+		{
+			// unknown node
+			given: "ns=2;s=Missing",
+			expected: expected{
+				err: true,
+			},
+		},
+	}
+
+	var browser = newTestStructureBrowser()
+	for i, tc := range testCases {
+		var encodingID, def, err = resolveStructure(browser, mustParseNodeID(tc.given))
+		if tc.expected.err {
+			assert.Error(t, err, "case %v", i+1)
+			continue
+		}
+		assert.NoError(t, err, "case %v", i+1)
+		assert.Equal(t, tc.expected.encodingID, encodingID.String(), "case %v", i+1)
+		assert.Equal(t, tc.expected.name, def.Name, "case %v", i+1)
+	}
+}
+
+func TestStructureVariant(t *testing.T) {
+	var encodingID, def, err = resolveStructure(newTestStructureBrowser(), mustParseNodeID("ns=2;s=Batch"))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// the variant goes through the binary encoding to verify the registered extension object.
+	var transfer = func(variant *ua.Variant) *ua.Variant {
+		var data, err = variant.Encode()
+		if !assert.NoError(t, err) {
+			return nil
+		}
+		var ret = new(ua.Variant)
+		if _, err = ret.Decode(data); !assert.NoError(t, err) {
+			return nil
+		}
+		return ret
+	}
+
+	var batch = `{"ID":"72962B91-FA75-4AE6-8D28-B404DC7DAF63","Name":"b1","Label":"first","Source":"ns=2;s=Line1","Mode":1,"Done":true,"Points":[{"X":1.5,"Y":2},{"X":3,"Y":-4.25}]}`
+	variant, err := stringToStructureVariant(encodingID, def, batch)
+	if !assert.NoError(t, err) {
+		return
+	}
+	var received = transfer(variant)
+	if assert.NotNil(t, received) {
+		assert.Equal(t, v1alpha1.OPCUADevicePropertyTypeExtensionObject, typeMap[received.Type()])
+		ret, err := OperateReadValue(received.Type(), received, "")
+		assert.NoError(t, err)
+		assert.JSONEq(t, batch, ret)
+	}
+
+	// fills the missing fields with zero values
+	variant, err = stringToStructureVariant(encodingID, def, `{"Name":"b2"}`)
+	if !assert.NoError(t, err) {
+		return
+	}
+	received = transfer(variant)
+	if assert.NotNil(t, received) {
+		assert.JSONEq(t, `{"ID":"00000000-0000-0000-0000-000000000000","Name":"b2","Label":"","Source":"i=0","Mode":0,"Done":false,"Points":null}`, VariantToString(received.Type(), received))
+	}
+
+	// array of structures
+	variant, err = stringToArrayVariant(v1alpha1.OPCUADevicePropertyTypeExtensionObject, `[{"Name":"b3","Points":[]},{"Name":"b4","Points":[]}]`, encodingID, def)
+	if !assert.NoError(t, err) {
+		return
+	}
+	received = transfer(variant)
+	if assert.NotNil(t, received) {
+		var propType, elementType = getPropertyType(received)
+		assert.Equal(t, v1alpha1.OPCUADevicePropertyTypeArray, propType)
+		assert.Equal(t, v1alpha1.OPCUADevicePropertyTypeExtensionObject, elementType)
+		assert.JSONEq(t, `[{"ID":"00000000-0000-0000-0000-000000000000","Name":"b3","Label":"","Source":"i=0","Mode":0,"Done":false,"Points":[]},{"ID":"00000000-0000-0000-0000-000000000000","Name":"b4","Label":"","Source":"i=0","Mode":0,"Done":false,"Points":[]}]`, VariantToString(received.Type(), received))
+	}
+
+	// invalid values
+	for _, input := range []string{
+		`[]`,
+		`{"Mode":"auto"}`,
+		`{"Points":{"X":1}}`,
+		`{"Points":[1]}`,
+		`{"ID":"abc"}`,
+	} {
+		_, err = stringToStructureVariant(encodingID, def, input)
+		assert.Error(t, err, "case %s", input)
+	}
+}
+
+func TestStructureDefinition_Malformed(t *testing.T) {
+	var dictionary, err = parseTypeDictionary([]byte(`<opc:TypeDictionary xmlns:opc="http://opcfoundation.org/BinarySchema/" xmlns:tns="urn:octopus:test" TargetNamespace="urn:octopus:test">
+  <opc:StructuredType Name="Samples">
+    <opc:Field Name="NoOfValues" TypeName="opc:Int32"/>
+    <opc:Field Name="Values" TypeName="opc:Byte" LengthField="NoOfValues"/>
+  </opc:StructuredType>
+  <opc:StructuredType Name="Tree">
+    <opc:Field Name="Child" TypeName="tns:Tree"/>
+  </opc:StructuredType>
+</opc:TypeDictionary>`))
+	if !assert.NoError(t, err) {
+		return
+	}
+	var samples = dictionary.structure("Samples")
+	var tree = dictionary.structure("Tree")
+
+	var testCases = []struct {
+		given    []byte
+		expected map[string]interface{}
+		err      bool
+	}{
+		{
+			given:    []byte{0x02, 0x00, 0x00, 0x00, 0x0a, 0x0b},
+			expected: map[string]interface{}{"Values": []interface{}{byte(0x0a), byte(0x0b)}},
+		},
+		{
+			given:    []byte{0xff, 0xff, 0xff, 0xff},
+			expected: map[string]interface{}{"Values": nil},
+		},
+		{
+			// truncated length
+			given: []byte{0x02, 0x00},
+			err:   true,
+		},
+		{
+			// length exceeds the remaining bytes
+			given: []byte{0xff, 0xff, 0xff, 0x7f, 0x0a},
+			err:   true,
+		},
+	}
+
+	for i, tc := range testCases {
+		var ret, err = samples.decode(tc.given)
+		if tc.err {
+			assert.Error(t, err, "case %v", i+1)
+			continue
+		}
+		assert.NoError(t, err, "case %v", i+1)
+		assert.Equal(t, tc.expected, ret, "case %v", i+1)
+	}
+
+	// stops the self-referencing structure
+	_, err = tree.decode(nil)
+	assert.Error(t, err)
+	_, err = tree.encode(`{}`)
+	assert.Error(t, err)
+}
@@ -0,0 +1,219 @@
+package physical
+
+import (
+	"regexp"
+
+	"github.com/gopcua/opcua"
+	"github.com/gopcua/opcua/id"
+	"github.com/gopcua/opcua/ua"
+	"github.com/pkg/errors"
+
+	"github.com/rancher/octopus/adaptors/opcua/api/v1alpha1"
+)
+
+// maxNodesPerRead is the maximum number of nodes in one read request when discovering.
+const maxNodesPerRead = 100
+
+// nodeBrowser browses the address space of OPC-UA server.
+type nodeBrowser interface {
+	// Browse returns the forward hierarchical references of the given node.
+	Browse(nodeID *ua.NodeID) ([]*ua.ReferenceDescription, error)
+	// Read returns the given attribute values of the given nodes in order.
+	Read(nodeIDs []*ua.NodeID, attributeID ua.AttributeID) ([]*ua.DataValue, error)
+}
+
+// clientBrowser browses via the OPC-UA client.
+type clientBrowser struct {
+	client *opcua.Client
+}
+
+func (b clientBrowser) Browse(nodeID *ua.NodeID) ([]*ua.ReferenceDescription, error) {
+	var mask = ua.NodeClassObject | ua.NodeClassVariable | ua.NodeClassMethod
+	return b.client.Node(nodeID).References(id.HierarchicalReferences, ua.BrowseDirectionForward, mask, true)
+}
+
+func (b clientBrowser) References(nodeID *ua.NodeID, referenceType uint32, direction ua.BrowseDirection) ([]*ua.ReferenceDescription, error) {
+	return b.client.Node(nodeID).References(referenceType, direction, ua.NodeClassAll, true)
+}
+
+func (b clientBrowser) Read(nodeIDs []*ua.NodeID, attributeID ua.AttributeID) ([]*ua.DataValue, error) {
+	var req = &ua.ReadRequest{
+		NodesToRead:        make([]*ua.ReadValueID, 0, len(nodeIDs)),
+		TimestampsToReturn: ua.TimestampsToReturnNeither,
+	}
+	for _, nodeID := range nodeIDs {
+		req.NodesToRead = append(req.NodesToRead, &ua.ReadValueID{NodeID: nodeID, AttributeID: attributeID})
+	}
+	var resp, err = b.client.Read(req)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Results) != len(nodeIDs) {
+		return nil, errors.Errorf("expected %d results, but got %d", len(nodeIDs), len(resp.Results))
+	}
+	return resp.Results, nil
+}
+
+var nodeClassMap = map[ua.NodeClass]v1alpha1.OPCUADeviceNodeClass{
+	ua.NodeClassObject:   v1alpha1.OPCUADeviceNodeClassObject,
+	ua.NodeClassVariable: v1alpha1.OPCUADeviceNodeClassVariable,
+	ua.NodeClassMethod:   v1alpha1.OPCUADeviceNodeClassMethod,
+}
+
+var dataTypeMap = map[string]v1alpha1.OPCUADevicePropertyType{
+	ua.NewNumericNodeID(0, id.Boolean).String():    v1alpha1.OPCUADevicePropertyTypeBoolean,
+	ua.NewNumericNodeID(0, id.Int16).String():      v1alpha1.OPCUADevicePropertyTypeInt16,
+	ua.NewNumericNodeID(0, id.UInt16).String():     v1alpha1.OPCUADevicePropertyTypeUInt16,
+	ua.NewNumericNodeID(0, id.Int32).String():      v1alpha1.OPCUADevicePropertyTypeInt32,
+	ua.NewNumericNodeID(0, id.UInt32).String():     v1alpha1.OPCUADevicePropertyTypeUInt32,
+	ua.NewNumericNodeID(0, id.Int64).String():      v1alpha1.OPCUADevicePropertyTypeInt64,
+	ua.NewNumericNodeID(0, id.UInt64).String():     v1alpha1.OPCUADevicePropertyTypeUInt64,
+	ua.NewNumericNodeID(0, id.Float).String():      v1alpha1.OPCUADevicePropertyTypeFloat,
+	ua.NewNumericNodeID(0, id.Double).String():     v1alpha1.OPCUADevicePropertyTypeDouble,
+	ua.NewNumericNodeID(0, id.String).String():     v1alpha1.OPCUADevicePropertyTypeString,
+	ua.NewNumericNodeID(0, id.DateTime).String():   v1alpha1.OPCUADevicePropertyTypeDatetime,
+	ua.NewNumericNodeID(0, id.ByteString).String(): v1alpha1.OPCUADevicePropertyTypeByteString,
+
+	ua.NewNumericNodeID(0, id.LocalizedText).String(): v1alpha1.OPCUADevicePropertyTypeLocalizedText,
+	ua.NewNumericNodeID(0, id.GUID).String():          v1alpha1.OPCUADevicePropertyTypeGUID,
+	ua.NewNumericNodeID(0, id.NodeID).String():        v1alpha1.OPCUADevicePropertyTypeNodeID,
+}
+
+var accessLevels = []struct {
+	mask ua.AccessLevelType
+	name string
+}{
+	{mask: ua.AccessLevelTypeCurrentRead, name: "CurrentRead"},
+	{mask: ua.AccessLevelTypeCurrentWrite, name: "CurrentWrite"},
+	{mask: ua.AccessLevelTypeHistoryRead, name: "HistoryRead"},
+	{mask: ua.AccessLevelTypeHistoryWrite, name: "HistoryWrite"},
+	{mask: ua.AccessLevelTypeSemanticChange, name: "SemanticChange"},
+	{mask: ua.AccessLevelTypeStatusWrite, name: "StatusWrite"},
+	{mask: ua.AccessLevelTypeTimestampWrite, name: "TimestampWrite"},
+}
+
+// discoverNodes browses the address space from the root node in breadth-first order,
+// and returns the nodes which match the given discovery.
+func discoverNodes(browser nodeBrowser, discovery *v1alpha1.OPCUADeviceDiscovery, stop <-chan struct{}) ([]v1alpha1.OPCUADeviceDiscoveredNode, error) {
+	var root, err = ua.ParseNodeID(discovery.GetRootNodeID())
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse root node ID %s", discovery.GetRootNodeID())
+	}
+	var pattern *regexp.Regexp
+	if discovery != nil && discovery.BrowseNamePattern != "" {
+		pattern, err = regexp.Compile(discovery.BrowseNamePattern)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to compile browse name pattern %s", discovery.BrowseNamePattern)
+		}
+	}
+	var nodeClasses = make(map[v1alpha1.OPCUADeviceNodeClass]bool)
+	for _, nodeClass := range discovery.GetNodeClasses() {
+		nodeClasses[nodeClass] = true
+	}
+	var maxDepth, maxNodes = discovery.GetMaxDepth(), discovery.GetMaxNodes()
+
+	type queued struct {
+		nodeID *ua.NodeID
+		depth  int
+	}
+	var queue = []queued{{nodeID: root}}
+	var visited = map[string]bool{root.String(): true}
+	var ret []v1alpha1.OPCUADeviceDiscoveredNode
+	var variableIndexes []int
+	var variableIDs []*ua.NodeID
+	for len(queue) != 0 && len(ret) < maxNodes {
+		select {
+		case <-stop:
+			return nil, errors.New("discovery is stopped")
+		default:
+		}
+
+		var current = queue[0]
+		queue = queue[1:]
+		var refs, err = browser.Browse(current.nodeID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to browse node %s", current.nodeID)
+		}
+		for _, ref := range refs {
+			if ref.NodeID == nil || ref.NodeID.NodeID == nil {
+				continue
+			}
+			var nodeID = ref.NodeID.NodeID
+			var key = nodeID.String()
+			if visited[key] {
+				continue
+			}
+			visited[key] = true
+
+			var depth = current.depth + 1
+			if depth < maxDepth && ref.NodeClass != ua.NodeClassMethod {
+				queue = append(queue, queued{nodeID: nodeID, depth: depth})
+			}
+
+			var nodeClass, supported = nodeClassMap[ref.NodeClass]
+			if !supported || !nodeClasses[nodeClass] {
+				continue
+			}
+			var browseName string
+			if ref.BrowseName != nil {
+				browseName = ref.BrowseName.Name
+			}
+			if pattern != nil && !pattern.MatchString(browseName) {
+				continue
+			}
+			if len(ret) >= maxNodes {
+				break
+			}
+			ret = append(ret, v1alpha1.OPCUADeviceDiscoveredNode{
+				NodeID:     key,
+				BrowseName: browseName,
+				NodeClass:  nodeClass,
+			})
+			if nodeClass == v1alpha1.OPCUADeviceNodeClassVariable {
+				variableIndexes = append(variableIndexes, len(ret)-1)
+				variableIDs = append(variableIDs, nodeID)
+			}
+		}
+	}
+
+	// reads the data type and access level of the discovered variables in batches
+	for start := 0; start < len(variableIDs); start += maxNodesPerRead {
+		var end = start + maxNodesPerRead
+		if end > len(variableIDs) {
+			end = len(variableIDs)
+		}
+		var ids = variableIDs[start:end]
+		dataTypes, err := browser.Read(ids, ua.AttributeIDDataType)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read the data type of variables")
+		}
+		levels, err := browser.Read(ids, ua.AttributeIDAccessLevel)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read the access level of variables")
+		}
+		for i := range ids {
+			var node = &ret[variableIndexes[start+i]]
+			if dv := dataTypes[i]; dv != nil && dv.Status == ua.StatusOK && dv.Value != nil {
+				if dataType := dv.Value.NodeID(); dataType != nil {
+					node.DataType = dataType.String()
+					node.Type = dataTypeMap[node.DataType]
+				}
+			}
+			if dv := levels[i]; dv != nil && dv.Status == ua.StatusOK && dv.Value != nil {
+				node.AccessLevel = getAccessLevel(ua.AccessLevelType(dv.Value.Uint()))
+			}
+		}
+	}
+	return ret, nil
+}
+
+// getAccessLevel returns the names of the given access level.
+func getAccessLevel(level ua.AccessLevelType) []string {
+	var ret []string
+	for _, l := range accessLevels {
+		if level&l.mask != 0 {
+			ret = append(ret, l.name)
+		}
+	}
+	return ret
+}
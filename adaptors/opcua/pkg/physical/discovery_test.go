@@ -0,0 +1,140 @@
+package physical
+
+import (
+	"testing"
+
+	"github.com/gopcua/opcua/id"
+	"github.com/gopcua/opcua/ua"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/rancher/octopus/adaptors/opcua/api/v1alpha1"
+)
+
+type fakeNode struct {
+	class       ua.NodeClass
+	dataType    uint32
+	accessLevel ua.AccessLevelType
+	children    []string
+}
+
+// fakeBrowser simulates the address space of OPC-UA server.
+type fakeBrowser map[string]fakeNode
+
+func (b fakeBrowser) Browse(nodeID *ua.NodeID) ([]*ua.ReferenceDescription, error) {
+	var ret []*ua.ReferenceDescription
+	for _, child := range b[nodeID.String()].children {
+		var childID, err = ua.ParseNodeID(child)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, &ua.ReferenceDescription{
+			NodeID:     ua.NewExpandedNodeID(false, false, childID, "", 0),
+			BrowseName: &ua.QualifiedName{NamespaceIndex: childID.Namespace(), Name: childID.StringID()},
+			NodeClass:  b[child].class,
+		})
+	}
+	return ret, nil
+}
+
+func (b fakeBrowser) Read(nodeIDs []*ua.NodeID, attributeID ua.AttributeID) ([]*ua.DataValue, error) {
+	var ret = make([]*ua.DataValue, 0, len(nodeIDs))
+	for _, nodeID := range nodeIDs {
+		var node = b[nodeID.String()]
+		var value *ua.Variant
+		switch attributeID {
+		case ua.AttributeIDDataType:
+			value = ua.MustVariant(ua.NewNumericNodeID(0, node.dataType))
+		case ua.AttributeIDAccessLevel:
+			value = ua.MustVariant(byte(node.accessLevel))
+		}
+		ret = append(ret, &ua.DataValue{Value: value})
+	}
+	return ret, nil
+}
+
+func TestDiscoverNodes(t *testing.T) {
+	var browser = fakeBrowser{
+		"i=85": {
+			class:    ua.NodeClassObject,
+			children: []string{"ns=1;s=Boiler", "ns=1;s=Pump"},
+		},
+		"ns=1;s=Boiler": {
+			class:    ua.NodeClassObject,
+			children: []string{"ns=1;s=Temperature", "ns=1;s=Reset", "ns=1;s=Sensors"},
+		},
+		"ns=1;s=Pump": {
+			class:    ua.NodeClassObject,
+			children: []string{"ns=1;s=Speed", "ns=1;s=Boiler"},
+		},
+		"ns=1;s=Temperature": {
+			class:       ua.NodeClassVariable,
+			dataType:    id.Double,
+			accessLevel: ua.AccessLevelTypeCurrentRead,
+		},
+		"ns=1;s=Speed": {
+			class:       ua.NodeClassVariable,
+			dataType:    id.Int32,
+			accessLevel: ua.AccessLevelTypeCurrentRead | ua.AccessLevelTypeCurrentWrite,
+		},
+		"ns=1;s=Reset": {
+			class: ua.NodeClassMethod,
+		},
+		"ns=1;s=Sensors": {
+			class:    ua.NodeClassObject,
+			children: []string{"ns=1;s=Pressure"},
+		},
+		"ns=1;s=Pressure": {
+			class:       ua.NodeClassVariable,
+			dataType:    id.Float,
+			accessLevel: ua.AccessLevelTypeCurrentRead,
+		},
+	}
+
+	var testCases = []struct {
+		name      string
+		discovery *v1alpha1.OPCUADeviceDiscovery
+		expected  []v1alpha1.OPCUADeviceDiscoveredNode
+	}{
+		{
+			name:      "default",
+			discovery: &v1alpha1.OPCUADeviceDiscovery{},
+			expected: []v1alpha1.OPCUADeviceDiscoveredNode{
+				{NodeID: "ns=1;s=Temperature", BrowseName: "Temperature", NodeClass: "Variable", DataType: "i=11", Type: "double", AccessLevel: []string{"CurrentRead"}},
+				{NodeID: "ns=1;s=Speed", BrowseName: "Speed", NodeClass: "Variable", DataType: "i=6", Type: "int32", AccessLevel: []string{"CurrentRead", "CurrentWrite"}},
+				{NodeID: "ns=1;s=Pressure", BrowseName: "Pressure", NodeClass: "Variable", DataType: "i=10", Type: "float", AccessLevel: []string{"CurrentRead"}},
+			},
+		},
+		{
+			name:      "limited depth",
+			discovery: &v1alpha1.OPCUADeviceDiscovery{MaxDepth: 1, NodeClasses: []v1alpha1.OPCUADeviceNodeClass{"Object", "Variable"}},
+			expected: []v1alpha1.OPCUADeviceDiscoveredNode{
+				{NodeID: "ns=1;s=Boiler", BrowseName: "Boiler", NodeClass: "Object"},
+				{NodeID: "ns=1;s=Pump", BrowseName: "Pump", NodeClass: "Object"},
+			},
+		},
+		{
+			name:      "filtered by browse name from sub-tree",
+			discovery: &v1alpha1.OPCUADeviceDiscovery{RootNodeID: "ns=1;s=Boiler", BrowseNamePattern: "^(Reset|Pressure)$", NodeClasses: []v1alpha1.OPCUADeviceNodeClass{"Method", "Variable"}},
+			expected: []v1alpha1.OPCUADeviceDiscoveredNode{
+				{NodeID: "ns=1;s=Reset", BrowseName: "Reset", NodeClass: "Method"},
+				{NodeID: "ns=1;s=Pressure", BrowseName: "Pressure", NodeClass: "Variable", DataType: "i=10", Type: "float", AccessLevel: []string{"CurrentRead"}},
+			},
+		},
+		{
+			name:      "limited nodes",
+			discovery: &v1alpha1.OPCUADeviceDiscovery{MaxNodes: 1},
+			expected: []v1alpha1.OPCUADeviceDiscoveredNode{
+				{NodeID: "ns=1;s=Temperature", BrowseName: "Temperature", NodeClass: "Variable", DataType: "i=11", Type: "double", AccessLevel: []string{"CurrentRead"}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		var actual, err = discoverNodes(browser, tc.discovery, nil)
+		assert.NoError(t, err, "case %q", tc.name)
+		assert.Equal(t, tc.expected, actual, "case %q", tc.name)
+	}
+
+	var _, err = discoverNodes(browser, &v1alpha1.OPCUADeviceDiscovery{BrowseNamePattern: "("}, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,210 @@
+package physical
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gopcua/opcua"
+	"github.com/gopcua/opcua/id"
+	"github.com/gopcua/opcua/ua"
+	"github.com/pkg/errors"
+
+	"github.com/rancher/octopus/adaptors/opcua/api/v1alpha1"
+)
+
+// defaultEventTypeDefinitionID is the id of "BaseEventType".
+const defaultEventTypeDefinitionID = "i=2041"
+
+func init() {
+	// the gopcua library doesn't register the extension objects of event filter and notification,
+	// they must be registered before decoding.
+	ua.RegisterExtensionObject(ua.NewNumericNodeID(0, id.EventFilter_Encoding_DefaultBinary), new(ua.EventFilter))
+	ua.RegisterExtensionObject(ua.NewNumericNodeID(0, id.EventFilterResult_Encoding_DefaultBinary), new(ua.EventFilterResult))
+	ua.RegisterExtensionObject(ua.NewNumericNodeID(0, id.EventNotificationList_Encoding_DefaultBinary), new(ua.EventNotificationList))
+	ua.RegisterExtensionObject(ua.NewNumericNodeID(0, id.SimpleAttributeOperand_Encoding_DefaultBinary), new(ua.SimpleAttributeOperand))
+	ua.RegisterExtensionObject(ua.NewNumericNodeID(0, id.LiteralOperand_Encoding_DefaultBinary), new(ua.LiteralOperand))
+	ua.RegisterExtensionObject(ua.NewNumericNodeID(0, id.ElementOperand_Encoding_DefaultBinary), new(ua.ElementOperand))
+}
+
+var filterOperatorMap = map[v1alpha1.OPCUADeviceEventFilterOperator]ua.FilterOperator{
+	v1alpha1.OPCUADeviceEventFilterOperatorEquals:             ua.FilterOperatorEquals,
+	v1alpha1.OPCUADeviceEventFilterOperatorGreaterThan:        ua.FilterOperatorGreaterThan,
+	v1alpha1.OPCUADeviceEventFilterOperatorGreaterThanOrEqual: ua.FilterOperatorGreaterThanOrEqual,
+	v1alpha1.OPCUADeviceEventFilterOperatorLessThan:           ua.FilterOperatorLessThan,
+	v1alpha1.OPCUADeviceEventFilterOperatorLessThanOrEqual:    ua.FilterOperatorLessThanOrEqual,
+	v1alpha1.OPCUADeviceEventFilterOperatorLike:               ua.FilterOperatorLike,
+	v1alpha1.OPCUADeviceEventFilterOperatorOfType:             ua.FilterOperatorOfType,
+}
+
+// newExtensionObject wraps the value as a binary extension object with the given encoding id,
+// the gopcua library cannot resolve the encoding id of the filter types.
+func newExtensionObject(encodingID uint16, value interface{}) *ua.ExtensionObject {
+	return &ua.ExtensionObject{
+		EncodingMask: ua.ExtensionObjectBinary,
+		TypeID:       ua.NewFourByteExpandedNodeID(0, encodingID),
+		Value:        value,
+	}
+}
+
+// parseBrowsePath parses the browse path like "1:Sensor/1:Value" to the qualified names.
+func parseBrowsePath(path string) ([]*ua.QualifiedName, error) {
+	if path == "" {
+		return nil, errors.New("blank browse path")
+	}
+
+	var names []*ua.QualifiedName
+	for _, segment := range strings.Split(path, "/") {
+		var name = &ua.QualifiedName{Name: segment}
+		if idx := strings.Index(segment, ":"); idx > 0 {
+			var ns, err = strconv.ParseUint(segment[:idx], 10, 16)
+			if err == nil {
+				name.NamespaceIndex = uint16(ns)
+				name.Name = segment[idx+1:]
+			}
+		}
+		if name.Name == "" {
+			return nil, errors.Errorf("blank browse name in browse path %s", path)
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// newSimpleAttributeOperand creates the operand to refer the value of event field.
+func newSimpleAttributeOperand(typeDefinitionID string, browsePath string) (*ua.SimpleAttributeOperand, error) {
+	if typeDefinitionID == "" {
+		typeDefinitionID = defaultEventTypeDefinitionID
+	}
+	var typeID, err = ua.ParseNodeID(typeDefinitionID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse type definition ID %s", typeDefinitionID)
+	}
+	names, err := parseBrowsePath(browsePath)
+	if err != nil {
+		return nil, err
+	}
+	return &ua.SimpleAttributeOperand{
+		TypeDefinitionID: typeID,
+		BrowsePath:       names,
+		AttributeID:      ua.AttributeIDValue,
+	}, nil
+}
+
+// newContentFilterElement converts the where clause to the content filter element.
+func newContentFilterElement(clause v1alpha1.OPCUADeviceEventWhereClause) (*ua.ContentFilterElement, error) {
+	var operator, ok = filterOperatorMap[clause.Operator]
+	if !ok {
+		return nil, errors.Errorf("invalid operator %s", clause.Operator)
+	}
+
+	if operator == ua.FilterOperatorOfType {
+		var typeID, err = ua.ParseNodeID(clause.Value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse event type ID %s", clause.Value)
+		}
+		return &ua.ContentFilterElement{
+			FilterOperator: operator,
+			FilterOperands: []*ua.ExtensionObject{
+				newExtensionObject(id.LiteralOperand_Encoding_DefaultBinary, &ua.LiteralOperand{Value: ua.MustVariant(typeID)}),
+			},
+		}, nil
+	}
+
+	var field, err = newSimpleAttributeOperand(clause.TypeDefinitionID, clause.BrowsePath)
+	if err != nil {
+		return nil, err
+	}
+	var dataType = clause.Type
+	if dataType == "" {
+		dataType = v1alpha1.OPCUADevicePropertyTypeString
+	}
+	value, err := StringToVariant(dataType, clause.Value)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to convert %s string to %s variant", clause.Value, dataType)
+	}
+	return &ua.ContentFilterElement{
+		FilterOperator: operator,
+		FilterOperands: []*ua.ExtensionObject{
+			newExtensionObject(id.SimpleAttributeOperand_Encoding_DefaultBinary, field),
+			newExtensionObject(id.LiteralOperand_Encoding_DefaultBinary, &ua.LiteralOperand{Value: value}),
+		},
+	}, nil
+}
+
+// newEventFilter converts the select clauses and where clause of event to the event filter.
+func newEventFilter(event v1alpha1.OPCUADeviceEvent) (*ua.EventFilter, error) {
+	if len(event.SelectClauses) == 0 {
+		return nil, errors.New("no select clause")
+	}
+
+	var filter = &ua.EventFilter{
+		SelectClauses: make([]*ua.SimpleAttributeOperand, 0, len(event.SelectClauses)),
+		WhereClause:   &ua.ContentFilter{},
+	}
+	for _, clause := range event.SelectClauses {
+		var operand, err = newSimpleAttributeOperand(clause.TypeDefinitionID, clause.BrowsePath)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid select clause %s", clause.Name)
+		}
+		filter.SelectClauses = append(filter.SelectClauses, operand)
+	}
+
+	// the conditions are combined with the "And" elements at the head,
+	// the element i refers to the condition i and the next "And" element,
+	// while the last "And" element refers to the last two conditions.
+	var conditions = len(event.WhereClause)
+	if conditions == 0 {
+		return filter, nil
+	}
+	var ands = conditions - 1
+	var elements = make([]*ua.ContentFilterElement, ands, ands+conditions)
+	for idx := 0; idx < ands; idx++ {
+		var next = uint32(idx + 1)
+		if idx == ands-1 {
+			next = uint32(ands + conditions - 1)
+		}
+		elements[idx] = &ua.ContentFilterElement{
+			FilterOperator: ua.FilterOperatorAnd,
+			FilterOperands: []*ua.ExtensionObject{
+				newExtensionObject(id.ElementOperand_Encoding_DefaultBinary, &ua.ElementOperand{Index: uint32(ands + idx)}),
+				newExtensionObject(id.ElementOperand_Encoding_DefaultBinary, &ua.ElementOperand{Index: next}),
+			},
+		}
+	}
+	for idx, clause := range event.WhereClause {
+		var element, err = newContentFilterElement(clause)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid where clause %d", idx)
+		}
+		elements = append(elements, element)
+	}
+	filter.WhereClause.Elements = elements
+	return filter, nil
+}
+
+// newEventMonitoredItemCreateRequest creates the request to monitor the event notifier of OPC-UA node.
+func newEventMonitoredItemCreateRequest(event v1alpha1.OPCUADeviceEvent, handle uint32) (*ua.MonitoredItemCreateRequest, error) {
+	var nodeID, err = ua.ParseNodeID(event.GetNodeID())
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse node ID %s", event.GetNodeID())
+	}
+	filter, err := newEventFilter(event)
+	if err != nil {
+		return nil, err
+	}
+	var req = opcua.NewMonitoredItemCreateRequestWithDefaults(nodeID, ua.AttributeIDEventNotifier, handle)
+	req.RequestedParameters.Filter = newExtensionObject(id.EventFilter_Encoding_DefaultBinary, filter)
+	return req, nil
+}
+
+// getEventFields converts the selected fields of event in order to the reported fields.
+func getEventFields(event v1alpha1.OPCUADeviceEvent, fields []*ua.Variant) map[string]string {
+	var ret = make(map[string]string, len(event.SelectClauses))
+	for idx, clause := range event.SelectClauses {
+		if idx >= len(fields) || fields[idx] == nil || fields[idx].Value() == nil {
+			continue
+		}
+		ret[clause.Name] = VariantToString(fields[idx].Type(), fields[idx])
+	}
+	return ret
+}
@@ -0,0 +1,205 @@
+package physical
+
+import (
+	"testing"
+
+	"github.com/gopcua/opcua/ua"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/rancher/octopus/adaptors/opcua/api/v1alpha1"
+)
+
+func TestParseBrowsePath(t *testing.T) {
+	type expected struct {
+		ret []*ua.QualifiedName
+		err bool
+	}
+
+	var testCases = []struct {
+		given    string
+		expected expected
+	}{
+		{
+			given: "Message",
+			expected: expected{
+				ret: []*ua.QualifiedName{{Name: "Message"}},
+			},
+		},
+		{
+			given: "1:Sensor/1:Value",
+			expected: expected{
+				ret: []*ua.QualifiedName{{NamespaceIndex: 1, Name: "Sensor"}, {NamespaceIndex: 1, Name: "Value"}},
+			},
+		},
+		{
+			given: "a:b",
+			expected: expected{
+				ret: []*ua.QualifiedName{{Name: "a:b"}},
+			},
+		},
+		{
+			given: "",
+			expected: expected{
+				err: true,
+			},
+		},
+		{
+			given: "Sensor//Value",
+			expected: expected{
+				err: true,
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		var ret, err = parseBrowsePath(tc.given)
+		if tc.expected.err {
+			assert.Error(t, err, "case %v", i+1)
+			continue
+		}
+		assert.NoError(t, err, "case %v", i+1)
+		assert.Equal(t, tc.expected.ret, ret, "case %v", i+1)
+	}
+}
+
+func TestNewEventFilter(t *testing.T) {
+	var selectClauses = []v1alpha1.OPCUADeviceEventSelectClause{
+		{Name: "message", BrowsePath: "Message"},
+		{Name: "value", BrowsePath: "1:Value", TypeDefinitionID: "ns=1;i=5000"},
+	}
+
+	type expected struct {
+		selectClauses []string
+		elements      []ua.FilterOperator
+		references    map[int][]uint32
+		err           bool
+	}
+
+	var testCases = []struct {
+		given    v1alpha1.OPCUADeviceEvent
+		expected expected
+	}{
+		{
+			given: v1alpha1.OPCUADeviceEvent{
+				SelectClauses: selectClauses,
+			},
+			expected: expected{
+				selectClauses: []string{"i=2041", "ns=1;i=5000"},
+			},
+		},
+		{
+			given: v1alpha1.OPCUADeviceEvent{
+				SelectClauses: selectClauses,
+				WhereClause: []v1alpha1.OPCUADeviceEventWhereClause{
+					{Operator: v1alpha1.OPCUADeviceEventFilterOperatorOfType, Value: "i=2041"},
+				},
+			},
+			expected: expected{
+				selectClauses: []string{"i=2041", "ns=1;i=5000"},
+				elements:      []ua.FilterOperator{ua.FilterOperatorOfType},
+			},
+		},
+		{
+			given: v1alpha1.OPCUADeviceEvent{
+				SelectClauses: selectClauses,
+				WhereClause: []v1alpha1.OPCUADeviceEventWhereClause{
+					{BrowsePath: "Severity", Operator: v1alpha1.OPCUADeviceEventFilterOperatorGreaterThanOrEqual, Type: v1alpha1.OPCUADevicePropertyTypeUInt16, Value: "500"},
+					{BrowsePath: "SourceName", Operator: v1alpha1.OPCUADeviceEventFilterOperatorEquals, Value: "Boiler"},
+				},
+			},
+			expected: expected{
+				selectClauses: []string{"i=2041", "ns=1;i=5000"},
+				elements:      []ua.FilterOperator{ua.FilterOperatorAnd, ua.FilterOperatorGreaterThanOrEqual, ua.FilterOperatorEquals},
+				references:    map[int][]uint32{0: {1, 2}},
+			},
+		},
+		{
+			given: v1alpha1.OPCUADeviceEvent{
+				SelectClauses: selectClauses,
+				WhereClause: []v1alpha1.OPCUADeviceEventWhereClause{
+					{Operator: v1alpha1.OPCUADeviceEventFilterOperatorOfType, Value: "i=2041"},
+					{BrowsePath: "Severity", Operator: v1alpha1.OPCUADeviceEventFilterOperatorGreaterThan, Type: v1alpha1.OPCUADevicePropertyTypeUInt16, Value: "100"},
+					{BrowsePath: "SourceName", Operator: v1alpha1.OPCUADeviceEventFilterOperatorLike, Value: "Boiler%"},
+				},
+			},
+			expected: expected{
+				selectClauses: []string{"i=2041", "ns=1;i=5000"},
+				elements:      []ua.FilterOperator{ua.FilterOperatorAnd, ua.FilterOperatorAnd, ua.FilterOperatorOfType, ua.FilterOperatorGreaterThan, ua.FilterOperatorLike},
+				references:    map[int][]uint32{0: {2, 1}, 1: {3, 4}},
+			},
+		},
+		{
+			given: v1alpha1.OPCUADeviceEvent{},
+			expected: expected{
+				err: true,
+			},
+		},
+		{
+			given: v1alpha1.OPCUADeviceEvent{
+				SelectClauses: selectClauses,
+				WhereClause: []v1alpha1.OPCUADeviceEventWhereClause{
+					{BrowsePath: "Severity", Operator: v1alpha1.OPCUADeviceEventFilterOperatorEquals, Type: v1alpha1.OPCUADevicePropertyTypeUInt16, Value: "high"},
+				},
+			},
+			expected: expected{
+				err: true,
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		var ret, err = newEventFilter(tc.given)
+		if tc.expected.err {
+			assert.Error(t, err, "case %v", i+1)
+			continue
+		}
+		assert.NoError(t, err, "case %v", i+1)
+
+		var selectClauses = make([]string, 0, len(ret.SelectClauses))
+		for _, clause := range ret.SelectClauses {
+			selectClauses = append(selectClauses, clause.TypeDefinitionID.String())
+		}
+		assert.Equal(t, tc.expected.selectClauses, selectClauses, "case %v", i+1)
+
+		var elements []ua.FilterOperator
+		var references = map[int][]uint32{}
+		for idx, element := range ret.WhereClause.Elements {
+			elements = append(elements, element.FilterOperator)
+			for _, operand := range element.FilterOperands {
+				if ref, ok := operand.Value.(*ua.ElementOperand); ok {
+					references[idx] = append(references[idx], ref.Index)
+				}
+			}
+		}
+		assert.Equal(t, tc.expected.elements, elements, "case %v", i+1)
+		if tc.expected.references == nil {
+			tc.expected.references = map[int][]uint32{}
+		}
+		assert.Equal(t, tc.expected.references, references, "case %v", i+1)
+	}
+}
+
+func TestGetEventFields(t *testing.T) {
+	var event = v1alpha1.OPCUADeviceEvent{
+		SelectClauses: []v1alpha1.OPCUADeviceEventSelectClause{
+			{Name: "message", BrowsePath: "Message"},
+			{Name: "severity", BrowsePath: "Severity"},
+			{Name: "source", BrowsePath: "SourceNode"},
+			{Name: "missing", BrowsePath: "Missing"},
+		},
+	}
+	var message = &ua.LocalizedText{Text: "overheat"}
+	message.UpdateMask()
+
+	var ret = getEventFields(event, []*ua.Variant{
+		ua.MustVariant(message),
+		ua.MustVariant(uint16(800)),
+		ua.MustVariant(ua.NewStringNodeID(1, "Boiler")),
+		{},
+	})
+	assert.Equal(t, map[string]string{
+		"message":  "overheat",
+		"severity": "800",
+		"source":   "ns=1;s=Boiler",
+	}, ret)
+}
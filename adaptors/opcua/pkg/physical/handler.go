@@ -16,7 +16,8 @@
 	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/rancher/octopus/adaptors/opcua/api/v1alpha1"
-	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha1"
+	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha2"
+	"github.com/rancher/octopus/pkg/adaptor/connection"
 	"github.com/rancher/octopus/pkg/util/converter"
 	"github.com/rancher/octopus/pkg/util/log/logflag"
 )
@@ -28,6 +29,43 @@
 
 // NewOPCUAClient creates a opcua.Client
 func NewOPCUAClient(protocol v1alpha1.OPCUADeviceProtocol, timeout time.Duration, references api.ReferencesHandler) (*opcua.Client, error) {
+	var options, err = newOPCUAClientOptions(protocol, timeout, references)
+	if err != nil {
+		return nil, err
+	}
+
+	var ctx, cancel = context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	var client = opcua.NewClient(protocol.Endpoint, options...)
+	if err := client.Connect(ctx); err != nil {
+		return nil, errors.Wrap(classifyConnectError(err), "failed to connect to OPC-UA endpoint")
+	}
+	return client, nil
+}
+
+// classifyConnectError classifies the failure of connecting OPC-UA endpoint by the status code,
+// the rejected identity or certificate is unauthorized.
+func classifyConnectError(err error) error {
+	var code, isStatus = err.(ua.StatusCode)
+	if !isStatus {
+		return err
+	}
+	switch code {
+	case ua.StatusBadUserAccessDenied, ua.StatusBadIdentityTokenInvalid, ua.StatusBadIdentityTokenRejected,
+		ua.StatusBadSecurityChecksFailed, ua.StatusBadCertificateInvalid, ua.StatusBadCertificateUntrusted:
+		return connection.NewClassifiedError(api.ErrorCode_Unauthorized, err)
+	case ua.StatusBadTimeout:
+		return connection.NewClassifiedError(api.ErrorCode_Timeout, err)
+	}
+	if isSessionLost(err) {
+		return connection.NewClassifiedError(api.ErrorCode_Unreachable, err)
+	}
+	return err
+}
+
+// newOPCUAClientOptions creates the options of opcua.Client,
+// which are reused to create the client after reconnecting.
+func newOPCUAClientOptions(protocol v1alpha1.OPCUADeviceProtocol, timeout time.Duration, references api.ReferencesHandler) ([]opcua.Option, error) {
 	if logflag.GetLogVerbosity() > 4 {
 		// setup opcua debug log
 		debug.Enable = true
@@ -53,13 +91,37 @@ func NewOPCUAClient(protocol v1alpha1.OPCUADeviceProtocol, timeout time.Duration
 		return nil, errors.Wrap(err, "failed to get OPC-UA endpoint")
 	}
 
+	certificate, key, err := getClientCertificate(protocol.TLSConfig, references)
+	if err != nil {
+		return nil, err
+	}
+	ca, thumbprint, err := getServerTrust(protocol.TLSConfig, references)
+	if err != nil {
+		return nil, err
+	}
+
+	var tokenType = ua.UserTokenTypeAnonymous
+	if protocol.BasicAuth != nil {
+		tokenType = ua.UserTokenTypeUserName
+	}
 	var policy = string(protocol.SecurityPolicy)
 	var mode = string(protocol.SecurityMode)
-	var ep = opcua.SelectEndpoint(endpoints, policy, ua.MessageSecurityModeFromString(mode))
+	var ep = selectEndpoint(endpoints, policy, mode, tokenType, certificate != nil, len(ca) != 0 || thumbprint != "")
+	if ep == nil {
+		return nil, errors.Errorf("failed to select OPC-UA endpoint with %s security policy and %s security mode", policy, mode)
+	}
+	if err := verifyServerCertificate(ep, ca, thumbprint); err != nil {
+		return nil, err
+	}
+
 	var options = []opcua.Option{
 		opcua.RequestTimeout(timeout),
-		opcua.SecurityPolicy(policy),
-		opcua.SecurityModeString(mode),
+	}
+	if !isAutoSecurity(protocol) {
+		options = append(options,
+			opcua.SecurityPolicy(policy),
+			opcua.SecurityModeString(mode),
+		)
 	}
 
 	if protocol.BasicAuth != nil {
@@ -94,55 +156,100 @@ func NewOPCUAClient(protocol v1alpha1.OPCUADeviceProtocol, timeout time.Duration
 	} else {
 		options = append(options,
 			opcua.AuthAnonymous(),
-			opcua.SecurityFromEndpoint(ep, ua.UserTokenTypeAnonymous),
 		)
 	}
+	// the security policy, mode and server certificate are taken from the selected endpoint.
+	options = append(options,
+		opcua.SecurityFromEndpoint(ep, tokenType),
+	)
 
-	if protocol.TLSConfig != nil {
-		var tlsConfigSpec = protocol.TLSConfig
-
-		var certEncodedPEM []byte
-		if tlsConfigSpec.CertFilePEM != "" {
-			certEncodedPEM = converter.UnsafeStringToBytes(tlsConfigSpec.CertFilePEM)
-		} else if ref := tlsConfigSpec.CertFilePEMRef; ref != nil {
-			if references == nil {
-				return nil, errors.Errorf("references handler is nil")
-			}
-			certEncodedPEM = references.GetData(ref.Name, ref.Item)
-		}
-		var certPEM, err = decodeCertificatePEM(certEncodedPEM)
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to get certificate from cert PEM content")
-		}
+	if certificate != nil {
 		options = append(options,
-			opcua.Certificate(certPEM),
+			opcua.Certificate(certificate),
+			opcua.PrivateKey(key),
 		)
+		// the server rejects the session if the application URI mismatches the URI of client certificate.
+		if uri := getCertificateApplicationURI(certificate); uri != "" {
+			options = append(options,
+				opcua.ApplicationURI(uri),
+			)
+		}
+	}
+	return options, nil
+}
 
-		var keyEncodedPEM []byte
-		if tlsConfigSpec.KeyFilePEM != "" {
-			keyEncodedPEM = converter.UnsafeStringToBytes(tlsConfigSpec.KeyFilePEM)
-		} else if ref := tlsConfigSpec.KeyFilePEMRef; ref != nil {
-			if references == nil {
-				return nil, errors.Errorf("references handler is nil")
-			}
-			keyEncodedPEM = references.GetData(ref.Name, ref.Item)
+// getClientCertificate returns the client certificate and private key from the TLS config,
+// or the generated one if the TLS config asks to generate.
+func getClientCertificate(tlsConfigSpec *v1alpha1.OPCUADeviceProtocolTLS, references api.ReferencesHandler) ([]byte, *rsa.PrivateKey, error) {
+	if tlsConfigSpec == nil {
+		return nil, nil, nil
+	}
+
+	var certEncodedPEM []byte
+	if tlsConfigSpec.CertFilePEM != "" {
+		certEncodedPEM = converter.UnsafeStringToBytes(tlsConfigSpec.CertFilePEM)
+	} else if ref := tlsConfigSpec.CertFilePEMRef; ref != nil {
+		if references == nil {
+			return nil, nil, errors.Errorf("references handler is nil")
 		}
-		key, err := decodeKeyPEM(keyEncodedPEM)
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to get private key from key PEM content")
+		certEncodedPEM = references.GetData(ref.Name, ref.Item)
+	}
+
+	var keyEncodedPEM []byte
+	if tlsConfigSpec.KeyFilePEM != "" {
+		keyEncodedPEM = converter.UnsafeStringToBytes(tlsConfigSpec.KeyFilePEM)
+	} else if ref := tlsConfigSpec.KeyFilePEMRef; ref != nil {
+		if references == nil {
+			return nil, nil, errors.Errorf("references handler is nil")
 		}
-		options = append(options,
-			opcua.PrivateKey(key),
-		)
+		keyEncodedPEM = references.GetData(ref.Name, ref.Item)
 	}
 
-	var ctx, cancel = context.WithTimeout(context.Background(), timeout)
-	defer cancel()
-	var client = opcua.NewClient(protocol.Endpoint, options...)
-	if err := client.Connect(ctx); err != nil {
-		return nil, errors.Wrap(err, "failed to connect to OPC-UA endpoint")
+	if tlsConfigSpec.GenerateClientCert && len(certEncodedPEM) == 0 && len(keyEncodedPEM) == 0 {
+		return loadOrGenerateClientCertificate(pkiDir, getApplicationURI())
 	}
-	return client, nil
+
+	var cert, err = decodeCertificatePEM(certEncodedPEM)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to get certificate from cert PEM content")
+	}
+	// gopcua exits the process if the certificate is unparsable.
+	if _, err := x509.ParseCertificate(cert); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to parse certificate")
+	}
+	key, err := decodeKeyPEM(keyEncodedPEM)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to get private key from key PEM content")
+	}
+	return cert, key, nil
+}
+
+// getServerTrust returns the CA PEM content and the pinned thumbprint from the TLS config.
+func getServerTrust(tlsConfigSpec *v1alpha1.OPCUADeviceProtocolTLS, references api.ReferencesHandler) ([]byte, string, error) {
+	if tlsConfigSpec == nil {
+		return nil, "", nil
+	}
+
+	var caEncodedPEM []byte
+	if tlsConfigSpec.CAFilePEM != "" {
+		caEncodedPEM = converter.UnsafeStringToBytes(tlsConfigSpec.CAFilePEM)
+	} else if ref := tlsConfigSpec.CAFilePEMRef; ref != nil {
+		if references == nil {
+			return nil, "", errors.Errorf("references handler is nil")
+		}
+		caEncodedPEM = references.GetData(ref.Name, ref.Item)
+	}
+
+	var thumbprint string
+	if tlsConfigSpec.ServerCertThumbprint != "" {
+		thumbprint = tlsConfigSpec.ServerCertThumbprint
+	} else if ref := tlsConfigSpec.ServerCertThumbprintRef; ref != nil {
+		if references == nil {
+			return nil, "", errors.Errorf("references handler is nil")
+		}
+		thumbprint = converter.UnsafeBytesToString(references.GetData(ref.Name, ref.Item))
+	}
+	return caEncodedPEM, thumbprint, nil
 }
 
 func decodeCertificatePEM(encodedPEM []byte) ([]byte, error) {
@@ -0,0 +1,54 @@
+package physical
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/gopcua/opcua/ua"
+	"github.com/stretchr/testify/assert"
+
+	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha2"
+)
+
+func TestClassifyConnectError(t *testing.T) {
+	var testCases = []struct {
+		given    error
+		expected api.ErrorCode
+	}{
+		{
+			given:    ua.StatusBadUserAccessDenied,
+			expected: api.ErrorCode_Unauthorized,
+		},
+		{
+			given:    ua.StatusBadCertificateUntrusted,
+			expected: api.ErrorCode_Unauthorized,
+		},
+		{
+			given:    ua.StatusBadTimeout,
+			expected: api.ErrorCode_Timeout,
+		},
+		{
+			given:    ua.StatusBadServerHalted,
+			expected: api.ErrorCode_Unreachable,
+		},
+		{
+			given:    ua.StatusBadNodeIDUnknown,
+			expected: api.ErrorCode_Unspecified,
+		},
+		{
+			given:    io.EOF,
+			expected: api.ErrorCode_Unspecified,
+		},
+		{
+			given:    errors.New("invalid endpoint"),
+			expected: api.ErrorCode_Unspecified,
+		},
+	}
+
+	for i, tc := range testCases {
+		var ret = classifyConnectError(tc.given)
+		assert.Equal(t, tc.expected, api.GetErrorCode(ret), "case %v", i+1)
+		assert.Equal(t, tc.given.Error(), ret.Error(), "case %v", i+1)
+	}
+}
@@ -0,0 +1,125 @@
+package physical
+
+import (
+	"encoding/json"
+
+	"github.com/gopcua/opcua/ua"
+	"github.com/pkg/errors"
+
+	"github.com/rancher/octopus/adaptors/opcua/api/v1alpha1"
+)
+
+// methodCaller calls the method of OPC-UA server.
+type methodCaller interface {
+	Call(req *ua.CallMethodRequest) (*ua.CallMethodResult, error)
+}
+
+// methodArguments is the arguments of the command to call method,
+// the declared input arguments of method are used if the input arguments are not specified.
+type methodArguments struct {
+	InputArguments []v1alpha1.OPCUADeviceArgument `json:"inputArguments,omitempty"`
+}
+
+// methodOutput is the output of the command to call method.
+type methodOutput struct {
+	OutputArguments []v1alpha1.OPCUADeviceArgument `json:"outputArguments"`
+}
+
+// newCallMethodRequest converts the method to the call request of OPC-UA,
+// the input arguments can be overridden by the JSON arguments of command.
+func newCallMethodRequest(method v1alpha1.OPCUADeviceMethod, arguments []byte) (*ua.CallMethodRequest, error) {
+	if len(arguments) != 0 {
+		var args methodArguments
+		if err := json.Unmarshal(arguments, &args); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal arguments")
+		}
+		if len(args.InputArguments) != 0 {
+			method.InputArguments = args.InputArguments
+		}
+	}
+
+	var objectID, err = ua.ParseNodeID(method.ObjectNodeID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse object node ID %s", method.ObjectNodeID)
+	}
+	methodID, err := ua.ParseNodeID(method.MethodNodeID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse method node ID %s", method.MethodNodeID)
+	}
+
+	var inputs = make([]*ua.Variant, 0, len(method.InputArguments))
+	for idx, arg := range method.InputArguments {
+		var input, err = StringToVariant(arg.Type, arg.Value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to convert input argument %d to %s variant", idx, arg.Type)
+		}
+		inputs = append(inputs, input)
+	}
+
+	return &ua.CallMethodRequest{
+		ObjectID:       objectID,
+		MethodID:       methodID,
+		InputArguments: inputs,
+	}, nil
+}
+
+// callMethod calls the method and captures the output arguments.
+func callMethod(caller methodCaller, req *ua.CallMethodRequest) ([]v1alpha1.OPCUADeviceArgument, error) {
+	var res, err = caller.Call(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to call")
+	}
+	if res.StatusCode != ua.StatusOK {
+		for idx, code := range res.InputArgumentResults {
+			if code != ua.StatusOK {
+				return nil, errors.Wrapf(res.StatusCode, "invalid input argument %d: %v", idx, code)
+			}
+		}
+		return nil, res.StatusCode
+	}
+
+	var outputs = make([]v1alpha1.OPCUADeviceArgument, 0, len(res.OutputArguments))
+	for _, output := range res.OutputArguments {
+		if output == nil {
+			outputs = append(outputs, v1alpha1.OPCUADeviceArgument{})
+			continue
+		}
+		outputs = append(outputs, v1alpha1.OPCUADeviceArgument{
+			Type:  typeMap[output.Type()],
+			Value: VariantToString(output.Type(), output),
+		})
+	}
+	return outputs, nil
+}
+
+// findMethod returns the method with the given name, or nil if not found.
+func findMethod(methods []v1alpha1.OPCUADeviceMethod, name string) *v1alpha1.OPCUADeviceMethod {
+	for idx := range methods {
+		if methods[idx].Name == name {
+			return &methods[idx]
+		}
+	}
+	return nil
+}
+
+// setStatusMethod replaces the status of method with the same name, or appends it if not found.
+func setStatusMethod(statusMethods []v1alpha1.OPCUADeviceStatusMethod, statusMethod v1alpha1.OPCUADeviceStatusMethod) []v1alpha1.OPCUADeviceStatusMethod {
+	for idx := range statusMethods {
+		if statusMethods[idx].Name == statusMethod.Name {
+			statusMethods[idx] = statusMethod
+			return statusMethods
+		}
+	}
+	return append(statusMethods, statusMethod)
+}
+
+// filterStatusMethods returns the status of the declared methods.
+func filterStatusMethods(methods []v1alpha1.OPCUADeviceMethod, statusMethods []v1alpha1.OPCUADeviceStatusMethod) []v1alpha1.OPCUADeviceStatusMethod {
+	var ret = make([]v1alpha1.OPCUADeviceStatusMethod, 0, len(statusMethods))
+	for _, statusMethod := range statusMethods {
+		if findMethod(methods, statusMethod.Name) != nil {
+			ret = append(ret, statusMethod)
+		}
+	}
+	return ret
+}
@@ -0,0 +1,212 @@
+package physical
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gopcua/opcua/ua"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/rancher/octopus/adaptors/opcua/api/v1alpha1"
+)
+
+func TestNewCallMethodRequest(t *testing.T) {
+	type expected struct {
+		objectID string
+		methodID string
+		inputs   []interface{}
+		err      bool
+	}
+
+	var testCases = []struct {
+		given     v1alpha1.OPCUADeviceMethod
+		arguments []byte
+		expected  expected
+	}{
+		{
+			given: v1alpha1.OPCUADeviceMethod{
+				ObjectNodeID: "ns=1;s=Device",
+				MethodNodeID: "ns=1;s=Device.Reset",
+			},
+			expected: expected{
+				objectID: "ns=1;s=Device",
+				methodID: "ns=1;s=Device.Reset",
+				inputs:   []interface{}{},
+			},
+		},
+		{ // overrides the declared input arguments
+			given: v1alpha1.OPCUADeviceMethod{
+				ObjectNodeID: "ns=1;s=Device",
+				MethodNodeID: "ns=1;s=Device.SetPoint",
+				InputArguments: []v1alpha1.OPCUADeviceArgument{
+					{Type: v1alpha1.OPCUADevicePropertyTypeInt32, Value: "3"},
+				},
+			},
+			arguments: []byte(`{"inputArguments":[{"type":"int32","value":"5"},{"type":"boolean","value":"false"}]}`),
+			expected: expected{
+				objectID: "ns=1;s=Device",
+				methodID: "ns=1;s=Device.SetPoint",
+				inputs:   []interface{}{int32(5), false},
+			},
+		},
+		{ // uses the declared input arguments if not specified
+			given: v1alpha1.OPCUADeviceMethod{
+				ObjectNodeID: "ns=1;s=Device",
+				MethodNodeID: "ns=1;s=Device.SetPoint",
+				InputArguments: []v1alpha1.OPCUADeviceArgument{
+					{Type: v1alpha1.OPCUADevicePropertyTypeInt32, Value: "3"},
+				},
+			},
+			arguments: []byte(`{}`),
+			expected: expected{
+				objectID: "ns=1;s=Device",
+				methodID: "ns=1;s=Device.SetPoint",
+				inputs:   []interface{}{int32(3)},
+			},
+		},
+		{
+			given: v1alpha1.OPCUADeviceMethod{
+				ObjectNodeID: "ns=1;s=Device",
+				MethodNodeID: "ns=1;s=Device.SetPoint",
+			},
+			arguments: []byte(`{"inputArguments":`),
+			expected: expected{
+				err: true,
+			},
+		},
+		{
+			given: v1alpha1.OPCUADeviceMethod{
+				ObjectNodeID: "ns=1;i=1000",
+				MethodNodeID: "ns=1;i=1001",
+				InputArguments: []v1alpha1.OPCUADeviceArgument{
+					{Type: v1alpha1.OPCUADevicePropertyTypeInt32, Value: "3"},
+					{Type: v1alpha1.OPCUADevicePropertyTypeBoolean, Value: "true"},
+					{Type: v1alpha1.OPCUADevicePropertyTypeString, Value: "on"},
+				},
+			},
+			expected: expected{
+				objectID: "ns=1;i=1000",
+				methodID: "ns=1;i=1001",
+				inputs:   []interface{}{int32(3), true, "on"},
+			},
+		},
+		{
+			given: v1alpha1.OPCUADeviceMethod{
+				ObjectNodeID: "ns=1;s=Device",
+				MethodNodeID: "ns=1;s=Device.Reset",
+				InputArguments: []v1alpha1.OPCUADeviceArgument{
+					{Type: v1alpha1.OPCUADevicePropertyTypeInt16, Value: "70000"},
+				},
+			},
+			expected: expected{
+				err: true,
+			},
+		},
+		{
+			given: v1alpha1.OPCUADeviceMethod{
+				ObjectNodeID: "ns=x;s=Device",
+				MethodNodeID: "ns=1;s=Device.Reset",
+			},
+			expected: expected{
+				err: true,
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		var ret, err = newCallMethodRequest(tc.given, tc.arguments)
+		if tc.expected.err {
+			assert.Error(t, err, "case %v", i+1)
+			continue
+		}
+		assert.NoError(t, err, "case %v", i+1)
+		assert.Equal(t, tc.expected.objectID, ret.ObjectID.String(), "case %v", i+1)
+		assert.Equal(t, tc.expected.methodID, ret.MethodID.String(), "case %v", i+1)
+		var inputs = make([]interface{}, 0, len(ret.InputArguments))
+		for _, input := range ret.InputArguments {
+			inputs = append(inputs, input.Value())
+		}
+		assert.Equal(t, tc.expected.inputs, inputs, "case %v", i+1)
+	}
+}
+
+func TestCallMethod(t *testing.T) {
+	var server = newTestServer(t)
+	defer server.Close()
+	server.AddMethod("ns=1;s=Device.Add", func(inputs []*ua.Variant) ([]*ua.Variant, ua.StatusCode) {
+		if len(inputs) != 2 {
+			return nil, ua.StatusBadArgumentsMissing
+		}
+		return []*ua.Variant{ua.MustVariant(int32(inputs[0].Int() + inputs[1].Int())), ua.MustVariant("added")}, ua.StatusOK
+	})
+
+	var client, err = NewOPCUAClient(v1alpha1.OPCUADeviceProtocol{
+		Endpoint:       server.endpoint,
+		SecurityPolicy: "None",
+		SecurityMode:   "None",
+	}, 5*time.Second, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer client.Close()
+
+	type expected struct {
+		outputs []v1alpha1.OPCUADeviceArgument
+		err     bool
+	}
+
+	var testCases = []struct {
+		given    v1alpha1.OPCUADeviceMethod
+		expected expected
+	}{
+		{
+			given: v1alpha1.OPCUADeviceMethod{
+				ObjectNodeID: "ns=1;s=Device",
+				MethodNodeID: "ns=1;s=Device.Add",
+				InputArguments: []v1alpha1.OPCUADeviceArgument{
+					{Type: v1alpha1.OPCUADevicePropertyTypeInt32, Value: "1"},
+					{Type: v1alpha1.OPCUADevicePropertyTypeInt32, Value: "2"},
+				},
+			},
+			expected: expected{
+				outputs: []v1alpha1.OPCUADeviceArgument{
+					{Type: v1alpha1.OPCUADevicePropertyTypeInt32, Value: "3"},
+					{Type: v1alpha1.OPCUADevicePropertyTypeString, Value: "added"},
+				},
+			},
+		},
+		{
+			given: v1alpha1.OPCUADeviceMethod{
+				ObjectNodeID: "ns=1;s=Device",
+				MethodNodeID: "ns=1;s=Device.Add",
+			},
+			expected: expected{
+				err: true,
+			},
+		},
+		{
+			given: v1alpha1.OPCUADeviceMethod{
+				ObjectNodeID: "ns=1;s=Device",
+				MethodNodeID: "ns=1;s=Device.Unknown",
+			},
+			expected: expected{
+				err: true,
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		var req, err = newCallMethodRequest(tc.given, nil)
+		if !assert.NoError(t, err, "case %v", i+1) {
+			continue
+		}
+		ret, err := callMethod(client, req)
+		if tc.expected.err {
+			assert.Error(t, err, "case %v", i+1)
+			continue
+		}
+		assert.NoError(t, err, "case %v", i+1)
+		assert.Equal(t, tc.expected.outputs, ret, "case %v", i+1)
+	}
+	assert.Len(t, server.Calls(), len(testCases))
+}
@@ -0,0 +1,84 @@
+package physical
+
+import (
+	"time"
+
+	"github.com/gopcua/opcua"
+	"github.com/gopcua/opcua/id"
+	"github.com/gopcua/opcua/ua"
+	"github.com/pkg/errors"
+
+	"github.com/rancher/octopus/adaptors/opcua/api/v1alpha1"
+	"github.com/rancher/octopus/pkg/util/deadband"
+)
+
+func init() {
+	// the gopcua library doesn't register the extension object of data change filter,
+	// it must be registered before decoding.
+	ua.RegisterExtensionObject(ua.NewNumericNodeID(0, id.DataChangeFilter_Encoding_DefaultBinary), new(ua.DataChangeFilter))
+}
+
+// monitoredItem is the monitored item of property.
+type monitoredItem struct {
+	property v1alpha1.OPCUADeviceProperty
+}
+
+// propertySubscription groups the monitored items of the properties in the same publishing interval.
+type propertySubscription struct {
+	interval time.Duration
+	sub      *subscription
+	stop     chan struct{}
+	// items are indexed by the client handle.
+	items map[uint32]monitoredItem
+}
+
+// has returns true if the property has been monitored in the subscription.
+func (s *propertySubscription) has(name string) bool {
+	for _, item := range s.items {
+		if item.property.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// newPropertyMonitoredItemCreateRequest creates the request to monitor the value of property's node.
+func newPropertyMonitoredItemCreateRequest(prop v1alpha1.OPCUADeviceProperty, handle uint32) (*ua.MonitoredItemCreateRequest, error) {
+	var visitor = prop.Visitor
+	var nodeID, err = ua.ParseNodeID(visitor.NodeID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse node ID %s", visitor.NodeID)
+	}
+
+	var req = opcua.NewMonitoredItemCreateRequestWithDefaults(nodeID, ua.AttributeIDValue, handle)
+	var params = req.RequestedParameters
+	params.SamplingInterval = float64(visitor.GetSamplingInterval() / time.Millisecond)
+	params.QueueSize = visitor.GetQueueSize()
+	params.DiscardOldest = visitor.GetDiscardOldest()
+	if visitor.Deadband != "" {
+		var band, err = deadband.Parse(visitor.Deadband)
+		if err != nil {
+			return nil, err
+		}
+		var filter = &ua.DataChangeFilter{
+			Trigger:       ua.DataChangeTriggerStatusValue,
+			DeadbandType:  uint32(ua.DeadbandTypeAbsolute),
+			DeadbandValue: band.Value,
+		}
+		if band.Percent {
+			filter.DeadbandType = uint32(ua.DeadbandTypePercent)
+		}
+		params.Filter = newExtensionObject(id.DataChangeFilter_Encoding_DefaultBinary, filter)
+	}
+	return req, nil
+}
+
+// isMonitoringChanged returns true if the monitored item of property needs to be re-created,
+// the changes of expressions don't affect the monitoring.
+func isMonitoringChanged(stale, new v1alpha1.OPCUADevicePropertyVisitor) bool {
+	return stale.NodeID != new.NodeID ||
+		stale.Deadband != new.Deadband ||
+		stale.GetSamplingInterval() != new.GetSamplingInterval() ||
+		stale.GetQueueSize() != new.GetQueueSize() ||
+		stale.GetDiscardOldest() != new.GetDiscardOldest()
+}
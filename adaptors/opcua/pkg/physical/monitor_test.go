@@ -0,0 +1,161 @@
+package physical
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gopcua/opcua/ua"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/rancher/octopus/adaptors/opcua/api/v1alpha1"
+)
+
+func TestNewPropertyMonitoredItemCreateRequest(t *testing.T) {
+	var queueSize = uint32(1)
+	var discardOldest = false
+
+	type expected struct {
+		samplingInterval float64
+		queueSize        uint32
+		discardOldest    bool
+		filter           *ua.DataChangeFilter
+		err              bool
+	}
+
+	var testCases = []struct {
+		given    v1alpha1.OPCUADevicePropertyVisitor
+		expected expected
+	}{
+		{
+			given: v1alpha1.OPCUADevicePropertyVisitor{
+				NodeID: "ns=1;s=Temperature",
+			},
+			expected: expected{
+				samplingInterval: 0,
+				queueSize:        10,
+				discardOldest:    true,
+			},
+		},
+		{
+			given: v1alpha1.OPCUADevicePropertyVisitor{
+				NodeID:           "ns=1;s=Temperature",
+				SamplingInterval: &metav1.Duration{Duration: 500 * time.Millisecond},
+				QueueSize:        &queueSize,
+				DiscardOldest:    &discardOldest,
+				Deadband:         "0.5",
+			},
+			expected: expected{
+				samplingInterval: 500,
+				queueSize:        1,
+				discardOldest:    false,
+				filter: &ua.DataChangeFilter{
+					Trigger:       ua.DataChangeTriggerStatusValue,
+					DeadbandType:  uint32(ua.DeadbandTypeAbsolute),
+					DeadbandValue: 0.5,
+				},
+			},
+		},
+		{
+			given: v1alpha1.OPCUADevicePropertyVisitor{
+				NodeID:   "ns=1;s=Temperature",
+				Deadband: "5%",
+			},
+			expected: expected{
+				samplingInterval: 0,
+				queueSize:        10,
+				discardOldest:    true,
+				filter: &ua.DataChangeFilter{
+					Trigger:       ua.DataChangeTriggerStatusValue,
+					DeadbandType:  uint32(ua.DeadbandTypePercent),
+					DeadbandValue: 5,
+				},
+			},
+		},
+		{
+			given: v1alpha1.OPCUADevicePropertyVisitor{
+				NodeID: "ns=x;i=1",
+			},
+			expected: expected{
+				err: true,
+			},
+		},
+		{
+			given: v1alpha1.OPCUADevicePropertyVisitor{
+				NodeID:   "ns=1;s=Temperature",
+				Deadband: "five",
+			},
+			expected: expected{
+				err: true,
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		var ret, err = newPropertyMonitoredItemCreateRequest(v1alpha1.OPCUADeviceProperty{Name: "temperature", Visitor: tc.given}, 1)
+		if tc.expected.err {
+			assert.Error(t, err, "case %v", i+1)
+			continue
+		}
+		assert.NoError(t, err, "case %v", i+1)
+
+		var params = ret.RequestedParameters
+		assert.Equal(t, uint32(1), params.ClientHandle, "case %v", i+1)
+		assert.Equal(t, tc.expected.samplingInterval, params.SamplingInterval, "case %v", i+1)
+		assert.Equal(t, tc.expected.queueSize, params.QueueSize, "case %v", i+1)
+		assert.Equal(t, tc.expected.discardOldest, params.DiscardOldest, "case %v", i+1)
+		var filter *ua.DataChangeFilter
+		if params.Filter != nil {
+			filter, _ = params.Filter.Value.(*ua.DataChangeFilter)
+		}
+		assert.Equal(t, tc.expected.filter, filter, "case %v", i+1)
+	}
+}
+
+func TestIsMonitoringChanged(t *testing.T) {
+	var queueSize = uint32(10)
+	var stale = v1alpha1.OPCUADevicePropertyVisitor{
+		NodeID:         "ns=1;s=Temperature",
+		ReadExpression: "x * 10",
+	}
+
+	var testCases = []struct {
+		given    v1alpha1.OPCUADevicePropertyVisitor
+		expected bool
+	}{
+		{
+			given: v1alpha1.OPCUADevicePropertyVisitor{
+				NodeID:          "ns=1;s=Temperature",
+				ReadExpression:  "x / 10",
+				WriteExpression: "x * 10",
+				QueueSize:       &queueSize,
+			},
+			expected: false,
+		},
+		{
+			given: v1alpha1.OPCUADevicePropertyVisitor{
+				NodeID: "ns=1;s=Humidity",
+			},
+			expected: true,
+		},
+		{
+			given: v1alpha1.OPCUADevicePropertyVisitor{
+				NodeID:   "ns=1;s=Temperature",
+				Deadband: "1",
+			},
+			expected: true,
+		},
+		{
+			given: v1alpha1.OPCUADevicePropertyVisitor{
+				NodeID:           "ns=1;s=Temperature",
+				SamplingInterval: &metav1.Duration{Duration: time.Second},
+			},
+			expected: true,
+		},
+	}
+
+	for i, tc := range testCases {
+		var ret = isMonitoringChanged(stale, tc.given)
+		assert.Equal(t, tc.expected, ret, "case %v", i+1)
+	}
+}
@@ -0,0 +1,307 @@
+package physical
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gopcua/opcua/ua"
+	"github.com/gopcua/opcua/uapolicy"
+	"github.com/pkg/errors"
+
+	"github.com/rancher/octopus/adaptors/opcua/api/v1alpha1"
+	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha2"
+)
+
+const (
+	clientCertFile = "cert.pem"
+	clientKeyFile  = "key.pem"
+
+	clientCertValidity = 365 * 24 * time.Hour
+	// renews the generated certificate before it expires.
+	clientCertRenewal = 24 * time.Hour
+)
+
+// pkiDir is the directory to persist the generated client certificate,
+// which is mounted from the host and survives the restarting of adaptor.
+var pkiDir = filepath.Join(api.AdaptorPath, "opcua", "pki", "own")
+
+// pkiLock avoids the concurrent devices generating the client certificate at the same time.
+var pkiLock sync.Mutex
+
+// isAutoSecurity returns true if the policy or mode is selected from the endpoints of server.
+func isAutoSecurity(protocol v1alpha1.OPCUADeviceProtocol) bool {
+	return protocol.SecurityPolicy == v1alpha1.OPCUADeviceProtocolSecurityPolicyAuto ||
+		protocol.SecurityMode == v1alpha1.OPCUADeviceProtocolSecurityModeAuto
+}
+
+// selectEndpoint selects the endpoint matched the given policy and mode,
+// "Auto" policy or mode selects the most secure one which the client is able to use.
+func selectEndpoint(endpoints []*ua.EndpointDescription, policy, mode string, tokenType ua.UserTokenType, hasCertificate bool, requireSecurity bool) *ua.EndpointDescription {
+	var supportedPolicies = make(map[string]bool)
+	for _, p := range uapolicy.SupportedPolicies() {
+		supportedPolicies[p] = true
+	}
+
+	var candidates []*ua.EndpointDescription
+	for _, ep := range endpoints {
+		if ep == nil {
+			continue
+		}
+		if policy != string(v1alpha1.OPCUADeviceProtocolSecurityPolicyAuto) &&
+			ep.SecurityPolicyURI != ua.FormatSecurityPolicyURI(policy) {
+			continue
+		}
+		if mode != string(v1alpha1.OPCUADeviceProtocolSecurityModeAuto) &&
+			ep.SecurityMode != ua.MessageSecurityModeFromString(mode) {
+			continue
+		}
+		if !supportedPolicies[ep.SecurityPolicyURI] {
+			continue
+		}
+		var secured = ep.SecurityMode != ua.MessageSecurityModeNone
+		if secured && !hasCertificate {
+			// the secured channel must be signed by the client certificate.
+			continue
+		}
+		if !secured && requireSecurity {
+			continue
+		}
+		if !hasUserTokenType(ep, tokenType) {
+			continue
+		}
+		candidates = append(candidates, ep)
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].SecurityLevel != candidates[j].SecurityLevel {
+			return candidates[i].SecurityLevel > candidates[j].SecurityLevel
+		}
+		return candidates[i].SecurityMode > candidates[j].SecurityMode
+	})
+	return candidates[0]
+}
+
+// hasUserTokenType returns true if the endpoint accepts the given type of user identity token.
+func hasUserTokenType(ep *ua.EndpointDescription, tokenType ua.UserTokenType) bool {
+	// some servers don't list the anonymous token, which is accepted by default.
+	if len(ep.UserIdentityTokens) == 0 {
+		return tokenType == ua.UserTokenTypeAnonymous
+	}
+	for _, t := range ep.UserIdentityTokens {
+		if t.TokenType == tokenType {
+			return true
+		}
+	}
+	return false
+}
+
+// verifyServerCertificate verifies the certificate of endpoint with the trusted CA or the pinned thumbprint,
+// nothing to verify if neither of them is specified.
+func verifyServerCertificate(ep *ua.EndpointDescription, caPEM []byte, thumbprint string) error {
+	if len(caPEM) == 0 && thumbprint == "" {
+		return nil
+	}
+	if ep.SecurityMode == ua.MessageSecurityModeNone || ep.SecurityMode == ua.MessageSecurityModeInvalid {
+		return errors.Errorf("could not trust the server certificate via an unsecured endpoint %s", ep.EndpointURL)
+	}
+	if len(ep.ServerCertificate) == 0 {
+		return errors.Errorf("endpoint %s doesn't provide the server certificate", ep.EndpointURL)
+	}
+
+	// the server certificate may be followed by the issuer chain.
+	var certs, err = x509.ParseCertificates(ep.ServerCertificate)
+	if err != nil || len(certs) == 0 {
+		return errors.Wrap(err, "failed to parse server certificate")
+	}
+	var leaf = certs[0]
+
+	if thumbprint != "" {
+		var expected = normalizeThumbprint(thumbprint)
+		var actual = hex.EncodeToString(uapolicy.Thumbprint(leaf.Raw))
+		if expected != actual {
+			return errors.Errorf("the thumbprint %s of server certificate doesn't match the pinned one", actual)
+		}
+	}
+
+	if len(caPEM) != 0 {
+		var roots = x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(caPEM) {
+			return errors.Errorf("failed to get CA certificates from CA PEM content")
+		}
+		var intermediates = x509.NewCertPool()
+		for _, cert := range certs[1:] {
+			intermediates.AddCert(cert)
+		}
+		var _, err = leaf.Verify(x509.VerifyOptions{
+			Roots:         roots,
+			Intermediates: intermediates,
+			KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to verify server certificate")
+		}
+	}
+
+	// the application instance certificate must carry the same URI as the server application.
+	if ep.Server != nil && ep.Server.ApplicationURI != "" && len(leaf.URIs) != 0 {
+		var matched bool
+		for _, uri := range leaf.URIs {
+			if uri.String() == ep.Server.ApplicationURI {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return errors.Errorf("the server certificate doesn't belong to application %s", ep.Server.ApplicationURI)
+		}
+	}
+	return nil
+}
+
+// normalizeThumbprint converts the thumbprint to the lowercase hex without colons.
+func normalizeThumbprint(thumbprint string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(thumbprint), ":", ""))
+}
+
+// getApplicationURI returns the application URI of the generated client certificate,
+// which is stable on the same node.
+func getApplicationURI() string {
+	var host = os.Getenv("NODE_NAME")
+	if host == "" {
+		host, _ = os.Hostname()
+	}
+	return fmt.Sprintf("urn:%s:rancher:octopus:opcua", host)
+}
+
+// getCertificateApplicationURI returns the first URI of the certificate's subject alternative names,
+// which is the application URI of OPC-UA application instance certificate.
+func getCertificateApplicationURI(certDER []byte) string {
+	var cert, err = x509.ParseCertificate(certDER)
+	if err != nil || len(cert.URIs) == 0 {
+		return ""
+	}
+	return cert.URIs[0].String()
+}
+
+// loadOrGenerateClientCertificate loads the persisted client certificate under the given directory,
+// or generates a new self-signed one if the persisted one is missing, mismatched or going to expire.
+func loadOrGenerateClientCertificate(dir string, applicationURI string) ([]byte, *rsa.PrivateKey, error) {
+	pkiLock.Lock()
+	defer pkiLock.Unlock()
+
+	var certPath = filepath.Join(dir, clientCertFile)
+	var keyPath = filepath.Join(dir, clientKeyFile)
+	if cert, key, err := loadClientCertificate(certPath, keyPath, applicationURI); err == nil {
+		return cert, key, nil
+	}
+
+	var cert, key, err = generateClientCertificate(applicationURI)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to generate client certificate")
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to create PKI directory %s", dir)
+	}
+	var keyPEM = pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	if err := ioutil.WriteFile(keyPath, keyPEM, 0600); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to persist client key")
+	}
+	var certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert})
+	if err := ioutil.WriteFile(certPath, certPEM, 0644); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to persist client certificate")
+	}
+	return cert, key, nil
+}
+
+// loadClientCertificate loads the persisted client certificate and key,
+// returns an error if they are invalid for the given application URI.
+func loadClientCertificate(certPath, keyPath string, applicationURI string) ([]byte, *rsa.PrivateKey, error) {
+	var certEncodedPEM, err = ioutil.ReadFile(certPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyEncodedPEM, err := ioutil.ReadFile(keyPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	certDER, err := decodeCertificatePEM(certEncodedPEM)
+	if err != nil {
+		return nil, nil, err
+	}
+	key, err := decodeKeyPEM(keyEncodedPEM)
+	if err != nil {
+		return nil, nil, err
+	}
+	cert, err := x509.ParseCertificate(certDER)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(cert.URIs) == 0 || cert.URIs[0].String() != applicationURI {
+		return nil, nil, errors.Errorf("application URI is changed")
+	}
+	if time.Now().Add(clientCertRenewal).After(cert.NotAfter) {
+		return nil, nil, errors.Errorf("certificate is going to expire")
+	}
+	if pub, ok := cert.PublicKey.(*rsa.PublicKey); !ok || pub.N.Cmp(key.N) != 0 {
+		return nil, nil, errors.Errorf("private key doesn't match the certificate")
+	}
+	return certDER, key, nil
+}
+
+// generateClientCertificate generates a self-signed application instance certificate for the given application URI.
+func generateClientCertificate(applicationURI string) ([]byte, *rsa.PrivateKey, error) {
+	var uri, err = url.Parse(applicationURI)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to parse application URI %s", applicationURI)
+	}
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, nil, err
+	}
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var now = time.Now()
+	var template = &x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject: pkix.Name{
+			CommonName:   "octopus-adaptor-opcua",
+			Organization: []string{"Rancher"},
+		},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(clientCertValidity),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageContentCommitment | x509.KeyUsageKeyEncipherment | x509.KeyUsageDataEncipherment | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		URIs:                  []*url.URL{uri},
+	}
+	if host, _ := os.Hostname(); host != "" {
+		template.DNSNames = []string{host}
+	}
+
+	cert, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cert, key, nil
+}
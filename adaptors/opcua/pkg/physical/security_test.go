@@ -0,0 +1,439 @@
+package physical
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gopcua/opcua"
+	"github.com/gopcua/opcua/ua"
+	"github.com/gopcua/opcua/uapolicy"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/rancher/octopus/adaptors/opcua/api/v1alpha1"
+)
+
+// newTestCertificate issues a certificate for the given URI, it's self-signed if the parent is nil.
+func newTestCertificate(t *testing.T, uri string, isCA bool, parent *x509.Certificate, parentKey *rsa.PrivateKey) (*x509.Certificate, *rsa.PrivateKey) {
+	var key, err = rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var template = &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: uri},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment | x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+	}
+	if u, _ := url.Parse(uri); !isCA {
+		template.URIs = []*url.URL{u}
+	}
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, key
+}
+
+func TestSelectEndpoint(t *testing.T) {
+	var newEndpoint = func(policy string, mode ua.MessageSecurityMode, level uint8, tokenTypes ...ua.UserTokenType) *ua.EndpointDescription {
+		var ep = &ua.EndpointDescription{
+			EndpointURL:       "opc.tcp://" + policy + "/" + mode.String(),
+			SecurityPolicyURI: ua.FormatSecurityPolicyURI(policy),
+			SecurityMode:      mode,
+			SecurityLevel:     level,
+		}
+		for _, tokenType := range tokenTypes {
+			ep.UserIdentityTokens = append(ep.UserIdentityTokens, &ua.UserTokenPolicy{TokenType: tokenType})
+		}
+		return ep
+	}
+	var endpoints = []*ua.EndpointDescription{
+		newEndpoint("None", ua.MessageSecurityModeNone, 0, ua.UserTokenTypeAnonymous),
+		newEndpoint("Basic256", ua.MessageSecurityModeSign, 3, ua.UserTokenTypeAnonymous, ua.UserTokenTypeUserName),
+		newEndpoint("Basic256Sha256", ua.MessageSecurityModeSign, 5, ua.UserTokenTypeUserName),
+		newEndpoint("Basic256Sha256", ua.MessageSecurityModeSignAndEncrypt, 5, ua.UserTokenTypeUserName),
+		newEndpoint("Unknown", ua.MessageSecurityModeSignAndEncrypt, 10, ua.UserTokenTypeAnonymous, ua.UserTokenTypeUserName),
+	}
+
+	type given struct {
+		policy          string
+		mode            string
+		tokenType       ua.UserTokenType
+		hasCertificate  bool
+		requireSecurity bool
+	}
+
+	var testCases = []struct {
+		given    given
+		expected *ua.EndpointDescription
+	}{
+		{
+			given:    given{policy: "None", mode: "None", tokenType: ua.UserTokenTypeAnonymous},
+			expected: endpoints[0],
+		},
+		{
+			// without client certificate
+			given:    given{policy: "Auto", mode: "Auto", tokenType: ua.UserTokenTypeAnonymous},
+			expected: endpoints[0],
+		},
+		{
+			// the unsupported policy is skipped
+			given:    given{policy: "Auto", mode: "Auto", tokenType: ua.UserTokenTypeAnonymous, hasCertificate: true},
+			expected: endpoints[1],
+		},
+		{
+			// the stronger mode wins the same level
+			given:    given{policy: "Auto", mode: "Auto", tokenType: ua.UserTokenTypeUserName, hasCertificate: true},
+			expected: endpoints[3],
+		},
+		{
+			given:    given{policy: "Auto", mode: "Sign", tokenType: ua.UserTokenTypeUserName, hasCertificate: true},
+			expected: endpoints[2],
+		},
+		{
+			given:    given{policy: "Basic256", mode: "Auto", tokenType: ua.UserTokenTypeUserName, hasCertificate: true},
+			expected: endpoints[1],
+		},
+		{
+			// the trusted server must be accessed via a secured endpoint
+			given:    given{policy: "Auto", mode: "Auto", tokenType: ua.UserTokenTypeAnonymous, requireSecurity: true},
+			expected: nil,
+		},
+		{
+			given:    given{policy: "Basic256Sha256", mode: "SignAndEncrypt", tokenType: ua.UserTokenTypeAnonymous, hasCertificate: true},
+			expected: nil,
+		},
+	}
+
+	for i, tc := range testCases {
+		var ret = selectEndpoint(endpoints, tc.given.policy, tc.given.mode, tc.given.tokenType, tc.given.hasCertificate, tc.given.requireSecurity)
+		assert.Equal(t, tc.expected, ret, "case %v", i+1)
+	}
+}
+
+func TestVerifyServerCertificate(t *testing.T) {
+	var ca, caKey = newTestCertificate(t, "octopus-test-ca", true, nil, nil)
+	var caPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.Raw})
+	var issued, _ = newTestCertificate(t, "urn:octopus:test", false, ca, caKey)
+	var selfSigned, _ = newTestCertificate(t, "urn:octopus:test", false, nil, nil)
+	var otherApplication, _ = newTestCertificate(t, "urn:octopus:other", false, ca, caKey)
+
+	var newEndpoint = func(mode ua.MessageSecurityMode, cert ...*x509.Certificate) *ua.EndpointDescription {
+		var ep = &ua.EndpointDescription{
+			EndpointURL:  "opc.tcp://127.0.0.1:4840",
+			Server:       &ua.ApplicationDescription{ApplicationURI: "urn:octopus:test"},
+			SecurityMode: mode,
+		}
+		for _, c := range cert {
+			ep.ServerCertificate = append(ep.ServerCertificate, c.Raw...)
+		}
+		return ep
+	}
+	var colonThumbprint = func(cert *x509.Certificate) string {
+		var hexed = strings.ToUpper(hex.EncodeToString(uapolicy.Thumbprint(cert.Raw)))
+		var parts []string
+		for i := 0; i < len(hexed); i += 2 {
+			parts = append(parts, hexed[i:i+2])
+		}
+		return strings.Join(parts, ":")
+	}
+
+	type given struct {
+		ep         *ua.EndpointDescription
+		ca         []byte
+		thumbprint string
+	}
+
+	var testCases = []struct {
+		given    given
+		expected bool
+	}{
+		{
+			// nothing to verify
+			given:    given{ep: newEndpoint(ua.MessageSecurityModeNone)},
+			expected: true,
+		},
+		{
+			given:    given{ep: newEndpoint(ua.MessageSecurityModeSign, issued), ca: caPEM},
+			expected: true,
+		},
+		{
+			// with chain
+			given:    given{ep: newEndpoint(ua.MessageSecurityModeSignAndEncrypt, issued, ca), ca: caPEM},
+			expected: true,
+		},
+		{
+			given:    given{ep: newEndpoint(ua.MessageSecurityModeSign, selfSigned), ca: caPEM},
+			expected: false,
+		},
+		{
+			given:    given{ep: newEndpoint(ua.MessageSecurityModeSign, selfSigned), thumbprint: colonThumbprint(selfSigned)},
+			expected: true,
+		},
+		{
+			given:    given{ep: newEndpoint(ua.MessageSecurityModeSign, selfSigned), thumbprint: hex.EncodeToString(uapolicy.Thumbprint(selfSigned.Raw))},
+			expected: true,
+		},
+		{
+			given:    given{ep: newEndpoint(ua.MessageSecurityModeSign, issued), thumbprint: colonThumbprint(selfSigned)},
+			expected: false,
+		},
+		{
+			// the certificate belongs to another application
+			given:    given{ep: newEndpoint(ua.MessageSecurityModeSign, otherApplication), ca: caPEM},
+			expected: false,
+		},
+		{
+			given:    given{ep: newEndpoint(ua.MessageSecurityModeNone, issued), ca: caPEM},
+			expected: false,
+		},
+		{
+			given:    given{ep: newEndpoint(ua.MessageSecurityModeSign), thumbprint: colonThumbprint(selfSigned)},
+			expected: false,
+		},
+	}
+
+	for i, tc := range testCases {
+		var err = verifyServerCertificate(tc.given.ep, tc.given.ca, tc.given.thumbprint)
+		if tc.expected {
+			assert.NoError(t, err, "case %v", i+1)
+		} else {
+			assert.Error(t, err, "case %v", i+1)
+		}
+	}
+}
+
+func TestLoadOrGenerateClientCertificate(t *testing.T) {
+	var dir, err = ioutil.TempDir("", "opcua-pki")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	dir = filepath.Join(dir, "own")
+
+	// generates
+	cert, key, err := loadOrGenerateClientCertificate(dir, "urn:node1:rancher:octopus:opcua")
+	if !assert.NoError(t, err) {
+		return
+	}
+	parsed, err := x509.ParseCertificate(cert)
+	if !assert.NoError(t, err) {
+		return
+	}
+	if assert.Len(t, parsed.URIs, 1) {
+		assert.Equal(t, "urn:node1:rancher:octopus:opcua", parsed.URIs[0].String())
+	}
+	assert.Equal(t, 2048, key.N.BitLen())
+	assert.NoError(t, parsed.CheckSignature(parsed.SignatureAlgorithm, parsed.RawTBSCertificate, parsed.Signature))
+	assert.FileExists(t, filepath.Join(dir, clientCertFile))
+	if info, err := os.Stat(filepath.Join(dir, clientKeyFile)); assert.NoError(t, err) {
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	}
+
+	// reuses the persisted one
+	reused, reusedKey, err := loadOrGenerateClientCertificate(dir, "urn:node1:rancher:octopus:opcua")
+	if assert.NoError(t, err) {
+		assert.Equal(t, cert, reused)
+		assert.Equal(t, key.N, reusedKey.N)
+	}
+
+	// regenerates for another application URI
+	regenerated, _, err := loadOrGenerateClientCertificate(dir, "urn:node2:rancher:octopus:opcua")
+	if assert.NoError(t, err) {
+		assert.NotEqual(t, cert, regenerated)
+	}
+
+	// regenerates the corrupted one
+	if err := ioutil.WriteFile(filepath.Join(dir, clientKeyFile), []byte("corrupted"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	recovered, _, err := loadOrGenerateClientCertificate(dir, "urn:node2:rancher:octopus:opcua")
+	if assert.NoError(t, err) {
+		assert.NotEqual(t, regenerated, recovered)
+	}
+}
+
+func TestGetCertificateApplicationURI(t *testing.T) {
+	var cert, _ = newTestCertificate(t, "urn:node1:rancher:octopus:opcua", false, nil, nil)
+	var caCert, _ = newTestCertificate(t, "urn:ca", true, nil, nil)
+
+	var testCases = []struct {
+		given    []byte
+		expected string
+	}{
+		{
+			given:    cert.Raw,
+			expected: "urn:node1:rancher:octopus:opcua",
+		},
+		{
+			// without URI SAN
+			given:    caCert.Raw,
+			expected: "",
+		},
+		{
+			given:    []byte("unparsable"),
+			expected: "",
+		},
+	}
+
+	for i, tc := range testCases {
+		var ret = getCertificateApplicationURI(tc.given)
+		assert.Equal(t, tc.expected, ret, "case %v", i+1)
+	}
+}
+
+func TestNewOPCUAClientOptions_ApplicationURI(t *testing.T) {
+	var server = newTestServer(t)
+	defer server.Close()
+
+	var dir, err = ioutil.TempDir("", "opcua-pki")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	var previousPKIDir = pkiDir
+	pkiDir = dir
+	defer func() { pkiDir = previousPKIDir }()
+
+	var cert, key = newTestCertificate(t, "urn:supplied:rancher:octopus:opcua", false, nil, nil)
+	var certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+	var keyPEM = pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	var testCases = []struct {
+		given    *v1alpha1.OPCUADeviceProtocolTLS
+		expected string
+	}{
+		{
+			given: &v1alpha1.OPCUADeviceProtocolTLS{
+				GenerateClientCert: true,
+			},
+			expected: getApplicationURI(),
+		},
+		{
+			given: &v1alpha1.OPCUADeviceProtocolTLS{
+				CertFilePEM: string(certPEM),
+				KeyFilePEM:  string(keyPEM),
+			},
+			expected: "urn:supplied:rancher:octopus:opcua",
+		},
+	}
+
+	for i, tc := range testCases {
+		var options, err = newOPCUAClientOptions(v1alpha1.OPCUADeviceProtocol{
+			Endpoint:       server.endpoint,
+			SecurityPolicy: v1alpha1.OPCUADeviceProtocolSecurityPolicyAuto,
+			SecurityMode:   v1alpha1.OPCUADeviceProtocolSecurityModeAuto,
+			TLSConfig:      tc.given,
+		}, 2*time.Second, nil)
+		if !assert.NoError(t, err, "case %v", i+1) {
+			continue
+		}
+		var _, sessionConfig = opcua.ApplyConfig(options...)
+		assert.Equal(t, tc.expected, sessionConfig.ClientDescription.ApplicationURI, "case %v", i+1)
+	}
+}
+
+func TestNewOPCUAClient_Security(t *testing.T) {
+	var server = newTestServer(t)
+	defer server.Close()
+
+	var dir, err = ioutil.TempDir("", "opcua-pki")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	var previousPKIDir = pkiDir
+	pkiDir = dir
+	defer func() { pkiDir = previousPKIDir }()
+
+	var testCases = []struct {
+		given    v1alpha1.OPCUADeviceProtocol
+		expected bool
+	}{
+		{
+			given: v1alpha1.OPCUADeviceProtocol{
+				Endpoint:       server.endpoint,
+				SecurityPolicy: v1alpha1.OPCUADeviceProtocolSecurityPolicyAuto,
+				SecurityMode:   v1alpha1.OPCUADeviceProtocolSecurityModeAuto,
+			},
+			expected: true,
+		},
+		{
+			// the generated certificate isn't needed by the unsecured endpoint
+			given: v1alpha1.OPCUADeviceProtocol{
+				Endpoint:       server.endpoint,
+				SecurityPolicy: v1alpha1.OPCUADeviceProtocolSecurityPolicyAuto,
+				SecurityMode:   v1alpha1.OPCUADeviceProtocolSecurityModeAuto,
+				TLSConfig: &v1alpha1.OPCUADeviceProtocolTLS{
+					GenerateClientCert: true,
+				},
+			},
+			expected: true,
+		},
+		{
+			// the server doesn't provide a secured endpoint
+			given: v1alpha1.OPCUADeviceProtocol{
+				Endpoint:       server.endpoint,
+				SecurityPolicy: v1alpha1.OPCUADeviceProtocolSecurityPolicyAuto,
+				SecurityMode:   v1alpha1.OPCUADeviceProtocolSecurityModeAuto,
+				TLSConfig: &v1alpha1.OPCUADeviceProtocolTLS{
+					GenerateClientCert:   true,
+					ServerCertThumbprint: "9A:3C:00:00:00:00:00:00:00:00:00:00:00:00:00:00:00:00:00:00",
+				},
+			},
+			expected: false,
+		},
+		{
+			given: v1alpha1.OPCUADeviceProtocol{
+				Endpoint:       server.endpoint,
+				SecurityPolicy: "Basic256Sha256",
+				SecurityMode:   "SignAndEncrypt",
+			},
+			expected: false,
+		},
+		{
+			// neither the certificate nor generating
+			given: v1alpha1.OPCUADeviceProtocol{
+				Endpoint:       server.endpoint,
+				SecurityPolicy: v1alpha1.OPCUADeviceProtocolSecurityPolicyAuto,
+				SecurityMode:   v1alpha1.OPCUADeviceProtocolSecurityModeAuto,
+				TLSConfig:      &v1alpha1.OPCUADeviceProtocolTLS{},
+			},
+			expected: false,
+		},
+	}
+
+	for i, tc := range testCases {
+		var client, err = NewOPCUAClient(tc.given, 2*time.Second, nil)
+		if !tc.expected {
+			assert.Error(t, err, "case %v", i+1)
+			continue
+		}
+		if assert.NoError(t, err, "case %v", i+1) {
+			_ = client.Close()
+		}
+	}
+	assert.FileExists(t, filepath.Join(dir, clientCertFile))
+}
@@ -0,0 +1,639 @@
+package physical
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gopcua/opcua/id"
+	"github.com/gopcua/opcua/ua"
+	"github.com/gopcua/opcua/uacp"
+	"github.com/gopcua/opcua/uasc"
+)
+
+// testMethod simulates the method of OPC-UA server.
+type testMethod func(inputs []*ua.Variant) ([]*ua.Variant, ua.StatusCode)
+
+// testMonitoredItem records the monitored item created by client.
+type testMonitoredItem struct {
+	id             uint32
+	subscriptionID uint32
+	nodeID         string
+	attributeID    ua.AttributeID
+	handle         uint32
+	parameters     *ua.MonitoringParameters
+	filter         *ua.EventFilter
+}
+
+// testServer is a minimal in-process OPC-UA server,
+// which only supports the "None" security policy and the anonymous authentication.
+type testServer struct {
+	sync.Mutex
+
+	ctx      context.Context
+	cancel   context.CancelFunc
+	endpoint string
+	listener *uacp.Listener
+	// conns are indexed by the connection, the value is used to stop receiving.
+	conns map[*uacp.Conn]context.CancelFunc
+
+	sessionID uint32
+	// sessions records the authentication tokens of the created sessions.
+	sessions       map[string]bool
+	methods        map[string]testMethod
+	calls          []*ua.CallMethodRequest
+	subscriptionID uint32
+	// subscriptions records the last sequence number of the created subscriptions.
+	subscriptions map[uint32]uint32
+	itemID        uint32
+	items         []testMonitoredItem
+	pending       []*ua.PublishResponse
+	// retained records the unacknowledged notification messages by subscription ID and sequence number.
+	retained map[uint32]map[uint32]*ua.NotificationMessage
+	notify   chan struct{}
+}
+
+// newTestServer starts a test server on a random local port.
+func newTestServer(t *testing.T) *testServer {
+	// the endpoint URL of OPC-UA must be the same as the one in the hello message,
+	// so a free port is picked at first.
+	var l, err = net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to pick a free port: %v", err)
+	}
+	var endpoint = fmt.Sprintf("opc.tcp://%s", l.Addr().String())
+	_ = l.Close()
+
+	listener, err := uacp.Listen(endpoint, nil)
+	if err != nil {
+		t.Fatalf("failed to listen on %s: %v", endpoint, err)
+	}
+	var ctx, cancel = context.WithCancel(context.Background())
+	var s = &testServer{
+		ctx:           ctx,
+		cancel:        cancel,
+		endpoint:      endpoint,
+		listener:      listener,
+		conns:         map[*uacp.Conn]context.CancelFunc{},
+		sessions:      map[string]bool{},
+		methods:       map[string]testMethod{},
+		subscriptions: map[uint32]uint32{},
+		retained:      map[uint32]map[uint32]*ua.NotificationMessage{},
+		notify:        make(chan struct{}, 1),
+	}
+	go s.accept()
+	return s
+}
+
+func (s *testServer) Close() {
+	// the secure channel keeps receiving if the error is not EOF,
+	// so it is stopped via the context before closing the connections.
+	s.cancel()
+	_ = s.listener.Close()
+
+	s.Lock()
+	defer s.Unlock()
+	s.closeConns()
+}
+
+// Disconnect closes the connections like a network interruption,
+// the sessions and subscriptions are kept.
+func (s *testServer) Disconnect() {
+	s.Lock()
+	defer s.Unlock()
+	s.closeConns()
+}
+
+// ExpireSessions closes the connections and forgets the sessions,
+// the subscriptions are kept to be transferred.
+func (s *testServer) ExpireSessions() {
+	s.Lock()
+	defer s.Unlock()
+	s.closeConns()
+	s.sessions = map[string]bool{}
+}
+
+// Restart closes the connections and forgets all sessions and subscriptions like a restarted server.
+func (s *testServer) Restart() {
+	s.Lock()
+	defer s.Unlock()
+	s.closeConns()
+	s.sessions = map[string]bool{}
+	s.subscriptions = map[uint32]uint32{}
+	s.items = nil
+	s.pending = nil
+	s.retained = map[uint32]map[uint32]*ua.NotificationMessage{}
+}
+
+// DropPending drops the queued notification messages like the responses lost in transit,
+// the messages are still retained for republishing.
+func (s *testServer) DropPending() {
+	s.Lock()
+	defer s.Unlock()
+	s.pending = nil
+}
+
+func (s *testServer) closeConns() {
+	for conn, cancel := range s.conns {
+		cancel()
+		_ = conn.Close()
+	}
+	s.conns = map[*uacp.Conn]context.CancelFunc{}
+}
+
+// enqueue queues the notification message of the given subscription,
+// the message is retained until acknowledged.
+func (s *testServer) enqueue(subscriptionID uint32, data *ua.ExtensionObject) {
+	var seq, exist = s.subscriptions[subscriptionID]
+	if !exist {
+		return
+	}
+	seq++
+	s.subscriptions[subscriptionID] = seq
+
+	var msg = &ua.NotificationMessage{
+		SequenceNumber:   seq,
+		PublishTime:      time.Now(),
+		NotificationData: []*ua.ExtensionObject{data},
+	}
+	if s.retained[subscriptionID] == nil {
+		s.retained[subscriptionID] = map[uint32]*ua.NotificationMessage{}
+	}
+	s.retained[subscriptionID][seq] = msg
+	s.pending = append(s.pending, &ua.PublishResponse{
+		SubscriptionID:      subscriptionID,
+		NotificationMessage: msg,
+	})
+}
+
+// AddMethod registers the method with the given node ID.
+func (s *testServer) AddMethod(methodNodeID string, method testMethod) {
+	s.Lock()
+	defer s.Unlock()
+	s.methods[methodNodeID] = method
+}
+
+// Calls returns the received call requests.
+func (s *testServer) Calls() []*ua.CallMethodRequest {
+	s.Lock()
+	defer s.Unlock()
+	return append([]*ua.CallMethodRequest(nil), s.calls...)
+}
+
+// Items returns the monitored items.
+func (s *testServer) Items() []testMonitoredItem {
+	s.Lock()
+	defer s.Unlock()
+	return append([]testMonitoredItem(nil), s.items...)
+}
+
+// FireEvent notifies the event with the given type and fields to the event monitored items of the given node,
+// the fields are selected by the last browse name of select clauses.
+func (s *testServer) FireEvent(nodeID string, eventType string, fields map[string]*ua.Variant) {
+	s.Lock()
+	defer s.Unlock()
+
+	var events = map[uint32][]*ua.EventFieldList{}
+	for _, item := range s.items {
+		if item.attributeID != ua.AttributeIDEventNotifier || item.nodeID != nodeID || item.filter == nil {
+			continue
+		}
+		if item.filter.WhereClause != nil && len(item.filter.WhereClause.Elements) != 0 {
+			if !evaluateTestContentFilter(item.filter.WhereClause.Elements, 0, eventType, fields) {
+				continue
+			}
+		}
+		var event = &ua.EventFieldList{ClientHandle: item.handle}
+		for _, clause := range item.filter.SelectClauses {
+			var field = fields[clause.BrowsePath[len(clause.BrowsePath)-1].Name]
+			if field == nil {
+				field = &ua.Variant{}
+			}
+			event.EventFields = append(event.EventFields, field)
+		}
+		events[item.subscriptionID] = append(events[item.subscriptionID], event)
+	}
+
+	for subscriptionID, list := range events {
+		s.enqueue(subscriptionID, newExtensionObject(id.EventNotificationList_Encoding_DefaultBinary, &ua.EventNotificationList{Events: list}))
+	}
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
+
+// ChangeValue notifies the value of the given node to the value monitored items of the node.
+func (s *testServer) ChangeValue(nodeID string, value *ua.Variant) {
+	s.Lock()
+	defer s.Unlock()
+
+	var changes = map[uint32][]*ua.MonitoredItemNotification{}
+	for _, item := range s.items {
+		if item.attributeID != ua.AttributeIDValue || item.nodeID != nodeID {
+			continue
+		}
+		changes[item.subscriptionID] = append(changes[item.subscriptionID], &ua.MonitoredItemNotification{
+			ClientHandle: item.handle,
+			Value: &ua.DataValue{
+				EncodingMask:    ua.DataValueValue | ua.DataValueSourceTimestamp,
+				Value:           value,
+				SourceTimestamp: time.Now(),
+			},
+		})
+	}
+
+	for subscriptionID, list := range changes {
+		s.enqueue(subscriptionID, newExtensionObject(id.DataChangeNotification_Encoding_DefaultBinary, &ua.DataChangeNotification{
+			MonitoredItems:  list,
+			DiagnosticInfos: []*ua.DiagnosticInfo{},
+		}))
+	}
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (s *testServer) accept() {
+	for {
+		var conn, err = s.listener.Accept(s.ctx)
+		if err != nil {
+			if _, ok := err.(net.Error); ok {
+				return
+			}
+			continue
+		}
+		var ctx, cancel = context.WithCancel(s.ctx)
+		s.Lock()
+		s.conns[conn] = cancel
+		s.Unlock()
+		go s.serve(ctx, conn)
+	}
+}
+
+func (s *testServer) serve(ctx context.Context, conn *uacp.Conn) {
+	defer conn.Close()
+
+	var sc, err = uasc.NewSecureChannel(s.endpoint, conn, &uasc.Config{SecurityPolicyURI: ua.SecurityPolicyURINone})
+	if err != nil {
+		return
+	}
+	for {
+		var msg = sc.Receive(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+		if msg.Err == io.EOF {
+			return
+		}
+		if _, ok := msg.Err.(*uacp.Error); ok {
+			return
+		}
+		var req, ok = msg.V.(ua.Request)
+		if msg.Err != nil || !ok {
+			continue
+		}
+		// the requests are responded in order,
+		// the secure channel takes the ID of the last received request as the response's.
+		if err := sc.SendResponse(s.handle(req)); err != nil {
+			return
+		}
+	}
+}
+
+func (s *testServer) handle(req ua.Request) ua.Response {
+	var header = &ua.ResponseHeader{
+		Timestamp:          time.Now(),
+		RequestHandle:      req.Header().RequestHandle,
+		ServiceDiagnostics: &ua.DiagnosticInfo{},
+		AdditionalHeader:   ua.NewExtensionObject(nil),
+	}
+
+	switch r := req.(type) {
+	case *ua.GetEndpointsRequest:
+		return &ua.GetEndpointsResponse{
+			ResponseHeader: header,
+			Endpoints:      []*ua.EndpointDescription{s.endpointDescription()},
+		}
+	case *ua.CreateSessionRequest:
+		s.Lock()
+		defer s.Unlock()
+		s.sessionID++
+		var token = ua.NewNumericNodeID(1, 1000+s.sessionID)
+		s.sessions[token.String()] = true
+		return &ua.CreateSessionResponse{
+			ResponseHeader:        header,
+			SessionID:             ua.NewNumericNodeID(1, s.sessionID),
+			AuthenticationToken:   token,
+			RevisedSessionTimeout: r.RequestedSessionTimeout,
+			ServerEndpoints:       []*ua.EndpointDescription{s.endpointDescription()},
+			ServerSignature:       &ua.SignatureData{},
+		}
+	case *ua.ActivateSessionRequest:
+		s.Lock()
+		defer s.Unlock()
+		if token := r.RequestHeader.AuthenticationToken; token == nil || !s.sessions[token.String()] {
+			header.ServiceResult = ua.StatusBadSessionIDInvalid
+			return &ua.ServiceFault{
+				ResponseHeader: header,
+			}
+		}
+		return &ua.ActivateSessionResponse{
+			ResponseHeader: header,
+		}
+	case *ua.CloseSessionRequest:
+		s.Lock()
+		defer s.Unlock()
+		if token := r.RequestHeader.AuthenticationToken; token != nil {
+			delete(s.sessions, token.String())
+		}
+		return &ua.CloseSessionResponse{
+			ResponseHeader: header,
+		}
+	case *ua.ReadRequest:
+		var results = make([]*ua.DataValue, 0, len(r.NodesToRead))
+		for range r.NodesToRead {
+			results = append(results, &ua.DataValue{
+				EncodingMask: ua.DataValueValue,
+				Value:        ua.MustVariant(int32(0)),
+			})
+		}
+		return &ua.ReadResponse{
+			ResponseHeader:  header,
+			Results:         results,
+			DiagnosticInfos: []*ua.DiagnosticInfo{},
+		}
+	case *ua.WriteRequest:
+		return &ua.WriteResponse{
+			ResponseHeader: header,
+			Results:        make([]ua.StatusCode, len(r.NodesToWrite)),
+		}
+	case *ua.CallRequest:
+		s.Lock()
+		defer s.Unlock()
+		var results = make([]*ua.CallMethodResult, 0, len(r.MethodsToCall))
+		for _, call := range r.MethodsToCall {
+			s.calls = append(s.calls, call)
+			var method, ok = s.methods[call.MethodID.String()]
+			if !ok {
+				results = append(results, &ua.CallMethodResult{StatusCode: ua.StatusBadMethodInvalid})
+				continue
+			}
+			var outputs, code = method(call.InputArguments)
+			results = append(results, &ua.CallMethodResult{StatusCode: code, OutputArguments: outputs})
+		}
+		return &ua.CallResponse{
+			ResponseHeader: header,
+			Results:        results,
+		}
+	case *ua.CreateSubscriptionRequest:
+		s.Lock()
+		defer s.Unlock()
+		s.subscriptionID++
+		s.subscriptions[s.subscriptionID] = 0
+		return &ua.CreateSubscriptionResponse{
+			ResponseHeader:            header,
+			SubscriptionID:            s.subscriptionID,
+			RevisedPublishingInterval: r.RequestedPublishingInterval,
+			RevisedLifetimeCount:      r.RequestedLifetimeCount,
+			RevisedMaxKeepAliveCount:  r.RequestedMaxKeepAliveCount,
+		}
+	case *ua.CreateMonitoredItemsRequest:
+		s.Lock()
+		defer s.Unlock()
+		var results = make([]*ua.MonitoredItemCreateResult, 0, len(r.ItemsToCreate))
+		for _, item := range r.ItemsToCreate {
+			s.itemID++
+			var monitored = testMonitoredItem{
+				id:             s.itemID,
+				subscriptionID: r.SubscriptionID,
+				nodeID:         item.ItemToMonitor.NodeID.String(),
+				attributeID:    item.ItemToMonitor.AttributeID,
+				handle:         item.RequestedParameters.ClientHandle,
+				parameters:     item.RequestedParameters,
+			}
+			if filter := item.RequestedParameters.Filter; filter != nil {
+				monitored.filter, _ = filter.Value.(*ua.EventFilter)
+			}
+			s.items = append(s.items, monitored)
+			results = append(results, &ua.MonitoredItemCreateResult{
+				StatusCode:       ua.StatusOK,
+				MonitoredItemID:  monitored.id,
+				RevisedQueueSize: item.RequestedParameters.QueueSize,
+				FilterResult:     ua.NewExtensionObject(nil),
+			})
+		}
+		return &ua.CreateMonitoredItemsResponse{
+			ResponseHeader: header,
+			Results:        results,
+		}
+	case *ua.DeleteMonitoredItemsRequest:
+		s.Lock()
+		defer s.Unlock()
+		var deleted = map[uint32]bool{}
+		for _, itemID := range r.MonitoredItemIDs {
+			deleted[itemID] = true
+		}
+		var items = s.items[:0]
+		for _, item := range s.items {
+			if item.subscriptionID != r.SubscriptionID || !deleted[item.id] {
+				items = append(items, item)
+			}
+		}
+		s.items = items
+		return &ua.DeleteMonitoredItemsResponse{
+			ResponseHeader:  header,
+			Results:         make([]ua.StatusCode, len(r.MonitoredItemIDs)),
+			DiagnosticInfos: []*ua.DiagnosticInfo{},
+		}
+	case *ua.DeleteSubscriptionsRequest:
+		s.Lock()
+		defer s.Unlock()
+		var deleted = map[uint32]bool{}
+		for _, subscriptionID := range r.SubscriptionIDs {
+			deleted[subscriptionID] = true
+			delete(s.subscriptions, subscriptionID)
+			delete(s.retained, subscriptionID)
+		}
+		var items = s.items[:0]
+		for _, item := range s.items {
+			if !deleted[item.subscriptionID] {
+				items = append(items, item)
+			}
+		}
+		s.items = items
+		return &ua.DeleteSubscriptionsResponse{
+			ResponseHeader: header,
+			Results:        make([]ua.StatusCode, len(r.SubscriptionIDs)),
+		}
+	case *ua.PublishRequest:
+		// the pending notifications are waited for a while, otherwise a keep-alive message is responded.
+		select {
+		case <-s.notify:
+		case <-time.After(50 * time.Millisecond):
+		}
+		s.Lock()
+		defer s.Unlock()
+		var results = make([]ua.StatusCode, 0, len(r.SubscriptionAcknowledgements))
+		for _, ack := range r.SubscriptionAcknowledgements {
+			if _, exist := s.retained[ack.SubscriptionID][ack.SequenceNumber]; !exist {
+				results = append(results, ua.StatusBadSequenceNumberUnknown)
+				continue
+			}
+			delete(s.retained[ack.SubscriptionID], ack.SequenceNumber)
+			results = append(results, ua.StatusOK)
+		}
+		var res = &ua.PublishResponse{
+			NotificationMessage: &ua.NotificationMessage{PublishTime: time.Now()},
+		}
+		if len(s.pending) != 0 {
+			res = s.pending[0]
+			s.pending = s.pending[1:]
+			if len(s.pending) != 0 {
+				select {
+				case s.notify <- struct{}{}:
+				default:
+				}
+			}
+		}
+		res.ResponseHeader = header
+		res.Results = results
+		return res
+	case *ua.RepublishRequest:
+		s.Lock()
+		defer s.Unlock()
+		var msg, exist = s.retained[r.SubscriptionID][r.RetransmitSequenceNumber]
+		if !exist {
+			header.ServiceResult = ua.StatusBadMessageNotAvailable
+			return &ua.ServiceFault{
+				ResponseHeader: header,
+			}
+		}
+		return &ua.RepublishResponse{
+			ResponseHeader:      header,
+			NotificationMessage: msg,
+		}
+	case *ua.TransferSubscriptionsRequest:
+		s.Lock()
+		defer s.Unlock()
+		var results = make([]*ua.TransferResult, 0, len(r.SubscriptionIDs))
+		for _, subscriptionID := range r.SubscriptionIDs {
+			if _, exist := s.subscriptions[subscriptionID]; !exist {
+				results = append(results, &ua.TransferResult{StatusCode: ua.StatusBadSubscriptionIDInvalid})
+				continue
+			}
+			var result = &ua.TransferResult{StatusCode: ua.StatusOK}
+			for seq := range s.retained[subscriptionID] {
+				result.AvailableSequenceNumbers = append(result.AvailableSequenceNumbers, seq)
+			}
+			results = append(results, result)
+		}
+		return &ua.TransferSubscriptionsResponse{
+			ResponseHeader:  header,
+			Results:         results,
+			DiagnosticInfos: []*ua.DiagnosticInfo{},
+		}
+	default:
+		header.ServiceResult = ua.StatusBadServiceUnsupported
+		return &ua.ServiceFault{
+			ResponseHeader: header,
+		}
+	}
+}
+
+func (s *testServer) endpointDescription() *ua.EndpointDescription {
+	var name = &ua.LocalizedText{Text: "octopus-test"}
+	name.UpdateMask()
+	return &ua.EndpointDescription{
+		EndpointURL: s.endpoint,
+		Server: &ua.ApplicationDescription{
+			ApplicationURI:  "urn:octopus:test",
+			ApplicationName: name,
+			ApplicationType: ua.ApplicationTypeServer,
+		},
+		SecurityMode:      ua.MessageSecurityModeNone,
+		SecurityPolicyURI: ua.SecurityPolicyURINone,
+		UserIdentityTokens: []*ua.UserTokenPolicy{
+			{PolicyID: "anonymous", TokenType: ua.UserTokenTypeAnonymous},
+		},
+		TransportProfileURI: "http://opcfoundation.org/UA-Profile/Transport/uatcp-uasc-uabinary",
+	}
+}
+
+// evaluateTestContentFilter evaluates the element of content filter with the given event.
+func evaluateTestContentFilter(elements []*ua.ContentFilterElement, idx uint32, eventType string, fields map[string]*ua.Variant) bool {
+	if int(idx) >= len(elements) {
+		return false
+	}
+	var element = elements[idx]
+	switch element.FilterOperator {
+	case ua.FilterOperatorAnd:
+		for _, operand := range element.FilterOperands {
+			var ref, ok = operand.Value.(*ua.ElementOperand)
+			if !ok || !evaluateTestContentFilter(elements, ref.Index, eventType, fields) {
+				return false
+			}
+		}
+		return true
+	case ua.FilterOperatorOfType:
+		var literal, ok = element.FilterOperands[0].Value.(*ua.LiteralOperand)
+		return ok && literal.Value.NodeID().String() == eventType
+	}
+
+	if len(element.FilterOperands) != 2 {
+		return false
+	}
+	var field, ok = element.FilterOperands[0].Value.(*ua.SimpleAttributeOperand)
+	if !ok {
+		return false
+	}
+	literal, ok := element.FilterOperands[1].Value.(*ua.LiteralOperand)
+	if !ok {
+		return false
+	}
+	var actual = fields[field.BrowsePath[len(field.BrowsePath)-1].Name]
+	if actual == nil {
+		return false
+	}
+
+	var cmp int
+	var left, leftErr = strconv.ParseFloat(fmt.Sprint(actual.Value()), 64)
+	var right, rightErr = strconv.ParseFloat(fmt.Sprint(literal.Value.Value()), 64)
+	if leftErr == nil && rightErr == nil {
+		switch {
+		case left < right:
+			cmp = -1
+		case left > right:
+			cmp = 1
+		}
+	} else {
+		var left, right = VariantToString(actual.Type(), actual), VariantToString(literal.Value.Type(), literal.Value)
+		switch {
+		case left < right:
+			cmp = -1
+		case left > right:
+			cmp = 1
+		}
+	}
+	switch element.FilterOperator {
+	case ua.FilterOperatorEquals:
+		return cmp == 0
+	case ua.FilterOperatorGreaterThan:
+		return cmp > 0
+	case ua.FilterOperatorGreaterThanOrEqual:
+		return cmp >= 0
+	case ua.FilterOperatorLessThan:
+		return cmp < 0
+	case ua.FilterOperatorLessThanOrEqual:
+		return cmp <= 0
+	}
+	return false
+}
@@ -0,0 +1,882 @@
+package physical
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/gopcua/opcua"
+	"github.com/gopcua/opcua/id"
+	"github.com/gopcua/opcua/ua"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/runtime"
+
+	"github.com/rancher/octopus/adaptors/opcua/api/v1alpha1"
+	"github.com/rancher/octopus/adaptors/opcua/pkg/metadata"
+	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha2"
+	"github.com/rancher/octopus/pkg/adaptor/socket/handler"
+)
+
+// maxRepublishMessages limits the amount of notification messages to republish at once.
+const maxRepublishMessages = 100
+
+// sessionStatusHandler receives the status of session when the session is lost or recovered.
+type sessionStatusHandler func(s *session, status v1alpha1.OPCUADeviceStatusSession)
+
+// session supervises the lifecycle of OPC-UA client,
+// it publishes all subscriptions in one loop to detect the loss of secure channel or session,
+// then reconnects with backoff and recovers the subscriptions.
+type session struct {
+	sync.Mutex
+
+	log            logr.Logger
+	endpoint       string
+	options        []opcua.Option
+	timeout        time.Duration
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	handler        sessionStatusHandler
+
+	client *opcua.Client
+	status v1alpha1.OPCUADeviceStatusSession
+	// subscriptions are indexed by the subscription ID.
+	subscriptions map[uint32]*subscription
+	acks          []*ua.SubscriptionAcknowledgement
+	wake          chan struct{}
+	stop          chan struct{}
+}
+
+// newSession connects to the OPC-UA server and supervises the session in backend.
+func newSession(log logr.Logger, protocol v1alpha1.OPCUADeviceProtocol, params *v1alpha1.OPCUADeviceParameters, references api.ReferencesHandler, handler sessionStatusHandler) (*session, error) {
+	var timeout = params.GetTimeout()
+	var options, err = newOPCUAClientOptions(protocol, timeout, references)
+	if err != nil {
+		return nil, err
+	}
+
+	var s = &session{
+		log:            log,
+		endpoint:       protocol.Endpoint,
+		options:        options,
+		timeout:        timeout,
+		initialBackoff: params.GetReconnectInitialBackoff(),
+		maxBackoff:     params.GetReconnectMaxBackoff(),
+		handler:        handler,
+		subscriptions:  make(map[uint32]*subscription),
+		wake:           make(chan struct{}, 1),
+		stop:           make(chan struct{}),
+	}
+
+	var ctx, cancel = context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	var client = opcua.NewClient(s.endpoint, s.options...)
+	if err := client.Connect(ctx); err != nil {
+		return nil, errors.Wrap(classifyConnectError(err), "failed to connect to OPC-UA endpoint")
+	}
+	s.client = client
+	s.status = v1alpha1.OPCUADeviceStatusSession{
+		State:       v1alpha1.OPCUADeviceSessionStateConnected,
+		ConnectedAt: now(),
+	}
+
+	go s.run()
+	return s, nil
+}
+
+// Client returns the current client, the client is replaced after reconnecting.
+func (s *session) Client() *opcua.Client {
+	s.Lock()
+	defer s.Unlock()
+	return s.client
+}
+
+// Status returns the last status of session.
+func (s *session) Status() v1alpha1.OPCUADeviceStatusSession {
+	s.Lock()
+	defer s.Unlock()
+	return *s.status.DeepCopy()
+}
+
+// Close stops the supervision and closes the client,
+// the subscriptions are deleted along with the session.
+func (s *session) Close() {
+	s.Lock()
+	defer s.Unlock()
+
+	select {
+	case <-s.stop:
+		return
+	default:
+	}
+	close(s.stop)
+	if err := s.client.Close(); err != nil {
+		if err != io.EOF {
+			s.log.Error(err, "Error closing OPC-UA connection")
+		}
+	}
+}
+
+// Subscribe creates a subscription in the given publishing interval,
+// the notifications are delivered to the given channel.
+func (s *session) Subscribe(interval time.Duration, notifyCh chan<- *opcua.PublishNotificationData) (*subscription, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	var id, err = s.createSubscription(s.client, interval)
+	if err != nil {
+		return nil, err
+	}
+	var sub = &subscription{
+		session:  s,
+		id:       id,
+		interval: interval,
+		notifyCh: notifyCh,
+		done:     make(chan struct{}),
+		items:    make(map[uint32]*subscriptionItem),
+	}
+	s.subscriptions[sub.id] = sub
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+	return sub, nil
+}
+
+// run publishes the subscriptions in one loop,
+// and recovers the session once the loss is detected.
+func (s *session) run() {
+	defer runtime.HandleCrash(handler.NewPanicsCleanupSocketHandler(metadata.Endpoint))
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		default:
+		}
+
+		var client, acks, interval, publishing = s.nextPublish()
+		if !publishing {
+			// the server is probed in idle, otherwise the loss cannot be detected without publishing.
+			select {
+			case <-s.stop:
+				return
+			case <-s.wake:
+				continue
+			case <-time.After(s.timeout):
+			}
+			if err := probe(client); isSessionLost(err) || err == ua.StatusBadTimeout {
+				s.recover(err)
+			}
+			continue
+		}
+
+		var res, err = publish(client, acks)
+		if err != nil {
+			s.requeue(acks)
+			switch {
+			case err == ua.StatusBadNoSubscription, err == ua.StatusBadTooManyPublishRequests:
+				s.sleep(interval)
+			case isSessionLost(err):
+				s.recover(err)
+			case err == ua.StatusBadTimeout:
+				// the server might hold the publish request longer than the timeout,
+				// so the session is only recovered if the server is unreachable, the missed message is republished later.
+				if err := probe(client); isSessionLost(err) || err == ua.StatusBadTimeout {
+					s.recover(err)
+				}
+			default:
+				s.log.Error(err, "Received error from publishing")
+				s.notifyError(err)
+				s.sleep(interval)
+			}
+			continue
+		}
+		s.dispatch(client, res)
+	}
+}
+
+// nextPublish returns the client and the acknowledgements for the next publish request,
+// it returns false if there are not any subscriptions to publish.
+func (s *session) nextPublish() (*opcua.Client, []*ua.SubscriptionAcknowledgement, time.Duration, bool) {
+	s.Lock()
+	defer s.Unlock()
+
+	var acks = s.acks
+	s.acks = nil
+	if acks == nil {
+		acks = []*ua.SubscriptionAcknowledgement{}
+	}
+	var interval time.Duration
+	for _, sub := range s.subscriptions {
+		if interval == 0 || sub.interval < interval {
+			interval = sub.interval
+		}
+	}
+	return s.client, acks, interval, len(s.subscriptions) != 0
+}
+
+// requeue puts back the acknowledgements of the failed publish request.
+func (s *session) requeue(acks []*ua.SubscriptionAcknowledgement) {
+	s.Lock()
+	defer s.Unlock()
+	s.acks = append(acks, s.acks...)
+}
+
+// sleep returns false if the session is closed during sleeping.
+func (s *session) sleep(d time.Duration) bool {
+	select {
+	case <-s.stop:
+		return false
+	case <-time.After(d):
+		return true
+	}
+}
+
+// dispatch delivers the notification message to the subscription,
+// the missed messages are republished before delivering.
+func (s *session) dispatch(client *opcua.Client, res *ua.PublishResponse) {
+	var msg = res.NotificationMessage
+	if msg == nil || len(msg.NotificationData) == 0 {
+		// keep-alive message
+		return
+	}
+
+	s.Lock()
+	s.acks = append(s.acks, &ua.SubscriptionAcknowledgement{
+		SubscriptionID: res.SubscriptionID,
+		SequenceNumber: msg.SequenceNumber,
+	})
+	var sub, exist = s.subscriptions[res.SubscriptionID]
+	if !exist {
+		s.Unlock()
+		return
+	}
+	var lastSequenceNumber = sub.lastSequenceNumber
+	if msg.SequenceNumber > sub.lastSequenceNumber {
+		sub.lastSequenceNumber = msg.SequenceNumber
+	}
+	s.Unlock()
+
+	// the republishing requests are sent without holding the lock,
+	// the sequence number is only changed by the publishing loop, so it's safe to acknowledge afterwards.
+	var msgs []*ua.NotificationMessage
+	if lastSequenceNumber != 0 && msg.SequenceNumber > lastSequenceNumber+1 {
+		msgs = s.republish(client, res.SubscriptionID, lastSequenceNumber+1, msg.SequenceNumber)
+		s.acknowledge(res.SubscriptionID, lastSequenceNumber+1, msgs)
+	}
+	msgs = append(msgs, msg)
+
+	for _, msg := range msgs {
+		if !sub.notify(msg) {
+			return
+		}
+	}
+}
+
+// republish requests the missed notification messages from the given sequence number,
+// it stops at the given until sequence number or the first unavailable message,
+// the returned messages are in the consecutive sequence numbers.
+func (s *session) republish(client *opcua.Client, id uint32, start, until uint32) []*ua.NotificationMessage {
+	var msgs []*ua.NotificationMessage
+	for seq := start; seq < start+maxRepublishMessages; seq++ {
+		if until != 0 && seq >= until {
+			break
+		}
+		var res *ua.RepublishResponse
+		var err = client.Send(&ua.RepublishRequest{
+			SubscriptionID:           id,
+			RetransmitSequenceNumber: seq,
+		}, func(v interface{}) error {
+			return assignResponse(v, &res)
+		})
+		if err != nil {
+			if err != ua.StatusBadMessageNotAvailable {
+				s.log.Error(err, "Unable to republish notification message", "id", id, "sequence", seq)
+			}
+			break
+		}
+		msgs = append(msgs, res.NotificationMessage)
+	}
+	return msgs
+}
+
+// acknowledge queues the acknowledgements of the republished messages for the next publish request.
+func (s *session) acknowledge(id uint32, start uint32, msgs []*ua.NotificationMessage) {
+	s.Lock()
+	defer s.Unlock()
+	for idx := range msgs {
+		s.acks = append(s.acks, &ua.SubscriptionAcknowledgement{
+			SubscriptionID: id,
+			SequenceNumber: start + uint32(idx),
+		})
+	}
+}
+
+// notifyError delivers the error to all subscriptions.
+func (s *session) notifyError(err error) {
+	s.Lock()
+	var subs = make([]*subscription, 0, len(s.subscriptions))
+	for _, sub := range s.subscriptions {
+		subs = append(subs, sub)
+	}
+	s.Unlock()
+
+	for _, sub := range subs {
+		sub.deliver(&opcua.PublishNotificationData{Error: err})
+	}
+}
+
+// recover reconnects with backoff until the session is recovered or closed,
+// the progress is reported via the status handler.
+func (s *session) recover(cause error) {
+	select {
+	case <-s.stop:
+		return
+	default:
+	}
+	s.log.Error(cause, "Lost OPC-UA session")
+	s.notifyError(errors.Wrap(cause, "lost OPC-UA session"))
+
+	// the session is detached before closing the client,
+	// otherwise the session is closed and the subscriptions are deleted along with it.
+	var staleClient = s.Client()
+	var stale, _ = staleClient.DetachSession()
+	_ = staleClient.Close()
+
+	var lostAt = now()
+	var reason = cause.Error()
+	for attempts := int32(0); ; attempts++ {
+		var backoff = s.getReconnectBackoff(attempts)
+		var nextReconnectAt = metav1.NewTime(time.Now().Add(backoff))
+		s.report(v1alpha1.OPCUADeviceStatusSession{
+			State:             v1alpha1.OPCUADeviceSessionStateReconnecting,
+			Reason:            reason,
+			ReconnectAttempts: attempts,
+			NextReconnectAt:   &nextReconnectAt,
+			LostAt:            lostAt,
+		})
+		if !s.sleep(backoff) {
+			return
+		}
+
+		var recovery, msgs, err = s.reconnect(stale)
+		if err != nil {
+			s.log.Error(err, "Unable to reconnect OPC-UA session", "attempts", attempts+1)
+			reason = err.Error()
+			continue
+		}
+
+		var republished int32
+		for sub, list := range msgs {
+			republished += int32(len(list))
+			for _, msg := range list {
+				if !sub.notify(msg) {
+					break
+				}
+			}
+		}
+		s.log.Info("Reconnected OPC-UA session", "attempts", attempts+1, "recovery", recovery, "republished", republished)
+		s.report(v1alpha1.OPCUADeviceStatusSession{
+			State:               v1alpha1.OPCUADeviceSessionStateConnected,
+			ReconnectAttempts:   attempts + 1,
+			Recovery:            recovery,
+			RepublishedMessages: republished,
+			LostAt:              lostAt,
+			ConnectedAt:         now(),
+		})
+		return
+	}
+}
+
+// recoveringSubscription is the snapshot of subscription to recover without holding the lock.
+type recoveringSubscription struct {
+	sub                *subscription
+	id                 uint32
+	interval           time.Duration
+	timestamps         ua.TimestampsToReturn
+	lastSequenceNumber uint32
+	handles            []uint32
+	requests           []*ua.MonitoredItemCreateRequest
+
+	// msgs are the republished messages of the transferred or reactivated subscription.
+	msgs []*ua.NotificationMessage
+	// recreatedID is the ID of the re-created subscription, it's zero if the subscription is not re-created.
+	recreatedID uint32
+	// recreatedItemIDs are the IDs of the re-created monitored items indexed by the client handle.
+	recreatedItemIDs map[uint32]uint32
+}
+
+// reconnect establishes a new secure channel and recovers the subscriptions,
+// it tries to activate the previous session at first, then transfers the subscriptions to a new session,
+// and finally re-creates the subscriptions which cannot be transferred.
+// The requests are sent without holding the lock, the client and subscriptions are replaced at last.
+func (s *session) reconnect(stale *opcua.Session) (recovery v1alpha1.OPCUADeviceSessionRecovery, msgs map[*subscription][]*ua.NotificationMessage, err error) {
+	select {
+	case <-s.stop:
+		return "", nil, errors.New("session has been closed")
+	default:
+	}
+
+	var ctx, cancel = context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	var recovering = s.snapshotSubscriptions()
+
+	if stale != nil {
+		var client = opcua.NewClient(s.endpoint, s.options...)
+		if err := client.Dial(ctx); err != nil {
+			return "", nil, errors.Wrap(err, "failed to dial OPC-UA endpoint")
+		}
+		if err := client.ActivateSession(stale); err == nil && client.Session() != nil {
+			for _, rs := range recovering {
+				rs.msgs = s.republish(client, rs.id, rs.lastSequenceNumber+1, 0)
+			}
+			msgs, err = s.applyRecovery(client, recovering)
+			if err != nil {
+				_ = client.Close()
+				return "", nil, err
+			}
+			return v1alpha1.OPCUADeviceSessionRecoveryReactivated, msgs, nil
+		} else if err != nil {
+			s.log.V(4).Info("Unable to activate the previous session", "error", err.Error())
+		}
+		_ = client.Close()
+	}
+
+	var client = opcua.NewClient(s.endpoint, s.options...)
+	if err := client.Connect(ctx); err != nil {
+		return "", nil, errors.Wrap(err, "failed to connect to OPC-UA endpoint")
+	}
+	defer func() {
+		if err != nil {
+			_ = client.Close()
+		}
+	}()
+
+	if len(recovering) != 0 {
+		var ids = make([]uint32, 0, len(recovering))
+		for _, rs := range recovering {
+			ids = append(ids, rs.id)
+		}
+		var res *ua.TransferSubscriptionsResponse
+		var transferErr = client.Send(&ua.TransferSubscriptionsRequest{
+			SubscriptionIDs:   ids,
+			SendInitialValues: true,
+		}, func(v interface{}) error {
+			return assignResponse(v, &res)
+		})
+		var results []*ua.TransferResult
+		if transferErr != nil {
+			s.log.V(4).Info("Unable to transfer subscriptions", "error", transferErr.Error())
+		} else {
+			results = res.Results
+		}
+
+		recovery = v1alpha1.OPCUADeviceSessionRecoveryTransferred
+		for idx, rs := range recovering {
+			if idx < len(results) && results[idx].StatusCode == ua.StatusOK {
+				rs.msgs = s.republish(client, rs.id, rs.lastSequenceNumber+1, 0)
+				continue
+			}
+			if err = s.recreateSubscription(client, rs); err != nil {
+				return "", nil, err
+			}
+			recovery = v1alpha1.OPCUADeviceSessionRecoveryRecreated
+		}
+	}
+
+	msgs, err = s.applyRecovery(client, recovering)
+	if err != nil {
+		return "", nil, err
+	}
+	return recovery, msgs, nil
+}
+
+// snapshotSubscriptions returns the snapshot of subscriptions in the order of subscription ID.
+func (s *session) snapshotSubscriptions() []*recoveringSubscription {
+	s.Lock()
+	defer s.Unlock()
+
+	var ret = make([]*recoveringSubscription, 0, len(s.subscriptions))
+	for _, sub := range s.subscriptions {
+		var rs = &recoveringSubscription{
+			sub:                sub,
+			id:                 sub.id,
+			interval:           sub.interval,
+			timestamps:         sub.timestamps,
+			lastSequenceNumber: sub.lastSequenceNumber,
+			handles:            make([]uint32, 0, len(sub.items)),
+		}
+		for handle := range sub.items {
+			rs.handles = append(rs.handles, handle)
+		}
+		sort.Slice(rs.handles, func(i, j int) bool { return rs.handles[i] < rs.handles[j] })
+		rs.requests = make([]*ua.MonitoredItemCreateRequest, 0, len(rs.handles))
+		for _, handle := range rs.handles {
+			rs.requests = append(rs.requests, sub.items[handle].request)
+		}
+		ret = append(ret, rs)
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].id < ret[j].id })
+	return ret
+}
+
+// applyRecovery replaces the client and updates the recovered subscriptions,
+// the subscription cancelled during reconnecting is skipped.
+func (s *session) applyRecovery(client *opcua.Client, recovering []*recoveringSubscription) (map[*subscription][]*ua.NotificationMessage, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	select {
+	case <-s.stop:
+		return nil, errors.New("session has been closed")
+	default:
+	}
+	s.client = client
+
+	var msgs = make(map[*subscription][]*ua.NotificationMessage, len(recovering))
+	for _, rs := range recovering {
+		var sub = rs.sub
+		if s.subscriptions[rs.id] != sub {
+			continue
+		}
+
+		if rs.recreatedID != 0 {
+			delete(s.subscriptions, rs.id)
+			sub.id = rs.recreatedID
+			sub.lastSequenceNumber = 0
+			s.subscriptions[sub.id] = sub
+			for handle, id := range rs.recreatedItemIDs {
+				if item, exist := sub.items[handle]; exist {
+					item.id = id
+				}
+			}
+			continue
+		}
+
+		for idx := range rs.msgs {
+			var seq = rs.lastSequenceNumber + 1 + uint32(idx)
+			s.acks = append(s.acks, &ua.SubscriptionAcknowledgement{
+				SubscriptionID: sub.id,
+				SequenceNumber: seq,
+			})
+			sub.lastSequenceNumber = seq
+		}
+		msgs[sub] = rs.msgs
+	}
+	return msgs, nil
+}
+
+// report records the status and feedbacks it to the handler.
+func (s *session) report(status v1alpha1.OPCUADeviceStatusSession) {
+	s.Lock()
+	s.status = status
+	s.Unlock()
+
+	select {
+	case <-s.stop:
+		return
+	default:
+	}
+	if s.handler != nil {
+		s.handler(s, status)
+	}
+}
+
+// getReconnectBackoff returns the backoff to reconnect, which is doubled after each failed attempt.
+func (s *session) getReconnectBackoff(attempts int32) time.Duration {
+	var backoff = s.initialBackoff
+	for i := int32(0); i < attempts && backoff < s.maxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > s.maxBackoff {
+		backoff = s.maxBackoff
+	}
+	return backoff
+}
+
+// createSubscription creates the subscription in the given publishing interval with the given client,
+// and returns the subscription ID.
+func (s *session) createSubscription(client *opcua.Client, interval time.Duration) (uint32, error) {
+	// the keep-alive message must arrive before the publish request timeout,
+	// and the server should keep the subscription during the reconnection.
+	var keepAliveCount = uint32(1)
+	if count := s.timeout / 2 / interval; count > 1 {
+		keepAliveCount = uint32(count)
+	}
+	var lifetimeCount = 3 * keepAliveCount
+	if count := 2 * (s.maxBackoff + s.timeout) / interval; count > time.Duration(lifetimeCount) {
+		lifetimeCount = uint32(count)
+	}
+
+	var res *ua.CreateSubscriptionResponse
+	var err = client.Send(&ua.CreateSubscriptionRequest{
+		RequestedPublishingInterval: float64(interval / time.Millisecond),
+		RequestedLifetimeCount:      lifetimeCount,
+		RequestedMaxKeepAliveCount:  keepAliveCount,
+		PublishingEnabled:           true,
+	}, func(v interface{}) error {
+		return assignResponse(v, &res)
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to create subscription")
+	}
+	return res.SubscriptionID, nil
+}
+
+// recreateSubscription re-creates the subscription and its monitored items in server with the given client,
+// the results are recorded into the snapshot and applied later.
+func (s *session) recreateSubscription(client *opcua.Client, rs *recoveringSubscription) error {
+	var id, err = s.createSubscription(client, rs.interval)
+	if err != nil {
+		return err
+	}
+	rs.recreatedID = id
+	s.log.Info("Re-created subscription", "staleID", rs.id, "id", id)
+
+	if len(rs.requests) == 0 {
+		return nil
+	}
+	var res *ua.CreateMonitoredItemsResponse
+	err = client.Send(&ua.CreateMonitoredItemsRequest{
+		SubscriptionID:     id,
+		TimestampsToReturn: rs.timestamps,
+		ItemsToCreate:      rs.requests,
+	}, func(v interface{}) error {
+		return assignResponse(v, &res)
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to re-create monitored items of subscription %d", id)
+	}
+	rs.recreatedItemIDs = make(map[uint32]uint32, len(res.Results))
+	for idx, result := range res.Results {
+		if idx >= len(rs.handles) {
+			break
+		}
+		if result.StatusCode != ua.StatusOK {
+			s.log.Error(result.StatusCode, "Unable to re-create monitored item", "id", id, "handle", rs.handles[idx])
+			continue
+		}
+		rs.recreatedItemIDs[rs.handles[idx]] = result.MonitoredItemID
+	}
+	return nil
+}
+
+// subscriptionItem records the monitored item to re-create after reconnecting.
+type subscriptionItem struct {
+	// id is the monitored item ID assigned by the OPC-UA server.
+	id      uint32
+	request *ua.MonitoredItemCreateRequest
+}
+
+// subscription is the subscription published by session,
+// it is re-created with the recorded monitored items if the server loses it.
+type subscription struct {
+	session            *session
+	id                 uint32
+	interval           time.Duration
+	timestamps         ua.TimestampsToReturn
+	lastSequenceNumber uint32
+	notifyCh           chan<- *opcua.PublishNotificationData
+	done               chan struct{}
+	// items are indexed by the client handle.
+	items map[uint32]*subscriptionItem
+}
+
+// ID returns the subscription ID, the ID is changed after re-creating.
+func (sub *subscription) ID() uint32 {
+	var s = sub.session
+	s.Lock()
+	defer s.Unlock()
+	return sub.id
+}
+
+// Monitor creates the monitored items in the subscription.
+func (sub *subscription) Monitor(ts ua.TimestampsToReturn, reqs ...*ua.MonitoredItemCreateRequest) (*ua.CreateMonitoredItemsResponse, error) {
+	var s = sub.session
+	s.Lock()
+	defer s.Unlock()
+
+	var res *ua.CreateMonitoredItemsResponse
+	var err = s.client.Send(&ua.CreateMonitoredItemsRequest{
+		SubscriptionID:     sub.id,
+		TimestampsToReturn: ts,
+		ItemsToCreate:      reqs,
+	}, func(v interface{}) error {
+		return assignResponse(v, &res)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(res.Results) != len(reqs) {
+		return nil, errors.Errorf("expected %d results of monitored items, but got %d", len(reqs), len(res.Results))
+	}
+	sub.timestamps = ts
+	for idx, result := range res.Results {
+		if result.StatusCode != ua.StatusOK {
+			continue
+		}
+		sub.items[reqs[idx].RequestedParameters.ClientHandle] = &subscriptionItem{
+			id:      result.MonitoredItemID,
+			request: reqs[idx],
+		}
+	}
+	return res, nil
+}
+
+// Unmonitor deletes the monitored items with the given client handles from the subscription.
+func (sub *subscription) Unmonitor(handles ...uint32) (*ua.DeleteMonitoredItemsResponse, error) {
+	var s = sub.session
+	s.Lock()
+	defer s.Unlock()
+
+	var ids = make([]uint32, 0, len(handles))
+	for _, handle := range handles {
+		if item, exist := sub.items[handle]; exist {
+			ids = append(ids, item.id)
+			delete(sub.items, handle)
+		}
+	}
+	if len(ids) == 0 {
+		return &ua.DeleteMonitoredItemsResponse{}, nil
+	}
+	var res *ua.DeleteMonitoredItemsResponse
+	var err = s.client.Send(&ua.DeleteMonitoredItemsRequest{
+		SubscriptionID:   sub.id,
+		MonitoredItemIDs: ids,
+	}, func(v interface{}) error {
+		return assignResponse(v, &res)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Cancel deletes the subscription, the subscription is not recovered anymore.
+func (sub *subscription) Cancel() error {
+	var s = sub.session
+	s.Lock()
+	defer s.Unlock()
+
+	select {
+	case <-sub.done:
+		return nil
+	default:
+	}
+	close(sub.done)
+	delete(s.subscriptions, sub.id)
+
+	var res *ua.DeleteSubscriptionsResponse
+	var err = s.client.Send(&ua.DeleteSubscriptionsRequest{
+		SubscriptionIDs: []uint32{sub.id},
+	}, func(v interface{}) error {
+		return assignResponse(v, &res)
+	})
+	if err != nil {
+		return err
+	}
+	if len(res.Results) != 0 && res.Results[0] != ua.StatusOK {
+		return res.Results[0]
+	}
+	return nil
+}
+
+// notify delivers the data of notification message, it returns false if the subscription is done.
+func (sub *subscription) notify(msg *ua.NotificationMessage) bool {
+	if msg == nil {
+		return true
+	}
+	for _, data := range msg.NotificationData {
+		var notification = &opcua.PublishNotificationData{}
+		switch {
+		case data == nil || data.Value == nil:
+			notification.Error = errors.New("missing notification data")
+		default:
+			if v, ok := data.Value.(*ua.StatusChangeNotification); ok && v.Status != ua.StatusOK {
+				notification.Error = v.Status
+			} else {
+				notification.Value = data.Value
+			}
+		}
+		if !sub.deliver(notification) {
+			return false
+		}
+	}
+	return true
+}
+
+// deliver sends the notification to the channel, it returns false if the subscription is done.
+func (sub *subscription) deliver(notification *opcua.PublishNotificationData) bool {
+	select {
+	case sub.notifyCh <- notification:
+		return true
+	case <-sub.done:
+		return false
+	case <-sub.session.stop:
+		return false
+	}
+}
+
+// publish sends the publish request with the given acknowledgements.
+func publish(client *opcua.Client, acks []*ua.SubscriptionAcknowledgement) (*ua.PublishResponse, error) {
+	var res *ua.PublishResponse
+	var err = client.Send(&ua.PublishRequest{
+		SubscriptionAcknowledgements: acks,
+	}, func(v interface{}) error {
+		return assignResponse(v, &res)
+	})
+	return res, err
+}
+
+// probe reads the state of server to check the connection.
+func probe(client *opcua.Client) error {
+	var res, err = client.Read(&ua.ReadRequest{
+		NodesToRead: []*ua.ReadValueID{
+			{
+				NodeID:      ua.NewNumericNodeID(0, id.Server_ServerStatus_State),
+				AttributeID: ua.AttributeIDValue,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if len(res.Results) == 0 {
+		return errors.New("no server state")
+	}
+	return nil
+}
+
+// isSessionLost returns true if the error indicates the loss of secure channel or session.
+func isSessionLost(err error) bool {
+	if err == nil {
+		return false
+	}
+	switch err {
+	case ua.StatusBadSessionIDInvalid, ua.StatusBadSessionClosed, ua.StatusBadSessionNotActivated,
+		ua.StatusBadSecureChannelIDInvalid, ua.StatusBadSecureChannelClosed, ua.StatusBadSecureChannelTokenUnknown,
+		ua.StatusBadConnectionClosed, ua.StatusBadNotConnected, ua.StatusBadServerNotConnected,
+		ua.StatusBadCommunicationError, ua.StatusBadServerHalted, ua.StatusBadShutdown:
+		return true
+	}
+	// the other errors come from the transport, e.g. io.EOF, uacp.Error or net.Error.
+	var _, isStatus = err.(ua.StatusCode)
+	return !isStatus
+}
+
+// assignResponse assigns the received response to the given pointer.
+func assignResponse(v interface{}, ptr interface{}) error {
+	var target = reflect.ValueOf(ptr).Elem()
+	var value = reflect.ValueOf(v)
+	if !value.IsValid() || !value.Type().AssignableTo(target.Type()) {
+		return errors.Errorf("expected response %s, but got %T", target.Type(), v)
+	}
+	target.Set(value)
+	return nil
+}
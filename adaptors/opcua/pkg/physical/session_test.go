@@ -0,0 +1,239 @@
+// +build !race
+
+// the secure channel of gopcua v0.1.11 reads the channel ID without lock
+// when dispatching the concurrent responses of subscription, which fails the race detector.
+
+package physical
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gopcua/opcua"
+	"github.com/gopcua/opcua/ua"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/rancher/octopus/adaptors/opcua/api/v1alpha1"
+	"github.com/rancher/octopus/pkg/util/log/zap"
+)
+
+// testSessionRecorder records the notifications and the status of session.
+type testSessionRecorder struct {
+	sync.Mutex
+
+	values   []string
+	errors   int
+	statuses []v1alpha1.OPCUADeviceStatusSession
+}
+
+func (r *testSessionRecorder) receive(notifyCh <-chan *opcua.PublishNotificationData, stop <-chan struct{}) {
+	for {
+		select {
+		case <-stop:
+			return
+		case res := <-notifyCh:
+			r.Lock()
+			if res.Error != nil {
+				r.errors++
+			} else if v, ok := res.Value.(*ua.DataChangeNotification); ok {
+				for _, item := range v.MonitoredItems {
+					r.values = append(r.values, fmt.Sprint(item.Value.Value.Value()))
+				}
+			}
+			r.Unlock()
+		}
+	}
+}
+
+func (r *testSessionRecorder) handle(_ *session, status v1alpha1.OPCUADeviceStatusSession) {
+	r.Lock()
+	defer r.Unlock()
+	r.statuses = append(r.statuses, status)
+}
+
+func (r *testSessionRecorder) hasValue(value string) bool {
+	r.Lock()
+	defer r.Unlock()
+	for _, v := range r.values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *testSessionRecorder) lastStatus() v1alpha1.OPCUADeviceStatusSession {
+	r.Lock()
+	defer r.Unlock()
+	if len(r.statuses) == 0 {
+		return v1alpha1.OPCUADeviceStatusSession{}
+	}
+	return r.statuses[len(r.statuses)-1]
+}
+
+func TestSession_Recover(t *testing.T) {
+	type expected struct {
+		recovery    v1alpha1.OPCUADeviceSessionRecovery
+		republished int32
+	}
+
+	var testCases = []struct {
+		name     string
+		given    func(server *testServer)
+		expected expected
+	}{
+		{
+			// the notification is lost in transit during the network interruption
+			name: "disconnect",
+			given: func(server *testServer) {
+				server.Disconnect()
+				server.ChangeValue("ns=1;s=Temperature", ua.MustVariant(int32(2)))
+				server.DropPending()
+			},
+			expected: expected{
+				recovery:    v1alpha1.OPCUADeviceSessionRecoveryReactivated,
+				republished: 1,
+			},
+		},
+		{
+			name: "expire sessions",
+			given: func(server *testServer) {
+				server.ExpireSessions()
+			},
+			expected: expected{
+				recovery: v1alpha1.OPCUADeviceSessionRecoveryTransferred,
+			},
+		},
+		{
+			name: "restart",
+			given: func(server *testServer) {
+				server.Restart()
+			},
+			expected: expected{
+				recovery: v1alpha1.OPCUADeviceSessionRecoveryRecreated,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		func() {
+			var server = newTestServer(t)
+			defer server.Close()
+
+			var recorder = &testSessionRecorder{}
+			var s, err = newSession(zap.WrapAsLogr(zap.NewDevelopmentLogger()),
+				v1alpha1.OPCUADeviceProtocol{
+					Endpoint:       server.endpoint,
+					SecurityPolicy: "None",
+					SecurityMode:   "None",
+				},
+				&v1alpha1.OPCUADeviceParameters{
+					Timeout:                 metav1.Duration{Duration: 2 * time.Second},
+					ReconnectInitialBackoff: metav1.Duration{Duration: 300 * time.Millisecond},
+					ReconnectMaxBackoff:     metav1.Duration{Duration: time.Second},
+				},
+				nil,
+				recorder.handle,
+			)
+			if !assert.NoError(t, err, "case %s", tc.name) {
+				return
+			}
+			defer s.Close()
+			assert.Equal(t, v1alpha1.OPCUADeviceSessionStateConnected, s.Status().State, "case %s", tc.name)
+
+			var notifyCh = make(chan *opcua.PublishNotificationData)
+			var stop = make(chan struct{})
+			defer close(stop)
+			go recorder.receive(notifyCh, stop)
+
+			sub, err := s.Subscribe(100*time.Millisecond, notifyCh)
+			if !assert.NoError(t, err, "case %s", tc.name) {
+				return
+			}
+			var req = opcua.NewMonitoredItemCreateRequestWithDefaults(mustParseNodeID("ns=1;s=Temperature"), ua.AttributeIDValue, 1)
+			_, err = sub.Monitor(ua.TimestampsToReturnBoth, req)
+			if !assert.NoError(t, err, "case %s", tc.name) {
+				return
+			}
+			server.ChangeValue("ns=1;s=Temperature", ua.MustVariant(int32(1)))
+			assert.Eventually(t, func() bool { return recorder.hasValue("1") }, 5*time.Second, 50*time.Millisecond, "case %s", tc.name)
+
+			// loses the session
+			var staleID = sub.ID()
+			tc.given(server)
+			assert.Eventually(t, func() bool {
+				return recorder.lastStatus().State == v1alpha1.OPCUADeviceSessionStateConnected
+			}, 5*time.Second, 50*time.Millisecond, "case %s", tc.name)
+
+			var status = recorder.lastStatus()
+			assert.Equal(t, tc.expected.recovery, status.Recovery, "case %s", tc.name)
+			assert.Equal(t, tc.expected.republished, status.RepublishedMessages, "case %s", tc.name)
+			assert.NotNil(t, status.LostAt, "case %s", tc.name)
+			assert.NotNil(t, status.ConnectedAt, "case %s", tc.name)
+			recorder.Lock()
+			assert.Equal(t, v1alpha1.OPCUADeviceSessionStateReconnecting, recorder.statuses[0].State, "case %s", tc.name)
+			assert.NotEmpty(t, recorder.statuses[0].Reason, "case %s", tc.name)
+			assert.NotNil(t, recorder.statuses[0].NextReconnectAt, "case %s", tc.name)
+			assert.NotZero(t, recorder.errors, "case %s", tc.name)
+			recorder.Unlock()
+			if tc.expected.recovery == v1alpha1.OPCUADeviceSessionRecoveryRecreated {
+				assert.NotEqual(t, staleID, sub.ID(), "case %s", tc.name)
+			} else {
+				assert.Equal(t, staleID, sub.ID(), "case %s", tc.name)
+			}
+			if tc.expected.republished != 0 {
+				assert.Eventually(t, func() bool { return recorder.hasValue("2") }, 5*time.Second, 50*time.Millisecond, "case %s", tc.name)
+			}
+
+			// keeps publishing after recovering
+			server.ChangeValue("ns=1;s=Temperature", ua.MustVariant(int32(3)))
+			assert.Eventually(t, func() bool { return recorder.hasValue("3") }, 5*time.Second, 50*time.Millisecond, "case %s", tc.name)
+		}()
+	}
+}
+
+func TestSession_GetReconnectBackoff(t *testing.T) {
+	var s = &session{
+		initialBackoff: time.Second,
+		maxBackoff:     5 * time.Second,
+	}
+
+	var testCases = []struct {
+		given    int32
+		expected time.Duration
+	}{
+		{given: 0, expected: time.Second},
+		{given: 1, expected: 2 * time.Second},
+		{given: 2, expected: 4 * time.Second},
+		{given: 3, expected: 5 * time.Second},
+		{given: 100, expected: 5 * time.Second},
+	}
+
+	for i, tc := range testCases {
+		var ret = s.getReconnectBackoff(tc.given)
+		assert.Equal(t, tc.expected, ret, "case %v", i+1)
+	}
+}
+
+func TestIsSessionLost(t *testing.T) {
+	var testCases = []struct {
+		given    error
+		expected bool
+	}{
+		{given: nil, expected: false},
+		{given: io.EOF, expected: true},
+		{given: ua.StatusBadSessionIDInvalid, expected: true},
+		{given: ua.StatusBadSecureChannelClosed, expected: true},
+		{given: ua.StatusBadTimeout, expected: false},
+		{given: ua.StatusBadNoSubscription, expected: false},
+	}
+
+	for i, tc := range testCases {
+		var ret = isSessionLost(tc.given)
+		assert.Equal(t, tc.expected, ret, "case %v", i+1)
+	}
+}
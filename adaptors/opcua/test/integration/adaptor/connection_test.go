@@ -11,8 +11,8 @@
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/rancher/octopus/adaptors/opcua/pkg/adaptor"
-	"github.com/rancher/octopus/pkg/adaptor/api/v1alpha1"
-	mock_v1alpha1 "github.com/rancher/octopus/pkg/adaptor/api/v1alpha1/mock"
+	"github.com/rancher/octopus/pkg/adaptor/api/v1alpha2"
+	mock_v1alpha2 "github.com/rancher/octopus/pkg/adaptor/api/v1alpha2/mock"
 )
 
 var _ = Describe("verify Connection", func() {
@@ -34,10 +34,10 @@
 
 	Context("on Connect server", func() {
 
-		var mockServer *mock_v1alpha1.MockConnection_ConnectServer
+		var mockServer *mock_v1alpha2.MockConnection_ConnectServer
 
 		BeforeEach(func() {
-			mockServer = mock_v1alpha1.NewMockConnection_ConnectServer(mockCtrl)
+			mockServer = mock_v1alpha2.NewMockConnection_ConnectServer(mockCtrl)
 		})
 
 		It("should be stopped if closed", func() {
@@ -69,7 +69,7 @@
 
 		It("should process the input device", func() {
 			// failed unmarshal
-			mockServer.EXPECT().Recv().Return(&v1alpha1.ConnectRequest{
+			mockServer.EXPECT().Recv().Return(&v1alpha2.ConnectRequest{
 				Model: &metav1.TypeMeta{
 					APIVersion: "devices.edge.cattle.io/v1alpha1",
 					Kind:       "OPCUADevice",
@@ -82,7 +82,7 @@
 			Expect(sts.Message()).To(HavePrefix("failed to unmarshal device"))
 
 			// failed to connect a device
-			mockServer.EXPECT().Recv().Return(&v1alpha1.ConnectRequest{
+			mockServer.EXPECT().Recv().Return(&v1alpha2.ConnectRequest{
 				Model: &metav1.TypeMeta{
 					APIVersion: "devices.edge.cattle.io/v1alpha1",
 					Kind:       "OPCUADevice",
@@ -108,7 +108,7 @@
 			}, nil)
 			err = service.Connect(mockServer)
 			sts = status.Convert(err)
-			Expect(sts.Code()).To(Equal(grpccodes.InvalidArgument))
+			Expect(sts.Code()).To(Equal(grpccodes.Unavailable))
 			Expect(sts.Message()).To(Equal("failed to configure the device: failed to create OPC-UA client: failed to get OPC-UA endpoint: dial tcp 127.0.0.1:53530: connect: connection refused"))
 		})
 
@@ -0,0 +1,116 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+type DeviceCommandPhase string
+
+// These are valid phases of a command
+const (
+	// Pending means that the command is waiting for the connection of device.
+	DeviceCommandPending DeviceCommandPhase = "Pending"
+
+	// Running means that the command has been sent to the adaptor,
+	// and is waiting for the result.
+	DeviceCommandRunning DeviceCommandPhase = "Running"
+
+	// Succeeded means that the command has been executed successfully.
+	DeviceCommandSucceeded DeviceCommandPhase = "Succeeded"
+
+	// Failed means that the command has been executed but failed,
+	// or it cannot be executed.
+	DeviceCommandFailed DeviceCommandPhase = "Failed"
+)
+
+// DeviceCommandSpec defines the desired state of DeviceCommand
+type DeviceCommandSpec struct {
+	// Specifies the name of DeviceLink in the same namespace,
+	// which the command is invoked on.
+	// +kubebuilder:validation:Required
+	DeviceLink string `json:"deviceLink"`
+
+	// Specifies the name of command.
+	// +kubebuilder:validation:Required
+	Command string `json:"command"`
+
+	// Specifies the arguments of command.
+	// +kubebuilder:validation:XPreserveUnknownFields
+	// +optional
+	Arguments *runtime.RawExtension `json:"arguments,omitempty"`
+
+	// Specifies the seconds to wait for the result of command, the default value is 30.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+}
+
+// DeviceCommandStatus defines the observed state of DeviceCommand
+type DeviceCommandStatus struct {
+	// Represents the phase of command.
+	// +optional
+	Phase DeviceCommandPhase `json:"phase,omitempty"`
+
+	// Represents the result code responded by the adaptor,
+	// one of Succeeded, Unsupported, InvalidArguments or Failed.
+	// +optional
+	Code string `json:"code,omitempty"`
+
+	// Represents the output responded by the adaptor.
+	// +kubebuilder:validation:XPreserveUnknownFields
+	// +optional
+	Output *runtime.RawExtension `json:"output,omitempty"`
+
+	// A human readable message indicating details about the result.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Represents the node name which the command is executed on.
+	// +optional
+	NodeName string `json:"nodeName,omitempty"`
+
+	// Represents the time when the command was sent to the adaptor.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// Represents the time when the command was completed.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +k8s:openapi-gen=true
+// +kubebuilder:resource:shortName=dc
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="LINK",type=string,JSONPath=`.spec.deviceLink`
+// +kubebuilder:printcolumn:name="COMMAND",type=string,JSONPath=`.spec.command`
+// +kubebuilder:printcolumn:name="PHASE",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="CODE",type=string,JSONPath=`.status.code`
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// DeviceCommand is the Schema for the devicecommands API
+type DeviceCommand struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DeviceCommandSpec   `json:"spec,omitempty"`
+	Status DeviceCommandStatus `json:"status,omitempty"`
+}
+
+// IsCompleted returns true if the command has been completed.
+func (in *DeviceCommand) IsCompleted() bool {
+	return in.Status.Phase == DeviceCommandSucceeded || in.Status.Phase == DeviceCommandFailed
+}
+
+// +kubebuilder:object:root=true
+// +k8s:openapi-gen=true
+// DeviceCommandList contains a list of DeviceCommand
+type DeviceCommandList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DeviceCommand `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DeviceCommand{}, &DeviceCommandList{})
+}
@@ -11,8 +11,8 @@ func (in *DeviceLink) FailOnNodeExisted(message string) {
 	if in == nil {
 		return
 	}
-	in.Status.Conditions = deviceLinkConditions(in.Status.Conditions).
-		did(DeviceLinkNodeExisted, metav1.ConditionFalse, "NotFound", message, in.Status.NodeName != in.Spec.Adaptor.Node)
+	in.setConditions(deviceLinkConditions(in.Status.Conditions).
+		did(DeviceLinkNodeExisted, metav1.ConditionFalse, "NotFound", message, in.Status.NodeName != in.Spec.Adaptor.Node))
 	in.Status.NodeName = ""
 }
 
@@ -20,9 +20,9 @@ func (in *DeviceLink) SucceedOnNodeExisted(node *corev1.Node) {
 	if in == nil {
 		return
 	}
-	in.Status.Conditions = deviceLinkConditions(in.Status.Conditions).
+	in.setConditions(deviceLinkConditions(in.Status.Conditions).
 		did(DeviceLinkNodeExisted, metav1.ConditionTrue, "Found", "", in.Status.NodeName != in.Spec.Adaptor.Node).
-		next(DeviceLinkModelExisted, "Confirming", "verify if there is a suitable model as a template")
+		next(DeviceLinkModelExisted, "Confirming", "verify if there is a suitable model as a template"))
 	in.Status.NodeName = in.Spec.Adaptor.Node
 	if node != nil {
 		for _, address := range node.Status.Addresses {
@@ -46,8 +46,8 @@ func (in *DeviceLink) ToCheckNodeExisted() {
 	if in == nil {
 		return
 	}
-	in.Status.Conditions = deviceLinkConditions(in.Status.Conditions).
-		did(DeviceLinkNodeExisted, metav1.ConditionUnknown, "Confirming", "verify if there is a suitable node to schedule", false)
+	in.setConditions(deviceLinkConditions(in.Status.Conditions).
+		did(DeviceLinkNodeExisted, metav1.ConditionUnknown, "Confirming", "verify if there is a suitable node to schedule", false))
 }
 
 func (in *DeviceLink) GetNodeExistedStatus() metav1.ConditionStatus {
@@ -61,8 +61,8 @@ func (in *DeviceLink) FailOnModelExisted(message string) {
 	if in == nil {
 		return
 	}
-	in.Status.Conditions = deviceLinkConditions(in.Status.Conditions).
-		did(DeviceLinkModelExisted, metav1.ConditionFalse, "NotFound", message, in.Status.Model == nil || *in.Status.Model != in.Spec.Model)
+	in.setConditions(deviceLinkConditions(in.Status.Conditions).
+		did(DeviceLinkModelExisted, metav1.ConditionFalse, "NotFound", message, in.Status.Model == nil || *in.Status.Model != in.Spec.Model))
 	in.Status.Model = nil
 }
 
@@ -70,9 +70,9 @@ func (in *DeviceLink) SucceedOnModelExisted() {
 	if in == nil {
 		return
 	}
-	in.Status.Conditions = deviceLinkConditions(in.Status.Conditions).
+	in.setConditions(deviceLinkConditions(in.Status.Conditions).
 		did(DeviceLinkModelExisted, metav1.ConditionTrue, "Found", "", in.Status.Model == nil || *in.Status.Model != in.Spec.Model).
-		next(DeviceLinkAdaptorExisted, "Confirming", "verify if there is a suitable adaptor to access")
+		next(DeviceLinkAdaptorExisted, "Confirming", "verify if there is a suitable adaptor to access"))
 	in.Status.Model = &in.Spec.Model
 }
 
@@ -80,8 +80,8 @@ func (in *DeviceLink) ToCheckModelExisted() {
 	if in == nil {
 		return
 	}
-	in.Status.Conditions = deviceLinkConditions(in.Status.Conditions).
-		did(DeviceLinkModelExisted, metav1.ConditionUnknown, "Confirming", "verify if there is a suitable model as a template", false)
+	in.setConditions(deviceLinkConditions(in.Status.Conditions).
+		did(DeviceLinkModelExisted, metav1.ConditionUnknown, "Confirming", "verify if there is a suitable model as a template", false))
 }
 
 func (in *DeviceLink) GetModelExistedStatus() metav1.ConditionStatus {
@@ -95,8 +95,8 @@ func (in *DeviceLink) FailOnAdaptorExisted(message string) {
 	if in == nil {
 		return
 	}
-	in.Status.Conditions = deviceLinkConditions(in.Status.Conditions).
-		did(DeviceLinkAdaptorExisted, metav1.ConditionFalse, "NotFound", message, in.Status.AdaptorName != in.Spec.Adaptor.Name)
+	in.setConditions(deviceLinkConditions(in.Status.Conditions).
+		did(DeviceLinkAdaptorExisted, metav1.ConditionFalse, "NotFound", message, in.Status.AdaptorName != in.Spec.Adaptor.Name))
 	in.Status.AdaptorName = ""
 }
 
@@ -104,9 +104,9 @@ func (in *DeviceLink) SucceedOnAdaptorExisted() {
 	if in == nil {
 		return
 	}
-	in.Status.Conditions = deviceLinkConditions(in.Status.Conditions).
+	in.setConditions(deviceLinkConditions(in.Status.Conditions).
 		did(DeviceLinkAdaptorExisted, metav1.ConditionTrue, "Found", "", in.Status.AdaptorName != in.Spec.Adaptor.Name).
-		next(DeviceLinkDeviceCreated, "Creating", "verify if there is a corresponding device created")
+		next(DeviceLinkDeviceCreated, "Creating", "verify if there is a corresponding device created"))
 	in.Status.AdaptorName = in.Spec.Adaptor.Name
 }
 
@@ -114,8 +114,8 @@ func (in *DeviceLink) ToCheckAdaptorExisted() {
 	if in == nil {
 		return
 	}
-	in.Status.Conditions = deviceLinkConditions(in.Status.Conditions).
-		did(DeviceLinkAdaptorExisted, metav1.ConditionUnknown, "Confirming", "verify if there is a suitable adaptor to access", false)
+	in.setConditions(deviceLinkConditions(in.Status.Conditions).
+		did(DeviceLinkAdaptorExisted, metav1.ConditionUnknown, "Confirming", "verify if there is a suitable adaptor to access", false))
 }
 
 func (in *DeviceLink) GetAdaptorExistedStatus() metav1.ConditionStatus {
@@ -129,25 +129,25 @@ func (in *DeviceLink) FailOnDeviceCreated(message string) {
 	if in == nil {
 		return
 	}
-	in.Status.Conditions = deviceLinkConditions(in.Status.Conditions).
-		did(DeviceLinkDeviceCreated, metav1.ConditionFalse, "Fail", message, false)
+	in.setConditions(deviceLinkConditions(in.Status.Conditions).
+		did(DeviceLinkDeviceCreated, metav1.ConditionFalse, "Fail", message, false))
 }
 
 func (in *DeviceLink) SucceedOnDeviceCreated() {
 	if in == nil {
 		return
 	}
-	in.Status.Conditions = deviceLinkConditions(in.Status.Conditions).
+	in.setConditions(deviceLinkConditions(in.Status.Conditions).
 		did(DeviceLinkDeviceCreated, metav1.ConditionTrue, "Success", "", false).
-		next(DeviceLinkDeviceConnected, "Connecting", "connect device")
+		next(DeviceLinkDeviceConnected, "Connecting", "connect device"))
 }
 
 func (in *DeviceLink) ToCheckDeviceCreated() {
 	if in == nil {
 		return
 	}
-	in.Status.Conditions = deviceLinkConditions(in.Status.Conditions).
-		did(DeviceLinkDeviceCreated, metav1.ConditionUnknown, "Creating", "verify if there is a corresponding device created", false)
+	in.setConditions(deviceLinkConditions(in.Status.Conditions).
+		did(DeviceLinkDeviceCreated, metav1.ConditionUnknown, "Creating", "verify if there is a corresponding device created", false))
 }
 
 func (in *DeviceLink) GetDeviceCreatedStatus() metav1.ConditionStatus {
@@ -158,27 +158,36 @@ func (in *DeviceLink) GetDeviceCreatedStatus() metav1.ConditionStatus {
 }
 
 func (in *DeviceLink) FailOnDeviceConnected(message string) {
+	in.FailOnDeviceConnectedWithReason("Unhealthy", message)
+}
+
+// FailOnDeviceConnectedWithReason fails the DeviceConnected condition with the given reason,
+// the reason is expected to be the class of the error received from adaptor, e.g. Unreachable.
+func (in *DeviceLink) FailOnDeviceConnectedWithReason(reason, message string) {
 	if in == nil {
 		return
 	}
-	in.Status.Conditions = deviceLinkConditions(in.Status.Conditions).
-		did(DeviceLinkDeviceConnected, metav1.ConditionFalse, "Unhealthy", message, false)
+	if reason == "" {
+		reason = "Unhealthy"
+	}
+	in.setConditions(deviceLinkConditions(in.Status.Conditions).
+		did(DeviceLinkDeviceConnected, metav1.ConditionFalse, reason, message, false))
 }
 
 func (in *DeviceLink) SucceedOnDeviceConnected() {
 	if in == nil {
 		return
 	}
-	in.Status.Conditions = deviceLinkConditions(in.Status.Conditions).
-		did(DeviceLinkDeviceConnected, metav1.ConditionTrue, "Healthy", "", false)
+	in.setConditions(deviceLinkConditions(in.Status.Conditions).
+		did(DeviceLinkDeviceConnected, metav1.ConditionTrue, "Healthy", "", false))
 }
 
 func (in *DeviceLink) ToCheckDeviceConnected() {
 	if in == nil {
 		return
 	}
-	in.Status.Conditions = deviceLinkConditions(in.Status.Conditions).
-		did(DeviceLinkDeviceConnected, metav1.ConditionUnknown, "Connecting", "connect device", false)
+	in.setConditions(deviceLinkConditions(in.Status.Conditions).
+		did(DeviceLinkDeviceConnected, metav1.ConditionUnknown, "Connecting", "connect device", false))
 }
 
 func (in *DeviceLink) GetDeviceConnectedStatus() metav1.ConditionStatus {
@@ -188,6 +197,54 @@ func (in *DeviceLink) GetDeviceConnectedStatus() metav1.ConditionStatus {
 	return deviceLinkConditions(in.Status.Conditions).get(DeviceLinkDeviceConnected).Status
 }
 
+// GetPhase returns the phase of device, which is derived from the type and status of the last condition.
+func (in *DeviceLink) GetPhase() DeviceLinkPhase {
+	if in == nil || len(in.Status.Conditions) == 0 {
+		return ""
+	}
+	var last = in.Status.Conditions[len(in.Status.Conditions)-1]
+	switch {
+	case last.Status == metav1.ConditionFalse:
+		return DeviceLinkFailed
+	case last.Status == metav1.ConditionTrue && last.Type == DeviceLinkDeviceConnected:
+		return DeviceLinkConnected
+	default:
+		return DeviceLinkPending
+	}
+}
+
+// MaxDeviceLinkConditionHistory is the maximum number of the transitions kept in the condition history.
+const MaxDeviceLinkConditionHistory = 10
+
+// setConditions sets the conditions and records the status transitions into the condition history.
+func (in *DeviceLink) setConditions(conditions deviceLinkConditions) {
+	var previous = deviceLinkConditions(in.Status.Conditions)
+	for _, c := range conditions {
+		var from = previous.get(c.Type).Status
+		if from == c.Status {
+			continue
+		}
+		// the pending condition appended by next() is not recorded.
+		if from == "" && c.Status == metav1.ConditionUnknown {
+			continue
+		}
+		in.Status.ConditionHistory = append(in.Status.ConditionHistory, DeviceLinkConditionTransition{
+			Type:   c.Type,
+			From:   from,
+			To:     c.Status,
+			Reason: c.Reason,
+			Time:   c.LastUpdateTime,
+		})
+		if c.Type == DeviceLinkDeviceConnected {
+			in.Status.DeviceConnectedTransitions++
+		}
+	}
+	if size := len(in.Status.ConditionHistory); size > MaxDeviceLinkConditionHistory {
+		in.Status.ConditionHistory = in.Status.ConditionHistory[size-MaxDeviceLinkConditionHistory:]
+	}
+	in.Status.Conditions = conditions
+}
+
 type deviceLinkConditions []DeviceLinkCondition
 
 func (d deviceLinkConditions) get(t DeviceLinkConditionType) DeviceLinkCondition {
@@ -0,0 +1,131 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDeviceLink_ConditionHistory(t *testing.T) {
+	var link = &DeviceLink{}
+	link.Spec.Adaptor.Node = "edge-worker"
+	link.Spec.Adaptor.Name = "adaptors.edge.cattle.io/dummy"
+
+	link.SucceedOnNodeExisted(nil)
+	link.SucceedOnModelExisted()
+	link.SucceedOnAdaptorExisted()
+	link.SucceedOnDeviceCreated()
+	link.SucceedOnDeviceConnected()
+	assert.Equal(t, DeviceLinkConnected, link.GetPhase())
+	assert.Equal(t, int64(1), link.Status.DeviceConnectedTransitions)
+
+	var actual []string
+	for _, h := range link.Status.ConditionHistory {
+		actual = append(actual, string(h.Type)+":"+string(h.From)+"->"+string(h.To))
+	}
+	assert.Equal(t, []string{
+		"NodeExisted:->True",
+		"ModelExisted:Unknown->True",
+		"AdaptorExisted:Unknown->True",
+		"DeviceCreated:Unknown->True",
+		"DeviceConnected:Unknown->True",
+	}, actual)
+
+	// flaps the connection
+	for i := 0; i < 10; i++ {
+		link.ToCheckDeviceConnected()
+		link.SucceedOnDeviceConnected()
+	}
+	assert.Equal(t, int64(21), link.Status.DeviceConnectedTransitions)
+	assert.Len(t, link.Status.ConditionHistory, MaxDeviceLinkConditionHistory)
+	var last = link.Status.ConditionHistory[MaxDeviceLinkConditionHistory-1]
+	assert.Equal(t, DeviceLinkDeviceConnected, last.Type)
+	assert.Equal(t, metav1.ConditionUnknown, last.From)
+	assert.Equal(t, metav1.ConditionTrue, last.To)
+
+	// doesn't record the same status
+	link.SucceedOnDeviceConnected()
+	assert.Equal(t, int64(21), link.Status.DeviceConnectedTransitions)
+}
+
+func TestDeviceLink_GetPhase(t *testing.T) {
+	var testCases = []struct {
+		name     string
+		given    func(link *DeviceLink)
+		expected DeviceLinkPhase
+	}{
+		{
+			name:     "without conditions",
+			given:    func(link *DeviceLink) {},
+			expected: "",
+		},
+		{
+			name: "checking model",
+			given: func(link *DeviceLink) {
+				link.SucceedOnNodeExisted(nil)
+			},
+			expected: DeviceLinkPending,
+		},
+		{
+			name: "failed to find model",
+			given: func(link *DeviceLink) {
+				link.SucceedOnNodeExisted(nil)
+				link.FailOnModelExisted("not found")
+			},
+			expected: DeviceLinkFailed,
+		},
+		{
+			name: "connecting",
+			given: func(link *DeviceLink) {
+				link.SucceedOnNodeExisted(nil)
+				link.SucceedOnModelExisted()
+				link.SucceedOnAdaptorExisted()
+				link.SucceedOnDeviceCreated()
+			},
+			expected: DeviceLinkPending,
+		},
+		{
+			name: "connected",
+			given: func(link *DeviceLink) {
+				link.SucceedOnNodeExisted(nil)
+				link.SucceedOnModelExisted()
+				link.SucceedOnAdaptorExisted()
+				link.SucceedOnDeviceCreated()
+				link.SucceedOnDeviceConnected()
+			},
+			expected: DeviceLinkConnected,
+		},
+		{
+			name: "failed to connect",
+			given: func(link *DeviceLink) {
+				link.SucceedOnNodeExisted(nil)
+				link.SucceedOnModelExisted()
+				link.SucceedOnAdaptorExisted()
+				link.SucceedOnDeviceCreated()
+				link.SucceedOnDeviceConnected()
+				link.FailOnDeviceConnected("unable to connect to device")
+			},
+			expected: DeviceLinkFailed,
+		},
+		{
+			name: "reconnecting",
+			given: func(link *DeviceLink) {
+				link.SucceedOnNodeExisted(nil)
+				link.SucceedOnModelExisted()
+				link.SucceedOnAdaptorExisted()
+				link.SucceedOnDeviceCreated()
+				link.SucceedOnDeviceConnected()
+				link.ToCheckDeviceConnected()
+			},
+			expected: DeviceLinkPending,
+		},
+	}
+
+	for _, tc := range testCases {
+		var link = &DeviceLink{}
+		link.Spec.Adaptor.Node = "edge-worker"
+		tc.given(link)
+		assert.Equal(t, tc.expected, link.GetPhase(), "case %q", tc.name)
+	}
+}
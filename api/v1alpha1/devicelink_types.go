@@ -153,6 +153,23 @@ type DeviceTemplateSpec struct {
 	DeviceLinkDeviceConnected DeviceLinkConditionType = "DeviceConnected"
 )
 
+// DeviceLinkPhase is derived from the type and status of the last condition.
+type DeviceLinkPhase string
+
+// These are valid phases of a device
+const (
+	// Pending means that the last condition is under checking,
+	// e.g. the device is connecting.
+	DeviceLinkPending DeviceLinkPhase = "Pending"
+
+	// Failed means that the last condition is failed.
+	DeviceLinkFailed DeviceLinkPhase = "Failed"
+
+	// DeviceConnected means that all conditions are succeeded,
+	// and the connection of device is healthy.
+	DeviceLinkConnected DeviceLinkPhase = "DeviceConnected"
+)
+
 // DeviceLinkCondition describes the state of a device at a certain point.
 type DeviceLinkCondition struct {
 	// Type of device condition.
@@ -174,6 +191,49 @@ type DeviceLinkCondition struct {
 	Message string `json:"message,omitempty"`
 }
 
+// DeviceLinkRetryPolicy defines the policy to reconnect the device when the adaptor returns an error.
+type DeviceLinkRetryPolicy struct {
+	// Specifies the maximum number of reconnecting attempts, the default value is 0 which means unlimited.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxRetries int32 `json:"maxRetries,omitempty"`
+
+	// Specifies the backoff of the first reconnecting attempt, the default value is 5s.
+	// The backoff is doubled on each attempt.
+	// +optional
+	InitialBackoff *metav1.Duration `json:"initialBackoff,omitempty"`
+
+	// Specifies the maximum backoff of reconnecting attempts, the default value is 5m.
+	// +optional
+	MaxBackoff *metav1.Duration `json:"maxBackoff,omitempty"`
+
+	// Specifies the error classes which are treated as permanent,
+	// the device is not reconnected if the adaptor returns these errors.
+	// The error class is the code of the error, one of InvalidSpec, Unreachable, Unauthorized, Timeout, Internal or Unspecified.
+	// +optional
+	PermanentErrors []string `json:"permanentErrors,omitempty"`
+}
+
+// DeviceLinkConditionTransition records a status transition of the device's condition.
+type DeviceLinkConditionTransition struct {
+	// Type of device condition.
+	Type DeviceLinkConditionType `json:"type"`
+
+	// The previous status of the condition.
+	// +optional
+	From metav1.ConditionStatus `json:"from,omitempty"`
+
+	// The current status of the condition.
+	To metav1.ConditionStatus `json:"to"`
+
+	// The reason for the transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// The time of the transition.
+	Time metav1.Time `json:"time"`
+}
+
 // DeviceLinkSpec defines the desired state of DeviceLink
 type DeviceLinkSpec struct {
 	// Specifies the desired adaptor of a device
@@ -191,6 +251,23 @@ type DeviceLinkSpec struct {
 	// Describe the device that will be created.
 	// +kubebuilder:validation:Required
 	Template DeviceTemplateSpec `json:"template"`
+
+	// Specifies the minimum interval between two status updates of the device,
+	// the status received within the interval is coalesced.
+	// The default value is configured by the limb.
+	// +optional
+	StatusUpdateInterval *metav1.Duration `json:"statusUpdateInterval,omitempty"`
+
+	// Specifies the names of device properties to be exported as Prometheus metrics by the limb,
+	// only the numeric properties are exported, and "*" means all numeric properties.
+	// It works only if the limb enables the property metrics.
+	// +optional
+	ExportedProperties []string `json:"exportedProperties,omitempty"`
+
+	// Specifies the policy to reconnect the device when the adaptor returns an error,
+	// the device is not reconnected automatically if it's not specified.
+	// +optional
+	RetryPolicy *DeviceLinkRetryPolicy `json:"retryPolicy,omitempty"`
 }
 
 // DeviceLinkStatus defines the observed state of DeviceLink
@@ -199,6 +276,24 @@ type DeviceLinkStatus struct {
 	// +optional
 	Conditions []DeviceLinkCondition `json:"conditions,omitempty"`
 
+	// Represents the latest status transitions of the device's conditions,
+	// at most 10 transitions are kept.
+	// +optional
+	ConditionHistory []DeviceLinkConditionTransition `json:"conditionHistory,omitempty"`
+
+	// Represents the number of times the DeviceConnected condition transitioned.
+	// +optional
+	DeviceConnectedTransitions int64 `json:"deviceConnectedTransitions,omitempty"`
+
+	// Represents the number of reconnecting attempts after the adaptor returned errors,
+	// it's reset when the device reports data again.
+	// +optional
+	ReconnectAttempts int32 `json:"reconnectAttempts,omitempty"`
+
+	// Represents the time of the next reconnecting attempt.
+	// +optional
+	NextReconnectTime *metav1.Time `json:"nextReconnectTime,omitempty"`
+
 	// Represents the observed scheduled Node name of the device.
 	// +optional
 	NodeName string `json:"nodeName,omitempty"`
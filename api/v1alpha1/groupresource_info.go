@@ -7,4 +7,7 @@
 var (
 	// GroupResourceDeviceLink is group resource represented to the DeviceLink
 	GroupResourceDeviceLink = schema.GroupResource{Group: GroupVersion.Group, Resource: "DeviceLink"}
+
+	// GroupResourceDeviceCommand is group resource represented to the DeviceCommand
+	GroupResourceDeviceCommand = schema.GroupResource{Group: GroupVersion.Group, Resource: "DeviceCommand"}
 )
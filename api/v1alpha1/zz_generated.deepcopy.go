@@ -45,6 +45,118 @@ func (in *DeviceAdaptor) DeepCopy() *DeviceAdaptor {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeviceCommand) DeepCopyInto(out *DeviceCommand) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceCommand.
+func (in *DeviceCommand) DeepCopy() *DeviceCommand {
+	if in == nil {
+		return nil
+	}
+	out := new(DeviceCommand)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DeviceCommand) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeviceCommandList) DeepCopyInto(out *DeviceCommandList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DeviceCommand, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceCommandList.
+func (in *DeviceCommandList) DeepCopy() *DeviceCommandList {
+	if in == nil {
+		return nil
+	}
+	out := new(DeviceCommandList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DeviceCommandList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeviceCommandSpec) DeepCopyInto(out *DeviceCommandSpec) {
+	*out = *in
+	if in.Arguments != nil {
+		in, out := &in.Arguments, &out.Arguments
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceCommandSpec.
+func (in *DeviceCommandSpec) DeepCopy() *DeviceCommandSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DeviceCommandSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeviceCommandStatus) DeepCopyInto(out *DeviceCommandStatus) {
+	*out = *in
+	if in.Output != nil {
+		in, out := &in.Output, &out.Output
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceCommandStatus.
+func (in *DeviceCommandStatus) DeepCopy() *DeviceCommandStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DeviceCommandStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DeviceLink) DeepCopyInto(out *DeviceLink) {
 	*out = *in
@@ -89,6 +201,22 @@ func (in *DeviceLinkCondition) DeepCopy() *DeviceLinkCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeviceLinkConditionTransition) DeepCopyInto(out *DeviceLinkConditionTransition) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceLinkConditionTransition.
+func (in *DeviceLinkConditionTransition) DeepCopy() *DeviceLinkConditionTransition {
+	if in == nil {
+		return nil
+	}
+	out := new(DeviceLinkConditionTransition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DeviceLinkList) DeepCopyInto(out *DeviceLinkList) {
 	*out = *in
@@ -264,6 +392,36 @@ func (in *DeviceLinkReferenceSource) DeepCopy() *DeviceLinkReferenceSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeviceLinkRetryPolicy) DeepCopyInto(out *DeviceLinkRetryPolicy) {
+	*out = *in
+	if in.InitialBackoff != nil {
+		in, out := &in.InitialBackoff, &out.InitialBackoff
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxBackoff != nil {
+		in, out := &in.MaxBackoff, &out.MaxBackoff
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.PermanentErrors != nil {
+		in, out := &in.PermanentErrors, &out.PermanentErrors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceLinkRetryPolicy.
+func (in *DeviceLinkRetryPolicy) DeepCopy() *DeviceLinkRetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(DeviceLinkRetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DeviceLinkSpec) DeepCopyInto(out *DeviceLinkSpec) {
 	*out = *in
@@ -277,6 +435,21 @@ func (in *DeviceLinkSpec) DeepCopyInto(out *DeviceLinkSpec) {
 		}
 	}
 	in.Template.DeepCopyInto(&out.Template)
+	if in.StatusUpdateInterval != nil {
+		in, out := &in.StatusUpdateInterval, &out.StatusUpdateInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ExportedProperties != nil {
+		in, out := &in.ExportedProperties, &out.ExportedProperties
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RetryPolicy != nil {
+		in, out := &in.RetryPolicy, &out.RetryPolicy
+		*out = new(DeviceLinkRetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceLinkSpec.
@@ -299,6 +472,17 @@ func (in *DeviceLinkStatus) DeepCopyInto(out *DeviceLinkStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ConditionHistory != nil {
+		in, out := &in.ConditionHistory, &out.ConditionHistory
+		*out = make([]DeviceLinkConditionTransition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NextReconnectTime != nil {
+		in, out := &in.NextReconnectTime, &out.NextReconnectTime
+		*out = (*in).DeepCopy()
+	}
 	if in.Model != nil {
 		in, out := &in.Model, &out.Model
 		*out = new(metav1.TypeMeta)
@@ -5,19 +5,27 @@
 )
 
 type Options struct {
-	MetricsAddr          int
-	EnableLeaderElection bool
+	MetricsAddr             int
+	EnableLeaderElection    bool
+	EnableAdmissionWebhook  bool
+	AdmissionWebhookPort    int
+	AdmissionWebhookCertDir string
 }
 
 func (in *Options) Flags(fsName string) (nfs cliflag.NamedFlagSets) {
 	fs := nfs.FlagSet(fsName)
 	fs.IntVar(&in.MetricsAddr, "metrics-addr", in.MetricsAddr, "The port is used for serving prometheus metrics")
 	fs.BoolVar(&in.EnableLeaderElection, "enable-leader-election", in.EnableLeaderElection, "Enable leader election for controller. Enabling this will ensure there is only one active controller manager.")
+	fs.BoolVar(&in.EnableAdmissionWebhook, "enable-admission-webhook", in.EnableAdmissionWebhook, "Enable admission webhook for validating DeviceLink. Enabling this requires a serving certificate under the admission webhook cert dir.")
+	fs.IntVar(&in.AdmissionWebhookPort, "admission-webhook-port", in.AdmissionWebhookPort, "The port is used for serving admission webhook")
+	fs.StringVar(&in.AdmissionWebhookCertDir, "admission-webhook-cert-dir", in.AdmissionWebhookCertDir, "The directory that contains the 'tls.crt' and 'tls.key' for serving admission webhook")
 	return
 }
 
 func NewOptions() *Options {
 	return &Options{
-		MetricsAddr: 8080,
+		MetricsAddr:             8080,
+		AdmissionWebhookPort:    9443,
+		AdmissionWebhookCertDir: "/tmp/k8s-webhook-server/serving-certs",
 	}
 }
@@ -1,23 +1,46 @@
 package options
 
 import (
+	"time"
+
 	cliflag "k8s.io/component-base/cli/flag"
 )
 
 type Options struct {
-	MetricsAddr int
-	NodeName    string
+	MetricsAddr            int
+	NodeName               string
+	StatusCoalescingWindow time.Duration
+	StatusUpdateInterval   time.Duration
+	StatusUpdateQPS        float32
+	StatusUpdateBurst      int
+	EnableHistory          bool
+	HistoryDir             string
+	HistoryCapacity        int
+	HistoryRetention       time.Duration
+	EnablePropertyMetrics  bool
 }
 
 func (in *Options) Flags(fsName string) (nfs cliflag.NamedFlagSets) {
 	fs := nfs.FlagSet(fsName)
 	fs.IntVar(&in.MetricsAddr, "metrics-addr", in.MetricsAddr, "The port is used for serving prometheus metrics")
 	fs.StringVar(&in.NodeName, "node-name", in.NodeName, "The name of the node, using 'NODE_NAME' environment variable is the same")
+	fs.DurationVar(&in.StatusCoalescingWindow, "status-coalescing-window", in.StatusCoalescingWindow, "The duration to coalesce the received status of a device before writing, the status is written immediately if it's zero")
+	fs.DurationVar(&in.StatusUpdateInterval, "status-update-interval", in.StatusUpdateInterval, "The default minimum interval between two status updates of a device, it can be overridden by the 'spec.statusUpdateInterval' of DeviceLink")
+	fs.Float32Var(&in.StatusUpdateQPS, "status-update-qps", in.StatusUpdateQPS, "The maximum QPS of the status updates of all devices on the node, the status updates are not limited if it's zero")
+	fs.IntVar(&in.StatusUpdateBurst, "status-update-burst", in.StatusUpdateBurst, "The maximum burst of the status updates of all devices on the node")
+	fs.BoolVar(&in.EnableHistory, "enable-history", in.EnableHistory, "Enable recording the history of device properties, which is served on '/history/{namespace}/{name}' of the metrics server")
+	fs.StringVar(&in.HistoryDir, "history-dir", in.HistoryDir, "The directory to persist the history of device properties, the history is kept in memory only if it's blank")
+	fs.IntVar(&in.HistoryCapacity, "history-capacity", in.HistoryCapacity, "The maximum number of the property readings kept for a device")
+	fs.DurationVar(&in.HistoryRetention, "history-retention", in.HistoryRetention, "The duration to keep the property readings of a device")
+	fs.BoolVar(&in.EnablePropertyMetrics, "enable-property-metrics", in.EnablePropertyMetrics, "Enable exporting the numeric device properties which are selected by the 'spec.exportedProperties' of DeviceLink as prometheus metrics")
 	return
 }
 
 func NewOptions() *Options {
 	return &Options{
-		MetricsAddr: 8080,
+		MetricsAddr:       8080,
+		StatusUpdateBurst: 10,
+		HistoryCapacity:   10000,
+		HistoryRetention:  24 * time.Hour,
 	}
 }
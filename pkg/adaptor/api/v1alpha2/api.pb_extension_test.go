@@ -0,0 +1,59 @@
+package v1alpha2
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetErrorCode(t *testing.T) {
+	var testCases = []struct {
+		given    error
+		expected ErrorCode
+	}{
+		{
+			given:    nil,
+			expected: ErrorCode_Unspecified,
+		},
+		{
+			given:    errors.New("unknown"),
+			expected: ErrorCode_Unspecified,
+		},
+		{
+			given:    &ConnectResponseError{Code: ErrorCode_Unauthorized, Message: "wrong password"},
+			expected: ErrorCode_Unauthorized,
+		},
+		{
+			given:    errors.Wrap(&ConnectResponseError{Code: ErrorCode_Timeout}, "wrapped"),
+			expected: ErrorCode_Timeout,
+		},
+		{
+			given:    status.Error(codes.InvalidArgument, "invalid spec"),
+			expected: ErrorCode_InvalidSpec,
+		},
+		{
+			given:    status.Error(codes.Unavailable, "transport is closing"),
+			expected: ErrorCode_Unreachable,
+		},
+		{
+			given:    status.Error(codes.PermissionDenied, "denied"),
+			expected: ErrorCode_Unauthorized,
+		},
+		{
+			given:    status.Error(codes.DeadlineExceeded, "timeout"),
+			expected: ErrorCode_Timeout,
+		},
+		{
+			given:    status.Error(codes.Internal, "internal"),
+			expected: ErrorCode_Internal,
+		},
+	}
+
+	for i, tc := range testCases {
+		var ret = GetErrorCode(tc.given)
+		assert.Equal(t, tc.expected, ret, "case %v", i+1)
+	}
+}
@@ -0,0 +1,22 @@
+package v1alpha2
+
+import (
+	"github.com/rancher/octopus/pkg/adaptor/api/v1alpha1"
+)
+
+const (
+	// Version is the current version of the API supported by Limb
+	Version = "v1alpha2"
+
+	// AdaptorPath is the folder the adaptor is expecting sockets to be on
+	AdaptorPath = "/var/lib/octopus/adaptors/"
+
+	// SocketSuffix is the suffix of the socket
+	SocketSuffix = ".sock"
+
+	// LimbSocket is the path of the Limb registry socket
+	LimbSocket = AdaptorPath + "limb" + SocketSuffix
+)
+
+// SupportedVersions are the versions of the API supported by Limb, in the order of preference.
+var SupportedVersions = []string{Version, v1alpha1.Version}
@@ -0,0 +1,75 @@
+package connection
+
+import (
+	"fmt"
+
+	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha2"
+)
+
+// CommandExecutor is implemented by the device which is able to execute the named commands.
+type CommandExecutor interface {
+	// Execute executes the named command with the JSON arguments, and returns the JSON output.
+	Execute(name string, arguments []byte) (output []byte, err error)
+}
+
+type commandError struct {
+	code    api.CommandResultCode
+	message string
+}
+
+func (e *commandError) Error() string {
+	return e.message
+}
+
+// NewUnsupportedCommandError returns an error indicates that the command is not supported.
+func NewUnsupportedCommandError(name string) error {
+	return &commandError{
+		code:    api.CommandResultCode_Unsupported,
+		message: fmt.Sprintf("unsupported command: %s", name),
+	}
+}
+
+// NewInvalidArgumentsError returns an error indicates that the arguments of command are invalid.
+func NewInvalidArgumentsError(format string, args ...interface{}) error {
+	return &commandError{
+		code:    api.CommandResultCode_InvalidArguments,
+		message: fmt.Sprintf(format, args...),
+	}
+}
+
+// ExecuteCommand executes the command via the executor and returns a response carrying the result,
+// the command is treated as unsupported if the executor doesn't implement the CommandExecutor.
+func ExecuteCommand(command *api.ConnectRequestCommand, executor interface{}) *api.ConnectResponse {
+	var result = &api.ConnectResponseCommandResult{
+		Id: command.GetId(),
+	}
+
+	var ce, ok = executor.(CommandExecutor)
+	if !ok || ce == nil {
+		var err = NewUnsupportedCommandError(command.GetName()).(*commandError)
+		result.Code = err.code
+		result.ErrorMessage = err.message
+		return &api.ConnectResponse{CommandResult: result}
+	}
+
+	var output, err = ce.Execute(command.GetName(), command.GetArguments())
+	if err != nil {
+		result.Code = api.CommandResultCode_Failed
+		if cerr, ok := err.(*commandError); ok {
+			result.Code = cerr.code
+		}
+		result.ErrorMessage = err.Error()
+	}
+	result.Output = output
+	return &api.ConnectResponse{CommandResult: result}
+}
+
+// ExecuteCommandInBackground executes the command via the executor in another goroutine and sends the result to limb,
+// so that a long running command doesn't block the receiving of connection,
+// the server should be wrapped by SerializeSend as the devices sync to limb at the same time.
+func ExecuteCommandInBackground(server api.Connection_ConnectServer, command *api.ConnectRequestCommand, executor interface{}) {
+	go func() {
+		// the broken stream is detected by the receiving loop, so the sending error is ignored here.
+		_ = server.Send(ExecuteCommand(command, executor))
+	}()
+}
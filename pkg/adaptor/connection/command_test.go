@@ -0,0 +1,106 @@
+package connection
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha2"
+)
+
+type fakeExecutor struct {
+	blocking chan struct{}
+}
+
+func (e *fakeExecutor) Execute(name string, arguments []byte) ([]byte, error) {
+	switch name {
+	case "block":
+		<-e.blocking
+		return nil, nil
+	case "echo":
+		if len(arguments) == 0 {
+			return nil, NewInvalidArgumentsError("arguments are required")
+		}
+		return arguments, nil
+	case "broken":
+		return nil, errors.New("device is broken")
+	}
+	return nil, NewUnsupportedCommandError(name)
+}
+
+func TestExecuteCommand(t *testing.T) {
+	var testCases = []struct {
+		name     string
+		given    *api.ConnectRequestCommand
+		executor interface{}
+		expected *api.ConnectResponseCommandResult
+	}{
+		{
+			name:     "without executor",
+			given:    &api.ConnectRequestCommand{Id: "1", Name: "echo"},
+			executor: nil,
+			expected: &api.ConnectResponseCommandResult{Id: "1", Code: api.CommandResultCode_Unsupported, ErrorMessage: "unsupported command: echo"},
+		},
+		{
+			name:     "succeeded",
+			given:    &api.ConnectRequestCommand{Id: "2", Name: "echo", Arguments: []byte(`{"a":1}`)},
+			executor: &fakeExecutor{},
+			expected: &api.ConnectResponseCommandResult{Id: "2", Code: api.CommandResultCode_Succeeded, Output: []byte(`{"a":1}`)},
+		},
+		{
+			name:     "invalid arguments",
+			given:    &api.ConnectRequestCommand{Id: "3", Name: "echo"},
+			executor: &fakeExecutor{},
+			expected: &api.ConnectResponseCommandResult{Id: "3", Code: api.CommandResultCode_InvalidArguments, ErrorMessage: "arguments are required"},
+		},
+		{
+			name:     "failed",
+			given:    &api.ConnectRequestCommand{Id: "4", Name: "broken"},
+			executor: &fakeExecutor{},
+			expected: &api.ConnectResponseCommandResult{Id: "4", Code: api.CommandResultCode_Failed, ErrorMessage: "device is broken"},
+		},
+	}
+
+	for _, tc := range testCases {
+		var actual = ExecuteCommand(tc.given, tc.executor)
+		assert.Equal(t, tc.expected, actual.GetCommandResult(), "case %q", tc.name)
+	}
+}
+
+// fakeResultServer records the sent results.
+type fakeResultServer struct {
+	api.Connection_ConnectServer
+
+	results chan *api.ConnectResponseCommandResult
+}
+
+func (s *fakeResultServer) Send(resp *api.ConnectResponse) error {
+	s.results <- resp.GetCommandResult()
+	return nil
+}
+
+func TestExecuteCommandInBackground(t *testing.T) {
+	var server = &fakeResultServer{results: make(chan *api.ConnectResponseCommandResult, 2)}
+	var executor = &fakeExecutor{blocking: make(chan struct{})}
+
+	// the blocking command doesn't block the others
+	ExecuteCommandInBackground(server, &api.ConnectRequestCommand{Id: "1", Name: "block"}, executor)
+	ExecuteCommandInBackground(server, &api.ConnectRequestCommand{Id: "2", Name: "echo", Arguments: []byte(`{}`)}, executor)
+	select {
+	case result := <-server.results:
+		assert.Equal(t, "2", result.GetId())
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout to wait for the result of the non-blocking command")
+	}
+
+	close(executor.blocking)
+	select {
+	case result := <-server.results:
+		assert.Equal(t, "1", result.GetId())
+		assert.Equal(t, api.CommandResultCode_Succeeded, result.GetCode())
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout to wait for the result of the blocking command")
+	}
+}
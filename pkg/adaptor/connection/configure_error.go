@@ -0,0 +1,70 @@
+package connection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"syscall"
+
+	grpccodes "google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+
+	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha2"
+)
+
+// NewClassifiedError returns an error classified with the given code, the message of error is kept.
+func NewClassifiedError(code api.ErrorCode, err error) error {
+	return &api.ConnectResponseError{
+		Code:    code,
+		Message: err.Error(),
+	}
+}
+
+// NewConfigureError returns the gRPC status error of failing to configure the device,
+// the status code is converted from the class of error,
+// so that limb is able to reconnect the unreachable device according to the retry policy,
+// the unclassified error is treated as the invalid spec.
+func NewConfigureError(message string, err error) error {
+	var code grpccodes.Code
+	switch classifyError(err) {
+	case api.ErrorCode_Unreachable:
+		code = grpccodes.Unavailable
+	case api.ErrorCode_Timeout:
+		code = grpccodes.DeadlineExceeded
+	case api.ErrorCode_Unauthorized:
+		code = grpccodes.PermissionDenied
+	case api.ErrorCode_Internal:
+		code = grpccodes.Internal
+	default:
+		code = grpccodes.InvalidArgument
+	}
+	return grpcstatus.Error(code, fmt.Sprintf("%s: %v", message, err))
+}
+
+// classifyError returns the class of the given error,
+// the network failures are classified as Unreachable or Timeout.
+func classifyError(err error) api.ErrorCode {
+	if code := api.GetErrorCode(err); code != api.ErrorCode_Unspecified {
+		return code
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return api.ErrorCode_Timeout
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		if netErr.Timeout() {
+			return api.ErrorCode_Timeout
+		}
+		return api.ErrorCode_Unreachable
+	}
+	switch {
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF),
+		errors.Is(err, syscall.ECONNREFUSED), errors.Is(err, syscall.ECONNRESET),
+		errors.Is(err, syscall.EHOSTUNREACH), errors.Is(err, syscall.ENETUNREACH):
+		return api.ErrorCode_Unreachable
+	}
+	return api.ErrorCode_Unspecified
+}
@@ -0,0 +1,69 @@
+package connection
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"syscall"
+	"testing"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	grpccodes "google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+
+	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha2"
+)
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestNewConfigureError(t *testing.T) {
+	var testCases = []struct {
+		given         error
+		expected      grpccodes.Code
+		expectedClass api.ErrorCode
+	}{
+		{
+			given:         pkgerrors.Wrap(&net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}, "failed to connect via TCP"),
+			expected:      grpccodes.Unavailable,
+			expectedClass: api.ErrorCode_Unreachable,
+		},
+		{
+			given:         pkgerrors.Wrap(io.EOF, "failed to read"),
+			expected:      grpccodes.Unavailable,
+			expectedClass: api.ErrorCode_Unreachable,
+		},
+		{
+			given:         pkgerrors.Wrap(context.DeadlineExceeded, "failed to connect"),
+			expected:      grpccodes.DeadlineExceeded,
+			expectedClass: api.ErrorCode_Timeout,
+		},
+		{
+			given:         &net.OpError{Op: "read", Net: "tcp", Err: timeoutError{}},
+			expected:      grpccodes.DeadlineExceeded,
+			expectedClass: api.ErrorCode_Timeout,
+		},
+		{
+			given:         pkgerrors.Wrap(NewClassifiedError(api.ErrorCode_Unauthorized, errors.New("wrong password")), "failed to activate session"),
+			expected:      grpccodes.PermissionDenied,
+			expectedClass: api.ErrorCode_Unauthorized,
+		},
+		{
+			given:         errors.New("invalid register"),
+			expected:      grpccodes.InvalidArgument,
+			expectedClass: api.ErrorCode_InvalidSpec,
+		},
+	}
+
+	for i, tc := range testCases {
+		var ret = NewConfigureError("failed to connect to device endpoint", tc.given)
+		assert.Equal(t, tc.expected, grpcstatus.Code(ret), "case %v", i+1)
+		assert.Equal(t, "failed to connect to device endpoint: "+tc.given.Error(), grpcstatus.Convert(ret).Message(), "case %v", i+1)
+		assert.Equal(t, tc.expectedClass, api.GetErrorCode(ret), "case %v", i+1)
+	}
+}
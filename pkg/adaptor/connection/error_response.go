@@ -0,0 +1,28 @@
+package connection
+
+import (
+	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha2"
+)
+
+// NewErrorResponse returns the response to send the classified error to limb,
+// the error message is sent along with the error for the compatibility.
+func NewErrorResponse(code api.ErrorCode, message string, details map[string]string) *api.ConnectResponse {
+	return &api.ConnectResponse{
+		ErrorMessage: message,
+		Error: &api.ConnectResponseError{
+			Code:    code,
+			Message: message,
+			Details: details,
+		},
+	}
+}
+
+// NewErrorResponseOf returns the response to send the given error to limb,
+// the code is converted from the gRPC status or the network failure of the error if the error is not classified.
+func NewErrorResponseOf(err error, details map[string]string) *api.ConnectResponse {
+	var code = classifyError(err)
+	if code == api.ErrorCode_Unspecified {
+		code = api.ErrorCode_Internal
+	}
+	return NewErrorResponse(code, err.Error(), details)
+}
@@ -0,0 +1,40 @@
+package connection
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	grpccodes "google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+
+	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha2"
+)
+
+func TestNewErrorResponseOf(t *testing.T) {
+	var testCases = []struct {
+		given    error
+		expected api.ErrorCode
+	}{
+		{
+			given:    errors.New("failed to read"),
+			expected: api.ErrorCode_Internal,
+		},
+		{
+			given:    grpcstatus.Error(grpccodes.Unavailable, "failed to connect"),
+			expected: api.ErrorCode_Unreachable,
+		},
+		{
+			given:    &api.ConnectResponseError{Code: api.ErrorCode_Unauthorized, Message: "wrong password"},
+			expected: api.ErrorCode_Unauthorized,
+		},
+	}
+
+	for i, tc := range testCases {
+		var ret = NewErrorResponseOf(tc.given, map[string]string{"endpoint": "tcp://127.0.0.1:502"})
+		assert.Equal(t, tc.expected, ret.GetError().GetCode(), "case %v", i+1)
+		assert.Equal(t, tc.given.Error(), ret.GetErrorMessage(), "case %v", i+1)
+		assert.Equal(t, tc.given.Error(), ret.GetError().GetMessage(), "case %v", i+1)
+		assert.Equal(t, "tcp://127.0.0.1:502", ret.GetError().GetDetails()["endpoint"], "case %v", i+1)
+	}
+}
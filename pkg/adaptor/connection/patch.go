@@ -0,0 +1,34 @@
+package connection
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha2"
+)
+
+// NewMergePatchResponse returns the response to send the JSON merge patch(RFC 7386) of device to limb,
+// only the `status` of the patch is accepted by limb.
+func NewMergePatchResponse(patch interface{}) (*api.ConnectResponse, error) {
+	return newDevicePatchResponse(api.PatchType_MergePatch, patch)
+}
+
+// NewPropertiesPatchResponse returns the response to send the changed properties of device to limb,
+// limb merges the properties into the `status.properties` of device by the `name` of property.
+func NewPropertiesPatchResponse(properties interface{}) (*api.ConnectResponse, error) {
+	return newDevicePatchResponse(api.PatchType_PropertiesPatch, properties)
+}
+
+func newDevicePatchResponse(patchType api.PatchType, patch interface{}) (*api.ConnectResponse, error) {
+	var data, err = json.Marshal(patch)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal %s", patchType)
+	}
+	return &api.ConnectResponse{
+		DevicePatch: &api.ConnectResponseDevicePatch{
+			Type: patchType,
+			Data: data,
+		},
+	}, nil
+}
@@ -0,0 +1,37 @@
+package connection
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha2"
+)
+
+func TestNewPatchResponse(t *testing.T) {
+	type property struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	}
+
+	var actual, err = NewPropertiesPatchResponse([]property{{Name: "temperature", Value: "21"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &api.ConnectResponse{
+		DevicePatch: &api.ConnectResponseDevicePatch{
+			Type: api.PatchType_PropertiesPatch,
+			Data: []byte(`[{"name":"temperature","value":"21"}]`),
+		},
+	}, actual)
+
+	actual, err = NewMergePatchResponse(map[string]interface{}{"status": map[string]interface{}{"on": true}})
+	assert.NoError(t, err)
+	assert.Equal(t, &api.ConnectResponse{
+		DevicePatch: &api.ConnectResponseDevicePatch{
+			Type: api.PatchType_MergePatch,
+			Data: []byte(`{"status":{"on":true}}`),
+		},
+	}, actual)
+
+	_, err = NewMergePatchResponse(make(chan int))
+	assert.Error(t, err)
+}
@@ -0,0 +1,29 @@
+package connection
+
+import (
+	"sync"
+
+	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha2"
+)
+
+// SerializeSend wraps the stream to serialize the sending,
+// the devices sync to limb and the commands are answered in their own goroutines.
+func SerializeSend(server api.Connection_ConnectServer) api.Connection_ConnectServer {
+	if _, ok := server.(*serializedSendServer); ok {
+		return server
+	}
+	return &serializedSendServer{Connection_ConnectServer: server}
+}
+
+type serializedSendServer struct {
+	api.Connection_ConnectServer
+
+	sendLock sync.Mutex
+}
+
+func (s *serializedSendServer) Send(resp *api.ConnectResponse) error {
+	// it is not safe to call `Send` on the same stream in different goroutines.
+	s.sendLock.Lock()
+	defer s.sendLock.Unlock()
+	return s.Connection_ConnectServer.Send(resp)
+}
@@ -0,0 +1,49 @@
+package connection
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/atomic"
+
+	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha2"
+)
+
+// fakeConnectServer records the overlapped sending.
+type fakeConnectServer struct {
+	api.Connection_ConnectServer
+
+	sending    atomic.Int32
+	overlapped atomic.Bool
+	sent       atomic.Int32
+}
+
+func (s *fakeConnectServer) Send(_ *api.ConnectResponse) error {
+	if s.sending.Inc() > 1 {
+		s.overlapped.Store(true)
+	}
+	time.Sleep(time.Millisecond)
+	s.sending.Dec()
+	s.sent.Inc()
+	return nil
+}
+
+func TestSerializeSend(t *testing.T) {
+	var fake = &fakeConnectServer{}
+	var server = SerializeSend(fake)
+	assert.Equal(t, server, SerializeSend(server), "wrapped only once")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = server.Send(&api.ConnectResponse{})
+		}()
+	}
+	wg.Wait()
+	assert.False(t, fake.overlapped.Load())
+	assert.Equal(t, int32(10), fake.sent.Load())
+}
@@ -3,7 +3,7 @@
 import (
 	"k8s.io/apimachinery/pkg/util/runtime"
 
-	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha1"
+	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha2"
 	"github.com/rancher/octopus/pkg/adaptor/socket/handler"
 )
 
@@ -8,7 +8,7 @@
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
 
-	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha1"
+	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha2"
 )
 
 type Server interface {
@@ -8,7 +8,7 @@
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
 
-	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha1"
+	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha2"
 )
 
 func Register(ctx context.Context, request api.RegisterRequest) error {
@@ -27,7 +27,11 @@ func Register(ctx context.Context, request api.RegisterRequest) error {
 		return errors.Wrapf(err, "failed to dial Limb %s", api.LimbSocket)
 	}
 
-	// register adaptor
+	// register adaptor,
+	// the adaptor only serves the version it was built against if the supported versions are not specified.
+	if len(request.Versions) == 0 {
+		request.Versions = []string{request.Version}
+	}
 	if _, err := api.NewRegistrationClient(conn).Register(ctx, &request); err != nil {
 		return errors.Wrapf(err, "failed to register to Limb")
 	}
@@ -4,7 +4,7 @@
 	"github.com/fsnotify/fsnotify"
 	"github.com/pkg/errors"
 
-	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha1"
+	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha2"
 )
 
 func newSocketWatcher() (*socketWatcher, error) {
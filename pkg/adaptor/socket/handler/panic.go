@@ -4,7 +4,7 @@
 	"os"
 	"path/filepath"
 
-	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha1"
+	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha2"
 )
 
 func NewPanicsCleanupSocketHandler(endpoint string) func(interface{}) {
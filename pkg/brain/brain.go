@@ -13,6 +13,7 @@
 	edgev1alpha1 "github.com/rancher/octopus/api/v1alpha1"
 	"github.com/rancher/octopus/cmd/brain/options"
 	"github.com/rancher/octopus/pkg/brain/controller"
+	"github.com/rancher/octopus/pkg/brain/webhook"
 	"github.com/rancher/octopus/pkg/util/log/handler"
 )
 
@@ -38,6 +39,8 @@ func Run(name string, opts *options.Options) error {
 			MetricsBindAddress: fmt.Sprintf(":%d", opts.MetricsAddr),
 			LeaderElection:     opts.EnableLeaderElection,
 			LeaderElectionID:   "octopus-brain-leader-election-id",
+			Port:               opts.AdmissionWebhookPort,
+			CertDir:            opts.AdmissionWebhookCertDir,
 		},
 	)
 	if err != nil {
@@ -71,6 +74,18 @@ func Run(name string, opts *options.Options) error {
 		return err
 	}
 
+	if opts.EnableAdmissionWebhook {
+		log.V(0).Info("Creating admission webhooks")
+		if err = (&webhook.DeviceLinkValidator{
+			Client: controllerMgr.GetClient(),
+			Ctx:    ctx,
+			Log:    ctrl.Log.WithName("webhook").WithName("deviceLink"),
+		}).SetupWithManager(controllerMgr); err != nil {
+			log.Error(err, "Unable to create admission webhook", "webhook", "DeviceLink")
+			return err
+		}
+	}
+
 	log.Info("Starting")
 	if err = controllerMgr.Start(ctrl.SetupSignalHandler()); err != nil {
 		log.Error(err, "Problem running")
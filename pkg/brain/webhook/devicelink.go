@@ -0,0 +1,253 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	edgev1alpha1 "github.com/rancher/octopus/api/v1alpha1"
+	"github.com/rancher/octopus/pkg/util/fieldpath"
+	modelutil "github.com/rancher/octopus/pkg/util/model"
+	"github.com/rancher/octopus/pkg/util/object"
+)
+
+const (
+	ValidatingDeviceLinkPath = "/validate-edge-cattle-io-v1alpha1-devicelink"
+)
+
+// DeviceLinkValidator validates a DeviceLink object
+type DeviceLinkValidator struct {
+	client.Client
+
+	Ctx context.Context
+	Log logr.Logger
+
+	decoder *admission.Decoder
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-edge-cattle-io-v1alpha1-devicelink,mutating=false,failurePolicy=fail,groups=edge.cattle.io,resources=devicelinks,versions=v1alpha1,name=vdevicelink.edge.cattle.io
+// +kubebuilder:rbac:groups="apiextensions.k8s.io",resources=customresourcedefinitions,verbs=get
+
+func (v *DeviceLinkValidator) Handle(_ context.Context, req admission.Request) admission.Response {
+	var ctx = v.Ctx
+	var log = v.Log.WithValues("deviceLink", types.NamespacedName{Namespace: req.Namespace, Name: req.Name})
+
+	var link edgev1alpha1.DeviceLink
+	if err := v.decoder.Decode(req, &link); err != nil {
+		log.Error(err, "Unable to decode DeviceLink")
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	// the DeviceLink under deleting doesn't need to be validated.
+	if req.Operation == admissionv1beta1.Update && object.IsDeleted(&link) {
+		return admission.Allowed("")
+	}
+
+	// fetches model,
+	// the model can be created after the DeviceLink, so we don't reject the request if it isn't existed.
+	var model apiextensionsv1.CustomResourceDefinition
+	if name := modelutil.GetCRDNameOfGroupVersionKind(link.Spec.Model.GroupVersionKind()); name != "" {
+		if err := v.Get(ctx, types.NamespacedName{Name: name}, &model); err != nil {
+			if !apierrs.IsNotFound(err) {
+				log.Error(err, "Unable to fetch the model of DeviceLink")
+				return admission.Errored(http.StatusInternalServerError, err)
+			}
+		}
+	}
+
+	var errs = ValidateDeviceLink(&link, &model)
+	if len(errs) != 0 {
+		var err = apierrs.NewInvalid(edgev1alpha1.GroupVersion.WithKind("DeviceLink").GroupKind(), link.Name, errs)
+		return admission.Denied(err.Error())
+	}
+	return admission.Allowed("")
+}
+
+func (v *DeviceLinkValidator) SetupWithManager(mgr ctrl.Manager) error {
+	var decoder, err = admission.NewDecoder(mgr.GetScheme())
+	if err != nil {
+		return err
+	}
+	v.decoder = decoder
+
+	mgr.GetWebhookServer().Register(ValidatingDeviceLinkPath, &webhook.Admission{Handler: v})
+	return nil
+}
+
+// ValidateDeviceLink validates the spec of DeviceLink,
+// the template is validated by the OpenAPI v3 schema of the given model if the model is activating.
+func ValidateDeviceLink(link *edgev1alpha1.DeviceLink, model *apiextensionsv1.CustomResourceDefinition) field.ErrorList {
+	var allErrs field.ErrorList
+	var specPath = field.NewPath("spec")
+
+	allErrs = append(allErrs, validateAdaptor(link.Spec.Adaptor, specPath.Child("adaptor"))...)
+	allErrs = append(allErrs, validateModel(link.Spec.Model, specPath.Child("model"))...)
+	allErrs = append(allErrs, validateReferences(link, specPath.Child("references"))...)
+	allErrs = append(allErrs, validateTemplate(link, model, specPath.Child("template"))...)
+	if interval := link.Spec.StatusUpdateInterval; interval != nil && interval.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("statusUpdateInterval"), interval.Duration.String(), "must be greater than or equal to 0"))
+	}
+	allErrs = append(allErrs, validateRetryPolicy(link.Spec.RetryPolicy, specPath.Child("retryPolicy"))...)
+
+	return allErrs
+}
+
+func validateAdaptor(adaptor edgev1alpha1.DeviceAdaptor, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if adaptor.Node == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("node"), ""))
+	}
+
+	// the adaptors are registered to the limb of each node, which is invisible to the brain,
+	// so only validates the name format that the limb accepts in the adaptor's registration.
+	if adaptor.Name == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("name"), ""))
+	} else {
+		for _, msg := range validation.IsQualifiedName(adaptor.Name) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("name"), adaptor.Name, fmt.Sprintf("must be a qualified adaptor name: %s", msg)))
+		}
+	}
+
+	return allErrs
+}
+
+func validateModel(model metav1.TypeMeta, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if model.Kind == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("kind"), ""))
+	}
+	if model.APIVersion == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("apiVersion"), ""))
+	} else {
+		var gv, err = schema.ParseGroupVersion(model.APIVersion)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("apiVersion"), model.APIVersion, err.Error()))
+		} else if gv.Group == "" || gv.Version == "" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("apiVersion"), model.APIVersion, "must be in the form of 'group/version'"))
+		}
+	}
+
+	return allErrs
+}
+
+func validateReferences(link *edgev1alpha1.DeviceLink, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	var names = make(map[string]struct{}, len(link.Spec.References))
+	for i, ref := range link.Spec.References {
+		var idxPath = fldPath.Index(i)
+
+		if ref.Name == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("name"), ""))
+		} else {
+			if _, exist := names[ref.Name]; exist {
+				allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), ref.Name))
+			}
+			names[ref.Name] = struct{}{}
+		}
+
+		var sources int
+		if ref.Secret != nil {
+			sources++
+			if ref.Secret.Name == "" {
+				allErrs = append(allErrs, field.Required(idxPath.Child("secret", "name"), ""))
+			}
+		}
+		if ref.ConfigMap != nil {
+			sources++
+			if ref.ConfigMap.Name == "" {
+				allErrs = append(allErrs, field.Required(idxPath.Child("configMap", "name"), ""))
+			}
+		}
+		if ref.DownwardAPI != nil {
+			sources++
+			var itemsPath = idxPath.Child("downwardAPI", "items")
+			if len(ref.DownwardAPI.Items) == 0 {
+				allErrs = append(allErrs, field.Required(itemsPath, ""))
+			}
+			for j, item := range ref.DownwardAPI.Items {
+				if item.Name == "" {
+					allErrs = append(allErrs, field.Required(itemsPath.Index(j).Child("name"), ""))
+				}
+				if item.FieldRef == nil {
+					allErrs = append(allErrs, field.Required(itemsPath.Index(j).Child("fieldRef"), ""))
+					continue
+				}
+				if _, err := fieldpath.ExtractDeviceLinkFieldPathAsBytes(link, item.FieldRef.FieldPath); err != nil {
+					allErrs = append(allErrs, field.Invalid(itemsPath.Index(j).Child("fieldRef", "fieldPath"), item.FieldRef.FieldPath, err.Error()))
+				}
+			}
+		}
+		switch sources {
+		case 0:
+			allErrs = append(allErrs, field.Required(idxPath, "must specify one of: secret, configMap or downwardAPI"))
+		case 1:
+		default:
+			allErrs = append(allErrs, field.Forbidden(idxPath, "may not specify more than 1 source"))
+		}
+	}
+
+	return allErrs
+}
+
+func validateRetryPolicy(policy *edgev1alpha1.DeviceLinkRetryPolicy, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if policy == nil {
+		return allErrs
+	}
+
+	if policy.MaxRetries < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxRetries"), policy.MaxRetries, "must be greater than or equal to 0"))
+	}
+	if policy.InitialBackoff != nil && policy.InitialBackoff.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("initialBackoff"), policy.InitialBackoff.Duration.String(), "must be greater than 0"))
+	}
+	if policy.MaxBackoff != nil {
+		if policy.MaxBackoff.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("maxBackoff"), policy.MaxBackoff.Duration.String(), "must be greater than 0"))
+		} else if policy.InitialBackoff != nil && policy.MaxBackoff.Duration < policy.InitialBackoff.Duration {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("maxBackoff"), policy.MaxBackoff.Duration.String(), "must be greater than or equal to initialBackoff"))
+		}
+	}
+	return allErrs
+}
+
+func validateTemplate(link *edgev1alpha1.DeviceLink, model *apiextensionsv1.CustomResourceDefinition, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	var template = link.Spec.Template
+
+	allErrs = append(allErrs, metav1validation.ValidateLabels(template.Labels, fldPath.Child("metadata", "labels"))...)
+
+	var spec interface{} = map[string]interface{}{}
+	if template.Spec != nil && len(template.Spec.Raw) != 0 {
+		if err := json.Unmarshal(template.Spec.Raw, &spec); err != nil {
+			return append(allErrs, field.Invalid(fldPath.Child("spec"), string(template.Spec.Raw), err.Error()))
+		}
+	}
+
+	if model == nil || !object.IsActivating(model) {
+		return allErrs
+	}
+	var modelSchema = modelutil.GetSchemaOfVersion(model, link.Spec.Model.GroupVersionKind().Version)
+	allErrs = append(allErrs, modelutil.ValidateBySchema(modelutil.GetPropertySchema(modelSchema, "spec"), spec, fldPath.Child("spec"))...)
+
+	return allErrs
+}
@@ -0,0 +1,202 @@
+package webhook
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	edgev1alpha1 "github.com/rancher/octopus/api/v1alpha1"
+)
+
+func TestValidateDeviceLink(t *testing.T) {
+	var model = &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "dummyspecialdevices.devices.edge.cattle.io",
+		},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+				{
+					Name:   "v1alpha1",
+					Served: true,
+					Schema: &apiextensionsv1.CustomResourceValidation{
+						OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+							Type: "object",
+							Properties: map[string]apiextensionsv1.JSONSchemaProps{
+								"spec": {
+									Type:     "object",
+									Required: []string{"protocol"},
+									Properties: map[string]apiextensionsv1.JSONSchemaProps{
+										"protocol": {
+											Type: "object",
+											Properties: map[string]apiextensionsv1.JSONSchemaProps{
+												"location": {
+													Type: "string",
+												},
+											},
+										},
+										"on": {
+											Type: "boolean",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	var newLink = func(spec string, references ...edgev1alpha1.DeviceLinkReference) *edgev1alpha1.DeviceLink {
+		return &edgev1alpha1.DeviceLink{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "living-room-fan",
+			},
+			Spec: edgev1alpha1.DeviceLinkSpec{
+				Adaptor: edgev1alpha1.DeviceAdaptor{
+					Node: "edge-worker",
+					Name: "adaptors.edge.cattle.io/dummy",
+				},
+				Model: metav1.TypeMeta{
+					Kind:       "DummySpecialDevice",
+					APIVersion: "devices.edge.cattle.io/v1alpha1",
+				},
+				References: references,
+				Template: edgev1alpha1.DeviceTemplateSpec{
+					Spec: &runtime.RawExtension{Raw: []byte(spec)},
+				},
+			},
+		}
+	}
+
+	var testCases = []struct {
+		name     string
+		given    *edgev1alpha1.DeviceLink
+		model    *apiextensionsv1.CustomResourceDefinition
+		expected []string
+	}{
+		{
+			name:  "valid",
+			given: newLink(`{"protocol":{"location":"living-room"},"on":true}`),
+			model: model,
+		},
+		{
+			name:  "without model",
+			given: newLink(`{"on":"true"}`),
+			model: &apiextensionsv1.CustomResourceDefinition{},
+		},
+		{
+			name:  "invalid template",
+			given: newLink(`{"on":"true"}`),
+			model: model,
+			expected: []string{
+				"spec.template.spec.protocol",
+				"spec.template.spec.on",
+			},
+		},
+		{
+			name: "unqualified adaptor and malformed model",
+			given: func() *edgev1alpha1.DeviceLink {
+				var link = newLink(`{"protocol":{}}`)
+				link.Spec.Adaptor.Node = ""
+				link.Spec.Adaptor.Name = "adaptors.edge.cattle.io/dummy/"
+				link.Spec.Model.APIVersion = "v1alpha1"
+				return link
+			}(),
+			model: model,
+			expected: []string{
+				"spec.adaptor.node",
+				"spec.adaptor.name",
+				"spec.model.apiVersion",
+			},
+		},
+		{
+			name: "malformed references",
+			given: newLink(`{"protocol":{}}`,
+				edgev1alpha1.DeviceLinkReference{
+					Name: "credential",
+					DeviceLinkReferenceSource: edgev1alpha1.DeviceLinkReferenceSource{
+						Secret: &edgev1alpha1.DeviceLinkReferenceSecretSource{},
+					},
+				},
+				edgev1alpha1.DeviceLinkReference{
+					Name: "credential",
+					DeviceLinkReferenceSource: edgev1alpha1.DeviceLinkReferenceSource{
+						Secret:    &edgev1alpha1.DeviceLinkReferenceSecretSource{Name: "credential"},
+						ConfigMap: &edgev1alpha1.DeviceLinkReferenceConfigMapSource{Name: "credential"},
+					},
+				},
+				edgev1alpha1.DeviceLinkReference{
+					Name: "node",
+					DeviceLinkReferenceSource: edgev1alpha1.DeviceLinkReferenceSource{
+						DownwardAPI: &edgev1alpha1.DeviceLinkReferenceDownwardAPISource{
+							Items: []edgev1alpha1.DeviceLinkReferenceDownwardAPISourceItem{
+								{
+									Name:     "ip",
+									FieldRef: &corev1.ObjectFieldSelector{FieldPath: "status.nodeInternalIP"},
+								},
+								{
+									Name:     "unknown",
+									FieldRef: &corev1.ObjectFieldSelector{FieldPath: "spec.unknown"},
+								},
+							},
+						},
+					},
+				},
+				edgev1alpha1.DeviceLinkReference{},
+			),
+			model: model,
+			expected: []string{
+				"spec.references[0].secret.name",
+				"spec.references[1].name",
+				"spec.references[1]",
+				"spec.references[2].downwardAPI.items[1].fieldRef.fieldPath",
+				"spec.references[3].name",
+				"spec.references[3]",
+			},
+		},
+		{
+			name: "negative status update interval",
+			given: func() *edgev1alpha1.DeviceLink {
+				var link = newLink(`{"protocol":{}}`)
+				link.Spec.StatusUpdateInterval = &metav1.Duration{Duration: -time.Second}
+				return link
+			}(),
+			model: model,
+			expected: []string{
+				"spec.statusUpdateInterval",
+			},
+		},
+		{
+			name: "invalid retry policy",
+			given: func() *edgev1alpha1.DeviceLink {
+				var link = newLink(`{"protocol":{}}`)
+				link.Spec.RetryPolicy = &edgev1alpha1.DeviceLinkRetryPolicy{
+					MaxRetries:     -1,
+					InitialBackoff: &metav1.Duration{Duration: time.Minute},
+					MaxBackoff:     &metav1.Duration{Duration: time.Second},
+				}
+				return link
+			}(),
+			model: model,
+			expected: []string{
+				"spec.retryPolicy.maxRetries",
+				"spec.retryPolicy.maxBackoff",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		var errs = ValidateDeviceLink(tc.given, tc.model)
+		var actual []string
+		for _, err := range errs {
+			actual = append(actual, err.Field)
+		}
+		assert.ElementsMatch(t, tc.expected, actual, "case %q", tc.name)
+	}
+}
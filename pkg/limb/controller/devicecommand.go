@@ -0,0 +1,298 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	edgev1alpha1 "github.com/rancher/octopus/api/v1alpha1"
+	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha2"
+	"github.com/rancher/octopus/pkg/limb/index"
+	"github.com/rancher/octopus/pkg/suctioncup"
+	"github.com/rancher/octopus/pkg/util/object"
+)
+
+const defaultDeviceCommandTimeoutSeconds = 30
+
+// DeviceCommandReconciler reconciles a DeviceCommand object
+type DeviceCommandReconciler struct {
+	client.Client
+	record.EventRecorder
+
+	Ctx context.Context
+	Log logr.Logger
+
+	SuctionCup suctioncup.Neurons
+	NodeName   string
+
+	// executionsLock guards the executions.
+	executionsLock sync.Mutex
+	// executions records the commands which are executing by this limb.
+	executions map[types.NamespacedName]*deviceCommandExecution
+	// executed notifies the controller to reconcile the command which execution is done.
+	executed chan event.GenericEvent
+}
+
+// deviceCommandExecution records the execution of a running command.
+type deviceCommandExecution struct {
+	uid    types.UID
+	done   bool
+	result *api.ConnectResponseCommandResult
+	err    error
+}
+
+// +kubebuilder:rbac:groups=edge.cattle.io,resources=devicecommands,verbs=get;list;watch
+// +kubebuilder:rbac:groups=edge.cattle.io,resources=devicecommands/status,verbs=get;update;patch
+
+func (r *DeviceCommandReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	var ctx = r.Ctx
+	var log = r.Log.WithValues("deviceCommand", req.NamespacedName)
+
+	// fetches command
+	var command edgev1alpha1.DeviceCommand
+	if err := r.Get(ctx, req.NamespacedName, &command); err != nil {
+		if !apierrs.IsNotFound(err) {
+			log.Error(err, "Unable to fetch DeviceCommand")
+			return ctrl.Result{Requeue: true}, nil
+		}
+		r.forgetExecution(req.NamespacedName)
+		// ignores error, since they can't be fixed by an immediate requeue
+		return ctrl.Result{}, nil
+	}
+	if object.IsDeleted(&command) || command.IsCompleted() {
+		r.forgetExecution(req.NamespacedName)
+		return ctrl.Result{}, nil
+	}
+
+	// a command running on this node is completed when its execution is done,
+	// the execution notifies the controller to reconcile the command again.
+	if command.Status.Phase == edgev1alpha1.DeviceCommandRunning && command.Status.NodeName == r.NodeName {
+		var execution = r.getExecution(&command)
+		if execution == nil {
+			// the execution is lost as the limb restarts,
+			// we cannot know whether the adaptor has received it, so we don't retry to avoid executing twice.
+			r.complete(&command, edgev1alpha1.DeviceCommandFailed, "", nil, "the command has been interrupted")
+			if err := r.Status().Update(ctx, &command); err != nil {
+				log.Error(err, "Unable to change the status of DeviceCommand")
+				return ctrl.Result{Requeue: true}, nil
+			}
+			return ctrl.Result{}, nil
+		}
+		if !execution.done {
+			return ctrl.Result{}, nil
+		}
+
+		var commandCopied = command.DeepCopy()
+		if execution.err != nil {
+			r.complete(&command, edgev1alpha1.DeviceCommandFailed, "", nil, execution.err.Error())
+			r.Eventf(&command, "Warning", "FailedExecuted", "cannot execute command on device: %v", execution.err)
+		} else if execution.result.GetCode() != api.CommandResultCode_Succeeded {
+			r.complete(&command, edgev1alpha1.DeviceCommandFailed, execution.result.GetCode().String(), execution.result.GetOutput(), execution.result.GetErrorMessage())
+			r.Eventf(&command, "Warning", "FailedExecuted", "command is responded with %s: %s", execution.result.GetCode(), execution.result.GetErrorMessage())
+		} else {
+			r.complete(&command, edgev1alpha1.DeviceCommandSucceeded, execution.result.GetCode().String(), execution.result.GetOutput(), "")
+		}
+
+		// the status is patched to avoid losing the result as conflicting.
+		if err := r.Status().Patch(ctx, &command, client.MergeFrom(commandCopied)); err != nil {
+			log.Error(err, "Unable to change the status of DeviceCommand")
+			return ctrl.Result{Requeue: true}, nil
+		}
+		r.forgetExecution(req.NamespacedName)
+		return ctrl.Result{}, nil
+	}
+
+	// fetches link
+	var link edgev1alpha1.DeviceLink
+	if err := r.Get(ctx, types.NamespacedName{Namespace: command.Namespace, Name: command.Spec.DeviceLink}, &link); err != nil {
+		if !apierrs.IsNotFound(err) {
+			log.Error(err, "Unable to fetch the DeviceLink of DeviceCommand")
+			return ctrl.Result{Requeue: true}, nil
+		}
+		// the command keeps pending until the link is created.
+		return ctrl.Result{}, nil
+	}
+
+	// only the limb on the scheduled node of link can execute the command.
+	if link.Status.NodeName != r.NodeName {
+		return ctrl.Result{}, nil
+	}
+
+	// a running command is executed by the limb of another node before the link is rescheduled,
+	// we cannot know whether the adaptor has received it, so we don't retry to avoid executing twice.
+	if command.Status.Phase == edgev1alpha1.DeviceCommandRunning {
+		r.complete(&command, edgev1alpha1.DeviceCommandFailed, "", nil, "the command has been interrupted")
+		if err := r.Status().Update(ctx, &command); err != nil {
+			log.Error(err, "Unable to change the status of DeviceCommand")
+			return ctrl.Result{Requeue: true}, nil
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// waits for the connection of link,
+	// the command will be reconciled again when the link changes.
+	if object.IsDeleted(&link) || link.GetDeviceConnectedStatus() != metav1.ConditionTrue {
+		if command.Status.Phase != edgev1alpha1.DeviceCommandPending {
+			command.Status.Phase = edgev1alpha1.DeviceCommandPending
+			command.Status.NodeName = r.NodeName
+			command.Status.Message = "the device isn't connected"
+			if err := r.Status().Update(ctx, &command); err != nil {
+				log.Error(err, "Unable to change the status of DeviceCommand")
+				return ctrl.Result{Requeue: true}, nil
+			}
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// records the running phase before sending
+	var now = metav1.Now()
+	command.Status.Phase = edgev1alpha1.DeviceCommandRunning
+	command.Status.NodeName = r.NodeName
+	command.Status.Message = ""
+	command.Status.StartTime = &now
+	if err := r.Status().Update(ctx, &command); err != nil {
+		log.Error(err, "Unable to change the status of DeviceCommand")
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	// executes command in background, so that a long running command doesn't block the other commands
+	r.execute(&command, &link)
+	return ctrl.Result{}, nil
+}
+
+func (r *DeviceCommandReconciler) SetupWithManager(ctrlMgr ctrl.Manager) error {
+	if err := ctrlMgr.GetFieldIndexer().IndexField(
+		r.Ctx,
+		&edgev1alpha1.DeviceCommand{},
+		index.DeviceCommandByDeviceLinkField,
+		index.DeviceCommandByDeviceLinkFunc,
+	); err != nil {
+		return err
+	}
+
+	r.executions = make(map[types.NamespacedName]*deviceCommandExecution)
+	r.executed = make(chan event.GenericEvent)
+	return ctrl.NewControllerManagedBy(ctrlMgr).
+		Named("limb_dc").
+		For(&edgev1alpha1.DeviceCommand{}).
+		Watches(
+			&source.Kind{Type: &edgev1alpha1.DeviceLink{}},
+			&handler.EnqueueRequestsFromMapFunc{ToRequests: handler.ToRequestsFunc(r.requestsOfDeviceLink)},
+		).
+		Watches(
+			&source.Channel{Source: r.executed},
+			&handler.EnqueueRequestForObject{},
+		).
+		Complete(r)
+}
+
+// execute sends the command to the adaptor of link in another goroutine,
+// and notifies the controller to reconcile the command when the result is responded or timeout.
+func (r *DeviceCommandReconciler) execute(command *edgev1alpha1.DeviceCommand, link *edgev1alpha1.DeviceLink) {
+	var timeoutSeconds int32 = defaultDeviceCommandTimeoutSeconds
+	if command.Spec.TimeoutSeconds != nil && *command.Spec.TimeoutSeconds > 0 {
+		timeoutSeconds = *command.Spec.TimeoutSeconds
+	}
+	var sendCommand = &api.ConnectRequestCommand{
+		Id:   string(command.UID),
+		Name: command.Spec.Command,
+	}
+	if command.Spec.Arguments != nil {
+		sendCommand.Arguments = command.Spec.Arguments.Raw
+	}
+
+	var execution = &deviceCommandExecution{uid: command.UID}
+	r.executionsLock.Lock()
+	r.executions[object.GetNamespacedName(command)] = execution
+	r.executionsLock.Unlock()
+
+	var notified = command.DeepCopy()
+	var by = link.DeepCopy()
+	go func() {
+		var result, err = r.SuctionCup.Command(sendCommand, time.Duration(timeoutSeconds)*time.Second, by)
+
+		r.executionsLock.Lock()
+		execution.done = true
+		execution.result = result
+		execution.err = err
+		r.executionsLock.Unlock()
+
+		select {
+		case <-r.Ctx.Done():
+		case r.executed <- event.GenericEvent{Meta: notified, Object: notified}:
+		}
+	}()
+}
+
+// getExecution returns a copy of the execution of the given command,
+// or nil if the command isn't executing by this limb.
+func (r *DeviceCommandReconciler) getExecution(command *edgev1alpha1.DeviceCommand) *deviceCommandExecution {
+	r.executionsLock.Lock()
+	defer r.executionsLock.Unlock()
+
+	var execution, exist = r.executions[object.GetNamespacedName(command)]
+	if !exist || execution.uid != command.UID {
+		return nil
+	}
+	var copied = *execution
+	return &copied
+}
+
+// forgetExecution removes the execution of the given command.
+func (r *DeviceCommandReconciler) forgetExecution(name types.NamespacedName) {
+	r.executionsLock.Lock()
+	defer r.executionsLock.Unlock()
+
+	delete(r.executions, name)
+}
+
+// requestsOfDeviceLink returns the requests of the uncompleted commands which are invoked on the given link.
+func (r *DeviceCommandReconciler) requestsOfDeviceLink(obj handler.MapObject) []reconcile.Request {
+	var link = object.ToDeviceLinkObject(obj.Object)
+	if link == nil || link.Status.NodeName != r.NodeName {
+		return nil
+	}
+
+	var commands edgev1alpha1.DeviceCommandList
+	if err := r.List(r.Ctx, &commands, client.InNamespace(link.Namespace), client.MatchingFields{index.DeviceCommandByDeviceLinkField: link.Name}); err != nil {
+		r.Log.Error(err, "Unable to list related DeviceCommand of DeviceLink", "deviceLink", object.GetNamespacedName(link))
+		return nil
+	}
+	var requests = make([]reconcile.Request, 0, len(commands.Items))
+	for i := range commands.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: object.GetNamespacedName(&commands.Items[i])})
+	}
+	return requests
+}
+
+// complete fills the result into the status of command.
+func (r *DeviceCommandReconciler) complete(command *edgev1alpha1.DeviceCommand, phase edgev1alpha1.DeviceCommandPhase, code string, output []byte, message string) {
+	var now = metav1.Now()
+	command.Status.Phase = phase
+	command.Status.Code = code
+	command.Status.Message = message
+	command.Status.CompletionTime = &now
+	command.Status.Output = nil
+	if len(output) != 0 {
+		// the output is expected to be JSON bytes,
+		// otherwise, we record it as a JSON string.
+		if !json.Valid(output) {
+			output, _ = json.Marshal(string(output))
+		}
+		command.Status.Output = &runtime.RawExtension{Raw: output}
+	}
+}
@@ -0,0 +1,118 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	edgev1alpha1 "github.com/rancher/octopus/api/v1alpha1"
+	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha2"
+	"github.com/rancher/octopus/pkg/suctioncup"
+	"github.com/rancher/octopus/pkg/util/log/zap"
+)
+
+// fakeCommandNeurons simulates the command execution of suction cup,
+// the execution is blocked until the result is given.
+type fakeCommandNeurons struct {
+	suctioncup.Neurons
+
+	results chan *api.ConnectResponseCommandResult
+}
+
+func (n *fakeCommandNeurons) Command(_ *api.ConnectRequestCommand, _ time.Duration, _ *edgev1alpha1.DeviceLink) (*api.ConnectResponseCommandResult, error) {
+	return <-n.results, nil
+}
+
+func newTestDeviceCommandReconciler(neurons suctioncup.Neurons, objs ...runtime.Object) *DeviceCommandReconciler {
+	var scheme = runtime.NewScheme()
+	_ = edgev1alpha1.AddToScheme(scheme)
+
+	return &DeviceCommandReconciler{
+		Client:        fake.NewFakeClientWithScheme(scheme, objs...),
+		EventRecorder: record.NewFakeRecorder(100),
+		Ctx:           context.Background(),
+		Log:           zap.WrapAsLogr(zap.NewDevelopmentLogger()),
+		SuctionCup:    neurons,
+		NodeName:      "edge-worker",
+		executions:    make(map[types.NamespacedName]*deviceCommandExecution),
+		executed:      make(chan event.GenericEvent, 1),
+	}
+}
+
+func getTestDeviceCommand(t *testing.T, r *DeviceCommandReconciler) edgev1alpha1.DeviceCommand {
+	var command edgev1alpha1.DeviceCommand
+	if err := r.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "test-command"}, &command); err != nil {
+		t.Fatalf("failed to get DeviceCommand: %v", err)
+	}
+	return command
+}
+
+func TestDeviceCommandReconciler_Reconcile(t *testing.T) {
+	var link = newTestDeviceLink(nil)
+	link.SucceedOnDeviceConnected()
+	var command = &edgev1alpha1.DeviceCommand{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test-command",
+			UID:       "test-command-uid",
+		},
+		Spec: edgev1alpha1.DeviceCommandSpec{
+			DeviceLink: link.Name,
+			Command:    "reset",
+		},
+	}
+	var neurons = &fakeCommandNeurons{results: make(chan *api.ConnectResponseCommandResult)}
+	var r = newTestDeviceCommandReconciler(neurons, link, command)
+	var req = ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "test-command"}}
+
+	// the reconciling returns without waiting for the result
+	var ret, err = r.Reconcile(req)
+	assert.NoError(t, err)
+	assert.Equal(t, ctrl.Result{}, ret)
+	var actual = getTestDeviceCommand(t, r)
+	assert.Equal(t, edgev1alpha1.DeviceCommandRunning, actual.Status.Phase)
+
+	// the running command keeps running until the execution is done
+	ret, err = r.Reconcile(req)
+	assert.NoError(t, err)
+	assert.Equal(t, ctrl.Result{}, ret)
+	actual = getTestDeviceCommand(t, r)
+	assert.Equal(t, edgev1alpha1.DeviceCommandRunning, actual.Status.Phase)
+
+	// the done execution notifies the controller to complete the command
+	neurons.results <- &api.ConnectResponseCommandResult{Id: "test-command-uid", Code: api.CommandResultCode_Succeeded, Output: []byte(`{"ok":true}`)}
+	select {
+	case e := <-r.executed:
+		assert.Equal(t, "test-command", e.Meta.GetName())
+	case <-time.After(5 * time.Second):
+		t.Fatal("the done execution isn't notified")
+	}
+	ret, err = r.Reconcile(req)
+	assert.NoError(t, err)
+	assert.Equal(t, ctrl.Result{}, ret)
+	actual = getTestDeviceCommand(t, r)
+	assert.Equal(t, edgev1alpha1.DeviceCommandSucceeded, actual.Status.Phase)
+	assert.Equal(t, `{"ok":true}`, string(actual.Status.Output.Raw))
+	assert.Empty(t, r.executions)
+
+	// the running command without execution is interrupted
+	actual.Status = edgev1alpha1.DeviceCommandStatus{Phase: edgev1alpha1.DeviceCommandRunning, NodeName: "edge-worker"}
+	if err := r.Status().Update(context.Background(), &actual); err != nil {
+		t.Fatalf("failed to prepare DeviceCommand: %v", err)
+	}
+	ret, err = r.Reconcile(req)
+	assert.NoError(t, err)
+	assert.Equal(t, ctrl.Result{}, ret)
+	actual = getTestDeviceCommand(t, r)
+	assert.Equal(t, edgev1alpha1.DeviceCommandFailed, actual.Status.Phase)
+	assert.Equal(t, "the command has been interrupted", actual.Status.Message)
+}
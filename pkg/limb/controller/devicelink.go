@@ -17,8 +17,10 @@
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	edgev1alpha1 "github.com/rancher/octopus/api/v1alpha1"
+	"github.com/rancher/octopus/pkg/limb/history"
 	"github.com/rancher/octopus/pkg/limb/index"
 	"github.com/rancher/octopus/pkg/limb/predicate"
+	"github.com/rancher/octopus/pkg/metrics"
 	"github.com/rancher/octopus/pkg/suctioncup"
 	"github.com/rancher/octopus/pkg/util/collection"
 	"github.com/rancher/octopus/pkg/util/converter"
@@ -41,6 +43,9 @@ type DeviceLinkReconciler struct {
 
 	SuctionCup suctioncup.Neurons
 	NodeName   string
+	History    history.Store
+
+	EnablePropertyMetrics bool
 }
 
 // +kubebuilder:rbac:groups=edge.cattle.io,resources=devicelinks,verbs=get;list;watch;create;update;patch;delete
@@ -73,6 +78,16 @@ func (r *DeviceLinkReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error)
 		// disconnects
 		r.SuctionCup.Disconnect(&link)
 
+		// cleans up history
+		if r.History != nil {
+			if err := r.History.Delete(req.NamespacedName); err != nil {
+				log.Error(err, "Unable to delete the history of DeviceLink")
+			}
+		}
+
+		// cleans up metrics
+		metrics.GetLimbMetricsRecorder().DeleteDevice(link.Namespace, link.Name)
+
 		// removes finalizer
 		link.Finalizers = collection.StringSliceRemove(link.Finalizers, ReconcilingDeviceLink)
 		if err := r.Update(ctx, &link); err != nil {
@@ -100,8 +115,11 @@ func (r *DeviceLinkReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error)
 	// adaptor.node 是用来指定这个设备由那个节点来管理，一个节点可能， 管理多个， 如果不是当前的节点，那么则执行disconnect的操作
 	if link.Status.NodeName != link.Spec.Adaptor.Node {
 		r.SuctionCup.Disconnect(&link)
+		metrics.GetLimbMetricsRecorder().DeleteDevice(link.Namespace, link.Name)
 		return ctrl.Result{}, nil
 	}
+	// records the phase of device, which is refreshed whenever the status is updated later.
+	metrics.GetLimbMetricsRecorder().SetDevicePhase(link.Namespace, link.Name, string(link.GetPhase()))
 
 	// NB(thxCode) we might see this as the `spec.model` has been changed,
 	// so we need to disconnect the previous connection and
@@ -127,7 +145,7 @@ func (r *DeviceLinkReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error)
 	if !isAdaptorExisted {
 		link.FailOnAdaptorExisted("the adaptor isn't existed")
 		// 如果适配器不存在，则更新状态，重新进入reconcile
-		if err := r.Status().Update(ctx, &link); err != nil {
+		if err := r.updateStatus(ctx, &link); err != nil {
 			log.Error(err, "Unable to change the status of DeviceLink")
 			return ctrl.Result{Requeue: true}, nil
 		}
@@ -144,7 +162,7 @@ func (r *DeviceLinkReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error)
 	if deviceNewErr != nil {
 		log.Error(deviceNewErr, "Unable to make device from model")
 		link.FailOnDeviceCreated("unable to make device from model")
-		if err := r.Status().Update(ctx, &link); err != nil {
+		if err := r.updateStatus(ctx, &link); err != nil {
 			log.Error(err, "Unable to change the status of DeviceLink")
 			return ctrl.Result{Requeue: true}, nil
 		}
@@ -172,7 +190,7 @@ func (r *DeviceLinkReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error)
 			// NB(thxCode) if the device creation is invalid, we don't need to retry.
 			log.Error(err, "Unable to create device from template")
 			link.FailOnDeviceCreated("unable to create device from template")
-			if err := r.Status().Update(ctx, &link); err != nil {
+			if err := r.updateStatus(ctx, &link); err != nil {
 				log.Error(err, "Unable to change the status of DeviceLink")
 				return ctrl.Result{Requeue: true}, nil
 			}
@@ -188,7 +206,7 @@ func (r *DeviceLinkReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error)
 	var references, err = r.fetchReferences(&link)
 	if err != nil {
 		link.FailOnDeviceConnected("unable to fetch the reference parameters")
-		if err := r.Status().Update(ctx, &link); err != nil {
+		if err := r.updateStatus(ctx, &link); err != nil {
 			log.Error(err, "Unable to change the status of DeviceLink")
 			return ctrl.Result{Requeue: true}, nil
 		}
@@ -207,7 +225,7 @@ func (r *DeviceLinkReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error)
 				return ctrl.Result{Requeue: true}, nil
 			}
 			link.FailOnDeviceConnected("unable to update the device from template")
-			if err := r.Status().Update(ctx, &link); err != nil {
+			if err := r.updateStatus(ctx, &link); err != nil {
 				log.Error(err, "Unable to change the status of DeviceLink")
 				return ctrl.Result{Requeue: true}, nil
 			}
@@ -216,20 +234,39 @@ func (r *DeviceLinkReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error)
 		}
 	}
 
+	// the scheduled reconnecting is unnecessary as we are going to connect.
+	link.Status.NextReconnectTime = nil
+
 	// connects to device
 	// 链接设备操作
 	if err := r.SuctionCup.Connect(references, &device, &link); err != nil {
-		link.FailOnDeviceConnected("unable to connect to device")
-		if err := r.Status().Update(ctx, &link); err != nil {
+		// the connecting failure follows the same retry policy as the error received from adaptor.
+		var errClass = getErrorClass(err)
+		var backoff, retry = getReconnectBackoff(link.Spec.RetryPolicy, link.Status.ReconnectAttempts, errClass)
+		if !retry {
+			link.FailOnDeviceConnectedWithReason(getErrorReason(errClass), "unable to connect to device")
+			if err := r.updateStatus(ctx, &link); err != nil {
+				log.Error(err, "Unable to change the status of DeviceLink")
+				return ctrl.Result{Requeue: true}, nil
+			}
+			r.Eventf(&link, "Warning", "FailedConnected", "cannot connect to device: %v", err)
+			return ctrl.Result{}, nil
+		}
+
+		var nextReconnectTime = metav1.NewTime(time.Now().Add(backoff))
+		link.Status.ReconnectAttempts++
+		link.Status.NextReconnectTime = &nextReconnectTime
+		link.FailOnDeviceConnectedWithReason(getErrorReason(errClass), fmt.Sprintf("unable to connect to device, reconnect in %v", backoff))
+		if err := r.updateStatus(ctx, &link); err != nil {
 			log.Error(err, "Unable to change the status of DeviceLink")
 			return ctrl.Result{Requeue: true}, nil
 		}
-		r.Eventf(&link, "Warning", "FailedConnected", "cannot connect to device: %v", err)
-		return ctrl.Result{}, nil
+		r.Eventf(&link, "Warning", "BackOff", "cannot connect to device: %v, reconnect in %v", err, backoff)
+		return ctrl.Result{RequeueAfter: backoff}, nil
 	}
 	link.SucceedOnDeviceConnected()
 
-	if err := r.Status().Update(ctx, &link); err != nil {
+	if err := r.updateStatus(ctx, &link); err != nil {
 		log.Error(err, "Unable to change the status of DeviceLink")
 		return ctrl.Result{Requeue: true}, nil
 	}
@@ -257,6 +294,16 @@ func (r *DeviceLinkReconciler) SetupWithManager(ctrlMgr ctrl.Manager, suctionCup
 		Complete(r)
 }
 
+// updateStatus updates the status of link, and records the phase of device after updated.
+func (r *DeviceLinkReconciler) updateStatus(ctx context.Context, link *edgev1alpha1.DeviceLink) error {
+	if err := r.Status().Update(ctx, link); err != nil {
+		return err
+	}
+	// records the phase of device, which is refreshed whenever the status is updated later.
+	metrics.GetLimbMetricsRecorder().SetDevicePhase(link.Namespace, link.Name, string(link.GetPhase()))
+	return nil
+}
+
 // fetchReferences fetches the references of deviceLink.
 func (r *DeviceLinkReconciler) fetchReferences(deviceLink *edgev1alpha1.DeviceLink) (map[string]map[string][]byte, error) {
 	var ctx = r.Ctx
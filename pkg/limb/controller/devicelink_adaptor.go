@@ -41,14 +41,14 @@ func (r *DeviceLinkReconciler) ReceiveAdaptorStatus(req suctioncup.RequestAdapto
 		if req.Registered {
 			if link.GetAdaptorExistedStatus() == metav1.ConditionFalse {
 				link.SucceedOnAdaptorExisted()
-				if err := r.Status().Update(ctx, &link); err != nil {
+				if err := r.updateStatus(ctx, &link); err != nil {
 					log.Error(err, "Unable to change the status of DeviceLink")
 					return suctioncup.Response{Requeue: true}, nil
 				}
 			}
 			if link.GetDeviceConnectedStatus() == metav1.ConditionFalse {
 				link.ToCheckDeviceConnected()
-				if err := r.Status().Update(ctx, &link); err != nil {
+				if err := r.updateStatus(ctx, &link); err != nil {
 					log.Error(err, "Unable to change the status of DeviceLink")
 					return suctioncup.Response{Requeue: true}, nil
 				}
@@ -56,7 +56,7 @@ func (r *DeviceLinkReconciler) ReceiveAdaptorStatus(req suctioncup.RequestAdapto
 		} else {
 			if link.GetAdaptorExistedStatus() != metav1.ConditionFalse {
 				link.FailOnAdaptorExisted("the adaptor is unregistered")
-				if err := r.Status().Update(ctx, &link); err != nil {
+				if err := r.updateStatus(ctx, &link); err != nil {
 					log.Error(err, "Unable to change the status of DeviceLink")
 					return suctioncup.Response{Requeue: true}, nil
 				}
@@ -2,17 +2,29 @@
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	edgev1alpha1 "github.com/rancher/octopus/api/v1alpha1"
+	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha2"
+	"github.com/rancher/octopus/pkg/metrics"
 	"github.com/rancher/octopus/pkg/suctioncup"
+	"github.com/rancher/octopus/pkg/util/collection"
 	"github.com/rancher/octopus/pkg/util/log/handler"
 	modelutil "github.com/rancher/octopus/pkg/util/model"
 	"github.com/rancher/octopus/pkg/util/object"
+	"github.com/rancher/octopus/pkg/util/patch"
+)
+
+const (
+	defaultReconnectInitialBackoff = 5 * time.Second
+	defaultReconnectMaxBackoff     = 5 * time.Minute
 )
 
 // +kubebuilder:rbac:groups=edge.cattle.io,resources=devicelinks,verbs=list
@@ -39,7 +51,7 @@ func (r *DeviceLinkReconciler) ReceiveConnectionStatus(req suctioncup.RequestCon
 		// NB(thxCode) we need to reconnect again if the connection is closed passively.
 		// TODO However, we need a way to stop the passive closed from unregistering adaptor.
 		link.ToCheckDeviceConnected()
-		if err := r.Status().Update(ctx, &link); err != nil {
+		if err := r.updateStatus(ctx, &link); err != nil {
 			log.Error(err, "Unable to change the status of DeviceLink")
 			return suctioncup.Response{Requeue: true}, nil
 		}
@@ -48,37 +60,71 @@ func (r *DeviceLinkReconciler) ReceiveConnectionStatus(req suctioncup.RequestCon
 	}
 
 	if req.Error != nil {
-		// NB(thxCode) we cannot reconnect directly if the connection returns an error,
+		// the error request is requeued until the backoff is expired,
+		// then we trigger the reconnecting by checking the DeviceConnected status.
+		if link.Status.NextReconnectTime != nil {
+			if remaining := time.Until(link.Status.NextReconnectTime.Time); remaining > 0 {
+				return suctioncup.Response{RequeueAfter: remaining}, nil
+			}
+			link.Status.NextReconnectTime = nil
+			link.ToCheckDeviceConnected()
+			if err := r.updateStatus(ctx, &link); err != nil {
+				log.Error(err, "Unable to change the status of DeviceLink")
+				return suctioncup.Response{Requeue: true}, nil
+			}
+			r.Eventf(&link, "Normal", "Reconnecting", "reconnect to device, attempt %d", link.Status.ReconnectAttempts)
+			return suctioncup.Response{}, nil
+		}
+
+		r.SuctionCup.Disconnect(&link)
+		metrics.GetLimbMetricsRecorder().IncreaseDeviceAdaptorErrors(link.Namespace, link.Name)
+
+		// we cannot reconnect if the retry policy is not specified or the error is permanent,
 		// it may be something uncontrollable happened, e.g. passed a wrong parameter or failed to connect the physical device.
 		// it can be recovered by user manually.
-		r.SuctionCup.Disconnect(&link)
-		link.FailOnDeviceConnected("received error from adaptor")
-		if err := r.Status().Update(ctx, &link); err != nil {
+		var errClass = getErrorClass(req.Error)
+		var backoff, retry = getReconnectBackoff(link.Spec.RetryPolicy, link.Status.ReconnectAttempts, errClass)
+		if !retry {
+			link.FailOnDeviceConnectedWithReason(getErrorReason(errClass), "received error from adaptor")
+			if err := r.updateStatus(ctx, &link); err != nil {
+				log.Error(err, "Unable to change the status of DeviceLink")
+				return suctioncup.Response{Requeue: true}, nil
+			}
+			r.Eventf(&link, "Warning", "Disconnected", "received %s error from adaptor: %v", errClass, req.Error)
+			return suctioncup.Response{}, nil
+		}
+
+		var nextReconnectTime = metav1.NewTime(time.Now().Add(backoff))
+		link.Status.ReconnectAttempts++
+		link.Status.NextReconnectTime = &nextReconnectTime
+		link.FailOnDeviceConnectedWithReason(getErrorReason(errClass), fmt.Sprintf("received %s error from adaptor, reconnect in %v", errClass, backoff))
+		if err := r.updateStatus(ctx, &link); err != nil {
 			log.Error(err, "Unable to change the status of DeviceLink")
 			return suctioncup.Response{Requeue: true}, nil
 		}
-		r.Eventf(&link, "Warning", "Disconnected", "received error from adaptor: %v", req.Error)
-		return suctioncup.Response{}, nil
+		r.Eventf(&link, "Warning", "BackOff", "received %s error from adaptor: %v, reconnect in %v", errClass, req.Error, backoff)
+		return suctioncup.Response{RequeueAfter: backoff}, nil
 	}
 
 	// moves next if success on DeviceConnected
-	if link.GetDeviceConnectedStatus() != metav1.ConditionTrue {
+	switch link.GetDeviceConnectedStatus() {
+	case metav1.ConditionTrue:
+	case metav1.ConditionFalse:
+		// the adaptor sends the whole device again after reconnecting.
 		return suctioncup.Response{}, nil
+	default:
+		// the data can be received before the connecting result is recorded,
+		// keeps it until the connecting is done, as the adaptor only sends the changed properties later.
+		return suctioncup.Response{RequeueAfter: time.Second}, nil
 	}
 
-	// parses device status
-	var updatedStatus interface{}
-	if err := func() error {
-		var updated = &unstructured.Unstructured{Object: make(map[string]interface{})}
-		if err := updated.UnmarshalJSON(req.Data); err != nil {
-			return err
+	// the device is recovered if it reports data again.
+	if link.Status.ReconnectAttempts != 0 {
+		link.Status.ReconnectAttempts = 0
+		if err := r.updateStatus(ctx, &link); err != nil {
+			log.Error(err, "Unable to change the status of DeviceLink")
+			return suctioncup.Response{Requeue: true}, nil
 		}
-		updatedStatus = updated.Object["status"]
-		return nil
-	}(); err != nil {
-		// NB(thxCode) if failed to process data, we just record an event for this.
-		r.Eventf(&link, "Warning", "FailReceived", "received invalid data from adaptor: %v", err)
-		return suctioncup.Response{}, nil
 	}
 
 	// validates device
@@ -96,18 +142,133 @@ func (r *DeviceLinkReconciler) ReceiveConnectionStatus(req suctioncup.RequestCon
 	if !object.IsActivating(&device) {
 		// NB(thxCode) we should trigger to recreate the device if it is deleted.
 		link.ToCheckDeviceCreated()
-		if err := r.Status().Update(ctx, &link); err != nil {
+		if err := r.updateStatus(ctx, &link); err != nil {
 			log.Error(err, "Unable to change the status of DeviceLink")
 			return suctioncup.Response{Requeue: true}, nil
 		}
 		r.Eventf(&link, "Warning", "Recreating", "previous device is inactivated")
 		return suctioncup.Response{}, nil
 	}
-	device.Object["status"] = updatedStatus
-	if err := r.Status().Update(ctx, &device); err != nil {
+
+	// applies device status
+	var original = device.DeepCopy()
+	if err := applyReceivedStatus(&device, req); err != nil {
+		// NB(thxCode) if failed to process data, we just record an event for this.
+		r.Eventf(&link, "Warning", "FailReceived", "received invalid data from adaptor: %v", err)
+		return suctioncup.Response{}, nil
+	}
+	// the status is patched to avoid overwriting the changes made by others.
+	if err := r.Status().Patch(ctx, &device, client.MergeFrom(original)); err != nil {
 		log.Error(err, "Unable to update the device of DeviceLink")
 		return suctioncup.Response{Requeue: true}, nil
 	}
 
+	// records the history of properties
+	if r.History != nil {
+		if err := r.History.Record(req.Name, original.Object["status"], device.Object["status"]); err != nil {
+			log.Error(err, "Unable to record the history of device")
+		}
+	}
+
+	// exports the numeric properties as metrics
+	if r.EnablePropertyMetrics {
+		var values = filterExportedProperties(modelutil.GetNumericProperties(device.Object["status"]), link.Spec.ExportedProperties)
+		metrics.GetLimbMetricsRecorder().SetDeviceProperties(link.Namespace, link.Name, device.GetKind(), values)
+	}
+
 	return suctioncup.Response{}, nil
 }
+
+// getErrorClass returns the class of the error received from adaptor,
+// which is the code of the classified error, or the converted code of the gRPC status.
+func getErrorClass(err error) string {
+	return api.GetErrorCode(err).String()
+}
+
+// getErrorReason returns the reason of DeviceConnected condition for the given error class,
+// the unclassified error is treated as Unhealthy.
+func getErrorReason(errClass string) string {
+	if errClass == api.ErrorCode_Unspecified.String() {
+		return "Unhealthy"
+	}
+	return errClass
+}
+
+// getReconnectBackoff returns the backoff to reconnect the device according to the retry policy,
+// it returns false if the device should not be reconnected.
+func getReconnectBackoff(policy *edgev1alpha1.DeviceLinkRetryPolicy, attempts int32, errClass string) (time.Duration, bool) {
+	if policy == nil {
+		return 0, false
+	}
+	if policy.MaxRetries > 0 && attempts >= policy.MaxRetries {
+		return 0, false
+	}
+	if collection.StringSliceContain(policy.PermanentErrors, errClass) {
+		return 0, false
+	}
+
+	var backoff = defaultReconnectInitialBackoff
+	if policy.InitialBackoff != nil && policy.InitialBackoff.Duration > 0 {
+		backoff = policy.InitialBackoff.Duration
+	}
+	var maxBackoff = defaultReconnectMaxBackoff
+	if policy.MaxBackoff != nil && policy.MaxBackoff.Duration > 0 {
+		maxBackoff = policy.MaxBackoff.Duration
+	}
+	for i := int32(0); i < attempts && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+	return backoff, true
+}
+
+// filterExportedProperties returns the values of the exported properties,
+// all values are returned if the exported properties contains "*".
+func filterExportedProperties(values map[string]float64, exported []string) map[string]float64 {
+	if collection.StringSliceContain(exported, "*") {
+		return values
+	}
+	var ret = make(map[string]float64, len(exported))
+	for _, name := range exported {
+		if value, exist := values[name]; exist {
+			ret[name] = value
+		}
+	}
+	return ret
+}
+
+// applyReceivedStatus applies the received data and patches to the status of device in order.
+func applyReceivedStatus(device *unstructured.Unstructured, req suctioncup.RequestConnectionStatus) error {
+	if req.Data != nil {
+		var received = &unstructured.Unstructured{Object: make(map[string]interface{})}
+		if err := received.UnmarshalJSON(req.Data); err != nil {
+			return err
+		}
+		device.Object["status"] = received.Object["status"]
+	}
+
+	for _, p := range req.Patches {
+		switch p.Type {
+		case suctioncup.PropertiesPatchType:
+			var status, _ = device.Object["status"].(map[string]interface{})
+			if status == nil {
+				status = make(map[string]interface{})
+			}
+			var patched, err = patch.ApplyPropertiesPatch(status, p.Data)
+			if err != nil {
+				return err
+			}
+			device.Object["status"] = patched
+		default:
+			// the merge patch is applied on the whole device, but only the status is accepted.
+			var patched, err = patch.ApplyMergePatch(map[string]interface{}{"status": device.Object["status"]}, p.Data)
+			if err != nil {
+				return err
+			}
+			device.Object["status"] = patched["status"]
+		}
+	}
+	return nil
+}
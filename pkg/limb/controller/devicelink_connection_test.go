@@ -0,0 +1,103 @@
+package controller
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	edgev1alpha1 "github.com/rancher/octopus/api/v1alpha1"
+	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha2"
+)
+
+func TestGetErrorClass(t *testing.T) {
+	assert.Equal(t, "InvalidSpec", getErrorClass(grpcstatus.Error(codes.InvalidArgument, "invalid spec")))
+	assert.Equal(t, "Unauthorized", getErrorClass(&api.ConnectResponseError{Code: api.ErrorCode_Unauthorized}))
+	assert.Equal(t, "Unspecified", getErrorClass(errors.New("failed to read")))
+}
+
+func TestGetErrorReason(t *testing.T) {
+	assert.Equal(t, "Unreachable", getErrorReason("Unreachable"))
+	assert.Equal(t, "Unhealthy", getErrorReason("Unspecified"))
+}
+
+func TestGetReconnectBackoff(t *testing.T) {
+	var policy = &edgev1alpha1.DeviceLinkRetryPolicy{
+		MaxRetries:      5,
+		InitialBackoff:  &metav1.Duration{Duration: time.Second},
+		MaxBackoff:      &metav1.Duration{Duration: 10 * time.Second},
+		PermanentErrors: []string{"InvalidSpec"},
+	}
+
+	var testCases = []struct {
+		name            string
+		policy          *edgev1alpha1.DeviceLinkRetryPolicy
+		attempts        int32
+		errClass        string
+		expectedBackoff time.Duration
+		expectedRetry   bool
+	}{
+		{
+			name:          "without policy",
+			policy:        nil,
+			errClass:      "Unreachable",
+			expectedRetry: false,
+		},
+		{
+			name:            "default policy",
+			policy:          &edgev1alpha1.DeviceLinkRetryPolicy{},
+			attempts:        100,
+			errClass:        "Unreachable",
+			expectedBackoff: defaultReconnectMaxBackoff,
+			expectedRetry:   true,
+		},
+		{
+			name:            "first attempt",
+			policy:          policy,
+			attempts:        0,
+			errClass:        "Unreachable",
+			expectedBackoff: time.Second,
+			expectedRetry:   true,
+		},
+		{
+			name:            "doubled backoff",
+			policy:          policy,
+			attempts:        3,
+			errClass:        "Timeout",
+			expectedBackoff: 8 * time.Second,
+			expectedRetry:   true,
+		},
+		{
+			name:            "limited backoff",
+			policy:          policy,
+			attempts:        4,
+			errClass:        "Unreachable",
+			expectedBackoff: 10 * time.Second,
+			expectedRetry:   true,
+		},
+		{
+			name:          "exhausted retries",
+			policy:        policy,
+			attempts:      5,
+			errClass:      "Unreachable",
+			expectedRetry: false,
+		},
+		{
+			name:          "permanent error",
+			policy:        policy,
+			attempts:      0,
+			errClass:      "InvalidSpec",
+			expectedRetry: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		var backoff, retry = getReconnectBackoff(tc.policy, tc.attempts, tc.errClass)
+		assert.Equal(t, tc.expectedRetry, retry, "case %q", tc.name)
+		assert.Equal(t, tc.expectedBackoff, backoff, "case %q", tc.name)
+	}
+}
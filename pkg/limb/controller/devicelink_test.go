@@ -0,0 +1,357 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	edgev1alpha1 "github.com/rancher/octopus/api/v1alpha1"
+	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha2"
+	"github.com/rancher/octopus/pkg/suctioncup"
+	"github.com/rancher/octopus/pkg/util/log/zap"
+)
+
+// fakeNeurons simulates the connections of suction cup.
+type fakeNeurons struct {
+	suctioncup.Neurons
+
+	connectErr   error
+	connected    int
+	disconnected int
+}
+
+func (n *fakeNeurons) ExistAdaptor(_ string) bool {
+	return true
+}
+
+func (n *fakeNeurons) Connect(_ map[string]map[string][]byte, _ *unstructured.Unstructured, _ *edgev1alpha1.DeviceLink) error {
+	if n.connectErr != nil {
+		return n.connectErr
+	}
+	n.connected++
+	return nil
+}
+
+func (n *fakeNeurons) Disconnect(_ *edgev1alpha1.DeviceLink) {
+	n.disconnected++
+}
+
+func newTestDeviceLinkReconciler(neurons suctioncup.Neurons, link *edgev1alpha1.DeviceLink) *DeviceLinkReconciler {
+	var scheme = runtime.NewScheme()
+	_ = edgev1alpha1.AddToScheme(scheme)
+	scheme.AddKnownTypeWithName(schema.FromAPIVersionAndKind(link.Spec.Model.APIVersion, link.Spec.Model.Kind), &unstructured.Unstructured{})
+
+	// the device has been created by the template of link
+	var device = &unstructured.Unstructured{Object: map[string]interface{}{"spec": map[string]interface{}{}}}
+	device.SetAPIVersion(link.Spec.Model.APIVersion)
+	device.SetKind(link.Spec.Model.Kind)
+	device.SetNamespace(link.Namespace)
+	device.SetName(link.Name)
+
+	return &DeviceLinkReconciler{
+		Client:        fake.NewFakeClientWithScheme(scheme, link, device),
+		EventRecorder: record.NewFakeRecorder(100),
+		Ctx:           context.Background(),
+		Log:           zap.WrapAsLogr(zap.NewDevelopmentLogger()),
+		SuctionCup:    neurons,
+		NodeName:      "edge-worker",
+	}
+}
+
+func newTestDeviceLink(policy *edgev1alpha1.DeviceLinkRetryPolicy) *edgev1alpha1.DeviceLink {
+	var model = metav1.TypeMeta{
+		APIVersion: "devices.edge.cattle.io/v1alpha1",
+		Kind:       "DummySpecialDevice",
+	}
+	return &edgev1alpha1.DeviceLink{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:  "default",
+			Name:       "test",
+			Finalizers: []string{ReconcilingDeviceLink},
+		},
+		Spec: edgev1alpha1.DeviceLinkSpec{
+			Adaptor: edgev1alpha1.DeviceAdaptor{
+				Node: "edge-worker",
+				Name: "adaptors.edge.cattle.io/dummy",
+			},
+			Model:       model,
+			RetryPolicy: policy,
+		},
+		Status: edgev1alpha1.DeviceLinkStatus{
+			NodeName:    "edge-worker",
+			AdaptorName: "adaptors.edge.cattle.io/dummy",
+			Model:       &model,
+		},
+	}
+}
+
+func getTestDeviceLink(t *testing.T, c client.Client) edgev1alpha1.DeviceLink {
+	var link edgev1alpha1.DeviceLink
+	if err := c.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "test"}, &link); err != nil {
+		t.Fatalf("failed to get DeviceLink: %v", err)
+	}
+	return link
+}
+
+func TestDeviceLinkReconciler_Reconcile(t *testing.T) {
+	var policy = &edgev1alpha1.DeviceLinkRetryPolicy{
+		MaxRetries:      2,
+		InitialBackoff:  &metav1.Duration{Duration: time.Second},
+		PermanentErrors: []string{"InvalidSpec"},
+	}
+	var neurons = &fakeNeurons{connectErr: &api.ConnectResponseError{Code: api.ErrorCode_Unreachable, Message: "no route to host"}}
+	var r = newTestDeviceLinkReconciler(neurons, newTestDeviceLink(policy))
+	var req = ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "test"}}
+
+	var testCases = []struct {
+		name              string
+		expectedResult    ctrl.Result
+		expectedAttempts  int32
+		expectedScheduled bool
+		expectedStatus    metav1.ConditionStatus
+	}{
+		{
+			name:              "first failure",
+			expectedResult:    ctrl.Result{RequeueAfter: time.Second},
+			expectedAttempts:  1,
+			expectedScheduled: true,
+			expectedStatus:    metav1.ConditionFalse,
+		},
+		{
+			name:              "doubled backoff",
+			expectedResult:    ctrl.Result{RequeueAfter: 2 * time.Second},
+			expectedAttempts:  2,
+			expectedScheduled: true,
+			expectedStatus:    metav1.ConditionFalse,
+		},
+		{
+			name:             "exhausted retries",
+			expectedResult:   ctrl.Result{},
+			expectedAttempts: 2,
+			expectedStatus:   metav1.ConditionFalse,
+		},
+	}
+
+	for _, tc := range testCases {
+		var ret, err = r.Reconcile(req)
+		assert.NoError(t, err, "case %s", tc.name)
+		assert.Equal(t, tc.expectedResult, ret, "case %s", tc.name)
+
+		var link = getTestDeviceLink(t, r.Client)
+		assert.Equal(t, tc.expectedAttempts, link.Status.ReconnectAttempts, "case %s", tc.name)
+		assert.Equal(t, tc.expectedScheduled, link.Status.NextReconnectTime != nil, "case %s", tc.name)
+		assert.Equal(t, tc.expectedStatus, link.GetDeviceConnectedStatus(), "case %s", tc.name)
+		assert.Equal(t, "Unreachable", getDeviceConnectedReason(&link), "case %s", tc.name)
+	}
+
+	// connects successfully
+	neurons.connectErr = nil
+	var ret, err = r.Reconcile(req)
+	assert.NoError(t, err)
+	assert.Equal(t, ctrl.Result{}, ret)
+	assert.Equal(t, 1, neurons.connected)
+	var link = getTestDeviceLink(t, r.Client)
+	assert.Nil(t, link.Status.NextReconnectTime)
+	assert.Equal(t, metav1.ConditionTrue, link.GetDeviceConnectedStatus())
+
+	// doesn't retry on the permanent error
+	r = newTestDeviceLinkReconciler(&fakeNeurons{connectErr: &api.ConnectResponseError{Code: api.ErrorCode_InvalidSpec}}, newTestDeviceLink(policy))
+	ret, err = r.Reconcile(req)
+	assert.NoError(t, err)
+	assert.Equal(t, ctrl.Result{}, ret)
+	link = getTestDeviceLink(t, r.Client)
+	assert.Equal(t, int32(0), link.Status.ReconnectAttempts)
+	assert.Equal(t, metav1.ConditionFalse, link.GetDeviceConnectedStatus())
+	assert.Equal(t, "InvalidSpec", getDeviceConnectedReason(&link))
+
+	// doesn't retry without retry policy
+	r = newTestDeviceLinkReconciler(&fakeNeurons{connectErr: errors.New("failed to connect")}, newTestDeviceLink(nil))
+	ret, err = r.Reconcile(req)
+	assert.NoError(t, err)
+	assert.Equal(t, ctrl.Result{}, ret)
+	link = getTestDeviceLink(t, r.Client)
+	assert.Equal(t, int32(0), link.Status.ReconnectAttempts)
+	assert.Equal(t, metav1.ConditionFalse, link.GetDeviceConnectedStatus())
+	assert.Equal(t, "Unhealthy", getDeviceConnectedReason(&link))
+}
+
+func TestDeviceLinkReconciler_ReceiveConnectionStatus(t *testing.T) {
+	var policy = &edgev1alpha1.DeviceLinkRetryPolicy{
+		MaxRetries:      2,
+		InitialBackoff:  &metav1.Duration{Duration: time.Second},
+		PermanentErrors: []string{"InvalidSpec"},
+	}
+	var neurons = &fakeNeurons{}
+	var r = newTestDeviceLinkReconciler(neurons, newTestDeviceLink(policy))
+	var name = types.NamespacedName{Namespace: "default", Name: "test"}
+
+	// connects at first
+	var ret, err = r.Reconcile(ctrl.Request{NamespacedName: name})
+	assert.NoError(t, err)
+	assert.Equal(t, ctrl.Result{}, ret)
+	var link = getTestDeviceLink(t, r.Client)
+	assert.Equal(t, metav1.ConditionTrue, link.GetDeviceConnectedStatus())
+
+	var unreachable = &api.ConnectResponseError{Code: api.ErrorCode_Unreachable, Message: "no route to host"}
+	var testCases = []struct {
+		name              string
+		given             suctioncup.RequestConnectionStatus
+		prepare           func(link *edgev1alpha1.DeviceLink)
+		expectedResponse  func(resp suctioncup.Response) bool
+		expectedAttempts  int32
+		expectedScheduled bool
+		expectedStatus    metav1.ConditionStatus
+		expectedReason    string
+	}{
+		{
+			name:  "received error",
+			given: suctioncup.RequestConnectionStatus{Name: name, Error: unreachable},
+			expectedResponse: func(resp suctioncup.Response) bool {
+				return resp.RequeueAfter == time.Second
+			},
+			expectedAttempts:  1,
+			expectedScheduled: true,
+			expectedStatus:    metav1.ConditionFalse,
+			expectedReason:    "Unreachable",
+		},
+		{
+			name:  "waiting for backoff",
+			given: suctioncup.RequestConnectionStatus{Name: name, Error: unreachable},
+			expectedResponse: func(resp suctioncup.Response) bool {
+				return resp.RequeueAfter > 0 && resp.RequeueAfter <= time.Second
+			},
+			expectedAttempts:  1,
+			expectedScheduled: true,
+			expectedStatus:    metav1.ConditionFalse,
+			expectedReason:    "Unreachable",
+		},
+		{
+			name:  "backoff expired",
+			given: suctioncup.RequestConnectionStatus{Name: name, Error: unreachable},
+			prepare: func(link *edgev1alpha1.DeviceLink) {
+				var expired = metav1.NewTime(time.Now().Add(-time.Second))
+				link.Status.NextReconnectTime = &expired
+			},
+			expectedResponse: func(resp suctioncup.Response) bool {
+				return resp == suctioncup.Response{}
+			},
+			expectedAttempts: 1,
+			expectedStatus:   metav1.ConditionUnknown,
+			expectedReason:   "Connecting",
+		},
+		{
+			name:  "received permanent error",
+			given: suctioncup.RequestConnectionStatus{Name: name, Error: &api.ConnectResponseError{Code: api.ErrorCode_InvalidSpec}},
+			expectedResponse: func(resp suctioncup.Response) bool {
+				return resp == suctioncup.Response{}
+			},
+			expectedAttempts: 1,
+			expectedStatus:   metav1.ConditionFalse,
+			expectedReason:   "InvalidSpec",
+		},
+		{
+			name:  "closed by adaptor",
+			given: suctioncup.RequestConnectionStatus{Name: name, Closed: true},
+			expectedResponse: func(resp suctioncup.Response) bool {
+				return resp == suctioncup.Response{}
+			},
+			expectedAttempts: 1,
+			expectedStatus:   metav1.ConditionUnknown,
+			expectedReason:   "Connecting",
+		},
+	}
+
+	for _, tc := range testCases {
+		if tc.prepare != nil {
+			var link = getTestDeviceLink(t, r.Client)
+			tc.prepare(&link)
+			if err := r.Status().Update(context.Background(), &link); err != nil {
+				t.Fatalf("failed to prepare DeviceLink: %v", err)
+			}
+		}
+
+		var resp, err = r.ReceiveConnectionStatus(tc.given)
+		assert.NoError(t, err, "case %s", tc.name)
+		assert.True(t, tc.expectedResponse(resp), "case %s: unexpected response %+v", tc.name, resp)
+
+		var link = getTestDeviceLink(t, r.Client)
+		assert.Equal(t, tc.expectedAttempts, link.Status.ReconnectAttempts, "case %s", tc.name)
+		assert.Equal(t, tc.expectedScheduled, link.Status.NextReconnectTime != nil, "case %s", tc.name)
+		assert.Equal(t, tc.expectedStatus, link.GetDeviceConnectedStatus(), "case %s", tc.name)
+		assert.Equal(t, tc.expectedReason, getDeviceConnectedReason(&link), "case %s", tc.name)
+	}
+	assert.Equal(t, 2, neurons.disconnected)
+
+	// reconnects, and recovers after receiving data
+	ret, err = r.Reconcile(ctrl.Request{NamespacedName: name})
+	assert.NoError(t, err)
+	assert.Equal(t, ctrl.Result{}, ret)
+	resp, err := r.ReceiveConnectionStatus(suctioncup.RequestConnectionStatus{
+		Name: name,
+		Data: []byte(`{"status":{"on":true}}`),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, suctioncup.Response{}, resp)
+	link = getTestDeviceLink(t, r.Client)
+	assert.Equal(t, int32(0), link.Status.ReconnectAttempts)
+	assert.Equal(t, metav1.ConditionTrue, link.GetDeviceConnectedStatus())
+}
+
+func TestDeviceLinkReconciler_ReceiveConnectionStatus_BeforeConnected(t *testing.T) {
+	var link = newTestDeviceLink(nil)
+	var r = newTestDeviceLinkReconciler(&fakeNeurons{}, link)
+	var name = types.NamespacedName{Namespace: "default", Name: "test"}
+	var data = suctioncup.RequestConnectionStatus{Name: name, Data: []byte(`{"apiVersion":"devices.edge.cattle.io/v1alpha1","kind":"DummySpecialDevice","status":{"on":true}}`)}
+
+	// keeps the data received before the connecting result is recorded
+	var resp, err = r.ReceiveConnectionStatus(data)
+	assert.NoError(t, err)
+	assert.Equal(t, suctioncup.Response{RequeueAfter: time.Second}, resp)
+
+	// applies the data after connected
+	ret, err := r.Reconcile(ctrl.Request{NamespacedName: name})
+	assert.NoError(t, err)
+	assert.Equal(t, ctrl.Result{}, ret)
+	resp, err = r.ReceiveConnectionStatus(data)
+	assert.NoError(t, err)
+	assert.Equal(t, suctioncup.Response{}, resp)
+	var device unstructured.Unstructured
+	device.SetAPIVersion(link.Spec.Model.APIVersion)
+	device.SetKind(link.Spec.Model.Kind)
+	if err := r.Get(context.Background(), name, &device); err != nil {
+		t.Fatalf("failed to get device: %v", err)
+	}
+	var on, _, _ = unstructured.NestedBool(device.Object, "status", "on")
+	assert.True(t, on)
+
+	// drops the data if failed to connect, as the adaptor sends the whole device again after reconnecting
+	var connected = getTestDeviceLink(t, r.Client)
+	connected.FailOnDeviceConnected("unable to connect to device")
+	if err := r.Status().Update(context.Background(), &connected); err != nil {
+		t.Fatalf("failed to prepare DeviceLink: %v", err)
+	}
+	resp, err = r.ReceiveConnectionStatus(data)
+	assert.NoError(t, err)
+	assert.Equal(t, suctioncup.Response{}, resp)
+}
+
+func getDeviceConnectedReason(link *edgev1alpha1.DeviceLink) string {
+	for _, cond := range link.Status.Conditions {
+		if cond.Type == edgev1alpha1.DeviceLinkDeviceConnected {
+			return cond.Reason
+		}
+	}
+	return ""
+}
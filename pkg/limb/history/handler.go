@@ -0,0 +1,80 @@
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// HandlerPath is the path to serve the history.
+const HandlerPath = "/history/"
+
+type response struct {
+	Namespace  string             `json:"namespace"`
+	Name       string             `json:"name"`
+	Properties map[string][]Point `json:"properties"`
+}
+
+// NewHandler returns the HTTP handler to query the history of devices,
+// the request is in form of `GET /history/{namespace}/{name}?property={property}&start={RFC3339}&end={RFC3339}`,
+// all properties are returned if the `property` is blank,
+// the `start` is the retention ago if it's blank, and the `end` is now if it's blank.
+func NewHandler(store Store) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			http.Error(w, "only GET is allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		var segments = strings.Split(strings.Trim(strings.TrimPrefix(req.URL.Path, HandlerPath), "/"), "/")
+		if len(segments) != 2 || segments[0] == "" || segments[1] == "" {
+			http.Error(w, fmt.Sprintf("path must be in form of %s{namespace}/{name}", HandlerPath), http.StatusBadRequest)
+			return
+		}
+		var name = types.NamespacedName{Namespace: segments[0], Name: segments[1]}
+		if err := validateName(name); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		var query = req.URL.Query()
+		var now = time.Now()
+		var start, err = parseTime(query.Get("start"), now.Add(-store.GetRetention()))
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid start: %v", err), http.StatusBadRequest)
+			return
+		}
+		end, err := parseTime(query.Get("end"), now)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid end: %v", err), http.StatusBadRequest)
+			return
+		}
+		if end.Before(start) {
+			http.Error(w, "end must not be before start", http.StatusBadRequest)
+			return
+		}
+
+		points, err := store.Query(name, query.Get("property"), start, end)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response{
+			Namespace:  name.Namespace,
+			Name:       name.Name,
+			Properties: points,
+		})
+	})
+}
+
+func parseTime(value string, defaultTime time.Time) (time.Time, error) {
+	if value == "" {
+		return defaultTime, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
@@ -0,0 +1,87 @@
+package history
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestHandler(t *testing.T) {
+	var s, err = NewStore(Options{})
+	assert.NoError(t, err)
+
+	var ts = time.Now().Add(-time.Minute).Truncate(time.Second).UTC()
+	var name = types.NamespacedName{Namespace: "default", Name: "living-room-fan"}
+	assert.NoError(t, s.Record(name, nil, newStatus(
+		map[string]interface{}{"name": "speed", "value": "1", "updatedAt": ts.Format(time.RFC3339)},
+		map[string]interface{}{"name": "power", "value": "on", "updatedAt": ts.Format(time.RFC3339)},
+	)))
+
+	var testCases = []struct {
+		name         string
+		method       string
+		path         string
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "query all properties",
+			method:       http.MethodGet,
+			path:         "/history/default/living-room-fan",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"namespace":"default","name":"living-room-fan","properties":{"power":[{"timestamp":"` + ts.Format(time.RFC3339) + `","value":"on"}],"speed":[{"timestamp":"` + ts.Format(time.RFC3339) + `","value":"1"}]}}`,
+		},
+		{
+			name:         "query property by range",
+			method:       http.MethodGet,
+			path:         "/history/default/living-room-fan?property=speed&start=" + ts.Format(time.RFC3339),
+			expectedCode: http.StatusOK,
+			expectedBody: `{"namespace":"default","name":"living-room-fan","properties":{"speed":[{"timestamp":"` + ts.Format(time.RFC3339) + `","value":"1"}]}}`,
+		},
+		{
+			name:         "query out of range",
+			method:       http.MethodGet,
+			path:         "/history/default/living-room-fan?end=" + ts.Add(-time.Second).Format(time.RFC3339),
+			expectedCode: http.StatusOK,
+			expectedBody: `{"namespace":"default","name":"living-room-fan","properties":{}}`,
+		},
+		{
+			name:         "invalid method",
+			method:       http.MethodPost,
+			path:         "/history/default/living-room-fan",
+			expectedCode: http.StatusMethodNotAllowed,
+		},
+		{
+			name:         "invalid path",
+			method:       http.MethodGet,
+			path:         "/history/default",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid namespace",
+			method:       http.MethodGet,
+			path:         "/history/../living-room-fan",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid time",
+			method:       http.MethodGet,
+			path:         "/history/default/living-room-fan?start=yesterday",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	var handler = NewHandler(s)
+	for _, tc := range testCases {
+		var recorder = httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(tc.method, tc.path, nil))
+		assert.Equal(t, tc.expectedCode, recorder.Code, "case %q", tc.name)
+		if tc.expectedBody != "" {
+			assert.JSONEq(t, tc.expectedBody, recorder.Body.String(), "case %q", tc.name)
+		}
+	}
+}
@@ -0,0 +1,383 @@
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	defaultCapacity  = 10000
+	defaultRetention = 24 * time.Hour
+)
+
+// Point is a reading of the device property.
+type Point struct {
+	Timestamp time.Time   `json:"timestamp"`
+	Value     interface{} `json:"value"`
+}
+
+type Options struct {
+	// Dir is the directory to persist the history, the history is kept in memory only if it's blank.
+	Dir string
+
+	// Capacity is the maximum number of the readings kept for a DeviceLink.
+	Capacity int
+
+	// Retention is the duration to keep the readings.
+	Retention time.Duration
+}
+
+type Store interface {
+	// Record records the changed properties between the previous status and the current status of the device.
+	Record(name types.NamespacedName, previous, current interface{}) error
+
+	// Query returns the readings of the property of the device within the range [start, end],
+	// all properties are returned if the given property is blank.
+	Query(name types.NamespacedName, property string, start, end time.Time) (map[string][]Point, error)
+
+	// Delete deletes the history of the device.
+	Delete(name types.NamespacedName) error
+
+	// GetRetention returns the duration to keep the readings.
+	GetRetention() time.Duration
+}
+
+func NewStore(opts Options) (Store, error) {
+	if opts.Capacity <= 0 {
+		opts.Capacity = defaultCapacity
+	}
+	if opts.Retention <= 0 {
+		opts.Retention = defaultRetention
+	}
+	if opts.Dir != "" {
+		if err := os.MkdirAll(opts.Dir, 0755); err != nil {
+			return nil, errors.Wrapf(err, "failed to create history directory %s", opts.Dir)
+		}
+	}
+	return &store{
+		opts:  opts,
+		rings: make(map[types.NamespacedName]*ring),
+	}, nil
+}
+
+type store struct {
+	sync.Mutex
+	opts  Options
+	rings map[types.NamespacedName]*ring
+}
+
+func (s *store) Record(name types.NamespacedName, previous, current interface{}) error {
+	var records = getChangedRecords(previous, current, time.Now())
+	if len(records) == 0 {
+		return nil
+	}
+
+	var r, err = s.getRing(name, true)
+	if err != nil {
+		return err
+	}
+	return r.append(records...)
+}
+
+func (s *store) Query(name types.NamespacedName, property string, start, end time.Time) (map[string][]Point, error) {
+	// looks up the ring without creating, the unknown device has no history.
+	var r, err = s.getRing(name, false)
+	if err != nil {
+		return nil, err
+	}
+	var ret = make(map[string][]Point)
+	if r == nil {
+		return ret, nil
+	}
+
+	var expired = time.Now().Add(-s.opts.Retention)
+	if start.Before(expired) {
+		start = expired
+	}
+	r.walk(func(rec record) {
+		if property != "" && rec.Property != property {
+			return
+		}
+		if rec.Timestamp.Before(start) || rec.Timestamp.After(end) {
+			return
+		}
+		ret[rec.Property] = append(ret[rec.Property], Point{Timestamp: rec.Timestamp, Value: rec.Value})
+	})
+	return ret, nil
+}
+
+func (s *store) Delete(name types.NamespacedName) error {
+	if err := validateName(name); err != nil {
+		return err
+	}
+
+	s.Lock()
+	delete(s.rings, name)
+	s.Unlock()
+
+	if s.opts.Dir == "" {
+		return nil
+	}
+	if err := os.Remove(getRingPath(s.opts.Dir, name)); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "failed to delete history of %s", name)
+	}
+	return nil
+}
+
+func (s *store) GetRetention() time.Duration {
+	return s.opts.Retention
+}
+
+// getRing returns the ring buffer of the device, the buffer is loaded from the directory if it's not cached,
+// the buffer is created only if the `create` is true, otherwise nil is returned for the device without history.
+func (s *store) getRing(name types.NamespacedName, create bool) (*ring, error) {
+	if err := validateName(name); err != nil {
+		return nil, err
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	if r, exist := s.rings[name]; exist {
+		return r, nil
+	}
+	var r = &ring{
+		records:   make([]record, 0, s.opts.Capacity),
+		capacity:  s.opts.Capacity,
+		retention: s.opts.Retention,
+	}
+	if s.opts.Dir != "" {
+		r.path = getRingPath(s.opts.Dir, name)
+		if create {
+			var dir = filepath.Dir(r.path)
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				return nil, errors.Wrapf(err, "failed to create history directory %s", dir)
+			}
+		} else if _, err := os.Stat(r.path); err != nil {
+			if os.IsNotExist(err) {
+				return nil, nil
+			}
+			return nil, errors.Wrapf(err, "failed to stat history file %s", r.path)
+		}
+		if err := r.load(); err != nil {
+			return nil, err
+		}
+	} else if !create {
+		return nil, nil
+	}
+	s.rings[name] = r
+	return r, nil
+}
+
+type record struct {
+	Property  string      `json:"property"`
+	Timestamp time.Time   `json:"timestamp"`
+	Value     interface{} `json:"value"`
+}
+
+// ring is a ring buffer of the readings of a device,
+// the readings are appended into the file if the path is not blank.
+type ring struct {
+	sync.RWMutex
+	records   []record
+	head      int
+	capacity  int
+	retention time.Duration
+
+	path    string
+	written int
+}
+
+func (r *ring) append(records ...record) error {
+	r.Lock()
+	defer r.Unlock()
+
+	for _, rec := range records {
+		r.put(rec)
+	}
+	if r.path == "" {
+		return nil
+	}
+
+	// the file is compacted to the records of buffer if it grows too large.
+	if r.written+len(records) > 2*r.capacity {
+		return r.compact()
+	}
+	var f, err = os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open history file %s", r.path)
+	}
+	defer f.Close()
+	var enc = json.NewEncoder(f)
+	for _, rec := range records {
+		if err := enc.Encode(rec); err != nil {
+			return errors.Wrapf(err, "failed to write history file %s", r.path)
+		}
+	}
+	r.written += len(records)
+	return nil
+}
+
+func (r *ring) walk(fn func(rec record)) {
+	r.RLock()
+	defer r.RUnlock()
+
+	for i := 0; i < len(r.records); i++ {
+		fn(r.records[(r.head+i)%len(r.records)])
+	}
+}
+
+func (r *ring) put(rec record) {
+	if len(r.records) < r.capacity {
+		r.records = append(r.records, rec)
+		return
+	}
+	r.records[r.head] = rec
+	r.head = (r.head + 1) % r.capacity
+}
+
+// load loads the unexpired records from the file.
+func (r *ring) load() error {
+	var f, err = os.Open(r.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "failed to open history file %s", r.path)
+	}
+	defer f.Close()
+
+	var expired = time.Now().Add(-r.retention)
+	var scanner = bufio.NewScanner(f)
+	for scanner.Scan() {
+		r.written++
+		var rec record
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			// the broken line is skipped, as it might be written partially.
+			continue
+		}
+		if rec.Timestamp.Before(expired) {
+			continue
+		}
+		r.put(rec)
+	}
+	if err := scanner.Err(); err != nil {
+		return errors.Wrapf(err, "failed to read history file %s", r.path)
+	}
+	return nil
+}
+
+// compact rewrites the file with the unexpired records of buffer.
+func (r *ring) compact() error {
+	var tmpPath = r.path + ".tmp"
+	var f, err = os.OpenFile(tmpPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open history file %s", tmpPath)
+	}
+
+	var expired = time.Now().Add(-r.retention)
+	var written int
+	var enc = json.NewEncoder(f)
+	for i := 0; i < len(r.records); i++ {
+		var rec = r.records[(r.head+i)%len(r.records)]
+		if rec.Timestamp.Before(expired) {
+			continue
+		}
+		if err = enc.Encode(rec); err != nil {
+			break
+		}
+		written++
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return errors.Wrapf(err, "failed to write history file %s", tmpPath)
+	}
+	if err := os.Rename(tmpPath, r.path); err != nil {
+		return errors.Wrapf(err, "failed to replace history file %s", r.path)
+	}
+	r.written = written
+	return nil
+}
+
+// validateName validates the namespace and name of the device,
+// they are used as the path segments of history file.
+func validateName(name types.NamespacedName) error {
+	if errs := validation.IsDNS1123Label(name.Namespace); len(errs) != 0 {
+		return errors.Errorf("invalid namespace %q: %s", name.Namespace, strings.Join(errs, ", "))
+	}
+	if errs := validation.IsDNS1123Subdomain(name.Name); len(errs) != 0 {
+		return errors.Errorf("invalid name %q: %s", name.Name, strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+func getRingPath(dir string, name types.NamespacedName) string {
+	return filepath.Join(dir, name.Namespace, name.Name+".jsonl")
+}
+
+// getChangedRecords returns the records of the changed properties,
+// the `updatedAt` of property is used as the timestamp of record if it's valid.
+func getChangedRecords(previous, current interface{}, now time.Time) []record {
+	var previousProps = indexProperties(previous)
+	var currentProps = indexProperties(current)
+
+	var ret []record
+	for _, name := range currentProps.names {
+		var prop = currentProps.items[name]
+		if reflect.DeepEqual(previousProps.items[name], prop) {
+			continue
+		}
+		var ts = now
+		if updatedAt, ok := prop["updatedAt"].(string); ok {
+			if t, err := time.Parse(time.RFC3339, updatedAt); err == nil {
+				ts = t
+			}
+		}
+		ret = append(ret, record{
+			Property:  name,
+			Timestamp: ts,
+			Value:     prop["value"],
+		})
+	}
+	return ret
+}
+
+type properties struct {
+	names []string
+	items map[string]map[string]interface{}
+}
+
+// indexProperties indexes the `properties` of status by the `name` of property.
+func indexProperties(status interface{}) properties {
+	var ret = properties{items: make(map[string]map[string]interface{})}
+	var statusObj, _ = status.(map[string]interface{})
+	var props, _ = statusObj["properties"].([]interface{})
+	for _, p := range props {
+		var prop, ok = p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		var name, _ = prop["name"].(string)
+		if name == "" {
+			continue
+		}
+		if _, exist := ret.items[name]; !exist {
+			ret.names = append(ret.names, name)
+		}
+		ret.items[name] = prop
+	}
+	return ret
+}
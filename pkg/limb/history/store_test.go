@@ -0,0 +1,164 @@
+package history
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func newStatus(props ...map[string]interface{}) map[string]interface{} {
+	var items = make([]interface{}, 0, len(props))
+	for _, p := range props {
+		items = append(items, p)
+	}
+	return map[string]interface{}{"properties": items}
+}
+
+func TestGetChangedRecords(t *testing.T) {
+	var now = time.Now()
+	var updatedAt = now.Add(-time.Minute).Truncate(time.Second).UTC()
+
+	var testCases = []struct {
+		name     string
+		previous interface{}
+		current  interface{}
+		expected []record
+	}{
+		{
+			name:     "without status",
+			previous: nil,
+			current:  nil,
+		},
+		{
+			name:     "new properties",
+			previous: nil,
+			current: newStatus(
+				map[string]interface{}{"name": "temperature", "value": "21", "updatedAt": updatedAt.Format(time.RFC3339)},
+				map[string]interface{}{"name": "humidity", "value": int64(40)},
+			),
+			expected: []record{
+				{Property: "temperature", Timestamp: updatedAt, Value: "21"},
+				{Property: "humidity", Timestamp: now, Value: int64(40)},
+			},
+		},
+		{
+			name: "changed properties",
+			previous: newStatus(
+				map[string]interface{}{"name": "temperature", "value": "21"},
+				map[string]interface{}{"name": "humidity", "value": int64(40)},
+			),
+			current: newStatus(
+				map[string]interface{}{"name": "temperature", "value": "21"},
+				map[string]interface{}{"name": "humidity", "value": int64(41)},
+				map[string]interface{}{"value": "without name"},
+			),
+			expected: []record{
+				{Property: "humidity", Timestamp: now, Value: int64(41)},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		var actual = getChangedRecords(tc.previous, tc.current, now)
+		assert.Equal(t, tc.expected, actual, "case %q", tc.name)
+	}
+}
+
+func TestStore(t *testing.T) {
+	var dir, err = ioutil.TempDir("", "history")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var name = types.NamespacedName{Namespace: "default", Name: "living-room-fan"}
+	var opts = Options{Dir: dir, Capacity: 3, Retention: time.Hour}
+	s, err := NewStore(opts)
+	assert.NoError(t, err)
+
+	var ts = time.Now().Add(-10 * time.Minute).Truncate(time.Second).UTC()
+	var status = func(i int) interface{} {
+		return newStatus(
+			map[string]interface{}{"name": "speed", "value": float64(i), "updatedAt": ts.Add(time.Duration(i) * time.Minute).Format(time.RFC3339)},
+		)
+	}
+	var previous interface{}
+	for i := 0; i < 8; i++ {
+		assert.NoError(t, s.Record(name, previous, status(i)))
+		previous = status(i)
+	}
+	// records the same status again
+	assert.NoError(t, s.Record(name, previous, status(7)))
+
+	// only keeps the latest records
+	var expected = map[string][]Point{
+		"speed": {
+			{Timestamp: ts.Add(5 * time.Minute), Value: float64(5)},
+			{Timestamp: ts.Add(6 * time.Minute), Value: float64(6)},
+			{Timestamp: ts.Add(7 * time.Minute), Value: float64(7)},
+		},
+	}
+	actual, err := s.Query(name, "", time.Time{}, time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, expected, normalize(actual))
+
+	// queries by range and property
+	actual, err = s.Query(name, "speed", ts.Add(6*time.Minute), ts.Add(6*time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]Point{"speed": {{Timestamp: ts.Add(6 * time.Minute), Value: float64(6)}}}, normalize(actual))
+	actual, err = s.Query(name, "unknown", time.Time{}, time.Now())
+	assert.NoError(t, err)
+	assert.Empty(t, actual)
+
+	// loads from the directory
+	reloaded, err := NewStore(opts)
+	assert.NoError(t, err)
+	actual, err = reloaded.Query(name, "", time.Time{}, time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, expected, normalize(actual))
+
+	// deletes the history
+	assert.NoError(t, reloaded.Delete(name))
+	reloaded, err = NewStore(opts)
+	assert.NoError(t, err)
+	actual, err = reloaded.Query(name, "", time.Time{}, time.Now())
+	assert.NoError(t, err)
+	assert.Empty(t, actual)
+
+	// queries the unknown device without creating the history
+	var unknown = types.NamespacedName{Namespace: "unknown", Name: "unknown"}
+	actual, err = reloaded.Query(unknown, "", time.Time{}, time.Now())
+	assert.NoError(t, err)
+	assert.Empty(t, actual)
+	_, err = os.Stat(filepath.Join(dir, unknown.Namespace))
+	assert.True(t, os.IsNotExist(err))
+	assert.Len(t, reloaded.(*store).rings, 0)
+
+	// rejects the invalid name
+	var invalids = []types.NamespacedName{
+		{Namespace: "..", Name: "living-room-fan"},
+		{Namespace: "default", Name: "../living-room-fan"},
+		{Namespace: "default", Name: ""},
+	}
+	for _, invalid := range invalids {
+		_, err = reloaded.Query(invalid, "", time.Time{}, time.Now())
+		assert.Error(t, err, "case %v", invalid)
+		assert.Error(t, reloaded.Record(invalid, nil, status(0)), "case %v", invalid)
+		assert.Error(t, reloaded.Delete(invalid), "case %v", invalid)
+	}
+}
+
+// normalize drops the monotonic clock reading and location of timestamps.
+func normalize(points map[string][]Point) map[string][]Point {
+	for _, ps := range points {
+		for i := range ps {
+			ps[i].Timestamp = ps[i].Timestamp.UTC().Round(0)
+		}
+	}
+	return points
+}
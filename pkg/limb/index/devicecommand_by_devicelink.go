@@ -0,0 +1,31 @@
+package index
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/rancher/octopus/pkg/util/object"
+)
+
+const DeviceCommandByDeviceLinkField = "deviceCommandByDeviceLink"
+
+var deviceCommandByDeviceLinkIndexLog = ctrl.Log.WithName("index").WithName(DeviceCommandByDeviceLinkField)
+
+func DeviceCommandByDeviceLinkFunc(rawObj runtime.Object) []string {
+	var command = object.ToDeviceCommandObject(rawObj)
+	if command == nil {
+		return nil
+	}
+
+	// the completed command doesn't need to be reconciled again.
+	if command.IsCompleted() {
+		return nil
+	}
+
+	var linkName = command.Spec.DeviceLink
+	if linkName != "" {
+		deviceCommandByDeviceLinkIndexLog.V(6).Info("Indexed", "deviceLink", linkName, "object", object.GetNamespacedName(command))
+		return []string{linkName}
+	}
+	return nil
+}
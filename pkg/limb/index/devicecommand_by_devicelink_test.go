@@ -0,0 +1,58 @@
+package index
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	edgev1alpha1 "github.com/rancher/octopus/api/v1alpha1"
+)
+
+func TestDeviceCommandByDeviceLinkFunc(t *testing.T) {
+	var testCases = []struct {
+		name     string
+		given    runtime.Object
+		expected []string
+	}{
+		{
+			name: "non-empty link",
+			given: &edgev1alpha1.DeviceCommand{
+				Spec: edgev1alpha1.DeviceCommandSpec{
+					DeviceLink: "living-room-fan",
+				},
+			},
+			expected: []string{"living-room-fan"},
+		},
+		{
+			name: "non-empty link but completed",
+			given: &edgev1alpha1.DeviceCommand{
+				Spec: edgev1alpha1.DeviceCommandSpec{
+					DeviceLink: "living-room-fan",
+				},
+				Status: edgev1alpha1.DeviceCommandStatus{
+					Phase: edgev1alpha1.DeviceCommandSucceeded,
+				},
+			},
+			expected: nil,
+		},
+		{
+			name: "empty link",
+			given: &edgev1alpha1.DeviceCommand{
+				Spec: edgev1alpha1.DeviceCommandSpec{},
+			},
+			expected: nil,
+		},
+		{
+			name:     "non-DeviceCommand object",
+			given:    &corev1.Node{},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		var actual = DeviceCommandByDeviceLinkFunc(tc.given)
+		assert.Equal(t, tc.expected, actual, "case %q", tc.name)
+	}
+}
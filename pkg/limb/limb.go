@@ -17,6 +17,7 @@
 	edgev1alpha1 "github.com/rancher/octopus/api/v1alpha1"
 	"github.com/rancher/octopus/cmd/limb/options"
 	"github.com/rancher/octopus/pkg/limb/controller"
+	"github.com/rancher/octopus/pkg/limb/history"
 	"github.com/rancher/octopus/pkg/metrics"
 	"github.com/rancher/octopus/pkg/suctioncup"
 	"github.com/rancher/octopus/pkg/util/critical"
@@ -69,12 +70,35 @@ func Run(name string, opts *options.Options) error {
 	}
 
 	log.V(0).Info("Creating suction cup manager")
-	suctionCupMgr, err := suctioncup.NewManager()
+	suctionCupMgr, err := suctioncup.NewManager(suctioncup.Options{
+		StatusCoalescingWindow: opts.StatusCoalescingWindow,
+		StatusUpdateInterval:   opts.StatusUpdateInterval,
+		StatusUpdateQPS:        opts.StatusUpdateQPS,
+		StatusUpdateBurst:      opts.StatusUpdateBurst,
+	})
 	if err != nil {
 		log.Error(err, "Unable to start suction cup manager")
 		return err
 	}
 
+	var historyStore history.Store
+	if opts.EnableHistory {
+		log.V(0).Info("Creating history store")
+		historyStore, err = history.NewStore(history.Options{
+			Dir:       opts.HistoryDir,
+			Capacity:  opts.HistoryCapacity,
+			Retention: opts.HistoryRetention,
+		})
+		if err != nil {
+			log.Error(err, "Unable to create history store")
+			return err
+		}
+		if err = controllerMgr.AddMetricsExtraHandler(history.HandlerPath, history.NewHandler(historyStore)); err != nil {
+			log.Error(err, "Unable to serve history store")
+			return err
+		}
+	}
+
 	log.V(0).Info("Creating controllers")
 	if err = (&controller.DeviceLinkReconciler{
 		Client:        controllerMgr.GetClient(),
@@ -83,10 +107,24 @@ func Run(name string, opts *options.Options) error {
 		Log:           ctrl.Log.WithName("controller").WithName("deviceLink"),
 		SuctionCup:    suctionCupMgr.GetNeurons(),
 		NodeName:      nodeName,
+		History:       historyStore,
+
+		EnablePropertyMetrics: opts.EnablePropertyMetrics,
 	}).SetupWithManager(controllerMgr, suctionCupMgr); err != nil {
 		log.Error(err, "Unable to create controller", "controller", "DeviceLink")
 		return err
 	}
+	if err = (&controller.DeviceCommandReconciler{
+		Client:        controllerMgr.GetClient(),
+		EventRecorder: controllerMgr.GetEventRecorderFor(name),
+		Ctx:           ctx,
+		Log:           ctrl.Log.WithName("controller").WithName("deviceCommand"),
+		SuctionCup:    suctionCupMgr.GetNeurons(),
+		NodeName:      nodeName,
+	}).SetupWithManager(controllerMgr); err != nil {
+		log.Error(err, "Unable to create controller", "controller", "DeviceCommand")
+		return err
+	}
 
 	log.Info("Starting")
 	var stop = ctrl.SetupSignalHandler()
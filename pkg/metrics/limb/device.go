@@ -0,0 +1,179 @@
+package limb
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// devicePhases are the valid phases of DeviceLink.
+var devicePhases = []string{"Pending", "Failed", "DeviceConnected"}
+
+var (
+	devicePropertyValue = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "device_property_value",
+			Help:      "The value of the numeric device property.",
+		},
+		[]string{"namespace", "name", "kind", "property"},
+	)
+
+	deviceReconnects = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "device_reconnects_total",
+			Help:      "Total number of reconnecting device.",
+		},
+		[]string{"namespace", "name"},
+	)
+
+	deviceAdaptorErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "device_adaptor_errors_total",
+			Help:      "Total number of errors of device received from adaptor.",
+		},
+		[]string{"namespace", "name"},
+	)
+
+	devicePhase = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "device_phase",
+			Help:      "The current phase of device, the value is 1 if the device is in the phase.",
+		},
+		[]string{"namespace", "name", "phase"},
+	)
+
+	deviceLastReceived = &deviceLastReceivedCollector{
+		desc: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "device_seconds_since_last_received"),
+			"Seconds since the data of device was received last time.",
+			[]string{"namespace", "name"},
+			nil,
+		),
+	}
+)
+
+type deviceKey struct {
+	namespace string
+	name      string
+}
+
+type deviceState struct {
+	connected    bool
+	lastReceived time.Time
+	kind         string
+	properties   map[string]struct{}
+}
+
+var (
+	devicesLock sync.Mutex
+	devices     = make(map[deviceKey]*deviceState)
+)
+
+// getDeviceState returns the state of device, it must be called with holding the lock.
+func getDeviceState(namespace, name string) *deviceState {
+	var key = deviceKey{namespace: namespace, name: name}
+	var state, exist = devices[key]
+	if !exist {
+		state = &deviceState{}
+		devices[key] = state
+	}
+	return state
+}
+
+// deviceLastReceivedCollector calculates the seconds since the last received time of devices on collecting.
+type deviceLastReceivedCollector struct {
+	desc *prometheus.Desc
+}
+
+func (c *deviceLastReceivedCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *deviceLastReceivedCollector) Collect(ch chan<- prometheus.Metric) {
+	devicesLock.Lock()
+	defer devicesLock.Unlock()
+
+	var now = time.Now()
+	for key, state := range devices {
+		if state.lastReceived.IsZero() {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, now.Sub(state.lastReceived).Seconds(), key.namespace, key.name)
+	}
+}
+
+func (metricsRecorder) SetDeviceProperties(namespace, name, kind string, values map[string]float64) {
+	devicesLock.Lock()
+	defer devicesLock.Unlock()
+
+	var state = getDeviceState(namespace, name)
+	for property := range state.properties {
+		if _, keep := values[property]; keep && state.kind == kind {
+			continue
+		}
+		devicePropertyValue.DeleteLabelValues(namespace, name, state.kind, property)
+	}
+
+	state.kind = kind
+	state.properties = make(map[string]struct{}, len(values))
+	for property, value := range values {
+		devicePropertyValue.WithLabelValues(namespace, name, kind, property).Set(value)
+		state.properties[property] = struct{}{}
+	}
+}
+
+func (metricsRecorder) ObserveDeviceConnected(namespace, name string) {
+	devicesLock.Lock()
+	defer devicesLock.Unlock()
+
+	var state = getDeviceState(namespace, name)
+	if state.connected {
+		deviceReconnects.WithLabelValues(namespace, name).Inc()
+		return
+	}
+	state.connected = true
+}
+
+func (metricsRecorder) ObserveDeviceReceived(namespace, name string) {
+	devicesLock.Lock()
+	defer devicesLock.Unlock()
+
+	getDeviceState(namespace, name).lastReceived = time.Now()
+}
+
+func (metricsRecorder) IncreaseDeviceAdaptorErrors(namespace, name string) {
+	deviceAdaptorErrors.WithLabelValues(namespace, name).Inc()
+}
+
+func (metricsRecorder) SetDevicePhase(namespace, name, phase string) {
+	for _, p := range devicePhases {
+		var value float64
+		if p == phase {
+			value = 1
+		}
+		devicePhase.WithLabelValues(namespace, name, p).Set(value)
+	}
+}
+
+func (metricsRecorder) DeleteDevice(namespace, name string) {
+	devicesLock.Lock()
+	defer devicesLock.Unlock()
+
+	var key = deviceKey{namespace: namespace, name: name}
+	if state, exist := devices[key]; exist {
+		for property := range state.properties {
+			devicePropertyValue.DeleteLabelValues(namespace, name, state.kind, property)
+		}
+		delete(devices, key)
+	}
+	deviceReconnects.DeleteLabelValues(namespace, name)
+	deviceAdaptorErrors.DeleteLabelValues(namespace, name)
+	for _, p := range devicePhases {
+		devicePhase.DeleteLabelValues(namespace, name, p)
+	}
+}
@@ -44,6 +44,33 @@
 		},
 		[]string{"adaptor"},
 	)
+
+	statusCoalesced = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "status_coalesced_total",
+			Help:      "Total number of received device status which are coalesced with the pending status.",
+		},
+		[]string{"adaptor"},
+	)
+
+	statusDropped = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "status_dropped_total",
+			Help:      "Total number of received device status which are dropped without writing.",
+		},
+		[]string{"adaptor"},
+	)
+
+	statusThrottled = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "status_throttled_total",
+			Help:      "Total number of device status writes which are delayed by throttling.",
+		},
+		[]string{"adaptor"},
+	)
 )
 
 func RegisterMetrics(registry prometheus.Registerer) error {
@@ -52,6 +79,14 @@ func RegisterMetrics(registry prometheus.Registerer) error {
 		connectErrors,
 		sendErrors,
 		sendLatency,
+		statusCoalesced,
+		statusDropped,
+		statusThrottled,
+		devicePropertyValue,
+		deviceReconnects,
+		deviceAdaptorErrors,
+		devicePhase,
+		deviceLastReceived,
 	}
 
 	for _, collector := range collectors {
@@ -80,6 +115,34 @@ type MetricsRecorder interface {
 
 	// IncreaseSendErrors increases the error counter when failed to send to adaptor.
 	IncreaseSendErrors(adaptorName string)
+
+	// IncreaseStatusCoalesced increases the counter when the received status is coalesced with the pending one.
+	IncreaseStatusCoalesced(adaptorName string)
+
+	// IncreaseStatusDropped increases the counter when the received status is dropped without writing.
+	IncreaseStatusDropped(adaptorName string)
+
+	// IncreaseStatusThrottled increases the counter when the status writing is delayed by throttling.
+	IncreaseStatusThrottled(adaptorName string)
+
+	// SetDeviceProperties sets the values of the device properties,
+	// the previous properties which are not in the given values are removed.
+	SetDeviceProperties(namespace, name, kind string, values map[string]float64)
+
+	// ObserveDeviceConnected observes the connection of device, it's counted as reconnecting except the first time.
+	ObserveDeviceConnected(namespace, name string)
+
+	// ObserveDeviceReceived observes the time when the data of device is received.
+	ObserveDeviceReceived(namespace, name string)
+
+	// IncreaseDeviceAdaptorErrors increases the error counter when received error of device from adaptor.
+	IncreaseDeviceAdaptorErrors(namespace, name string)
+
+	// SetDevicePhase sets the current phase of device.
+	SetDevicePhase(namespace, name, phase string)
+
+	// DeleteDevice removes all metrics of device.
+	DeleteDevice(namespace, name string)
 }
 
 type metricsRecorder struct{}
@@ -108,6 +171,18 @@ func (metricsRecorder) IncreaseSendErrors(adaptorName string) {
 	sendErrors.WithLabelValues(adaptorName).Inc()
 }
 
+func (metricsRecorder) IncreaseStatusCoalesced(adaptorName string) {
+	statusCoalesced.WithLabelValues(adaptorName).Inc()
+}
+
+func (metricsRecorder) IncreaseStatusDropped(adaptorName string) {
+	statusDropped.WithLabelValues(adaptorName).Inc()
+}
+
+func (metricsRecorder) IncreaseStatusThrottled(adaptorName string) {
+	statusThrottled.WithLabelValues(adaptorName).Inc()
+}
+
 var recorder = metricsRecorder{}
 
 func GetMetricsRecorder() MetricsRecorder {
@@ -2,14 +2,17 @@
 
 import (
 	"bytes"
+	"strings"
 	"time"
 
 	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/eclipse/paho.mqtt.golang/packets"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 
-	adaptorapi "github.com/rancher/octopus/pkg/adaptor/api/v1alpha1"
+	adaptorapi "github.com/rancher/octopus/pkg/adaptor/api/v1alpha2"
+	"github.com/rancher/octopus/pkg/adaptor/connection"
 	"github.com/rancher/octopus/pkg/mqtt/api"
 	"github.com/rancher/octopus/pkg/util/converter"
 )
@@ -122,7 +125,21 @@ func (c *client) Connect() error {
 	var token = c.raw.Connect()
 	_ = token.Wait()
 	// NB(thxCode) we don't need to call token.WaitTimeout() in here as the connection timeout has been injected.
-	return token.Error()
+	return classifyConnectError(token.Error())
+}
+
+// classifyConnectError classifies the failure of connecting MQTT broker,
+// the network error of paho client doesn't keep the cause, so it is recognized by the message.
+func classifyConnectError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case err == packets.ConnErrors[packets.ErrRefusedBadUsernameOrPassword], err == packets.ConnErrors[packets.ErrRefusedNotAuthorised]:
+		return connection.NewClassifiedError(adaptorapi.ErrorCode_Unauthorized, err)
+	case strings.HasPrefix(err.Error(), packets.ConnErrors[packets.ErrNetworkError].Error()):
+		return connection.NewClassifiedError(adaptorapi.ErrorCode_Unreachable, err)
+	}
+	return err
 }
 
 func (c *client) Disconnect() {
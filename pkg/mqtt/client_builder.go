@@ -11,7 +11,7 @@
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 
-	adaptorapi "github.com/rancher/octopus/pkg/adaptor/api/v1alpha1"
+	adaptorapi "github.com/rancher/octopus/pkg/adaptor/api/v1alpha2"
 	"github.com/rancher/octopus/pkg/mqtt/api"
 	"github.com/rancher/octopus/pkg/util/converter"
 	"github.com/rancher/octopus/pkg/util/uuid"
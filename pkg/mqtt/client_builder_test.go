@@ -10,7 +10,7 @@
 	corev1 "k8s.io/api/core/v1"
 
 	edgev1alpha1 "github.com/rancher/octopus/api/v1alpha1"
-	adaptorapi "github.com/rancher/octopus/pkg/adaptor/api/v1alpha1"
+	adaptorapi "github.com/rancher/octopus/pkg/adaptor/api/v1alpha2"
 	"github.com/rancher/octopus/pkg/mqtt/api"
 	"github.com/rancher/octopus/test/util/testdata"
 )
@@ -23,6 +23,9 @@ type Adaptor interface {
 	// GetEndpoint returns the endpoint of adaptor
 	GetEndpoint() string
 
+	// GetVersion returns the negotiated API version of adaptor
+	GetVersion() string
+
 	// Stop stops the adaptor and deletes all connections
 	Stop() error
 
@@ -31,9 +34,17 @@ type Adaptor interface {
 
 	// DeleteConnection deletes the connection of name
 	DeleteConnection(name types.NamespacedName) (exist bool)
+
+	// GetConnection returns the connection of name, it's always return nil if the connection is not existed or stopped
+	GetConnection(name types.NamespacedName) connection.Connection
 }
 
-func NewAdaptor(dir, name, endpoint string, notifier event.ConnectionNotifier) (Adaptor, error) {
+func NewAdaptor(dir, name, endpoint, version string, notifier event.ConnectionNotifier) (Adaptor, error) {
+	var codec, err = connection.GetCodec(version)
+	if err != nil {
+		return nil, err
+	}
+
 	// 为每个model都创建一个本地soket， 用于链接执行的model，每个model都是socket，
 	var socketPath = filepath.Join(dir, endpoint)
 
@@ -49,7 +60,7 @@ func NewAdaptor(dir, name, endpoint string, notifier event.ConnectionNotifier) (
 	var setupCtx, cancel = context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	var conn, err = grpc.DialContext(setupCtx, socketPath, cliOptions...)
+	conn, err := grpc.DialContext(setupCtx, socketPath, cliOptions...)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to dial adaptor: %s", socketPath)
 	}
@@ -58,6 +69,7 @@ func NewAdaptor(dir, name, endpoint string, notifier event.ConnectionNotifier) (
 		name:       name,
 		endpoint:   endpoint,
 		clientConn: conn,
+		codec:      codec,
 		conns:      connection.NewConnections(),
 		notifier:   notifier,
 	}, nil
@@ -67,6 +79,7 @@ type adaptor struct {
 	name       string
 	endpoint   string
 	clientConn *grpc.ClientConn
+	codec      connection.Codec
 	conns      connection.Connections
 	notifier   event.ConnectionNotifier
 }
@@ -79,6 +92,10 @@ func (a *adaptor) GetEndpoint() string {
 	return a.endpoint
 }
 
+func (a *adaptor) GetVersion() string {
+	return a.codec.GetVersion()
+}
+
 func (a *adaptor) Stop() error {
 	a.conns.Cleanup()
 
@@ -100,7 +117,7 @@ func (a *adaptor) CreateConnection(name types.NamespacedName) (overwritten bool,
 			return true, conn, nil
 		}
 	}
-	conn, err = connection.NewConnection(a.name, name, a.clientConn, a.notifier)
+	conn, err = connection.NewConnection(a.name, name, a.clientConn, a.codec, a.notifier)
 	if err != nil {
 		return false, nil, err
 	}
@@ -110,3 +127,11 @@ func (a *adaptor) CreateConnection(name types.NamespacedName) (overwritten bool,
 func (a *adaptor) DeleteConnection(name types.NamespacedName) bool {
 	return a.conns.Delete(name)
 }
+
+func (a *adaptor) GetConnection(name types.NamespacedName) connection.Connection {
+	var conn = a.conns.Get(name)
+	if conn == nil || conn.IsStop() {
+		return nil
+	}
+	return conn
+}
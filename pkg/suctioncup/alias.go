@@ -11,4 +11,11 @@
 	RequestAdaptorStatus = event.RequestAdaptorStatus
 
 	RequestConnectionStatus = event.RequestConnectionStatus
+
+	Patch = event.Patch
+)
+
+const (
+	MergePatchType      = event.MergePatchType
+	PropertiesPatchType = event.PropertiesPatchType
 )
@@ -0,0 +1,126 @@
+package connection
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
+	"github.com/rancher/octopus/pkg/adaptor/api/v1alpha1"
+	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha2"
+)
+
+// Stream is the connect stream between the Limb and adaptor,
+// the messages are always represented in the latest version of API.
+type Stream interface {
+	// Send sends the request to adaptor
+	Send(*api.ConnectRequest) error
+
+	// Recv receives the response from adaptor
+	Recv() (*api.ConnectResponse, error)
+
+	// CloseSend closes the sending direction of stream
+	CloseSend() error
+}
+
+// Codec opens the connect stream in the negotiated version of API,
+// and converts the messages between the latest version and the negotiated version.
+type Codec interface {
+	// GetVersion returns the negotiated version of API
+	GetVersion() string
+
+	// Connect opens a connect stream on the client connection
+	Connect(ctx context.Context, clientConn *grpc.ClientConn) (Stream, error)
+}
+
+// GetCodec returns the codec of the given version.
+func GetCodec(version string) (Codec, error) {
+	switch version {
+	case api.Version:
+		return v1alpha2Codec{}, nil
+	case v1alpha1.Version:
+		return v1alpha1Codec{}, nil
+	}
+	return nil, errors.Errorf("%s is not a supported version", version)
+}
+
+type v1alpha2Codec struct{}
+
+func (v1alpha2Codec) GetVersion() string {
+	return api.Version
+}
+
+func (v1alpha2Codec) Connect(ctx context.Context, clientConn *grpc.ClientConn) (Stream, error) {
+	return api.NewConnectionClient(clientConn).Connect(ctx)
+}
+
+type v1alpha1Codec struct{}
+
+func (v1alpha1Codec) GetVersion() string {
+	return v1alpha1.Version
+}
+
+func (v1alpha1Codec) Connect(ctx context.Context, clientConn *grpc.ClientConn) (Stream, error) {
+	var stream, err = v1alpha1.NewConnectionClient(clientConn).Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &v1alpha1Stream{stream: stream}, nil
+}
+
+type v1alpha1Stream struct {
+	stream v1alpha1.Connection_ConnectClient
+}
+
+func (s *v1alpha1Stream) Send(req *api.ConnectRequest) error {
+	// the command is introduced since v1alpha2.
+	if req.GetCommand() != nil {
+		return errors.Errorf("command is not supported in %s", v1alpha1.Version)
+	}
+	return s.stream.Send(convertRequestToV1alpha1(req))
+}
+
+func (s *v1alpha1Stream) Recv() (*api.ConnectResponse, error) {
+	var resp, err = s.stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+	return convertResponseFromV1alpha1(resp), nil
+}
+
+func (s *v1alpha1Stream) CloseSend() error {
+	return s.stream.CloseSend()
+}
+
+func convertRequestToV1alpha1(in *api.ConnectRequest) *v1alpha1.ConnectRequest {
+	if in == nil {
+		return nil
+	}
+
+	var out = &v1alpha1.ConnectRequest{
+		Model:  in.Model,
+		Device: in.Device,
+	}
+	if in.References != nil {
+		out.References = make(map[string]*v1alpha1.ConnectRequestReferenceEntry, len(in.References))
+		for name, ref := range in.References {
+			if ref == nil {
+				out.References[name] = nil
+				continue
+			}
+			out.References[name] = &v1alpha1.ConnectRequestReferenceEntry{Items: ref.Items}
+		}
+	}
+	return out
+}
+
+func convertResponseFromV1alpha1(in *v1alpha1.ConnectResponse) *api.ConnectResponse {
+	if in == nil {
+		return nil
+	}
+
+	return &api.ConnectResponse{
+		Device:       in.Device,
+		ErrorMessage: in.ErrorMessage,
+	}
+}
@@ -0,0 +1,87 @@
+package connection
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/rancher/octopus/pkg/adaptor/api/v1alpha1"
+	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha2"
+)
+
+func TestGetCodec(t *testing.T) {
+	for _, version := range api.SupportedVersions {
+		var codec, err = GetCodec(version)
+		if assert.NoError(t, err, "version %q", version) {
+			assert.Equal(t, version, codec.GetVersion())
+		}
+	}
+
+	var _, err = GetCodec("v1beta1")
+	assert.Error(t, err)
+}
+
+func TestConvertRequestToV1alpha1(t *testing.T) {
+	var testCases = []struct {
+		name     string
+		given    *api.ConnectRequest
+		expected *v1alpha1.ConnectRequest
+	}{
+		{
+			name: "device",
+			given: &api.ConnectRequest{
+				Model:  &metav1.TypeMeta{Kind: "DummySpecialDevice", APIVersion: "devices.edge.cattle.io/v1alpha1"},
+				Device: []byte(`{"spec":{"on":true}}`),
+				References: map[string]*api.ConnectRequestReferenceEntry{
+					"credential": {Items: map[string][]byte{"username": []byte("admin")}},
+				},
+			},
+			expected: &v1alpha1.ConnectRequest{
+				Model:  &metav1.TypeMeta{Kind: "DummySpecialDevice", APIVersion: "devices.edge.cattle.io/v1alpha1"},
+				Device: []byte(`{"spec":{"on":true}}`),
+				References: map[string]*v1alpha1.ConnectRequestReferenceEntry{
+					"credential": {Items: map[string][]byte{"username": []byte("admin")}},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		var actual = convertRequestToV1alpha1(tc.given)
+		assert.Equal(t, tc.expected, actual, "case %q", tc.name)
+	}
+}
+
+func TestConvertResponseFromV1alpha1(t *testing.T) {
+	var testCases = []struct {
+		name     string
+		given    *v1alpha1.ConnectResponse
+		expected *api.ConnectResponse
+	}{
+		{
+			name:     "device",
+			given:    &v1alpha1.ConnectResponse{Device: []byte(`{"status":{}}`)},
+			expected: &api.ConnectResponse{Device: []byte(`{"status":{}}`)},
+		},
+		{
+			name:     "error",
+			given:    &v1alpha1.ConnectResponse{ErrorMessage: "failed"},
+			expected: &api.ConnectResponse{ErrorMessage: "failed"},
+		},
+	}
+
+	for _, tc := range testCases {
+		var actual = convertResponseFromV1alpha1(tc.given)
+		assert.Equal(t, tc.expected, actual, "case %q", tc.name)
+	}
+}
+
+func TestV1alpha1Stream_Send(t *testing.T) {
+	// the command cannot be sent in v1alpha1
+	var s = &v1alpha1Stream{}
+	var err = s.Send(&api.ConnectRequest{
+		Command: &api.ConnectRequestCommand{Id: "1", Name: "reset"},
+	})
+	assert.Error(t, err)
+}
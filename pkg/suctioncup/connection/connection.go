@@ -4,6 +4,7 @@
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"go.uber.org/atomic"
@@ -11,7 +12,8 @@
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 
-	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha1"
+	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha2"
+	"github.com/rancher/octopus/pkg/metrics"
 	"github.com/rancher/octopus/pkg/suctioncup/event"
 )
 
@@ -25,6 +27,9 @@ type Connection interface {
 	// Send sends the device model, desired data and references to connection
 	Send(model *metav1.TypeMeta, device []byte, references map[string]*api.ConnectRequestReferenceEntry) error
 
+	// Command sends the command to connection and waits for the result of the command
+	Command(command *api.ConnectRequestCommand, timeout time.Duration) (*api.ConnectResponseCommandResult, error)
+
 	// Stop stops the connection
 	Stop() error
 
@@ -32,8 +37,8 @@ type Connection interface {
 	IsStop() bool
 }
 
-func NewConnection(adaptorName string, name types.NamespacedName, clientConn *grpc.ClientConn, notifier event.ConnectionNotifier) (Connection, error) {
-	var conn, err = api.NewConnectionClient(clientConn).Connect(context.Background())
+func NewConnection(adaptorName string, name types.NamespacedName, clientConn *grpc.ClientConn, codec Codec, notifier event.ConnectionNotifier) (Connection, error) {
+	var conn, err = codec.Connect(context.Background(), clientConn)
 	if err != nil {
 		return nil, err
 	}
@@ -45,6 +50,8 @@ func NewConnection(adaptorName string, name types.NamespacedName, clientConn *gr
 		notifier:        notifier,
 		interruptSignal: make(chan struct{}),
 		interruptError:  make(chan error),
+		commandResults:  make(map[string]chan *api.ConnectResponseCommandResult),
+		stopSignal:      make(chan struct{}),
 	}
 	go c.receive()
 	return c, nil
@@ -54,11 +61,16 @@ type connection struct {
 	stopped     atomic.Bool
 	adaptorName string
 	name        types.NamespacedName
-	conn        api.Connection_ConnectClient
+	conn        Stream
 	notifier    event.ConnectionNotifier
 
 	interruptSignal chan struct{}
 	interruptError  chan error
+
+	sendLock           sync.Mutex
+	commandResultsLock sync.Mutex
+	commandResults     map[string]chan *api.ConnectResponseCommandResult
+	stopSignal         chan struct{}
 }
 
 func (c *connection) GetAdaptorName() string {
@@ -92,7 +104,7 @@ func (c *connection) Send(model *metav1.TypeMeta, device []byte, references map[
 		}()
 		c.interruptSignal <- struct{}{}
 	}()
-	if err = c.conn.Send(&api.ConnectRequest{
+	if err = c.send(&api.ConnectRequest{
 		Model:      model,
 		Device:     device,
 		References: references,
@@ -112,21 +124,91 @@ func (c *connection) Send(model *metav1.TypeMeta, device []byte, references map[
 	}
 }
 
+func (c *connection) Command(command *api.ConnectRequestCommand, timeout time.Duration) (*api.ConnectResponseCommandResult, error) {
+	if command == nil || command.GetId() == "" {
+		return nil, errors.New("invalid command without ID")
+	}
+	if c.IsStop() {
+		return nil, errors.New("connection has been stopped")
+	}
+
+	var resultC = make(chan *api.ConnectResponseCommandResult, 1)
+	c.commandResultsLock.Lock()
+	if _, exist := c.commandResults[command.GetId()]; exist {
+		c.commandResultsLock.Unlock()
+		return nil, fmt.Errorf("command %s is executing", command.GetId())
+	}
+	c.commandResults[command.GetId()] = resultC
+	c.commandResultsLock.Unlock()
+	defer func() {
+		c.commandResultsLock.Lock()
+		delete(c.commandResults, command.GetId())
+		c.commandResultsLock.Unlock()
+	}()
+
+	if err := c.send(&api.ConnectRequest{Command: command}); err != nil {
+		return nil, err
+	}
+
+	var timer = time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case result := <-resultC:
+		return result, nil
+	case <-c.stopSignal:
+		return nil, errors.New("connection has been stopped")
+	case <-timer.C:
+		return nil, fmt.Errorf("timeout to wait for the result of command in %v", timeout)
+	}
+}
+
+func (c *connection) send(req *api.ConnectRequest) error {
+	// it is not safe to call `Send` on the same stream in different goroutines.
+	c.sendLock.Lock()
+	defer c.sendLock.Unlock()
+	return c.conn.Send(req)
+}
+
 func (c *connection) stop() error {
 	var err error
 	if c.stopped.CAS(false, true) {
 		err = c.conn.CloseSend()
 		close(c.interruptSignal)
 		close(c.interruptError)
+		close(c.stopSignal)
 	}
 	return err
 }
 
+// receiveCommandResult delivers the result to the command waiter,
+// the result is dropped if there is not any waiter, e.g. the waiting has been timeout.
+func (c *connection) receiveCommandResult(result *api.ConnectResponseCommandResult) {
+	c.commandResultsLock.Lock()
+	defer c.commandResultsLock.Unlock()
+
+	var resultC, exist = c.commandResults[result.GetId()]
+	if !exist {
+		log.V(4).Info("Dropped the result of unknown command", "connection", c.name, "command", result.GetId())
+		return
+	}
+	select {
+	case resultC <- result:
+	default:
+	}
+}
+
 func (c *connection) receive() {
 	defer c.stop()
 
 	for {
 		var resp, err = c.conn.Recv()
+		// the command result is correlated by ID,
+		// so it should not interrupt the sending of device.
+		if err == nil && resp.GetCommandResult() != nil {
+			c.receiveCommandResult(resp.GetCommandResult())
+			continue
+		}
+
 		select {
 		case _, active := <-c.interruptSignal:
 			if !active {
@@ -142,14 +224,10 @@ func (c *connection) receive() {
 				return
 			}
 
-			if resp.GetErrorMessage() != "" {
-				c.interruptError <- errors.New(resp.GetErrorMessage())
+			if respErr := getResponseError(resp); respErr != nil {
+				c.interruptError <- respErr
 			} else {
-				c.notifier.NoticeConnectionReceivedData(
-					c.adaptorName,
-					c.name,
-					resp.GetDevice(),
-				)
+				c.noticeReceived(resp)
 				c.interruptError <- nil
 			}
 			continue
@@ -183,18 +261,60 @@ func (c *connection) receive() {
 			return
 		}
 
-		if resp.GetErrorMessage() != "" {
+		if respErr := getResponseError(resp); respErr != nil {
 			c.notifier.NoticeConnectionReceivedError(
 				c.adaptorName,
 				c.name,
-				errors.New(resp.GetErrorMessage()),
+				respErr,
 			)
 		} else {
-			c.notifier.NoticeConnectionReceivedData(
-				c.adaptorName,
-				c.name,
-				resp.GetDevice(),
-			)
+			c.noticeReceived(resp)
 		}
 	}
 }
+
+// getResponseError returns the error of response,
+// the classified error is preferred to the error message.
+func getResponseError(resp *api.ConnectResponse) error {
+	if respErr := resp.GetError(); respErr != nil {
+		if respErr.GetMessage() == "" {
+			respErr.Message = resp.GetErrorMessage()
+		}
+		return respErr
+	}
+	if resp.GetErrorMessage() != "" {
+		return errors.New(resp.GetErrorMessage())
+	}
+	return nil
+}
+
+// noticeReceived notices the received device or the received patch of device.
+func (c *connection) noticeReceived(resp *api.ConnectResponse) {
+	metrics.GetLimbMetricsRecorder().ObserveDeviceReceived(c.name.Namespace, c.name.Name)
+
+	var devicePatch = resp.GetDevicePatch()
+	if devicePatch == nil {
+		c.notifier.NoticeConnectionReceivedData(
+			c.adaptorName,
+			c.name,
+			resp.GetDevice(),
+		)
+		return
+	}
+
+	var patchType event.PatchType
+	switch devicePatch.GetType() {
+	case api.PatchType_PropertiesPatch:
+		patchType = event.PropertiesPatchType
+	default:
+		patchType = event.MergePatchType
+	}
+	c.notifier.NoticeConnectionReceivedPatch(
+		c.adaptorName,
+		c.name,
+		event.Patch{
+			Type: patchType,
+			Data: devicePatch.GetData(),
+		},
+	)
+}
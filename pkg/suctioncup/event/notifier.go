@@ -11,6 +11,7 @@ type AdaptorNotifier interface {
 
 type ConnectionNotifier interface {
 	NoticeConnectionReceivedData(adaptorName string, name types.NamespacedName, data []byte)
+	NoticeConnectionReceivedPatch(adaptorName string, name types.NamespacedName, patch Patch)
 	NoticeConnectionReceivedError(adaptorName string, name types.NamespacedName, err error)
 	NoticeConnectionClosed(adaptorName string, name types.NamespacedName)
 }
@@ -2,9 +2,14 @@
 
 import (
 	"sync"
+	"time"
 
+	"golang.org/x/time/rate"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/workqueue"
+
+	"github.com/rancher/octopus/pkg/metrics"
+	"github.com/rancher/octopus/pkg/util/patch"
 )
 
 type Queue interface {
@@ -14,25 +19,80 @@ type Queue interface {
 	RegisterConnectionHandler(handler ConnectionHandler)
 	GetAdaptorNotifier() AdaptorNotifier
 	GetConnectionNotifier() ConnectionNotifier
+
+	// ConfigureConnection configures the minimum status update interval of the connection,
+	// the default interval of queue is used if the given interval is nil.
+	ConfigureConnection(adaptorName string, name types.NamespacedName, statusUpdateInterval *time.Duration)
+
+	// ForgetConnection forgets the throttling state of the connection.
+	ForgetConnection(adaptorName string, name types.NamespacedName)
+}
+
+type QueueOptions struct {
+	// CoalescingWindow is the duration to coalesce the received data and patches of a connection,
+	// the received data are handled immediately if the window is not positive.
+	CoalescingWindow time.Duration
+
+	// StatusUpdateInterval is the default minimum interval between two status updates of a connection,
+	// the status updates are not limited per connection if the interval is not positive.
+	StatusUpdateInterval time.Duration
+
+	// StatusUpdateQPS is the maximum QPS of the status updates of all connections,
+	// the status updates are not limited globally if the QPS is not positive.
+	StatusUpdateQPS float32
+
+	// StatusUpdateBurst is the maximum burst of the status updates of all connections.
+	StatusUpdateBurst int
 }
 
-func NewQueue() Queue {
+func NewQueue(opts QueueOptions) Queue {
 	var q = workqueue.NewNamedRateLimitingQueue(
 		workqueue.DefaultControllerRateLimiter(),
 		"suctioncup_event",
 	)
+	var limiter *rate.Limiter
+	if opts.StatusUpdateQPS > 0 {
+		var burst = opts.StatusUpdateBurst
+		if burst <= 0 {
+			burst = 1
+		}
+		limiter = rate.NewLimiter(rate.Limit(opts.StatusUpdateQPS), burst)
+	}
 	return &queue{
-		queue: q,
+		queue:                q,
+		coalescingWindow:     opts.CoalescingWindow,
+		statusUpdateInterval: opts.StatusUpdateInterval,
+		statusUpdateLimiter:  limiter,
+		receivedDataCache:    make(map[connectionReceivedData]*receivedData),
+		throttles:            make(map[connectionReceivedData]*throttle),
 	}
 }
 
 type queue struct {
-	queue             workqueue.RateLimitingInterface
-	adaptorHandler    AdaptorHandler
-	connectionHandler ConnectionHandler
+	queue                workqueue.RateLimitingInterface
+	adaptorHandler       AdaptorHandler
+	connectionHandler    ConnectionHandler
+	coalescingWindow     time.Duration
+	statusUpdateInterval time.Duration
+	statusUpdateLimiter  *rate.Limiter
 
-	receivedDataCache  sync.Map
-	receivedErrorCache sync.Map
+	receivedDataCacheLock sync.Mutex
+	receivedDataCache     map[connectionReceivedData]*receivedData
+	throttles             map[connectionReceivedData]*throttle
+	receivedErrorCache    sync.Map
+}
+
+// throttle holds the throttling state of a connection.
+type throttle struct {
+	interval  *time.Duration
+	updatedAt time.Time
+}
+
+// receivedData holds the coalesced data of a connection,
+// the latest device data overwrites the previous data and patches.
+type receivedData struct {
+	device  []byte
+	patches []Patch
 }
 
 func (q *queue) ShutDown() {
@@ -65,8 +125,59 @@ func (q *queue) NoticeConnectionReceivedData(adaptorName string, name types.Name
 		adaptorName: adaptorName,
 		name:        name,
 	}
-	q.receivedDataCache.Store(key, data)
-	q.queue.AddRateLimited(key)
+	q.receivedDataCacheLock.Lock()
+	if _, exist := q.receivedDataCache[key]; exist {
+		// the pending data is overwritten by the received device.
+		metrics.GetLimbMetricsRecorder().IncreaseStatusDropped(adaptorName)
+	}
+	q.receivedDataCache[key] = &receivedData{device: data}
+	q.receivedDataCacheLock.Unlock()
+	q.addReceivedData(key)
+}
+
+func (q *queue) NoticeConnectionReceivedPatch(adaptorName string, name types.NamespacedName, p Patch) {
+	var key = connectionReceivedData{
+		adaptorName: adaptorName,
+		name:        name,
+	}
+	q.receivedDataCacheLock.Lock()
+	var rd, exist = q.receivedDataCache[key]
+	if !exist {
+		rd = &receivedData{}
+		q.receivedDataCache[key] = rd
+	} else {
+		metrics.GetLimbMetricsRecorder().IncreaseStatusCoalesced(adaptorName)
+	}
+	rd.patches = appendPatch(rd.patches, p)
+	q.receivedDataCacheLock.Unlock()
+	q.addReceivedData(key)
+}
+
+func (q *queue) ConfigureConnection(adaptorName string, name types.NamespacedName, statusUpdateInterval *time.Duration) {
+	var key = connectionReceivedData{
+		adaptorName: adaptorName,
+		name:        name,
+	}
+	q.receivedDataCacheLock.Lock()
+	defer q.receivedDataCacheLock.Unlock()
+
+	var t, exist = q.throttles[key]
+	if !exist {
+		t = &throttle{}
+		q.throttles[key] = t
+	}
+	t.interval = statusUpdateInterval
+}
+
+func (q *queue) ForgetConnection(adaptorName string, name types.NamespacedName) {
+	var key = connectionReceivedData{
+		adaptorName: adaptorName,
+		name:        name,
+	}
+	q.receivedDataCacheLock.Lock()
+	defer q.receivedDataCacheLock.Unlock()
+
+	delete(q.throttles, key)
 }
 
 func (q *queue) NoticeConnectionReceivedError(adaptorName string, name types.NamespacedName, err error) {
@@ -140,15 +251,26 @@ func (q *queue) reconcileHandler(obj interface{}) bool {
 			}
 		}
 	case connectionReceivedData:
-		if obj, ok := q.receivedDataCache.Load(req); ok {
+		var rd, delay = q.takeReceivedData(req)
+		if delay > 0 {
+			// the data received during throttling are coalesced.
+			metrics.GetLimbMetricsRecorder().IncreaseStatusThrottled(req.adaptorName)
+			q.queue.Forget(obj)
+			q.queue.AddAfter(obj, delay)
+			return true
+		}
+		if rd != nil {
 			resp, err = q.ReceiveConnectionStatus(RequestConnectionStatus{
 				AdaptorName: req.adaptorName,
 				Name:        req.name,
-				Data:        obj.([]byte),
+				Data:        rd.device,
+				Patches:     rd.patches,
 			})
 
-			if resp.RequeueAfter <= 0 && !resp.Requeue {
-				q.receivedDataCache.Delete(req)
+			if err != nil || resp.RequeueAfter > 0 || resp.Requeue {
+				q.restoreReceivedData(req, rd)
+			} else {
+				q.markUpdated(req)
 			}
 		}
 	case connectionClosed:
@@ -179,6 +301,119 @@ func (q *queue) reconcileHandler(obj interface{}) bool {
 	return true
 }
 
+// addReceivedData adds the key of received data into queue,
+// the data received within the coalescing window are handled together.
+func (q *queue) addReceivedData(key connectionReceivedData) {
+	if q.coalescingWindow > 0 {
+		q.queue.AddAfter(key, q.coalescingWindow)
+		return
+	}
+	q.queue.AddRateLimited(key)
+}
+
+// takeReceivedData takes the received data out from cache,
+// it returns the delay instead if the status update of connection is throttled.
+func (q *queue) takeReceivedData(key connectionReceivedData) (*receivedData, time.Duration) {
+	q.receivedDataCacheLock.Lock()
+	defer q.receivedDataCacheLock.Unlock()
+
+	var rd, exist = q.receivedDataCache[key]
+	if !exist {
+		return nil, 0
+	}
+
+	// throttles by the minimum interval of connection
+	var interval = q.statusUpdateInterval
+	var t = q.throttles[key]
+	if t != nil && t.interval != nil {
+		interval = *t.interval
+	}
+	if t != nil && interval > 0 {
+		if delay := time.Until(t.updatedAt.Add(interval)); delay > 0 {
+			return nil, delay
+		}
+	}
+
+	// throttles by the global QPS
+	if q.statusUpdateLimiter != nil {
+		var reservation = q.statusUpdateLimiter.Reserve()
+		if delay := reservation.Delay(); delay > 0 {
+			reservation.Cancel()
+			return nil, delay
+		}
+	}
+
+	delete(q.receivedDataCache, key)
+	return rd, 0
+}
+
+// markUpdated records the time of the latest status update of connection.
+func (q *queue) markUpdated(key connectionReceivedData) {
+	q.receivedDataCacheLock.Lock()
+	defer q.receivedDataCacheLock.Unlock()
+
+	var t, exist = q.throttles[key]
+	if !exist {
+		t = &throttle{}
+		q.throttles[key] = t
+	}
+	t.updatedAt = time.Now()
+}
+
+// restoreReceivedData restores the taken data into cache if it cannot be handled,
+// the data received during handling is merged with it.
+func (q *queue) restoreReceivedData(key connectionReceivedData, taken *receivedData) {
+	q.receivedDataCacheLock.Lock()
+	defer q.receivedDataCacheLock.Unlock()
+
+	var rd, exist = q.receivedDataCache[key]
+	if !exist {
+		q.receivedDataCache[key] = taken
+		return
+	}
+	if rd.device != nil {
+		// the received device overwrites the taken one.
+		metrics.GetLimbMetricsRecorder().IncreaseStatusDropped(key.adaptorName)
+		return
+	}
+	var patches = taken.patches
+	for _, p := range rd.patches {
+		patches = appendPatch(patches, p)
+	}
+	rd.device = taken.device
+	rd.patches = patches
+}
+
+// appendPatch appends the patch into the patches,
+// it's always composed with the last one if they are the same type.
+func appendPatch(patches []Patch, p Patch) []Patch {
+	if len(patches) == 0 {
+		return append(patches, p)
+	}
+
+	var last = &patches[len(patches)-1]
+	if last.Type != p.Type {
+		return append(patches, p)
+	}
+	switch p.Type {
+	case MergePatchType:
+		var composed, composable, err = patch.ComposeMergePatches(last.Data, p.Data)
+		if err != nil || !composable {
+			return append(patches, p)
+		}
+		last.Data = composed
+	case PropertiesPatchType:
+		var composed, err = patch.ComposePropertiesPatches(last.Data, p.Data)
+		if err != nil {
+			return append(patches, p)
+		}
+		last.Data = composed
+	default:
+		return append(patches, p)
+	}
+	return patches
+}
+
 // proxy
 func (q *queue) ReceiveConnectionStatus(req RequestConnectionStatus) (Response, error) {
 	if q.connectionHandler == nil {
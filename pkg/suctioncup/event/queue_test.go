@@ -0,0 +1,173 @@
+package event
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestAppendPatch(t *testing.T) {
+	var testCases = []struct {
+		name     string
+		given    []Patch
+		expected []Patch
+	}{
+		{
+			name: "compose merge patches",
+			given: []Patch{
+				{Type: MergePatchType, Data: []byte(`{"status":{"a":1}}`)},
+				{Type: MergePatchType, Data: []byte(`{"status":{"b":2}}`)},
+			},
+			expected: []Patch{
+				{Type: MergePatchType, Data: []byte(`{"status":{"a":1,"b":2}}`)},
+			},
+		},
+		{
+			name: "keep uncomposable merge patches",
+			given: []Patch{
+				{Type: MergePatchType, Data: []byte(`{"status":{"a":null}}`)},
+				{Type: MergePatchType, Data: []byte(`{"status":{"a":{"b":2}}}`)},
+			},
+			expected: []Patch{
+				{Type: MergePatchType, Data: []byte(`{"status":{"a":null}}`)},
+				{Type: MergePatchType, Data: []byte(`{"status":{"a":{"b":2}}}`)},
+			},
+		},
+		{
+			name: "compose properties patches",
+			given: []Patch{
+				{Type: PropertiesPatchType, Data: []byte(`[{"name":"a","value":"1"}]`)},
+				{Type: PropertiesPatchType, Data: []byte(`[{"name":"a","value":"2"},{"name":"b","value":"1"}]`)},
+			},
+			expected: []Patch{
+				{Type: PropertiesPatchType, Data: []byte(`[{"name":"a","value":"2"},{"name":"b","value":"1"}]`)},
+			},
+		},
+		{
+			name: "keep the order of different patches",
+			given: []Patch{
+				{Type: PropertiesPatchType, Data: []byte(`[{"name":"a","value":"1"}]`)},
+				{Type: MergePatchType, Data: []byte(`{"status":{"a":1}}`)},
+				{Type: PropertiesPatchType, Data: []byte(`[{"name":"b","value":"1"}]`)},
+			},
+			expected: []Patch{
+				{Type: PropertiesPatchType, Data: []byte(`[{"name":"a","value":"1"}]`)},
+				{Type: MergePatchType, Data: []byte(`{"status":{"a":1}}`)},
+				{Type: PropertiesPatchType, Data: []byte(`[{"name":"b","value":"1"}]`)},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		var actual []Patch
+		for _, p := range tc.given {
+			actual = appendPatch(actual, p)
+		}
+		assert.Equal(t, tc.expected, actual, "case %q", tc.name)
+	}
+}
+
+func TestQueue_ReceivedData(t *testing.T) {
+	var q = NewQueue(QueueOptions{}).(*queue)
+	defer q.queue.ShutDown()
+
+	var key = connectionReceivedData{
+		adaptorName: "adaptors.edge.cattle.io/dummy",
+		name:        types.NamespacedName{Namespace: "default", Name: "test"},
+	}
+
+	// the received device clears the previous patches
+	q.NoticeConnectionReceivedPatch(key.adaptorName, key.name, Patch{Type: PropertiesPatchType, Data: []byte(`[{"name":"a"}]`)})
+	q.NoticeConnectionReceivedData(key.adaptorName, key.name, []byte(`{"status":{}}`))
+	q.NoticeConnectionReceivedPatch(key.adaptorName, key.name, Patch{Type: PropertiesPatchType, Data: []byte(`[{"name":"b"}]`)})
+	var taken, _ = q.takeReceivedData(key)
+	assert.Equal(t, &receivedData{
+		device:  []byte(`{"status":{}}`),
+		patches: []Patch{{Type: PropertiesPatchType, Data: []byte(`[{"name":"b"}]`)}},
+	}, taken)
+	var none, _ = q.takeReceivedData(key)
+	assert.Nil(t, none)
+
+	// the taken data is restored before the patches received during handling
+	q.NoticeConnectionReceivedPatch(key.adaptorName, key.name, Patch{Type: MergePatchType, Data: []byte(`{"status":{"c":1}}`)})
+	q.restoreReceivedData(key, taken)
+	var restored, _ = q.takeReceivedData(key)
+	assert.Equal(t, &receivedData{
+		device: []byte(`{"status":{}}`),
+		patches: []Patch{
+			{Type: PropertiesPatchType, Data: []byte(`[{"name":"b"}]`)},
+			{Type: MergePatchType, Data: []byte(`{"status":{"c":1}}`)},
+		},
+	}, restored)
+
+	// the device received during handling overwrites the taken data
+	q.NoticeConnectionReceivedData(key.adaptorName, key.name, []byte(`{"status":{"d":1}}`))
+	q.restoreReceivedData(key, taken)
+	restored, _ = q.takeReceivedData(key)
+	assert.Equal(t, &receivedData{
+		device: []byte(`{"status":{"d":1}}`),
+	}, restored)
+}
+
+func TestQueue_Throttle(t *testing.T) {
+	var q = NewQueue(QueueOptions{
+		StatusUpdateInterval: time.Hour,
+		StatusUpdateQPS:      1,
+		StatusUpdateBurst:    1,
+	}).(*queue)
+	defer q.queue.ShutDown()
+
+	var key = connectionReceivedData{
+		adaptorName: "adaptors.edge.cattle.io/dummy",
+		name:        types.NamespacedName{Namespace: "default", Name: "test"},
+	}
+	var another = connectionReceivedData{
+		adaptorName: "adaptors.edge.cattle.io/dummy",
+		name:        types.NamespacedName{Namespace: "default", Name: "another"},
+	}
+
+	// the first update is not throttled
+	q.NoticeConnectionReceivedData(key.adaptorName, key.name, []byte(`{"status":{}}`))
+	var rd, delay = q.takeReceivedData(key)
+	assert.NotNil(t, rd)
+	assert.Zero(t, delay)
+	q.markUpdated(key)
+
+	// the next update is throttled by the interval of connection
+	q.NoticeConnectionReceivedData(key.adaptorName, key.name, []byte(`{"status":{}}`))
+	rd, delay = q.takeReceivedData(key)
+	assert.Nil(t, rd)
+	assert.True(t, delay > 59*time.Minute)
+
+	// the interval of connection can be overridden
+	var interval = 10 * time.Minute
+	q.ConfigureConnection(key.adaptorName, key.name, &interval)
+	rd, delay = q.takeReceivedData(key)
+	assert.Nil(t, rd)
+	assert.True(t, delay > 9*time.Minute && delay <= 10*time.Minute)
+
+	// the connection is only throttled by the global QPS if the overridden interval is not positive
+	interval = 0
+	q.ConfigureConnection(key.adaptorName, key.name, &interval)
+	rd, delay = q.takeReceivedData(key)
+	assert.Nil(t, rd)
+	assert.True(t, delay > 0 && delay <= time.Second)
+
+	// the default interval of queue is used if the overridden interval is nil
+	q.ConfigureConnection(key.adaptorName, key.name, nil)
+	rd, delay = q.takeReceivedData(key)
+	assert.Nil(t, rd)
+	assert.True(t, delay > 59*time.Minute)
+
+	// the update of another connection is throttled by the global QPS
+	q.NoticeConnectionReceivedData(another.adaptorName, another.name, []byte(`{"status":{}}`))
+	rd, delay = q.takeReceivedData(another)
+	assert.Nil(t, rd)
+	assert.True(t, delay > 0 && delay <= time.Second)
+
+	// the throttling state is forgotten
+	q.ForgetConnection(key.adaptorName, key.name)
+	assert.Empty(t, q.throttles)
+}
@@ -20,10 +20,28 @@ type RequestConnectionStatus struct {
 	AdaptorName string
 	Name        types.NamespacedName
 	Data        []byte
+	Patches     []Patch
 	Error       error
 	Closed      bool
 }
 
+type PatchType string
+
+// These are valid types of patch
+const (
+	// MergePatchType means that the patch is a JSON merge patch of device.
+	MergePatchType PatchType = "MergePatch"
+
+	// PropertiesPatchType means that the patch is a JSON array of the properties of device status.
+	PropertiesPatchType PatchType = "PropertiesPatch"
+)
+
+// Patch is a partial update of the observed device.
+type Patch struct {
+	Type PatchType
+	Data []byte
+}
+
 type adaptorRegistered struct {
 	name string
 }
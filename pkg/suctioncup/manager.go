@@ -8,7 +8,7 @@
 	"k8s.io/apimachinery/pkg/util/wait"
 	ctrl "sigs.k8s.io/controller-runtime"
 
-	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha1"
+	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha2"
 	"github.com/rancher/octopus/pkg/suctioncup/adaptor"
 	"github.com/rancher/octopus/pkg/suctioncup/event"
 	"github.com/rancher/octopus/pkg/suctioncup/registration"
@@ -17,11 +17,16 @@
 
 var log = ctrl.Log.WithName("suctioncup").WithName("manager")
 
-func NewManager() (Manager, error) {
+func NewManager(opts Options) (Manager, error) {
 	// adaptors adapter cache管理功能
 	var adaptors = adaptor.NewAdaptors()
 	// event queue
-	var queue = event.NewQueue()
+	var queue = event.NewQueue(event.QueueOptions{
+		CoalescingWindow:     opts.StatusCoalescingWindow,
+		StatusUpdateInterval: opts.StatusUpdateInterval,
+		StatusUpdateQPS:      opts.StatusUpdateQPS,
+		StatusUpdateBurst:    opts.StatusUpdateBurst,
+	})
 	return NewManagerWith(adaptors, queue)
 }
 
@@ -8,7 +8,7 @@
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	edgev1alpha1 "github.com/rancher/octopus/api/v1alpha1"
-	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha1"
+	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha2"
 	"github.com/rancher/octopus/pkg/metrics"
 	"github.com/rancher/octopus/pkg/suctioncup/connection"
 	"github.com/rancher/octopus/pkg/util/object"
@@ -40,6 +40,7 @@ func (m *manager) Connect(referencesData map[string]map[string][]byte, device *u
 			metrics.GetLimbMetricsRecorder().IncreaseConnectErrors(adaptorName)
 		} else if !overwritten {
 			metrics.GetLimbMetricsRecorder().IncreaseConnections(adaptorName)
+			metrics.GetLimbMetricsRecorder().ObserveDeviceConnected(by.Namespace, by.Name)
 		}
 	}()
 	// 获取device 名称（也就是model的名称）
@@ -50,6 +51,13 @@ func (m *manager) Connect(referencesData map[string]map[string][]byte, device *u
 		return errors.Wrapf(connectedErr, "cannot to link device %s via adaptor", deviceName)
 	}
 
+	// configures the status update throttling of connection
+	var statusUpdateInterval *time.Duration
+	if by.Spec.StatusUpdateInterval != nil {
+		statusUpdateInterval = &by.Spec.StatusUpdateInterval.Duration
+	}
+	m.queue.ConfigureConnection(adaptorName, deviceName, statusUpdateInterval)
+
 	// records metrics
 	var (
 		sendStartTS = time.Now()
@@ -105,7 +113,32 @@ func (m *manager) Disconnect(by *edgev1alpha1.DeviceLink) {
 		}
 	}()
 
-	exist = adaptor.DeleteConnection(object.GetNamespacedName(by))
+	var deviceName = object.GetNamespacedName(by)
+	exist = adaptor.DeleteConnection(deviceName)
+	m.queue.ForgetConnection(adaptorName, deviceName)
+}
+
+func (m *manager) Command(command *api.ConnectRequestCommand, timeout time.Duration, by *edgev1alpha1.DeviceLink) (*api.ConnectResponseCommandResult, error) {
+	var adaptorName = by.Status.AdaptorName
+	if adaptorName == "" {
+		return nil, errors.New("adaptor name is empty")
+	}
+	var adaptor = m.adaptors.Get(adaptorName)
+	if adaptor == nil {
+		return nil, errors.Errorf("cannot find adaptor %s", adaptorName)
+	}
+
+	var deviceName = object.GetNamespacedName(by)
+	var conn = adaptor.GetConnection(deviceName)
+	if conn == nil {
+		return nil, errors.Errorf("device %s is not connected", deviceName)
+	}
+
+	var result, err = conn.Command(command, timeout)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot invoke command %s on device %s via adaptor", command.GetName(), deviceName)
+	}
+	return result, nil
 }
 
 func cleanupDevice(device *unstructured.Unstructured) *unstructured.Unstructured {
@@ -15,7 +15,8 @@
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 
-	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha1"
+	"github.com/rancher/octopus/pkg/adaptor/api/v1alpha1"
+	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha2"
 	"github.com/rancher/octopus/pkg/suctioncup/adaptor"
 	"github.com/rancher/octopus/pkg/suctioncup/event"
 	"github.com/rancher/octopus/pkg/suctioncup/validation"
@@ -77,6 +78,7 @@ func (s *server) Start(stop <-chan struct{}) error {
 
 	// register services
 	api.RegisterRegistrationServer(srv, s)
+	v1alpha1.RegisterRegistrationServer(srv, &v1alpha1Server{srv: s})
 
 	// serve
 	var errC = make(chan error)
@@ -93,27 +95,43 @@ func (s *server) Start(stop <-chan struct{}) error {
 }
 
 // implement the Registration rpc protoc
-func (s *server) Register(_ context.Context, req *api.RegisterRequest) (*api.Empty, error) {
+func (s *server) Register(_ context.Context, req *api.RegisterRequest) (*api.RegisterResponse, error) {
 	var log = log.WithValues("adaptor", req.Name)
 
 	defer utilruntime.HandleCrash(handler.NewPanicsLogHandler(log))
 
-	if err := validate(req); err != nil {
+	var version, err = validate(req)
+	if err != nil {
 		log.Error(err, "Rejected the register request")
-		return &api.Empty{}, grpcstatus.Error(grpccodes.InvalidArgument, err.Error())
+		return &api.RegisterResponse{}, grpcstatus.Error(grpccodes.InvalidArgument, err.Error())
 	}
 
-	var adp, err = adaptor.NewAdaptor(api.AdaptorPath, req.Name, req.Endpoint, s.connNotifier)
+	adp, err := adaptor.NewAdaptor(api.AdaptorPath, req.Name, req.Endpoint, version, s.connNotifier)
 	if err != nil {
 		log.Error(err, "Unable to connect adaptor")
-		return &api.Empty{}, grpcstatus.Errorf(grpcstatus.Code(err), "could not connect the registering adaptor %s", req.Name)
+		return &api.RegisterResponse{}, grpcstatus.Errorf(grpcstatus.Code(err), "could not connect the registering adaptor %s", req.Name)
 	}
 	if err := s.sockWatcher.Watch(adp); err != nil {
 		log.Error(err, "Unable to watch adaptor's socket")
-		return &api.Empty{}, grpcstatus.Errorf(grpccodes.Internal, "could not watch the socket of registering adaptor %s", req.Name)
+		return &api.RegisterResponse{}, grpcstatus.Errorf(grpccodes.Internal, "could not watch the socket of registering adaptor %s", req.Name)
 	}
+	log.V(1).Info("Registered", "version", version)
+
+	return &api.RegisterResponse{Version: version}, nil
+}
 
-	return &api.Empty{}, nil
+// v1alpha1Server serves the registration of the adaptors which are built against v1alpha1 API.
+type v1alpha1Server struct {
+	srv *server
+}
+
+func (s *v1alpha1Server) Register(ctx context.Context, req *v1alpha1.RegisterRequest) (*v1alpha1.Empty, error) {
+	var _, err = s.srv.Register(ctx, &api.RegisterRequest{
+		Name:     req.Name,
+		Version:  req.Version,
+		Endpoint: req.Endpoint,
+	})
+	return &v1alpha1.Empty{}, err
 }
 
 func cleanup(socketDir string) error {
@@ -158,12 +176,18 @@ func cleanup(socketDir string) error {
 	return nil
 }
 
-func validate(req *api.RegisterRequest) error {
-	if !validation.IsSupportedVersion(req.Version) {
-		return errors.Errorf("%s is not a supported version", req.Version)
+// validate validates the request and returns the highest mutual version.
+func validate(req *api.RegisterRequest) (string, error) {
+	var versions = req.Versions
+	if len(versions) == 0 {
+		versions = []string{req.Version}
+	}
+	var version = validation.NegotiateVersion(versions...)
+	if version == "" {
+		return "", errors.Errorf("none of %v is a supported version", versions)
 	}
 	if !validation.IsQualifiedName(req.Name) {
-		return errors.Errorf("the requested name %s is not qualified", req.Name)
+		return "", errors.Errorf("the requested name %s is not qualified", req.Name)
 	}
-	return nil
+	return version, nil
 }
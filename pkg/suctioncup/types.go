@@ -1,12 +1,29 @@
 package suctioncup
 
 import (
+	"time"
+
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	edgev1alpha1 "github.com/rancher/octopus/api/v1alpha1"
+	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha2"
 	"github.com/rancher/octopus/pkg/suctioncup/event"
 )
 
+type Options struct {
+	// StatusCoalescingWindow is the duration to coalesce the received status of a device before writing.
+	StatusCoalescingWindow time.Duration
+
+	// StatusUpdateInterval is the default minimum interval between two status updates of a device.
+	StatusUpdateInterval time.Duration
+
+	// StatusUpdateQPS is the maximum QPS of the status updates of all devices.
+	StatusUpdateQPS float32
+
+	// StatusUpdateBurst is the maximum burst of the status updates of all devices.
+	StatusUpdateBurst int
+}
+
 type Manager interface {
 	// Start starts the suction cup manager.
 	Start(<-chan struct{}) error
@@ -30,4 +47,7 @@ type Neurons interface {
 
 	// Disconnect stops a connection by link
 	Disconnect(by *edgev1alpha1.DeviceLink)
+
+	// Command invokes a command on the connection of link and waits for the result until timeout.
+	Command(command *api.ConnectRequestCommand, timeout time.Duration, by *edgev1alpha1.DeviceLink) (*api.ConnectResponseCommandResult, error)
 }
@@ -3,18 +3,22 @@
 import (
 	"k8s.io/apimachinery/pkg/util/validation"
 
-	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha1"
+	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha2"
 )
 
 func IsQualifiedName(name string) bool {
 	return len(validation.IsQualifiedName(name)) == 0
 }
 
-func IsSupportedVersion(version string) bool {
+// NegotiateVersion returns the highest mutual version between the Limb and the given versions,
+// it's always return blank if there is not any mutual version.
+func NegotiateVersion(versions ...string) string {
 	for _, v := range api.SupportedVersions {
-		if version == v {
-			return true
+		for _, rv := range versions {
+			if rv == v {
+				return v
+			}
 		}
 	}
-	return false
+	return ""
 }
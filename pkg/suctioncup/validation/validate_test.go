@@ -0,0 +1,46 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiateVersion(t *testing.T) {
+	var testCases = []struct {
+		name     string
+		given    []string
+		expected string
+	}{
+		{
+			name:     "latest version",
+			given:    []string{"v1alpha2"},
+			expected: "v1alpha2",
+		},
+		{
+			name:     "legacy version",
+			given:    []string{"v1alpha1"},
+			expected: "v1alpha1",
+		},
+		{
+			name:     "highest mutual version",
+			given:    []string{"v1alpha1", "v1beta1", "v1alpha2"},
+			expected: "v1alpha2",
+		},
+		{
+			name:     "unsupported versions",
+			given:    []string{"v1beta1", ""},
+			expected: "",
+		},
+		{
+			name:     "without versions",
+			given:    nil,
+			expected: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		var actual = NegotiateVersion(tc.given...)
+		assert.Equal(t, tc.expected, actual, "case %q", tc.name)
+	}
+}
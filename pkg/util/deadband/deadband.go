@@ -0,0 +1,104 @@
+// Package deadband provides the report-by-exception filtering of device properties,
+// which avoids synchronizing the unchanged or slightly changed values to limb.
+package deadband
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Policy is the reporting policy of property.
+type Policy struct {
+	// Deadband is the absolute deadband, e.g. "0.5", or the percent deadband, e.g. "5%".
+	Deadband string
+	// ReportOnChangeOnly reports the property only if its value is changed.
+	ReportOnChangeOnly bool
+}
+
+// Band is the parsed deadband.
+type Band struct {
+	Value   float64
+	Percent bool
+}
+
+// Parse parses the given string as a Band, e.g. "0.5" or "5%".
+func Parse(s string) (Band, error) {
+	var ret Band
+	if strings.HasSuffix(s, "%") {
+		ret.Percent = true
+		s = strings.TrimSuffix(s, "%")
+	}
+	var value, err = strconv.ParseFloat(s, 64)
+	if err != nil {
+		return Band{}, errors.Wrapf(err, "failed to parse deadband %q", s)
+	}
+	if value < 0 || math.IsNaN(value) || math.IsInf(value, 0) {
+		return Band{}, errors.Errorf("invalid deadband %q", s)
+	}
+	ret.Value = value
+	return ret, nil
+}
+
+// Crossed returns true if the current value is out of the band around the reported value,
+// the non-numeric values are crossed if they are not equal.
+func (b Band) Crossed(reported, current string) bool {
+	var r, rerr = strconv.ParseFloat(reported, 64)
+	var c, cerr = strconv.ParseFloat(current, 64)
+	if rerr != nil || cerr != nil {
+		return reported != current
+	}
+
+	var band = b.Value
+	if b.Percent {
+		band = math.Abs(r) * b.Value / 100
+	}
+	return math.Abs(c-r) > band
+}
+
+// Reporter tracks the reported values of properties, and decides whether the properties should be reported.
+type Reporter struct {
+	// MaxSilence is the maximum duration without reporting, the properties are reported anyway after it.
+	// There is no limit if it's not positive.
+	MaxSilence time.Duration
+
+	reportedAt time.Time
+	reported   map[string]string
+}
+
+// Expired returns true if the duration without reporting has reached the MaxSilence.
+func (r *Reporter) Expired(now time.Time) bool {
+	if r.MaxSilence <= 0 || r.reportedAt.IsZero() {
+		return false
+	}
+	return now.Sub(r.reportedAt) >= r.MaxSilence
+}
+
+// Changed returns true if the value of the named property should be reported according to the given policy,
+// the value is always reported if the policy is empty or the property has not been reported yet.
+func (r *Reporter) Changed(name string, policy Policy, value string) bool {
+	var reported, exist = r.reported[name]
+	if !exist {
+		return true
+	}
+	if policy.Deadband != "" {
+		var band, err = Parse(policy.Deadband)
+		if err != nil {
+			return true
+		}
+		return band.Crossed(reported, value)
+	}
+	if policy.ReportOnChangeOnly {
+		return reported != value
+	}
+	return true
+}
+
+// Report records the reported values of properties.
+func (r *Reporter) Report(now time.Time, values map[string]string) {
+	r.reportedAt = now
+	r.reported = values
+}
@@ -0,0 +1,59 @@
+package deadband
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBandCrossed(t *testing.T) {
+	var testCases = []struct {
+		band     string
+		reported string
+		current  string
+		expected bool
+	}{
+		{band: "0.5", reported: "10", current: "10.5", expected: false},
+		{band: "0.5", reported: "10", current: "9.4", expected: true},
+		{band: "5%", reported: "200", current: "209", expected: false},
+		{band: "5%", reported: "200", current: "211", expected: true},
+		{band: "5%", reported: "0", current: "0.1", expected: true},
+		{band: "0", reported: "1", current: "1", expected: false},
+		{band: "1", reported: "on", current: "on", expected: false},
+		{band: "1", reported: "on", current: "off", expected: true},
+	}
+
+	for _, tc := range testCases {
+		var band, err = Parse(tc.band)
+		if !assert.NoError(t, err, "case %q", tc.band) {
+			continue
+		}
+		assert.Equal(t, tc.expected, band.Crossed(tc.reported, tc.current), "case %q: %s -> %s", tc.band, tc.reported, tc.current)
+	}
+
+	for _, s := range []string{"", "%", "-1", "abc", "NaN"} {
+		var _, err = Parse(s)
+		assert.Error(t, err, "case %q", s)
+	}
+}
+
+func TestReporter(t *testing.T) {
+	var now = time.Unix(0, 0)
+	var r = Reporter{MaxSilence: time.Minute}
+
+	// reports the unreported property
+	assert.True(t, r.Changed("temp", Policy{Deadband: "1"}, "20"))
+	assert.False(t, r.Expired(now))
+	r.Report(now, map[string]string{"temp": "20", "mode": "auto", "counter": "1"})
+
+	assert.False(t, r.Changed("temp", Policy{Deadband: "1"}, "20.8"))
+	assert.True(t, r.Changed("temp", Policy{Deadband: "1"}, "21.2"))
+	assert.False(t, r.Changed("mode", Policy{ReportOnChangeOnly: true}, "auto"))
+	assert.True(t, r.Changed("mode", Policy{ReportOnChangeOnly: true}, "manual"))
+	assert.True(t, r.Changed("counter", Policy{}, "1"))
+
+	// heartbeat
+	assert.False(t, r.Expired(now.Add(59*time.Second)))
+	assert.True(t, r.Expired(now.Add(time.Minute)))
+}
@@ -0,0 +1,91 @@
+// Package expression provides a small expression language for transforming the value of device property,
+// e.g. the raw-to-engineering-unit scaling, polynomial calibration, clamping, lookup table and conditionals.
+//
+// The expression is evaluated on float64 values, the boolean is represented as 1 or 0.
+// It's sandboxed and deterministic, there is not any loop, assignment or I/O in the language:
+//   - operators: + - * / % ^, == != < <= > >=, && || !, cond ? a : b
+//   - constants: pi, e, true, false
+//   - functions: abs, floor, ceil, trunc, round, sqrt, exp, ln, log10, sin, cos, tan, pow,
+//     min, max, clamp, if, poly, lookup
+//
+// For example, "clamp(poly(x, -40, 0.1), -40, 125)" converts the raw value x to celsius,
+// and "lookup(x, 0, 0, 4095, 100)" scales the 12-bits ADC value to percentage.
+package expression
+
+import (
+	"math"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Program is a compiled expression.
+type Program struct {
+	source string
+	root   node
+}
+
+// Compile compiles the given source as a Program.
+func Compile(source string) (*Program, error) {
+	var root, err = parse(source)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to compile expression %q", source)
+	}
+	return &Program{source: source, root: root}, nil
+}
+
+// Evaluate evaluates the program with the given variables,
+// it returns error if the result is not a finite number.
+func (p *Program) Evaluate(vars map[string]float64) (float64, error) {
+	var ret, err = p.root.eval(vars)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to evaluate expression %q", p.source)
+	}
+	if math.IsNaN(ret) || math.IsInf(ret, 0) {
+		return 0, errors.Errorf("failed to evaluate expression %q, the result is not a finite number", p.source)
+	}
+	return ret, nil
+}
+
+func (p *Program) String() string {
+	return p.source
+}
+
+const maxCacheSize = 1024
+
+var cache = struct {
+	sync.RWMutex
+	programs map[string]*Program
+}{
+	programs: make(map[string]*Program),
+}
+
+// Evaluate compiles and evaluates the given source with the given variables,
+// the compiled program is cached for the next evaluation.
+func Evaluate(source string, vars map[string]float64) (float64, error) {
+	cache.RLock()
+	var p, exist = cache.programs[source]
+	cache.RUnlock()
+
+	if !exist {
+		var err error
+		p, err = Compile(source)
+		if err != nil {
+			return 0, err
+		}
+		cache.Lock()
+		// the expressions are from the spec of devices, which are limited in practice,
+		// so we simply reset the cache if it's full.
+		if len(cache.programs) >= maxCacheSize {
+			cache.programs = make(map[string]*Program)
+		}
+		cache.programs[source] = p
+		cache.Unlock()
+	}
+	return p.Evaluate(vars)
+}
+
+// EvaluateValue evaluates the given source with the variable "x".
+func EvaluateValue(source string, x float64) (float64, error) {
+	return Evaluate(source, map[string]float64{"x": x})
+}
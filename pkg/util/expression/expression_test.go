@@ -0,0 +1,84 @@
+package expression
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluate(t *testing.T) {
+	var testCases = []struct {
+		source      string
+		vars        map[string]float64
+		expected    float64
+		expectedErr bool
+	}{
+		// arithmetic
+		{source: "1 + 2 * 3", expected: 7},
+		{source: "(1 + 2) * 3", expected: 9},
+		{source: "2 ^ 3 ^ 2", expected: 512},
+		{source: "-2 ^ 2", expected: -4},
+		{source: "7 % 4", expected: 3},
+		{source: "0x10 + 1.5e1", expected: 31},
+		{source: "x / 10 - 40", vars: map[string]float64{"x": 650}, expected: 25},
+		{source: "1 / 0", expectedErr: true},
+		// logical and conditionals
+		{source: "x > 10 && x < 20", vars: map[string]float64{"x": 15}, expected: 1},
+		{source: "!(x > 10) || false", vars: map[string]float64{"x": 15}, expected: 0},
+		{source: "x >= 0 ? x : -x", vars: map[string]float64{"x": -3}, expected: 3},
+		{source: "x < 0 ? -1 : x == 0 ? 0 : 1", vars: map[string]float64{"x": 0}, expected: 0},
+		{source: "if(x != 0, 1 / x, 0)", vars: map[string]float64{"x": 4}, expected: 0.25},
+		{source: "x == 0 || 1 / x > 0", vars: map[string]float64{"x": 0}, expected: 1},
+		// functions
+		{source: "poly(x, 1, 2, 3)", vars: map[string]float64{"x": 2}, expected: 17},
+		{source: "clamp(x, 0, 100)", vars: map[string]float64{"x": 120}, expected: 100},
+		{source: "clamp(x, 100, 0)", vars: map[string]float64{"x": 120}, expectedErr: true},
+		{source: "lookup(x, 0, 0, 10, 100, 20, 150)", vars: map[string]float64{"x": 15}, expected: 125},
+		{source: "lookup(x, 0, 0, 10, 100)", vars: map[string]float64{"x": -5}, expected: 0},
+		{source: "lookup(x, 0, 0, 10, 100)", vars: map[string]float64{"x": 50}, expected: 100},
+		{source: "lookup(x, 10, 0, 0, 100)", vars: map[string]float64{"x": 5}, expectedErr: true},
+		{source: "round(x, 2)", vars: map[string]float64{"x": 3.14159}, expected: 3.14},
+		{source: "max(1, x, 3)", vars: map[string]float64{"x": 5}, expected: 5},
+		{source: "sqrt(-1)", expectedErr: true},
+		// errors
+		{source: "y + 1", vars: map[string]float64{"x": 1}, expectedErr: true},
+		{source: "unknown(1)", expectedErr: true},
+		{source: "clamp(1, 2)", expectedErr: true},
+		{source: "lookup(1, 2, 3, 4)", expectedErr: true},
+		{source: "1 +", expectedErr: true},
+		{source: "(1 + 2", expectedErr: true},
+		{source: "1 2", expectedErr: true},
+		{source: "x ? 1", vars: map[string]float64{"x": 1}, expectedErr: true},
+		{source: "$x", expectedErr: true},
+		{source: strings.Repeat("(", 100) + "1" + strings.Repeat(")", 100), expectedErr: true},
+		{source: strings.Repeat("-", 100) + "1", expectedErr: true},
+	}
+
+	for _, tc := range testCases {
+		var ret, err = Evaluate(tc.source, tc.vars)
+		if tc.expectedErr {
+			assert.Error(t, err, "case %q", tc.source)
+			continue
+		}
+		if assert.NoError(t, err, "case %q", tc.source) {
+			assert.InDelta(t, tc.expected, ret, 1e-9, "case %q", tc.source)
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	// converts the raw value to celsius and back
+	var read, err = Compile("x * 0.1 - 40")
+	assert.NoError(t, err)
+	write, err := Compile("(x + 40) * 10")
+	assert.NoError(t, err)
+
+	for _, raw := range []float64{0, 400, 650, 1650} {
+		var value, err = read.Evaluate(map[string]float64{"x": raw})
+		assert.NoError(t, err)
+		ret, err := write.Evaluate(map[string]float64{"x": value})
+		assert.NoError(t, err)
+		assert.InDelta(t, raw, ret, 1e-9)
+	}
+}
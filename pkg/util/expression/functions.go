@@ -0,0 +1,188 @@
+package expression
+
+import (
+	"math"
+
+	"github.com/pkg/errors"
+)
+
+var constants = map[string]float64{
+	"pi":    math.Pi,
+	"e":     math.E,
+	"true":  1,
+	"false": 0,
+}
+
+type function struct {
+	// minArgs and maxArgs are the limit of the arguments, maxArgs is unlimited if it's negative.
+	minArgs int
+	maxArgs int
+	// pairs indicates that the arguments after the first one are in pairs.
+	pairs bool
+	call  func(args []float64) (float64, error)
+}
+
+func (f function) validate(argc int) error {
+	if argc < f.minArgs {
+		return errors.Errorf("requires at least %d arguments", f.minArgs)
+	}
+	if f.maxArgs >= 0 && argc > f.maxArgs {
+		return errors.Errorf("requires at most %d arguments", f.maxArgs)
+	}
+	if f.pairs && (argc-1)%2 != 0 {
+		return errors.New("requires the arguments in pairs after the first one")
+	}
+	return nil
+}
+
+func unary(fn func(float64) float64) function {
+	return function{
+		minArgs: 1,
+		maxArgs: 1,
+		call: func(args []float64) (float64, error) {
+			return fn(args[0]), nil
+		},
+	}
+}
+
+var functions = map[string]function{
+	"abs":   unary(math.Abs),
+	"floor": unary(math.Floor),
+	"ceil":  unary(math.Ceil),
+	"trunc": unary(math.Trunc),
+	"exp":   unary(math.Exp),
+	"sin":   unary(math.Sin),
+	"cos":   unary(math.Cos),
+	"tan":   unary(math.Tan),
+	"sqrt": {
+		minArgs: 1,
+		maxArgs: 1,
+		call: func(args []float64) (float64, error) {
+			if args[0] < 0 {
+				return 0, errors.New("square root of negative number")
+			}
+			return math.Sqrt(args[0]), nil
+		},
+	},
+	"ln": {
+		minArgs: 1,
+		maxArgs: 1,
+		call: func(args []float64) (float64, error) {
+			if args[0] <= 0 {
+				return 0, errors.New("logarithm of non-positive number")
+			}
+			return math.Log(args[0]), nil
+		},
+	},
+	"log10": {
+		minArgs: 1,
+		maxArgs: 1,
+		call: func(args []float64) (float64, error) {
+			if args[0] <= 0 {
+				return 0, errors.New("logarithm of non-positive number")
+			}
+			return math.Log10(args[0]), nil
+		},
+	},
+	"pow": {
+		minArgs: 2,
+		maxArgs: 2,
+		call: func(args []float64) (float64, error) {
+			return math.Pow(args[0], args[1]), nil
+		},
+	},
+	// round(x) rounds half away from zero, round(x, n) rounds to n decimal places.
+	"round": {
+		minArgs: 1,
+		maxArgs: 2,
+		call: func(args []float64) (float64, error) {
+			if len(args) == 1 {
+				return math.Round(args[0]), nil
+			}
+			var scale = math.Pow(10, math.Trunc(args[1]))
+			return math.Round(args[0]*scale) / scale, nil
+		},
+	},
+	"min": {
+		minArgs: 1,
+		maxArgs: -1,
+		call: func(args []float64) (float64, error) {
+			var ret = args[0]
+			for _, v := range args[1:] {
+				ret = math.Min(ret, v)
+			}
+			return ret, nil
+		},
+	},
+	"max": {
+		minArgs: 1,
+		maxArgs: -1,
+		call: func(args []float64) (float64, error) {
+			var ret = args[0]
+			for _, v := range args[1:] {
+				ret = math.Max(ret, v)
+			}
+			return ret, nil
+		},
+	},
+	// clamp(x, lo, hi) limits x into [lo, hi].
+	"clamp": {
+		minArgs: 3,
+		maxArgs: 3,
+		call: func(args []float64) (float64, error) {
+			if args[1] > args[2] {
+				return 0, errors.New("lower bound is greater than upper bound")
+			}
+			return math.Max(args[1], math.Min(args[2], args[0])), nil
+		},
+	},
+	// if(cond, a, b) returns a if cond is not 0, otherwise returns b.
+	"if": {
+		minArgs: 3,
+		maxArgs: 3,
+		call: func(args []float64) (float64, error) {
+			if args[0] != 0 {
+				return args[1], nil
+			}
+			return args[2], nil
+		},
+	},
+	// poly(x, c0, c1, ..., cn) returns c0 + c1*x + ... + cn*x^n.
+	"poly": {
+		minArgs: 2,
+		maxArgs: -1,
+		call: func(args []float64) (float64, error) {
+			var x, coefficients = args[0], args[1:]
+			var ret float64
+			for i := len(coefficients) - 1; i >= 0; i-- {
+				ret = ret*x + coefficients[i]
+			}
+			return ret, nil
+		},
+	},
+	// lookup(x, x0, y0, x1, y1, ...) interpolates x linearly in the table,
+	// the x of table must be ascending, and x is clamped into the range of table.
+	"lookup": {
+		minArgs: 3,
+		maxArgs: -1,
+		pairs:   true,
+		call: func(args []float64) (float64, error) {
+			var x, table = args[0], args[1:]
+			for i := 2; i < len(table); i += 2 {
+				if table[i] <= table[i-2] {
+					return 0, errors.New("the table isn't ascending")
+				}
+			}
+			if x <= table[0] {
+				return table[1], nil
+			}
+			for i := 2; i < len(table); i += 2 {
+				if x <= table[i] {
+					var x0, y0, x1, y1 = table[i-2], table[i-1], table[i], table[i+1]
+					return y0 + (x-x0)*(y1-y0)/(x1-x0), nil
+				}
+			}
+			return table[len(table)-1], nil
+		},
+	},
+}
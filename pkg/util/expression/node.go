@@ -0,0 +1,169 @@
+package expression
+
+import (
+	"math"
+
+	"github.com/pkg/errors"
+)
+
+type node interface {
+	eval(vars map[string]float64) (float64, error)
+}
+
+type numberNode struct {
+	value float64
+}
+
+func (n *numberNode) eval(map[string]float64) (float64, error) {
+	return n.value, nil
+}
+
+type variableNode struct {
+	name string
+}
+
+func (n *variableNode) eval(vars map[string]float64) (float64, error) {
+	var value, exist = vars[n.name]
+	if !exist {
+		return 0, errors.Errorf("undefined variable %q", n.name)
+	}
+	return value, nil
+}
+
+type unaryNode struct {
+	op      string
+	operand node
+}
+
+func (n *unaryNode) eval(vars map[string]float64) (float64, error) {
+	var v, err = n.operand.eval(vars)
+	if err != nil {
+		return 0, err
+	}
+	switch n.op {
+	case "-":
+		return -v, nil
+	case "!":
+		return fromBool(v == 0), nil
+	}
+	return v, nil
+}
+
+type binaryNode struct {
+	op    string
+	left  node
+	right node
+}
+
+func (n *binaryNode) eval(vars map[string]float64) (float64, error) {
+	var l, err = n.left.eval(vars)
+	if err != nil {
+		return 0, err
+	}
+
+	// the logical operators are short-circuit.
+	switch n.op {
+	case "&&":
+		if l == 0 {
+			return 0, nil
+		}
+		r, err := n.right.eval(vars)
+		if err != nil {
+			return 0, err
+		}
+		return fromBool(r != 0), nil
+	case "||":
+		if l != 0 {
+			return 1, nil
+		}
+		r, err := n.right.eval(vars)
+		if err != nil {
+			return 0, err
+		}
+		return fromBool(r != 0), nil
+	}
+
+	r, err := n.right.eval(vars)
+	if err != nil {
+		return 0, err
+	}
+	switch n.op {
+	case "+":
+		return l + r, nil
+	case "-":
+		return l - r, nil
+	case "*":
+		return l * r, nil
+	case "/":
+		if r == 0 {
+			return 0, errors.New("division by zero")
+		}
+		return l / r, nil
+	case "%":
+		if r == 0 {
+			return 0, errors.New("modulo by zero")
+		}
+		return math.Mod(l, r), nil
+	case "^":
+		return math.Pow(l, r), nil
+	case "==":
+		return fromBool(l == r), nil
+	case "!=":
+		return fromBool(l != r), nil
+	case "<":
+		return fromBool(l < r), nil
+	case "<=":
+		return fromBool(l <= r), nil
+	case ">":
+		return fromBool(l > r), nil
+	case ">=":
+		return fromBool(l >= r), nil
+	}
+	return 0, errors.Errorf("unknown operator %q", n.op)
+}
+
+type conditionalNode struct {
+	cond      node
+	then      node
+	otherwise node
+}
+
+func (n *conditionalNode) eval(vars map[string]float64) (float64, error) {
+	var c, err = n.cond.eval(vars)
+	if err != nil {
+		return 0, err
+	}
+	if c != 0 {
+		return n.then.eval(vars)
+	}
+	return n.otherwise.eval(vars)
+}
+
+type callNode struct {
+	name string
+	fn   function
+	args []node
+}
+
+func (n *callNode) eval(vars map[string]float64) (float64, error) {
+	var args = make([]float64, 0, len(n.args))
+	for _, arg := range n.args {
+		var v, err = arg.eval(vars)
+		if err != nil {
+			return 0, err
+		}
+		args = append(args, v)
+	}
+	var ret, err = n.fn.call(args)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to call %s", n.name)
+	}
+	return ret, nil
+}
+
+func fromBool(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
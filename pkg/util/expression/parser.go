@@ -0,0 +1,336 @@
+package expression
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	maxSourceLength = 4096
+	maxDepth        = 64
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenNumber
+	tokenIdent
+	tokenOperator
+)
+
+type token struct {
+	kind  tokenKind
+	text  string
+	value float64
+	pos   int
+}
+
+// operators are sorted by length, so the longer operator is matched first.
+var operators = []string{
+	"&&", "||", "==", "!=", "<=", ">=",
+	"+", "-", "*", "/", "%", "^", "<", ">", "!", "?", ":", "(", ")", ",",
+}
+
+// tokenize splits the source into tokens.
+func tokenize(src string) ([]token, error) {
+	var tokens []token
+	var i = 0
+	for i < len(src) {
+		var c = src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case isDigit(c) || (c == '.' && i+1 < len(src) && isDigit(src[i+1])):
+			var start = i
+			var value float64
+			if c == '0' && i+1 < len(src) && (src[i+1] == 'x' || src[i+1] == 'X') {
+				i += 2
+				for i < len(src) && isHexDigit(src[i]) {
+					i++
+				}
+				var v, err = strconv.ParseUint(src[start+2:i], 16, 64)
+				if err != nil {
+					return nil, errors.Errorf("invalid number %q at %d", src[start:i], start)
+				}
+				value = float64(v)
+			} else {
+				for i < len(src) && (isDigit(src[i]) || src[i] == '.') {
+					i++
+				}
+				if i < len(src) && (src[i] == 'e' || src[i] == 'E') {
+					i++
+					if i < len(src) && (src[i] == '+' || src[i] == '-') {
+						i++
+					}
+					for i < len(src) && isDigit(src[i]) {
+						i++
+					}
+				}
+				var v, err = strconv.ParseFloat(src[start:i], 64)
+				if err != nil {
+					return nil, errors.Errorf("invalid number %q at %d", src[start:i], start)
+				}
+				value = v
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: src[start:i], value: value, pos: start})
+		case isLetter(c):
+			var start = i
+			for i < len(src) && (isLetter(src[i]) || isDigit(src[i])) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: src[start:i], pos: start})
+		default:
+			var matched bool
+			for _, op := range operators {
+				if strings.HasPrefix(src[i:], op) {
+					tokens = append(tokens, token{kind: tokenOperator, text: op, pos: i})
+					i += len(op)
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return nil, errors.Errorf("unexpected character %q at %d", c, i)
+			}
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, pos: len(src)}), nil
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isHexDigit(c byte) bool {
+	return isDigit(c) || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
+
+func isLetter(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// parser is a recursive descent parser, the precedence from low to high is:
+//
+//	?:
+//	||
+//	&&
+//	== !=
+//	< <= > >=
+//	+ -
+//	* / %
+//	unary - + !
+//	^ (right associative)
+type parser struct {
+	tokens []token
+	pos    int
+	depth  int
+}
+
+func parse(src string) (node, error) {
+	if len(src) > maxSourceLength {
+		return nil, errors.Errorf("expression is longer than %d", maxSourceLength)
+	}
+	var tokens, err = tokenize(src)
+	if err != nil {
+		return nil, err
+	}
+	var p = &parser{tokens: tokens}
+	n, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, errors.Errorf("unexpected %q at %d", tok.text, tok.pos)
+	}
+	return n, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	var tok = p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// accept consumes the next token if it's one of the given operators.
+func (p *parser) accept(ops ...string) (string, bool) {
+	var tok = p.peek()
+	if tok.kind != tokenOperator {
+		return "", false
+	}
+	for _, op := range ops {
+		if tok.text == op {
+			p.pos++
+			return op, true
+		}
+	}
+	return "", false
+}
+
+func (p *parser) expect(op string) error {
+	if _, ok := p.accept(op); !ok {
+		var tok = p.peek()
+		if tok.kind == tokenEOF {
+			return errors.Errorf("expected %q at the end", op)
+		}
+		return errors.Errorf("expected %q but got %q at %d", op, tok.text, tok.pos)
+	}
+	return nil
+}
+
+func (p *parser) parseExpression() (node, error) {
+	p.depth++
+	defer func() { p.depth-- }()
+	if p.depth > maxDepth {
+		return nil, errors.Errorf("expression is nested deeper than %d", maxDepth)
+	}
+
+	var cond, err = p.parseBinary(0)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := p.accept("?"); !ok {
+		return cond, nil
+	}
+	then, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(":"); err != nil {
+		return nil, err
+	}
+	otherwise, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+	return &conditionalNode{cond: cond, then: then, otherwise: otherwise}, nil
+}
+
+// binaryLevels are the binary operators from low precedence to high precedence.
+var binaryLevels = [][]string{
+	{"||"},
+	{"&&"},
+	{"==", "!="},
+	{"<", "<=", ">", ">="},
+	{"+", "-"},
+	{"*", "/", "%"},
+}
+
+func (p *parser) parseBinary(level int) (node, error) {
+	if level >= len(binaryLevels) {
+		return p.parseUnary()
+	}
+	var left, err = p.parseBinary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		var op, ok = p.accept(binaryLevels[level]...)
+		if !ok {
+			return left, nil
+		}
+		right, err := p.parseBinary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{op: op, left: left, right: right}
+	}
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if op, ok := p.accept("-", "+", "!"); ok {
+		p.depth++
+		defer func() { p.depth-- }()
+		if p.depth > maxDepth {
+			return nil, errors.Errorf("expression is nested deeper than %d", maxDepth)
+		}
+
+		var operand, err = p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &unaryNode{op: op, operand: operand}, nil
+	}
+	return p.parsePower()
+}
+
+func (p *parser) parsePower() (node, error) {
+	var base, err = p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := p.accept("^"); !ok {
+		return base, nil
+	}
+	exponent, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	return &binaryNode{op: "^", left: base, right: exponent}, nil
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	var tok = p.next()
+	switch tok.kind {
+	case tokenNumber:
+		return &numberNode{value: tok.value}, nil
+	case tokenIdent:
+		if _, ok := p.accept("("); ok {
+			return p.parseCall(tok)
+		}
+		if value, ok := constants[tok.text]; ok {
+			return &numberNode{value: value}, nil
+		}
+		return &variableNode{name: tok.text}, nil
+	case tokenOperator:
+		if tok.text == "(" {
+			var n, err = p.parseExpression()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return n, nil
+		}
+		return nil, errors.Errorf("unexpected %q at %d", tok.text, tok.pos)
+	}
+	return nil, errors.New("unexpected end of expression")
+}
+
+func (p *parser) parseCall(name token) (node, error) {
+	var fn, exist = functions[name.text]
+	if !exist {
+		return nil, errors.Errorf("unknown function %q at %d", name.text, name.pos)
+	}
+
+	var args []node
+	if _, ok := p.accept(")"); !ok {
+		for {
+			var arg, err = p.parseExpression()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+			if _, ok := p.accept(","); ok {
+				continue
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			break
+		}
+	}
+	if err := fn.validate(len(args)); err != nil {
+		return nil, errors.Wrapf(err, "invalid call of %s at %d", name.text, name.pos)
+	}
+	return &callNode{name: name.text, fn: fn, args: args}, nil
+}
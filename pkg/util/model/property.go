@@ -0,0 +1,100 @@
+package model
+
+import (
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// the value fields are tried in order,
+// e.g. the `operatedValue` of modbus property is preferred to the raw `value`.
+var propertyValueFields = []string{"operatedValue", "value", "intValue", "floatValue", "booleanValue"}
+
+// GetNumericProperties returns the numeric properties of the device status,
+// it recognizes the following layouts of status:
+//   - the `properties` array of objects with `name`, e.g. modbus, opcua and ble devices.
+//   - the `properties` map of objects, e.g. dummy protocol device.
+//   - the scalar fields of status, e.g. dummy special device.
+// The boolean value is recognized as 1 or 0, and the string value is recognized if it's a number.
+func GetNumericProperties(status interface{}) map[string]float64 {
+	var statusObj, ok = status.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	var ret = make(map[string]float64)
+	for field, fieldValue := range statusObj {
+		if field != "properties" {
+			if v, ok := parseNumber(fieldValue); ok {
+				ret[field] = v
+			}
+			continue
+		}
+
+		switch props := fieldValue.(type) {
+		case []interface{}:
+			for _, p := range props {
+				var prop, _ = p.(map[string]interface{})
+				var name, _ = prop["name"].(string)
+				if name == "" {
+					continue
+				}
+				if v, ok := getPropertyValue(prop); ok {
+					ret[name] = v
+				}
+			}
+		case map[string]interface{}:
+			for name, p := range props {
+				var prop, _ = p.(map[string]interface{})
+				if v, ok := getPropertyValue(prop); ok {
+					ret[name] = v
+				}
+			}
+		}
+	}
+	return ret
+}
+
+func getPropertyValue(prop map[string]interface{}) (float64, bool) {
+	for _, field := range propertyValueFields {
+		var value, exist = prop[field]
+		if !exist {
+			continue
+		}
+		if v, ok := parseNumber(value); ok {
+			return v, true
+		}
+		// the float value of dummy device is a quantity.
+		if s, ok := value.(string); ok && field == "floatValue" {
+			if q, err := resource.ParseQuantity(s); err == nil {
+				return float64(q.MilliValue()) / 1000, true
+			}
+		}
+	}
+	return 0, false
+}
+
+func parseNumber(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case bool:
+		if v {
+			return 1, true
+		}
+		return 0, true
+	case string:
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return f, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,68 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetNumericProperties(t *testing.T) {
+	var testCases = []struct {
+		name     string
+		given    interface{}
+		expected map[string]float64
+	}{
+		{
+			name:     "not an object",
+			given:    "status",
+			expected: nil,
+		},
+		{
+			name: "array properties",
+			given: map[string]interface{}{
+				"properties": []interface{}{
+					map[string]interface{}{"name": "temperature", "value": "2150", "operatedValue": "21.5"},
+					map[string]interface{}{"name": "humidity", "value": "40"},
+					map[string]interface{}{"name": "switch", "value": "true"},
+					map[string]interface{}{"name": "label", "value": "living-room"},
+					map[string]interface{}{"value": "1"},
+				},
+			},
+			expected: map[string]float64{
+				"temperature": 21.5,
+				"humidity":    40,
+			},
+		},
+		{
+			name: "map properties",
+			given: map[string]interface{}{
+				"properties": map[string]interface{}{
+					"speed": map[string]interface{}{"type": "int", "intValue": int64(3)},
+					"ratio": map[string]interface{}{"type": "float", "floatValue": "1500m"},
+					"on":    map[string]interface{}{"type": "boolean", "booleanValue": true},
+					"name":  map[string]interface{}{"type": "string", "stringValue": "fan"},
+				},
+			},
+			expected: map[string]float64{
+				"speed": 3,
+				"ratio": 1.5,
+				"on":    1,
+			},
+		},
+		{
+			name: "scalar fields",
+			given: map[string]interface{}{
+				"gear":          "slow",
+				"rotatingSpeed": int64(100),
+			},
+			expected: map[string]float64{
+				"rotatingSpeed": 100,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		var actual = GetNumericProperties(tc.given)
+		assert.Equal(t, tc.expected, actual, "case %q", tc.name)
+	}
+}
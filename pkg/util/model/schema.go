@@ -0,0 +1,225 @@
+package model
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// GetSchemaOfVersion returns the OpenAPI v3 schema of the given version from the CRD,
+// it's always return nil if the version is not found or doesn't have a schema.
+func GetSchemaOfVersion(crd *apiextensionsv1.CustomResourceDefinition, version string) *apiextensionsv1.JSONSchemaProps {
+	if crd == nil {
+		return nil
+	}
+	for _, ver := range crd.Spec.Versions {
+		if ver.Name != version {
+			continue
+		}
+		if ver.Schema == nil {
+			return nil
+		}
+		return ver.Schema.OpenAPIV3Schema
+	}
+	return nil
+}
+
+// GetPropertySchema returns the schema of the given property name,
+// it's always return nil if the property is not defined.
+func GetPropertySchema(schema *apiextensionsv1.JSONSchemaProps, name string) *apiextensionsv1.JSONSchemaProps {
+	if schema == nil {
+		return nil
+	}
+	var prop, exist = schema.Properties[name]
+	if !exist {
+		return nil
+	}
+	return &prop
+}
+
+// ValidateBySchema validates the JSON-like value, which is decoded by `k8s.io/apimachinery/pkg/util/json`,
+// with the structural schema of CRD.
+// the validation only covers the keywords that a structural schema allows,
+// the unknown fields are not treated as invalid as they are pruned by apiserver.
+func ValidateBySchema(schema *apiextensionsv1.JSONSchemaProps, value interface{}, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if schema == nil {
+		return allErrs
+	}
+
+	if value == nil {
+		if !schema.Nullable && schema.Type != "" {
+			allErrs = append(allErrs, field.Invalid(fldPath, value, "must not be null"))
+		}
+		return allErrs
+	}
+
+	if schema.XIntOrString {
+		switch value.(type) {
+		case string, int64, float64:
+		default:
+			allErrs = append(allErrs, field.Invalid(fldPath, value, "must be an integer or a string"))
+		}
+		return allErrs
+	}
+
+	switch schema.Type {
+	case "object":
+		var obj, ok = value.(map[string]interface{})
+		if !ok {
+			return append(allErrs, field.Invalid(fldPath, value, "must be an object"))
+		}
+		allErrs = append(allErrs, validateObject(schema, obj, fldPath)...)
+	case "array":
+		var arr, ok = value.([]interface{})
+		if !ok {
+			return append(allErrs, field.Invalid(fldPath, value, "must be an array"))
+		}
+		allErrs = append(allErrs, validateArray(schema, arr, fldPath)...)
+	case "string":
+		var str, ok = value.(string)
+		if !ok {
+			return append(allErrs, field.Invalid(fldPath, value, "must be a string"))
+		}
+		allErrs = append(allErrs, validateString(schema, str, fldPath)...)
+	case "integer":
+		var num, ok = value.(int64)
+		if !ok {
+			return append(allErrs, field.Invalid(fldPath, value, "must be an integer"))
+		}
+		allErrs = append(allErrs, validateNumber(schema, float64(num), fldPath)...)
+	case "number":
+		var num float64
+		switch v := value.(type) {
+		case int64:
+			num = float64(v)
+		case float64:
+			num = v
+		default:
+			return append(allErrs, field.Invalid(fldPath, value, "must be a number"))
+		}
+		allErrs = append(allErrs, validateNumber(schema, num, fldPath)...)
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return append(allErrs, field.Invalid(fldPath, value, "must be a boolean"))
+		}
+	}
+
+	if len(schema.Enum) != 0 {
+		var supported = make([]string, 0, len(schema.Enum))
+		var matched bool
+		for _, e := range schema.Enum {
+			var expected interface{}
+			if err := json.Unmarshal(e.Raw, &expected); err != nil {
+				continue
+			}
+			if reflect.DeepEqual(expected, value) {
+				matched = true
+				break
+			}
+			supported = append(supported, string(e.Raw))
+		}
+		if !matched {
+			allErrs = append(allErrs, field.NotSupported(fldPath, value, supported))
+		}
+	}
+
+	return allErrs
+}
+
+func validateObject(schema *apiextensionsv1.JSONSchemaProps, obj map[string]interface{}, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for _, name := range schema.Required {
+		if _, exist := obj[name]; !exist {
+			allErrs = append(allErrs, field.Required(fldPath.Child(name), ""))
+		}
+	}
+	if schema.MaxProperties != nil && int64(len(obj)) > *schema.MaxProperties {
+		allErrs = append(allErrs, field.TooMany(fldPath, len(obj), int(*schema.MaxProperties)))
+	}
+	if schema.MinProperties != nil && int64(len(obj)) < *schema.MinProperties {
+		allErrs = append(allErrs, field.Invalid(fldPath, len(obj), fmt.Sprintf("must have at least %d properties", *schema.MinProperties)))
+	}
+
+	for name, value := range obj {
+		if prop, exist := schema.Properties[name]; exist {
+			allErrs = append(allErrs, ValidateBySchema(&prop, value, fldPath.Child(name))...)
+			continue
+		}
+		if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+			allErrs = append(allErrs, ValidateBySchema(schema.AdditionalProperties.Schema, value, fldPath.Key(name))...)
+		}
+	}
+
+	return allErrs
+}
+
+func validateArray(schema *apiextensionsv1.JSONSchemaProps, arr []interface{}, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if schema.MaxItems != nil && int64(len(arr)) > *schema.MaxItems {
+		allErrs = append(allErrs, field.TooMany(fldPath, len(arr), int(*schema.MaxItems)))
+	}
+	if schema.MinItems != nil && int64(len(arr)) < *schema.MinItems {
+		allErrs = append(allErrs, field.Invalid(fldPath, len(arr), fmt.Sprintf("must have at least %d items", *schema.MinItems)))
+	}
+
+	if schema.Items != nil && schema.Items.Schema != nil {
+		for i, item := range arr {
+			allErrs = append(allErrs, ValidateBySchema(schema.Items.Schema, item, fldPath.Index(i))...)
+		}
+	}
+
+	return allErrs
+}
+
+func validateString(schema *apiextensionsv1.JSONSchemaProps, str string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if schema.MaxLength != nil && int64(len(str)) > *schema.MaxLength {
+		allErrs = append(allErrs, field.TooLong(fldPath, str, int(*schema.MaxLength)))
+	}
+	if schema.MinLength != nil && int64(len(str)) < *schema.MinLength {
+		allErrs = append(allErrs, field.Invalid(fldPath, str, fmt.Sprintf("must be at least %d characters long", *schema.MinLength)))
+	}
+	if schema.Pattern != "" {
+		var re, err = regexp.Compile(schema.Pattern)
+		if err == nil && !re.MatchString(str) {
+			allErrs = append(allErrs, field.Invalid(fldPath, str, fmt.Sprintf("must match the pattern %q", schema.Pattern)))
+		}
+	}
+
+	return allErrs
+}
+
+func validateNumber(schema *apiextensionsv1.JSONSchemaProps, num float64, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if schema.Maximum != nil {
+		if schema.ExclusiveMaximum && num >= *schema.Maximum {
+			allErrs = append(allErrs, field.Invalid(fldPath, num, fmt.Sprintf("must be less than %v", *schema.Maximum)))
+		} else if num > *schema.Maximum {
+			allErrs = append(allErrs, field.Invalid(fldPath, num, fmt.Sprintf("must be less than or equal to %v", *schema.Maximum)))
+		}
+	}
+	if schema.Minimum != nil {
+		if schema.ExclusiveMinimum && num <= *schema.Minimum {
+			allErrs = append(allErrs, field.Invalid(fldPath, num, fmt.Sprintf("must be greater than %v", *schema.Minimum)))
+		} else if num < *schema.Minimum {
+			allErrs = append(allErrs, field.Invalid(fldPath, num, fmt.Sprintf("must be greater than or equal to %v", *schema.Minimum)))
+		}
+	}
+	if schema.MultipleOf != nil && *schema.MultipleOf != 0 {
+		var quotient = num / *schema.MultipleOf
+		if quotient != float64(int64(quotient)) {
+			allErrs = append(allErrs, field.Invalid(fldPath, num, fmt.Sprintf("must be a multiple of %v", *schema.MultipleOf)))
+		}
+	}
+
+	return allErrs
+}
@@ -0,0 +1,115 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestValidateBySchema(t *testing.T) {
+	var maxLength int64 = 5
+	var minimum float64 = 1
+	var maxItems int64 = 2
+	var schema = &apiextensionsv1.JSONSchemaProps{
+		Type:     "object",
+		Required: []string{"protocol"},
+		Properties: map[string]apiextensionsv1.JSONSchemaProps{
+			"protocol": {
+				Type:     "object",
+				Required: []string{"endpoint"},
+				Properties: map[string]apiextensionsv1.JSONSchemaProps{
+					"endpoint": {
+						Type:      "string",
+						MaxLength: &maxLength,
+					},
+					"workerID": {
+						Type:    "integer",
+						Minimum: &minimum,
+					},
+				},
+			},
+			"properties": {
+				Type:     "array",
+				MaxItems: &maxItems,
+				Items: &apiextensionsv1.JSONSchemaPropsOrArray{
+					Schema: &apiextensionsv1.JSONSchemaProps{
+						Type: "object",
+						Properties: map[string]apiextensionsv1.JSONSchemaProps{
+							"type": {
+								Type: "string",
+								Enum: []apiextensionsv1.JSON{
+									{Raw: []byte(`"int"`)},
+									{Raw: []byte(`"float"`)},
+								},
+							},
+							"value": {
+								XIntOrString: true,
+							},
+						},
+					},
+				},
+			},
+			"labels": {
+				Type: "object",
+				AdditionalProperties: &apiextensionsv1.JSONSchemaPropsOrBool{
+					Schema: &apiextensionsv1.JSONSchemaProps{
+						Type: "string",
+					},
+				},
+			},
+		},
+	}
+
+	var testCases = []struct {
+		name     string
+		given    string
+		expected []string
+	}{
+		{
+			name:  "valid",
+			given: `{"protocol":{"endpoint":"a:80","workerID":1},"properties":[{"type":"int","value":1},{"type":"float","value":"1.0"}],"labels":{"a":"b"},"unknown":true}`,
+		},
+		{
+			name:  "missing required",
+			given: `{"protocol":{}}`,
+			expected: []string{
+				"spec.protocol.endpoint",
+			},
+		},
+		{
+			name:  "mismatched type",
+			given: `{"protocol":{"endpoint":8080,"workerID":1.5},"labels":{"a":1}}`,
+			expected: []string{
+				"spec.labels[a]",
+				"spec.protocol.endpoint",
+				"spec.protocol.workerID",
+			},
+		},
+		{
+			name:  "out of range",
+			given: `{"protocol":{"endpoint":"127.0.0.1:8080","workerID":0},"properties":[{"type":"int"},{"type":"int"},{"type":"bool"}]}`,
+			expected: []string{
+				"spec.properties",
+				"spec.properties[2].type",
+				"spec.protocol.endpoint",
+				"spec.protocol.workerID",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		var given interface{}
+		if err := json.Unmarshal([]byte(tc.given), &given); err != nil {
+			t.Fatalf("case %q: %v", tc.name, err)
+		}
+		var errs = ValidateBySchema(schema, given, field.NewPath("spec"))
+		var actual []string
+		for _, err := range errs {
+			actual = append(actual, err.Field)
+		}
+		assert.ElementsMatch(t, tc.expected, actual, "case %q", tc.name)
+	}
+}
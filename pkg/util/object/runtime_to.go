@@ -17,6 +17,15 @@ func ToDeviceLinkObject(obj runtime.Object) *edgev1alpha1.DeviceLink {
 	return nil
 }
 
+func ToDeviceCommandObject(obj runtime.Object) *edgev1alpha1.DeviceCommand {
+	if obj != nil {
+		if r, ok := obj.(*edgev1alpha1.DeviceCommand); ok {
+			return r
+		}
+	}
+	return nil
+}
+
 func ToNodeObject(obj runtime.Object) *corev1.Node {
 	if obj != nil {
 		if r, ok := obj.(*corev1.Node); ok {
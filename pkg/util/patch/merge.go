@@ -0,0 +1,90 @@
+package patch
+
+import (
+	"k8s.io/apimachinery/pkg/util/json"
+)
+
+// ApplyMergePatch applies the JSON merge patch(RFC 7386) to the target object,
+// the target object is modified in place and returned.
+func ApplyMergePatch(target map[string]interface{}, patch []byte) (map[string]interface{}, error) {
+	var p map[string]interface{}
+	if err := json.Unmarshal(patch, &p); err != nil {
+		return nil, err
+	}
+	if target == nil {
+		target = make(map[string]interface{}, len(p))
+	}
+	return mergeObject(target, p), nil
+}
+
+// ComposeMergePatches composes the previous JSON merge patch and the next JSON merge patch into one,
+// applying the composed patch is the same as applying the previous one and then the next one.
+// It returns false if the patches cannot be composed,
+// e.g. the previous one removes an object which is recreated by the next one.
+func ComposeMergePatches(previous, next []byte) ([]byte, bool, error) {
+	var prev, nxt map[string]interface{}
+	if err := json.Unmarshal(previous, &prev); err != nil {
+		return nil, false, err
+	}
+	if err := json.Unmarshal(next, &nxt); err != nil {
+		return nil, false, err
+	}
+	if prev == nil {
+		prev = make(map[string]interface{}, len(nxt))
+	}
+	if !composeObject(prev, nxt) {
+		return nil, false, nil
+	}
+	var ret, err = json.Marshal(prev)
+	if err != nil {
+		return nil, false, err
+	}
+	return ret, true, nil
+}
+
+func mergeObject(target, patch map[string]interface{}) map[string]interface{} {
+	for key, pv := range patch {
+		if pv == nil {
+			delete(target, key)
+			continue
+		}
+		var pvObj, pvIsObj = pv.(map[string]interface{})
+		if !pvIsObj {
+			target[key] = pv
+			continue
+		}
+		var tvObj, tvIsObj = target[key].(map[string]interface{})
+		if !tvIsObj {
+			tvObj = make(map[string]interface{}, len(pvObj))
+		}
+		target[key] = mergeObject(tvObj, pvObj)
+	}
+	return target
+}
+
+// composeObject composes the next object into the previous one, it returns false if the result cannot be represented by a merge patch,
+// different from merging, the null values of the next object are kept.
+func composeObject(previous, next map[string]interface{}) bool {
+	for key, nv := range next {
+		var nvObj, nvIsObj = nv.(map[string]interface{})
+		if !nvIsObj {
+			previous[key] = nv
+			continue
+		}
+		var pv, pvExist = previous[key]
+		if !pvExist {
+			previous[key] = nvObj
+			continue
+		}
+		var pvObj, pvIsObj = pv.(map[string]interface{})
+		if !pvIsObj {
+			// the previous patch removes or replaces the field with non-object value,
+			// a merge patch cannot represent to recreate the object.
+			return false
+		}
+		if !composeObject(pvObj, nvObj) {
+			return false
+		}
+	}
+	return true
+}
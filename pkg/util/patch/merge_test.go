@@ -0,0 +1,107 @@
+package patch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/json"
+)
+
+func TestApplyMergePatch(t *testing.T) {
+	var testCases = []struct {
+		name     string
+		target   string
+		patch    string
+		expected string
+	}{
+		{
+			name:     "add and replace",
+			target:   `{"status":{"on":false,"gear":"slow"}}`,
+			patch:    `{"status":{"on":true,"rotatingSpeed":100}}`,
+			expected: `{"status":{"on":true,"gear":"slow","rotatingSpeed":100}}`,
+		},
+		{
+			name:     "remove",
+			target:   `{"status":{"on":false,"gear":"slow"}}`,
+			patch:    `{"status":{"gear":null}}`,
+			expected: `{"status":{"on":false}}`,
+		},
+		{
+			name:     "replace array",
+			target:   `{"status":{"properties":[{"name":"a"},{"name":"b"}]}}`,
+			patch:    `{"status":{"properties":[{"name":"c"}]}}`,
+			expected: `{"status":{"properties":[{"name":"c"}]}}`,
+		},
+		{
+			name:     "replace non-object",
+			target:   `{"status":"unknown"}`,
+			patch:    `{"status":{"on":true,"gear":null}}`,
+			expected: `{"status":{"on":true}}`,
+		},
+		{
+			name:     "nil target",
+			target:   `null`,
+			patch:    `{"status":{"on":true}}`,
+			expected: `{"status":{"on":true}}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		var target, expected map[string]interface{}
+		_ = json.Unmarshal([]byte(tc.target), &target)
+		_ = json.Unmarshal([]byte(tc.expected), &expected)
+		var actual, err = ApplyMergePatch(target, []byte(tc.patch))
+		assert.NoError(t, err, "case %q", tc.name)
+		assert.Equal(t, expected, actual, "case %q", tc.name)
+	}
+}
+
+func TestComposeMergePatches(t *testing.T) {
+	var testCases = []struct {
+		name       string
+		target     string
+		previous   string
+		next       string
+		composable bool
+	}{
+		{
+			name:       "disjoint",
+			target:     `{"status":{"on":false}}`,
+			previous:   `{"status":{"on":true}}`,
+			next:       `{"status":{"gear":"fast"}}`,
+			composable: true,
+		},
+		{
+			name:       "overwrite",
+			target:     `{"status":{"on":false,"gear":"slow"}}`,
+			previous:   `{"status":{"on":true,"gear":null}}`,
+			next:       `{"status":{"gear":"fast","on":null}}`,
+			composable: true,
+		},
+		{
+			name:       "remove and recreate",
+			target:     `{"status":{"extension":{"a":1,"b":2}}}`,
+			previous:   `{"status":{"extension":null}}`,
+			next:       `{"status":{"extension":{"a":3}}}`,
+			composable: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		var composed, composable, err = ComposeMergePatches([]byte(tc.previous), []byte(tc.next))
+		assert.NoError(t, err, "case %q", tc.name)
+		assert.Equal(t, tc.composable, composable, "case %q", tc.name)
+		if !composable {
+			continue
+		}
+
+		// applying the composed patch is the same as applying the patches one by one
+		var expected, actual map[string]interface{}
+		_ = json.Unmarshal([]byte(tc.target), &expected)
+		_ = json.Unmarshal([]byte(tc.target), &actual)
+		expected, _ = ApplyMergePatch(expected, []byte(tc.previous))
+		expected, _ = ApplyMergePatch(expected, []byte(tc.next))
+		actual, _ = ApplyMergePatch(actual, composed)
+		assert.Equal(t, expected, actual, "case %q", tc.name)
+	}
+}
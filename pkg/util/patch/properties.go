@@ -0,0 +1,69 @@
+package patch
+
+import (
+	"k8s.io/apimachinery/pkg/util/json"
+)
+
+// PropertiesField is the field of properties in the status of device.
+const PropertiesField = "properties"
+
+// ApplyPropertiesPatch merges the JSON array of properties into the `properties` field of status by the `name` of property,
+// the status object is modified in place and returned.
+func ApplyPropertiesPatch(status map[string]interface{}, patch []byte) (map[string]interface{}, error) {
+	var patchProps []interface{}
+	if err := json.Unmarshal(patch, &patchProps); err != nil {
+		return nil, err
+	}
+	if status == nil {
+		status = make(map[string]interface{}, 1)
+	}
+	var props, _ = status[PropertiesField].([]interface{})
+	status[PropertiesField] = mergeProperties(props, patchProps)
+	return status, nil
+}
+
+// ComposePropertiesPatches composes the previous properties patch and the next properties patch into one,
+// applying the composed patch is the same as applying the previous one and then the next one.
+func ComposePropertiesPatches(previous, next []byte) ([]byte, error) {
+	var prev, nxt []interface{}
+	if err := json.Unmarshal(previous, &prev); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(next, &nxt); err != nil {
+		return nil, err
+	}
+	return json.Marshal(mergeProperties(prev, nxt))
+}
+
+// mergeProperties merges the patch properties into the target properties by name,
+// the property of patch replaces the same name one of target, or is appended if it is not existed.
+func mergeProperties(target, patch []interface{}) []interface{} {
+	var indexes = make(map[string]int, len(target))
+	for i, prop := range target {
+		if name := getPropertyName(prop); name != "" {
+			indexes[name] = i
+		}
+	}
+	for _, prop := range patch {
+		var name = getPropertyName(prop)
+		if name == "" {
+			continue
+		}
+		if i, exist := indexes[name]; exist {
+			target[i] = prop
+			continue
+		}
+		indexes[name] = len(target)
+		target = append(target, prop)
+	}
+	return target
+}
+
+func getPropertyName(prop interface{}) string {
+	var obj, ok = prop.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	var name, _ = obj["name"].(string)
+	return name
+}
@@ -0,0 +1,57 @@
+package patch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/json"
+)
+
+func TestApplyPropertiesPatch(t *testing.T) {
+	var testCases = []struct {
+		name     string
+		status   string
+		patch    string
+		expected string
+	}{
+		{
+			name:     "replace and append",
+			status:   `{"on":true,"properties":[{"name":"temperature","value":"20"},{"name":"humidity","value":"40"}]}`,
+			patch:    `[{"name":"temperature","value":"23.5"},{"name":"pressure","value":"1"}]`,
+			expected: `{"on":true,"properties":[{"name":"temperature","value":"23.5"},{"name":"humidity","value":"40"},{"name":"pressure","value":"1"}]}`,
+		},
+		{
+			name:     "without properties",
+			status:   `{"on":true}`,
+			patch:    `[{"name":"temperature","value":"23.5"},{"value":"unnamed"}]`,
+			expected: `{"on":true,"properties":[{"name":"temperature","value":"23.5"}]}`,
+		},
+		{
+			name:     "nil status",
+			status:   `null`,
+			patch:    `[{"name":"temperature","value":"23.5"}]`,
+			expected: `{"properties":[{"name":"temperature","value":"23.5"}]}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		var status, expected map[string]interface{}
+		_ = json.Unmarshal([]byte(tc.status), &status)
+		_ = json.Unmarshal([]byte(tc.expected), &expected)
+		var actual, err = ApplyPropertiesPatch(status, []byte(tc.patch))
+		assert.NoError(t, err, "case %q", tc.name)
+		assert.Equal(t, expected, actual, "case %q", tc.name)
+	}
+}
+
+func TestComposePropertiesPatches(t *testing.T) {
+	var composed, err = ComposePropertiesPatches(
+		[]byte(`[{"name":"temperature","value":"20"},{"name":"humidity","value":"40"}]`),
+		[]byte(`[{"name":"temperature","value":"23.5"}]`),
+	)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[{"name":"temperature","value":"23.5"},{"name":"humidity","value":"40"}]`, string(composed))
+
+	_, err = ComposePropertiesPatches([]byte(`{}`), []byte(`[]`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,118 @@
+// Package scheduler provides a scheduler for polling the properties of device in different intervals.
+package scheduler
+
+import (
+	"time"
+)
+
+// Overrun describes the task whose cycle took longer than its interval.
+type Overrun struct {
+	// Index is the index of task.
+	Index int
+	// Interval is the interval of task.
+	Interval time.Duration
+	// Elapsed is the elapsed time of cycle.
+	Elapsed time.Duration
+}
+
+// Scheduler schedules the tasks by their due time,
+// the tasks which are due at the same time are grouped into one cycle.
+type Scheduler struct {
+	intervals []time.Duration
+	next      []time.Time
+}
+
+// New creates a Scheduler with the intervals of tasks, all tasks are due after their intervals from the start.
+func New(intervals []time.Duration, start time.Time) *Scheduler {
+	var next = make([]time.Time, len(intervals))
+	for i, interval := range intervals {
+		next[i] = start.Add(interval)
+	}
+	return &Scheduler{
+		intervals: intervals,
+		next:      next,
+	}
+}
+
+// Next returns the earliest due time of the tasks,
+// it returns zero time if there is not any task.
+func (s *Scheduler) Next() time.Time {
+	var ret time.Time
+	for i, next := range s.next {
+		if i == 0 || next.Before(ret) {
+			ret = next
+		}
+	}
+	return ret
+}
+
+// Due returns the ascending indexes of the tasks which are due at the given time.
+func (s *Scheduler) Due(now time.Time) []int {
+	var ret []int
+	for i, next := range s.next {
+		if !next.After(now) {
+			ret = append(ret, i)
+		}
+	}
+	return ret
+}
+
+// Complete reschedules the given tasks of the cycle which started at start and ended at end,
+// it returns the tasks whose cycle took longer than their interval.
+func (s *Scheduler) Complete(tasks []int, start, end time.Time) []Overrun {
+	var elapsed = end.Sub(start)
+	var ret []Overrun
+	for _, i := range tasks {
+		var interval = s.intervals[i]
+		if elapsed > interval {
+			ret = append(ret, Overrun{Index: i, Interval: interval, Elapsed: elapsed})
+		}
+
+		// the missed cycles are skipped instead of bursting to catch up,
+		// the next due time keeps the phase of task.
+		var next = s.next[i].Add(interval)
+		if !next.After(end) {
+			var missed = end.Sub(next)/interval + 1
+			next = next.Add(missed * interval)
+		}
+		s.next[i] = next
+	}
+	return ret
+}
+
+// Run is blocked, it runs the due tasks periodically until the stop channel is closed,
+// the overrun tasks of each cycle are passed to the given overrun function if any.
+func (s *Scheduler) Run(stop <-chan struct{}, run func(tasks []int), overrun func(overruns []Overrun)) {
+	if len(s.intervals) == 0 {
+		<-stop
+		return
+	}
+
+	var timer = time.NewTimer(time.Until(s.Next()))
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-timer.C:
+		}
+
+		var start = time.Now()
+		var tasks = s.Due(start)
+		if len(tasks) != 0 {
+			run(tasks)
+			var overruns = s.Complete(tasks, start, time.Now())
+			if len(overruns) != 0 && overrun != nil {
+				overrun(overruns)
+			}
+		}
+
+		select {
+		case <-stop:
+			return
+		default:
+		}
+		timer.Reset(time.Until(s.Next()))
+	}
+}
@@ -0,0 +1,66 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduler(t *testing.T) {
+	var start = time.Unix(0, 0)
+	var at = func(d time.Duration) time.Time {
+		return start.Add(d)
+	}
+	var s = New([]time.Duration{time.Second, 3 * time.Second, time.Second}, start)
+
+	assert.Equal(t, at(time.Second), s.Next())
+	assert.Empty(t, s.Due(at(500*time.Millisecond)))
+
+	// groups the tasks with the same due time
+	var tasks = s.Due(at(time.Second))
+	assert.Equal(t, []int{0, 2}, tasks)
+	assert.Empty(t, s.Complete(tasks, at(time.Second), at(1100*time.Millisecond)))
+	assert.Equal(t, at(2*time.Second), s.Next())
+
+	tasks = s.Due(at(2 * time.Second))
+	assert.Equal(t, []int{0, 2}, tasks)
+	assert.Empty(t, s.Complete(tasks, at(2*time.Second), at(2100*time.Millisecond)))
+
+	tasks = s.Due(at(3 * time.Second))
+	assert.Equal(t, []int{0, 1, 2}, tasks)
+
+	// overruns the shorter interval tasks and skips the missed cycles
+	var overruns = s.Complete(tasks, at(3*time.Second), at(5500*time.Millisecond))
+	assert.Equal(t, []Overrun{
+		{Index: 0, Interval: time.Second, Elapsed: 2500 * time.Millisecond},
+		{Index: 2, Interval: time.Second, Elapsed: 2500 * time.Millisecond},
+	}, overruns)
+	assert.Equal(t, at(6*time.Second), s.Next())
+	assert.Equal(t, []int{0, 1, 2}, s.Due(at(6*time.Second)))
+}
+
+func TestSchedulerRun(t *testing.T) {
+	var s = New([]time.Duration{10 * time.Millisecond, time.Hour}, time.Now())
+	var stop = make(chan struct{})
+	var cycles = make(chan []int, 10)
+	var done = make(chan struct{})
+	go func() {
+		defer close(done)
+		s.Run(stop, func(tasks []int) {
+			select {
+			case cycles <- tasks:
+			default:
+			}
+		}, nil)
+	}()
+
+	select {
+	case tasks := <-cycles:
+		assert.Equal(t, []int{0}, tasks)
+	case <-time.After(time.Second):
+		t.Fatal("timeout to wait for the cycle")
+	}
+	close(stop)
+	<-done
+}
@@ -6,7 +6,7 @@
 	k8sruntime "k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 
-	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha1"
+	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha2"
 	"github.com/rancher/octopus/template/adaptor/api/v1alpha1"
 )
 
@@ -34,6 +34,7 @@ func (s *Service) toJSON(in metav1.Object) []byte {
 }
 
 func (s *Service) Connect(server api.Connection_ConnectServer) error {
-	// TODO implement the logic
+	// TODO implement the logic,
+	//  the command carried by request can be executed via `connection.ExecuteCommandInBackground`.
 	panic("implement me")
 }
@@ -4,7 +4,7 @@
 	"golang.org/x/sync/errgroup"
 	ctrl "sigs.k8s.io/controller-runtime"
 
-	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha1"
+	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha2"
 	"github.com/rancher/octopus/pkg/adaptor/connection"
 	"github.com/rancher/octopus/pkg/adaptor/log"
 	"github.com/rancher/octopus/pkg/adaptor/registration"
@@ -14,7 +14,7 @@
 
 const (
 	Name     = "adaptors.edge.cattle.io/template"
-	Version  = "v1alpha1"
+	Version  = "v1alpha2"
 	Endpoint = "template.sock"
 )
 
@@ -0,0 +1,112 @@
+package brain
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	edgev1alpha1 "github.com/rancher/octopus/api/v1alpha1"
+	"github.com/rancher/octopus/pkg/util/object"
+	"github.com/rancher/octopus/test/util/crd"
+	"github.com/rancher/octopus/test/util/node"
+)
+
+var _ = Describe("verify DeviceLink webhook", func() {
+	var (
+		testNamespace corev1.Namespace
+		testNodeName  string
+		testModel     metav1.TypeMeta
+
+		targetItem edgev1alpha1.DeviceLink
+	)
+
+	BeforeEach(func() {
+		testNamespace = corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+			},
+		}
+		_ = k8sCli.Create(testCtx, &testNamespace)
+
+		testNodeName, _ = node.GetValidWorker(testCtx, k8sCli)
+
+		testModel = metav1.TypeMeta{
+			Kind:       "IntegrationBrainDLWebhookDevice",
+			APIVersion: "devices.edge.cattle.io/v1alpha1",
+		}
+		_ = k8sCli.Create(testCtx, crd.MakeOfTypeMeta(testModel))
+	})
+
+	AfterEach(func() {
+		_ = k8sCli.DeleteAllOf(testCtx, &edgev1alpha1.DeviceLink{}, client.InNamespace(testNamespace.Name))
+		_ = k8sCli.Delete(testCtx, &testNamespace)
+	})
+
+	JustBeforeEach(func() {
+		targetItem = edgev1alpha1.DeviceLink{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:    testNamespace.Name,
+				GenerateName: "test-",
+			},
+			Spec: edgev1alpha1.DeviceLinkSpec{
+				Adaptor: edgev1alpha1.DeviceAdaptor{
+					Node: testNodeName,
+					Name: "adaptors.edge.cattle.io/fake",
+				},
+				Model: testModel,
+			},
+		}
+	})
+
+	Context("if the adaptor name is unqualified", func() {
+
+		It("should be denied", func() {
+
+			By("given a new link with an unqualified adaptor name", func() {
+				targetItem.Spec.Adaptor.Name = "adaptors.edge.cattle.io/fake/"
+			})
+
+			By("then it is denied on creation", func() {
+				// the webhook server may not be ready yet, so waits until the request is denied.
+				Eventually(func() string {
+					var err = k8sCli.Create(testCtx, targetItem.DeepCopy())
+					if err == nil {
+						return ""
+					}
+					return err.Error()
+				}, 30, 1).Should(ContainSubstring("must be a qualified adaptor name"))
+			})
+
+		})
+
+	})
+
+	Context("if the adaptor name is qualified", func() {
+
+		It("should be denied after modified to unqualified adaptor name", func() {
+
+			By("given a new link which is allowed", func() {
+				Eventually(func() error {
+					return k8sCli.Create(testCtx, &targetItem)
+				}, 30, 1).Should(Succeed())
+			})
+
+			By("when modify to unqualified adaptor name", func() {
+				Expect(k8sCli.Get(testCtx, object.GetNamespacedName(&targetItem), &targetItem)).Should(Succeed())
+
+				targetItem.Spec.Adaptor.Name = "-fake"
+			})
+
+			By("then it is denied on update", func() {
+				var err = k8sCli.Update(testCtx, &targetItem)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("must be a qualified adaptor name"))
+			})
+
+		})
+
+	})
+
+})
@@ -4,6 +4,7 @@
 	"context"
 	"path/filepath"
 	"testing"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -16,6 +17,7 @@
 
 	"github.com/rancher/octopus/pkg/brain"
 	"github.com/rancher/octopus/pkg/brain/controller"
+	"github.com/rancher/octopus/pkg/brain/webhook"
 	"github.com/rancher/octopus/pkg/util/log/zap"
 	"github.com/rancher/octopus/test/framework/envtest"
 	"github.com/rancher/octopus/test/framework/envtest/printer"
@@ -59,6 +61,13 @@ func TestBrain(t *testing.T) {
 				filepath.Join(testRootDir, "deploy", "manifests", "crd", "base"),
 			},
 		},
+		WebhookInstallOptions: envtest.WebhookInstallOptions{
+			DirectoryPaths: []string{
+				filepath.Join(testRootDir, "deploy", "manifests", "webhook"),
+			},
+			MaxTime:      30 * time.Second,
+			PollInterval: time.Second,
+		},
 	}
 
 	// NB(thxCode) use the native client to avoid that the cache is not started
@@ -80,7 +89,13 @@ func TestBrain(t *testing.T) {
 	err = brain.RegisterScheme(ctrlScheme)
 	Expect(err).NotTo(HaveOccurred())
 
-	controllerMgr, err := ctrl.NewManager(k8sCfg, ctrl.Options{Scheme: ctrlScheme, LeaderElection: false})
+	controllerMgr, err := ctrl.NewManager(k8sCfg, ctrl.Options{
+		Scheme:         ctrlScheme,
+		LeaderElection: false,
+		Host:           testEnv.WebhookInstallOptions.LocalServingHost,
+		Port:           testEnv.WebhookInstallOptions.LocalServingPort,
+		CertDir:        testEnv.WebhookInstallOptions.LocalServingCertDir,
+	})
 	Expect(err).ToNot(HaveOccurred())
 	Expect(controllerMgr).ToNot(BeNil())
 
@@ -104,6 +119,14 @@ func TestBrain(t *testing.T) {
 	}).SetupWithManager(controllerMgr)
 	Expect(err).ToNot(HaveOccurred())
 
+	By("creating webhooks")
+	err = (&webhook.DeviceLinkValidator{
+		Client: controllerMgr.GetClient(),
+		Ctx:    testCtx,
+		Log:    ctrl.Log.WithName("webhook").WithName("deviceLink"),
+	}).SetupWithManager(controllerMgr)
+	Expect(err).ToNot(HaveOccurred())
+
 	go func() {
 		err = controllerMgr.Start(testCtx.Done())
 		Expect(err).ToNot(HaveOccurred())
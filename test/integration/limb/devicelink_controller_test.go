@@ -2,6 +2,7 @@
 
 import (
 	"fmt"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -12,7 +13,7 @@
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	edgev1alpha1 "github.com/rancher/octopus/api/v1alpha1"
-	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha1"
+	api "github.com/rancher/octopus/pkg/adaptor/api/v1alpha2"
 	"github.com/rancher/octopus/pkg/suctioncup/connection"
 	modelutil "github.com/rancher/octopus/pkg/util/model"
 	"github.com/rancher/octopus/pkg/util/object"
@@ -336,6 +337,10 @@ func (a fakeAdaptor) GetEndpoint() string {
 	return "fake.sock"
 }
 
+func (a fakeAdaptor) GetVersion() string {
+	return api.Version
+}
+
 func (a fakeAdaptor) Stop() error {
 	return nil
 }
@@ -348,6 +353,10 @@ func (a fakeAdaptor) DeleteConnection(name types.NamespacedName) bool {
 	return false
 }
 
+func (a fakeAdaptor) GetConnection(name types.NamespacedName) connection.Connection {
+	return fakeConnection(name)
+}
+
 type fakeConnection types.NamespacedName
 
 func (c fakeConnection) GetAdaptorName() string {
@@ -369,3 +378,7 @@ func (c fakeConnection) IsStop() bool {
 func (c fakeConnection) Send(*metav1.TypeMeta, []byte, map[string]*api.ConnectRequestReferenceEntry) error {
 	return nil
 }
+
+func (c fakeConnection) Command(command *api.ConnectRequestCommand, _ time.Duration) (*api.ConnectResponseCommandResult, error) {
+	return &api.ConnectResponseCommandResult{Id: command.GetId(), Code: api.CommandResultCode_Succeeded}, nil
+}
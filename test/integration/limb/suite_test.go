@@ -102,7 +102,7 @@ func TestLimb(t *testing.T) {
 
 	By("starting suctioncup manager")
 	testAdaptors = adaptor.NewAdaptors()
-	testEventQueue = event.NewQueue()
+	testEventQueue = event.NewQueue(event.QueueOptions{})
 	suctionCupMgr, err := suctioncup.NewManagerWith(testAdaptors, testEventQueue)
 	Expect(err).ToNot(HaveOccurred())
 